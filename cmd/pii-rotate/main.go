@@ -0,0 +1,60 @@
+// Command pii-rotate encrypts the personal data of users again with the
+// active master key, while the service keeps running. Rows still in
+// cleartext, such as those written before encryption was enabled, are
+// encrypted too.
+//
+// With PII_MASTER_KEYS, put the new key first, restart the service and then
+// run it, old keys can be dropped once it is done. With PII_KMS_FILE, -new-key
+// adds the new key to the local KMS first.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/labstack/gommon/log"
+
+	"github.com/Beriw98/user-management/internal/config"
+	"github.com/Beriw98/user-management/internal/container"
+)
+
+func main() {
+	newKey := flag.Bool("new-key", false, "add a new master key to the local KMS and make it active first")
+	flag.Parse()
+
+	cfg := config.New()
+
+	ctr, err := container.NewContainer(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	if ctr.PIIRotation == nil {
+		log.Fatal("personal data is not encrypted, set PII_MASTER_KEYS or PII_KMS_FILE")
+	}
+
+	if *newKey {
+		if ctr.LocalKMS == nil {
+			log.Fatal("-new-key requires PII_KMS_FILE")
+		}
+
+		id, err := ctr.LocalKMS.Rotate()
+		if err != nil {
+			log.Fatal("adding a master key: ", err)
+		}
+		log.Info("master key ", id, " is active")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	n, err := ctr.PIIRotation.Rotate(ctx)
+	if err != nil {
+		log.Fatal("rotated ", n, " users before failing: ", err)
+	}
+
+	log.Info("rotated ", n, " users")
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/labstack/gommon/log"
 
@@ -13,6 +15,10 @@ import (
 	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv"
 )
 
+// shutdownTimeout is how long in-flight requests, imports and data exports
+// are waited for on shutdown.
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	cfg := config.New()
 
@@ -21,7 +27,27 @@ func main() {
 		panic(err)
 	}
 
-	r := httpsrv.NewRouter(ctr)
+	r, err := httpsrv.NewRouter(ctr)
+	if err != nil {
+		panic(err)
+	}
+
+	jobs, stopJobs := context.WithCancel(context.Background())
+	var running sync.WaitGroup
+	for _, run := range []func(context.Context){
+		ctr.UserPurge.Run,
+		ctr.DataExportPurge.Run,
+		ctr.StaleImports.Run,
+		ctr.OutboxRelay.Run,
+		ctr.WebhookDispatch.Run,
+		ctr.EventListener.Run,
+	} {
+		running.Add(1)
+		go func() {
+			defer running.Done()
+			run(jobs)
+		}()
+	}
 
 	go func() {
 		if err = r.Start(":8080"); err != nil {
@@ -34,11 +60,29 @@ func main() {
 	signal.Notify(quit, syscall.SIGTERM)
 	<-quit
 	log.Info("initiating graceful shutdown...")
+	stopJobs()
 
-	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
 
 	if err = r.Shutdown(ctx); err != nil {
-		r.Logger.Fatal(err)
+		log.Error("shutting down the server: ", err)
+	}
+
+	// Imports and data exports still running after the timeout are failed
+	// by the next instances.
+	if err = ctr.UserHandler.Wait(ctx); err != nil {
+		log.Error("waiting for imports: ", err)
+	}
+	if err = ctr.DataExport.Wait(ctx); err != nil {
+		log.Error("waiting for data exports: ", err)
+	}
+	running.Wait()
+
+	if ctr.Broker != nil {
+		if err = ctr.Broker.Close(); err != nil {
+			log.Error("closing the broker: ", err)
+		}
 	}
 
 }
@@ -0,0 +1,8 @@
+-- The service connects as app, which unlike postgres is subject to row
+-- level security. Tables are created by the migrations, as postgres.
+CREATE ROLE app LOGIN PASSWORD 'app';
+
+ALTER DEFAULT PRIVILEGES FOR ROLE postgres IN SCHEMA public
+    GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO app;
+ALTER DEFAULT PRIVILEGES FOR ROLE postgres IN SCHEMA public
+    GRANT USAGE, SELECT ON SEQUENCES TO app;
@@ -0,0 +1,246 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Beriw98/user-management/ent/auditevent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// AuditEvent is the model entity for the AuditEvent schema.
+type AuditEvent struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// OccurredAt holds the value of the "occurred_at" field.
+	OccurredAt time.Time `json:"occurred_at,omitempty"`
+	// Actor holds the value of the "actor" field.
+	Actor string `json:"actor,omitempty"`
+	// Action holds the value of the "action" field.
+	Action domain.AuditAction `json:"action,omitempty"`
+	// TargetType holds the value of the "target_type" field.
+	TargetType string `json:"target_type,omitempty"`
+	// TargetID holds the value of the "target_id" field.
+	TargetID string `json:"target_id,omitempty"`
+	// Changes holds the value of the "changes" field.
+	Changes map[string]domain.Change `json:"changes,omitempty"`
+	// RequestID holds the value of the "request_id" field.
+	RequestID string `json:"request_id,omitempty"`
+	// ClientIP holds the value of the "client_ip" field.
+	ClientIP string `json:"client_ip,omitempty"`
+	// UserAgent holds the value of the "user_agent" field.
+	UserAgent string `json:"user_agent,omitempty"`
+	// Certificate holds the value of the "certificate" field.
+	Certificate *domain.ErasureCertificate `json:"certificate,omitempty"`
+	// PrevHash holds the value of the "prev_hash" field.
+	PrevHash string `json:"prev_hash,omitempty"`
+	// Hash holds the value of the "hash" field.
+	Hash string `json:"hash,omitempty"`
+	// OrganizationID holds the value of the "organization_id" field.
+	OrganizationID string `json:"organization_id,omitempty"`
+	selectValues   sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*AuditEvent) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case auditevent.FieldChanges, auditevent.FieldCertificate:
+			values[i] = new([]byte)
+		case auditevent.FieldID:
+			values[i] = new(sql.NullInt64)
+		case auditevent.FieldActor, auditevent.FieldAction, auditevent.FieldTargetType, auditevent.FieldTargetID, auditevent.FieldRequestID, auditevent.FieldClientIP, auditevent.FieldUserAgent, auditevent.FieldPrevHash, auditevent.FieldHash, auditevent.FieldOrganizationID:
+			values[i] = new(sql.NullString)
+		case auditevent.FieldOccurredAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the AuditEvent fields.
+func (ae *AuditEvent) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case auditevent.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			ae.ID = int64(value.Int64)
+		case auditevent.FieldOccurredAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field occurred_at", values[i])
+			} else if value.Valid {
+				ae.OccurredAt = value.Time
+			}
+		case auditevent.FieldActor:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field actor", values[i])
+			} else if value.Valid {
+				ae.Actor = value.String
+			}
+		case auditevent.FieldAction:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field action", values[i])
+			} else if value.Valid {
+				ae.Action = domain.AuditAction(value.String)
+			}
+		case auditevent.FieldTargetType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field target_type", values[i])
+			} else if value.Valid {
+				ae.TargetType = value.String
+			}
+		case auditevent.FieldTargetID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field target_id", values[i])
+			} else if value.Valid {
+				ae.TargetID = value.String
+			}
+		case auditevent.FieldChanges:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field changes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ae.Changes); err != nil {
+					return fmt.Errorf("unmarshal field changes: %w", err)
+				}
+			}
+		case auditevent.FieldRequestID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field request_id", values[i])
+			} else if value.Valid {
+				ae.RequestID = value.String
+			}
+		case auditevent.FieldClientIP:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field client_ip", values[i])
+			} else if value.Valid {
+				ae.ClientIP = value.String
+			}
+		case auditevent.FieldUserAgent:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user_agent", values[i])
+			} else if value.Valid {
+				ae.UserAgent = value.String
+			}
+		case auditevent.FieldCertificate:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field certificate", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ae.Certificate); err != nil {
+					return fmt.Errorf("unmarshal field certificate: %w", err)
+				}
+			}
+		case auditevent.FieldPrevHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field prev_hash", values[i])
+			} else if value.Valid {
+				ae.PrevHash = value.String
+			}
+		case auditevent.FieldHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field hash", values[i])
+			} else if value.Valid {
+				ae.Hash = value.String
+			}
+		case auditevent.FieldOrganizationID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field organization_id", values[i])
+			} else if value.Valid {
+				ae.OrganizationID = value.String
+			}
+		default:
+			ae.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the AuditEvent.
+// This includes values selected through modifiers, order, etc.
+func (ae *AuditEvent) Value(name string) (ent.Value, error) {
+	return ae.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this AuditEvent.
+// Note that you need to call AuditEvent.Unwrap() before calling this method if this AuditEvent
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (ae *AuditEvent) Update() *AuditEventUpdateOne {
+	return NewAuditEventClient(ae.config).UpdateOne(ae)
+}
+
+// Unwrap unwraps the AuditEvent entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (ae *AuditEvent) Unwrap() *AuditEvent {
+	_tx, ok := ae.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: AuditEvent is not a transactional entity")
+	}
+	ae.config.driver = _tx.drv
+	return ae
+}
+
+// String implements the fmt.Stringer.
+func (ae *AuditEvent) String() string {
+	var builder strings.Builder
+	builder.WriteString("AuditEvent(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", ae.ID))
+	builder.WriteString("occurred_at=")
+	builder.WriteString(ae.OccurredAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("actor=")
+	builder.WriteString(ae.Actor)
+	builder.WriteString(", ")
+	builder.WriteString("action=")
+	builder.WriteString(fmt.Sprintf("%v", ae.Action))
+	builder.WriteString(", ")
+	builder.WriteString("target_type=")
+	builder.WriteString(ae.TargetType)
+	builder.WriteString(", ")
+	builder.WriteString("target_id=")
+	builder.WriteString(ae.TargetID)
+	builder.WriteString(", ")
+	builder.WriteString("changes=")
+	builder.WriteString(fmt.Sprintf("%v", ae.Changes))
+	builder.WriteString(", ")
+	builder.WriteString("request_id=")
+	builder.WriteString(ae.RequestID)
+	builder.WriteString(", ")
+	builder.WriteString("client_ip=")
+	builder.WriteString(ae.ClientIP)
+	builder.WriteString(", ")
+	builder.WriteString("user_agent=")
+	builder.WriteString(ae.UserAgent)
+	builder.WriteString(", ")
+	builder.WriteString("certificate=")
+	builder.WriteString(fmt.Sprintf("%v", ae.Certificate))
+	builder.WriteString(", ")
+	builder.WriteString("prev_hash=")
+	builder.WriteString(ae.PrevHash)
+	builder.WriteString(", ")
+	builder.WriteString("hash=")
+	builder.WriteString(ae.Hash)
+	builder.WriteString(", ")
+	builder.WriteString("organization_id=")
+	builder.WriteString(ae.OrganizationID)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// AuditEvents is a parsable slice of AuditEvent.
+type AuditEvents []*AuditEvent
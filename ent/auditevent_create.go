@@ -0,0 +1,751 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/auditevent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// AuditEventCreate is the builder for creating a AuditEvent entity.
+type AuditEventCreate struct {
+	config
+	mutation *AuditEventMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetOccurredAt sets the "occurred_at" field.
+func (aec *AuditEventCreate) SetOccurredAt(t time.Time) *AuditEventCreate {
+	aec.mutation.SetOccurredAt(t)
+	return aec
+}
+
+// SetActor sets the "actor" field.
+func (aec *AuditEventCreate) SetActor(s string) *AuditEventCreate {
+	aec.mutation.SetActor(s)
+	return aec
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (aec *AuditEventCreate) SetNillableActor(s *string) *AuditEventCreate {
+	if s != nil {
+		aec.SetActor(*s)
+	}
+	return aec
+}
+
+// SetAction sets the "action" field.
+func (aec *AuditEventCreate) SetAction(da domain.AuditAction) *AuditEventCreate {
+	aec.mutation.SetAction(da)
+	return aec
+}
+
+// SetTargetType sets the "target_type" field.
+func (aec *AuditEventCreate) SetTargetType(s string) *AuditEventCreate {
+	aec.mutation.SetTargetType(s)
+	return aec
+}
+
+// SetTargetID sets the "target_id" field.
+func (aec *AuditEventCreate) SetTargetID(s string) *AuditEventCreate {
+	aec.mutation.SetTargetID(s)
+	return aec
+}
+
+// SetChanges sets the "changes" field.
+func (aec *AuditEventCreate) SetChanges(m map[string]domain.Change) *AuditEventCreate {
+	aec.mutation.SetChanges(m)
+	return aec
+}
+
+// SetRequestID sets the "request_id" field.
+func (aec *AuditEventCreate) SetRequestID(s string) *AuditEventCreate {
+	aec.mutation.SetRequestID(s)
+	return aec
+}
+
+// SetNillableRequestID sets the "request_id" field if the given value is not nil.
+func (aec *AuditEventCreate) SetNillableRequestID(s *string) *AuditEventCreate {
+	if s != nil {
+		aec.SetRequestID(*s)
+	}
+	return aec
+}
+
+// SetClientIP sets the "client_ip" field.
+func (aec *AuditEventCreate) SetClientIP(s string) *AuditEventCreate {
+	aec.mutation.SetClientIP(s)
+	return aec
+}
+
+// SetNillableClientIP sets the "client_ip" field if the given value is not nil.
+func (aec *AuditEventCreate) SetNillableClientIP(s *string) *AuditEventCreate {
+	if s != nil {
+		aec.SetClientIP(*s)
+	}
+	return aec
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (aec *AuditEventCreate) SetUserAgent(s string) *AuditEventCreate {
+	aec.mutation.SetUserAgent(s)
+	return aec
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (aec *AuditEventCreate) SetNillableUserAgent(s *string) *AuditEventCreate {
+	if s != nil {
+		aec.SetUserAgent(*s)
+	}
+	return aec
+}
+
+// SetCertificate sets the "certificate" field.
+func (aec *AuditEventCreate) SetCertificate(dc *domain.ErasureCertificate) *AuditEventCreate {
+	aec.mutation.SetCertificate(dc)
+	return aec
+}
+
+// SetPrevHash sets the "prev_hash" field.
+func (aec *AuditEventCreate) SetPrevHash(s string) *AuditEventCreate {
+	aec.mutation.SetPrevHash(s)
+	return aec
+}
+
+// SetHash sets the "hash" field.
+func (aec *AuditEventCreate) SetHash(s string) *AuditEventCreate {
+	aec.mutation.SetHash(s)
+	return aec
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (aec *AuditEventCreate) SetOrganizationID(s string) *AuditEventCreate {
+	aec.mutation.SetOrganizationID(s)
+	return aec
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (aec *AuditEventCreate) SetNillableOrganizationID(s *string) *AuditEventCreate {
+	if s != nil {
+		aec.SetOrganizationID(*s)
+	}
+	return aec
+}
+
+// SetID sets the "id" field.
+func (aec *AuditEventCreate) SetID(i int64) *AuditEventCreate {
+	aec.mutation.SetID(i)
+	return aec
+}
+
+// Mutation returns the AuditEventMutation object of the builder.
+func (aec *AuditEventCreate) Mutation() *AuditEventMutation {
+	return aec.mutation
+}
+
+// Save creates the AuditEvent in the database.
+func (aec *AuditEventCreate) Save(ctx context.Context) (*AuditEvent, error) {
+	if err := aec.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, aec.sqlSave, aec.mutation, aec.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (aec *AuditEventCreate) SaveX(ctx context.Context) *AuditEvent {
+	v, err := aec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (aec *AuditEventCreate) Exec(ctx context.Context) error {
+	_, err := aec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aec *AuditEventCreate) ExecX(ctx context.Context) {
+	if err := aec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (aec *AuditEventCreate) defaults() error {
+	if _, ok := aec.mutation.Actor(); !ok {
+		v := auditevent.DefaultActor
+		aec.mutation.SetActor(v)
+	}
+	if _, ok := aec.mutation.RequestID(); !ok {
+		v := auditevent.DefaultRequestID
+		aec.mutation.SetRequestID(v)
+	}
+	if _, ok := aec.mutation.ClientIP(); !ok {
+		v := auditevent.DefaultClientIP
+		aec.mutation.SetClientIP(v)
+	}
+	if _, ok := aec.mutation.UserAgent(); !ok {
+		v := auditevent.DefaultUserAgent
+		aec.mutation.SetUserAgent(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (aec *AuditEventCreate) check() error {
+	if _, ok := aec.mutation.OccurredAt(); !ok {
+		return &ValidationError{Name: "occurred_at", err: errors.New(`ent: missing required field "AuditEvent.occurred_at"`)}
+	}
+	if _, ok := aec.mutation.Actor(); !ok {
+		return &ValidationError{Name: "actor", err: errors.New(`ent: missing required field "AuditEvent.actor"`)}
+	}
+	if _, ok := aec.mutation.Action(); !ok {
+		return &ValidationError{Name: "action", err: errors.New(`ent: missing required field "AuditEvent.action"`)}
+	}
+	if v, ok := aec.mutation.Action(); ok {
+		if err := auditevent.ActionValidator(string(v)); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "AuditEvent.action": %w`, err)}
+		}
+	}
+	if _, ok := aec.mutation.TargetType(); !ok {
+		return &ValidationError{Name: "target_type", err: errors.New(`ent: missing required field "AuditEvent.target_type"`)}
+	}
+	if v, ok := aec.mutation.TargetType(); ok {
+		if err := auditevent.TargetTypeValidator(v); err != nil {
+			return &ValidationError{Name: "target_type", err: fmt.Errorf(`ent: validator failed for field "AuditEvent.target_type": %w`, err)}
+		}
+	}
+	if _, ok := aec.mutation.TargetID(); !ok {
+		return &ValidationError{Name: "target_id", err: errors.New(`ent: missing required field "AuditEvent.target_id"`)}
+	}
+	if v, ok := aec.mutation.TargetID(); ok {
+		if err := auditevent.TargetIDValidator(v); err != nil {
+			return &ValidationError{Name: "target_id", err: fmt.Errorf(`ent: validator failed for field "AuditEvent.target_id": %w`, err)}
+		}
+	}
+	if _, ok := aec.mutation.RequestID(); !ok {
+		return &ValidationError{Name: "request_id", err: errors.New(`ent: missing required field "AuditEvent.request_id"`)}
+	}
+	if _, ok := aec.mutation.ClientIP(); !ok {
+		return &ValidationError{Name: "client_ip", err: errors.New(`ent: missing required field "AuditEvent.client_ip"`)}
+	}
+	if _, ok := aec.mutation.UserAgent(); !ok {
+		return &ValidationError{Name: "user_agent", err: errors.New(`ent: missing required field "AuditEvent.user_agent"`)}
+	}
+	if _, ok := aec.mutation.PrevHash(); !ok {
+		return &ValidationError{Name: "prev_hash", err: errors.New(`ent: missing required field "AuditEvent.prev_hash"`)}
+	}
+	if _, ok := aec.mutation.Hash(); !ok {
+		return &ValidationError{Name: "hash", err: errors.New(`ent: missing required field "AuditEvent.hash"`)}
+	}
+	return nil
+}
+
+func (aec *AuditEventCreate) sqlSave(ctx context.Context) (*AuditEvent, error) {
+	if err := aec.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := aec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, aec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	aec.mutation.id = &_node.ID
+	aec.mutation.done = true
+	return _node, nil
+}
+
+func (aec *AuditEventCreate) createSpec() (*AuditEvent, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AuditEvent{config: aec.config}
+		_spec = sqlgraph.NewCreateSpec(auditevent.Table, sqlgraph.NewFieldSpec(auditevent.FieldID, field.TypeInt64))
+	)
+	_spec.OnConflict = aec.conflict
+	if id, ok := aec.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := aec.mutation.OccurredAt(); ok {
+		_spec.SetField(auditevent.FieldOccurredAt, field.TypeTime, value)
+		_node.OccurredAt = value
+	}
+	if value, ok := aec.mutation.Actor(); ok {
+		_spec.SetField(auditevent.FieldActor, field.TypeString, value)
+		_node.Actor = value
+	}
+	if value, ok := aec.mutation.Action(); ok {
+		_spec.SetField(auditevent.FieldAction, field.TypeString, value)
+		_node.Action = value
+	}
+	if value, ok := aec.mutation.TargetType(); ok {
+		_spec.SetField(auditevent.FieldTargetType, field.TypeString, value)
+		_node.TargetType = value
+	}
+	if value, ok := aec.mutation.TargetID(); ok {
+		_spec.SetField(auditevent.FieldTargetID, field.TypeString, value)
+		_node.TargetID = value
+	}
+	if value, ok := aec.mutation.Changes(); ok {
+		_spec.SetField(auditevent.FieldChanges, field.TypeJSON, value)
+		_node.Changes = value
+	}
+	if value, ok := aec.mutation.RequestID(); ok {
+		_spec.SetField(auditevent.FieldRequestID, field.TypeString, value)
+		_node.RequestID = value
+	}
+	if value, ok := aec.mutation.ClientIP(); ok {
+		_spec.SetField(auditevent.FieldClientIP, field.TypeString, value)
+		_node.ClientIP = value
+	}
+	if value, ok := aec.mutation.UserAgent(); ok {
+		_spec.SetField(auditevent.FieldUserAgent, field.TypeString, value)
+		_node.UserAgent = value
+	}
+	if value, ok := aec.mutation.Certificate(); ok {
+		_spec.SetField(auditevent.FieldCertificate, field.TypeJSON, value)
+		_node.Certificate = value
+	}
+	if value, ok := aec.mutation.PrevHash(); ok {
+		_spec.SetField(auditevent.FieldPrevHash, field.TypeString, value)
+		_node.PrevHash = value
+	}
+	if value, ok := aec.mutation.Hash(); ok {
+		_spec.SetField(auditevent.FieldHash, field.TypeString, value)
+		_node.Hash = value
+	}
+	if value, ok := aec.mutation.OrganizationID(); ok {
+		_spec.SetField(auditevent.FieldOrganizationID, field.TypeString, value)
+		_node.OrganizationID = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.AuditEvent.Create().
+//		SetOccurredAt(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.AuditEventUpsert) {
+//			SetOccurredAt(v+v).
+//		}).
+//		Exec(ctx)
+func (aec *AuditEventCreate) OnConflict(opts ...sql.ConflictOption) *AuditEventUpsertOne {
+	aec.conflict = opts
+	return &AuditEventUpsertOne{
+		create: aec,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.AuditEvent.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (aec *AuditEventCreate) OnConflictColumns(columns ...string) *AuditEventUpsertOne {
+	aec.conflict = append(aec.conflict, sql.ConflictColumns(columns...))
+	return &AuditEventUpsertOne{
+		create: aec,
+	}
+}
+
+type (
+	// AuditEventUpsertOne is the builder for "upsert"-ing
+	//  one AuditEvent node.
+	AuditEventUpsertOne struct {
+		create *AuditEventCreate
+	}
+
+	// AuditEventUpsert is the "OnConflict" setter.
+	AuditEventUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.AuditEvent.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(auditevent.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *AuditEventUpsertOne) UpdateNewValues() *AuditEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(auditevent.FieldID)
+		}
+		if _, exists := u.create.mutation.OccurredAt(); exists {
+			s.SetIgnore(auditevent.FieldOccurredAt)
+		}
+		if _, exists := u.create.mutation.Actor(); exists {
+			s.SetIgnore(auditevent.FieldActor)
+		}
+		if _, exists := u.create.mutation.Action(); exists {
+			s.SetIgnore(auditevent.FieldAction)
+		}
+		if _, exists := u.create.mutation.TargetType(); exists {
+			s.SetIgnore(auditevent.FieldTargetType)
+		}
+		if _, exists := u.create.mutation.TargetID(); exists {
+			s.SetIgnore(auditevent.FieldTargetID)
+		}
+		if _, exists := u.create.mutation.Changes(); exists {
+			s.SetIgnore(auditevent.FieldChanges)
+		}
+		if _, exists := u.create.mutation.RequestID(); exists {
+			s.SetIgnore(auditevent.FieldRequestID)
+		}
+		if _, exists := u.create.mutation.ClientIP(); exists {
+			s.SetIgnore(auditevent.FieldClientIP)
+		}
+		if _, exists := u.create.mutation.UserAgent(); exists {
+			s.SetIgnore(auditevent.FieldUserAgent)
+		}
+		if _, exists := u.create.mutation.Certificate(); exists {
+			s.SetIgnore(auditevent.FieldCertificate)
+		}
+		if _, exists := u.create.mutation.PrevHash(); exists {
+			s.SetIgnore(auditevent.FieldPrevHash)
+		}
+		if _, exists := u.create.mutation.Hash(); exists {
+			s.SetIgnore(auditevent.FieldHash)
+		}
+		if _, exists := u.create.mutation.OrganizationID(); exists {
+			s.SetIgnore(auditevent.FieldOrganizationID)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.AuditEvent.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *AuditEventUpsertOne) Ignore() *AuditEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *AuditEventUpsertOne) DoNothing() *AuditEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the AuditEventCreate.OnConflict
+// documentation for more info.
+func (u *AuditEventUpsertOne) Update(set func(*AuditEventUpsert)) *AuditEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&AuditEventUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// Exec executes the query.
+func (u *AuditEventUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for AuditEventCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *AuditEventUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *AuditEventUpsertOne) ID(ctx context.Context) (id int64, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *AuditEventUpsertOne) IDX(ctx context.Context) int64 {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// AuditEventCreateBulk is the builder for creating many AuditEvent entities in bulk.
+type AuditEventCreateBulk struct {
+	config
+	err      error
+	builders []*AuditEventCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the AuditEvent entities in the database.
+func (aecb *AuditEventCreateBulk) Save(ctx context.Context) ([]*AuditEvent, error) {
+	if aecb.err != nil {
+		return nil, aecb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(aecb.builders))
+	nodes := make([]*AuditEvent, len(aecb.builders))
+	mutators := make([]Mutator, len(aecb.builders))
+	for i := range aecb.builders {
+		func(i int, root context.Context) {
+			builder := aecb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AuditEventMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, aecb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = aecb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, aecb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, aecb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aecb *AuditEventCreateBulk) SaveX(ctx context.Context) []*AuditEvent {
+	v, err := aecb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (aecb *AuditEventCreateBulk) Exec(ctx context.Context) error {
+	_, err := aecb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aecb *AuditEventCreateBulk) ExecX(ctx context.Context) {
+	if err := aecb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.AuditEvent.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.AuditEventUpsert) {
+//			SetOccurredAt(v+v).
+//		}).
+//		Exec(ctx)
+func (aecb *AuditEventCreateBulk) OnConflict(opts ...sql.ConflictOption) *AuditEventUpsertBulk {
+	aecb.conflict = opts
+	return &AuditEventUpsertBulk{
+		create: aecb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.AuditEvent.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (aecb *AuditEventCreateBulk) OnConflictColumns(columns ...string) *AuditEventUpsertBulk {
+	aecb.conflict = append(aecb.conflict, sql.ConflictColumns(columns...))
+	return &AuditEventUpsertBulk{
+		create: aecb,
+	}
+}
+
+// AuditEventUpsertBulk is the builder for "upsert"-ing
+// a bulk of AuditEvent nodes.
+type AuditEventUpsertBulk struct {
+	create *AuditEventCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.AuditEvent.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(auditevent.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *AuditEventUpsertBulk) UpdateNewValues() *AuditEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(auditevent.FieldID)
+			}
+			if _, exists := b.mutation.OccurredAt(); exists {
+				s.SetIgnore(auditevent.FieldOccurredAt)
+			}
+			if _, exists := b.mutation.Actor(); exists {
+				s.SetIgnore(auditevent.FieldActor)
+			}
+			if _, exists := b.mutation.Action(); exists {
+				s.SetIgnore(auditevent.FieldAction)
+			}
+			if _, exists := b.mutation.TargetType(); exists {
+				s.SetIgnore(auditevent.FieldTargetType)
+			}
+			if _, exists := b.mutation.TargetID(); exists {
+				s.SetIgnore(auditevent.FieldTargetID)
+			}
+			if _, exists := b.mutation.Changes(); exists {
+				s.SetIgnore(auditevent.FieldChanges)
+			}
+			if _, exists := b.mutation.RequestID(); exists {
+				s.SetIgnore(auditevent.FieldRequestID)
+			}
+			if _, exists := b.mutation.ClientIP(); exists {
+				s.SetIgnore(auditevent.FieldClientIP)
+			}
+			if _, exists := b.mutation.UserAgent(); exists {
+				s.SetIgnore(auditevent.FieldUserAgent)
+			}
+			if _, exists := b.mutation.Certificate(); exists {
+				s.SetIgnore(auditevent.FieldCertificate)
+			}
+			if _, exists := b.mutation.PrevHash(); exists {
+				s.SetIgnore(auditevent.FieldPrevHash)
+			}
+			if _, exists := b.mutation.Hash(); exists {
+				s.SetIgnore(auditevent.FieldHash)
+			}
+			if _, exists := b.mutation.OrganizationID(); exists {
+				s.SetIgnore(auditevent.FieldOrganizationID)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.AuditEvent.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *AuditEventUpsertBulk) Ignore() *AuditEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *AuditEventUpsertBulk) DoNothing() *AuditEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the AuditEventCreateBulk.OnConflict
+// documentation for more info.
+func (u *AuditEventUpsertBulk) Update(set func(*AuditEventUpsert)) *AuditEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&AuditEventUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// Exec executes the query.
+func (u *AuditEventUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the AuditEventCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for AuditEventCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *AuditEventUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
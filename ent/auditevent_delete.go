@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/auditevent"
+	"github.com/Beriw98/user-management/ent/predicate"
+)
+
+// AuditEventDelete is the builder for deleting a AuditEvent entity.
+type AuditEventDelete struct {
+	config
+	hooks    []Hook
+	mutation *AuditEventMutation
+}
+
+// Where appends a list predicates to the AuditEventDelete builder.
+func (aed *AuditEventDelete) Where(ps ...predicate.AuditEvent) *AuditEventDelete {
+	aed.mutation.Where(ps...)
+	return aed
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (aed *AuditEventDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, aed.sqlExec, aed.mutation, aed.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aed *AuditEventDelete) ExecX(ctx context.Context) int {
+	n, err := aed.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (aed *AuditEventDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(auditevent.Table, sqlgraph.NewFieldSpec(auditevent.FieldID, field.TypeInt64))
+	if ps := aed.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, aed.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	aed.mutation.done = true
+	return affected, err
+}
+
+// AuditEventDeleteOne is the builder for deleting a single AuditEvent entity.
+type AuditEventDeleteOne struct {
+	aed *AuditEventDelete
+}
+
+// Where appends a list predicates to the AuditEventDelete builder.
+func (aedo *AuditEventDeleteOne) Where(ps ...predicate.AuditEvent) *AuditEventDeleteOne {
+	aedo.aed.mutation.Where(ps...)
+	return aedo
+}
+
+// Exec executes the deletion query.
+func (aedo *AuditEventDeleteOne) Exec(ctx context.Context) error {
+	n, err := aedo.aed.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{auditevent.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aedo *AuditEventDeleteOne) ExecX(ctx context.Context) {
+	if err := aedo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,182 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Beriw98/user-management/ent/consent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// Consent is the model entity for the Consent schema.
+type Consent struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID string `json:"id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID string `json:"user_id,omitempty"`
+	// DocumentID holds the value of the "document_id" field.
+	DocumentID string `json:"document_id,omitempty"`
+	// Kind holds the value of the "kind" field.
+	Kind domain.PolicyKind `json:"kind,omitempty"`
+	// Version holds the value of the "version" field.
+	Version string `json:"version,omitempty"`
+	// AcceptedAt holds the value of the "accepted_at" field.
+	AcceptedAt time.Time `json:"accepted_at,omitempty"`
+	// ClientIP holds the value of the "client_ip" field.
+	ClientIP string `json:"client_ip,omitempty"`
+	// UserAgent holds the value of the "user_agent" field.
+	UserAgent string `json:"user_agent,omitempty"`
+	// OrganizationID holds the value of the "organization_id" field.
+	OrganizationID string `json:"organization_id,omitempty"`
+	selectValues   sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Consent) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case consent.FieldID, consent.FieldUserID, consent.FieldDocumentID, consent.FieldKind, consent.FieldVersion, consent.FieldClientIP, consent.FieldUserAgent, consent.FieldOrganizationID:
+			values[i] = new(sql.NullString)
+		case consent.FieldAcceptedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Consent fields.
+func (c *Consent) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case consent.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				c.ID = value.String
+			}
+		case consent.FieldUserID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				c.UserID = value.String
+			}
+		case consent.FieldDocumentID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field document_id", values[i])
+			} else if value.Valid {
+				c.DocumentID = value.String
+			}
+		case consent.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				c.Kind = domain.PolicyKind(value.String)
+			}
+		case consent.FieldVersion:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				c.Version = value.String
+			}
+		case consent.FieldAcceptedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field accepted_at", values[i])
+			} else if value.Valid {
+				c.AcceptedAt = value.Time
+			}
+		case consent.FieldClientIP:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field client_ip", values[i])
+			} else if value.Valid {
+				c.ClientIP = value.String
+			}
+		case consent.FieldUserAgent:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user_agent", values[i])
+			} else if value.Valid {
+				c.UserAgent = value.String
+			}
+		case consent.FieldOrganizationID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field organization_id", values[i])
+			} else if value.Valid {
+				c.OrganizationID = value.String
+			}
+		default:
+			c.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Consent.
+// This includes values selected through modifiers, order, etc.
+func (c *Consent) Value(name string) (ent.Value, error) {
+	return c.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Consent.
+// Note that you need to call Consent.Unwrap() before calling this method if this Consent
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (c *Consent) Update() *ConsentUpdateOne {
+	return NewConsentClient(c.config).UpdateOne(c)
+}
+
+// Unwrap unwraps the Consent entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (c *Consent) Unwrap() *Consent {
+	_tx, ok := c.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Consent is not a transactional entity")
+	}
+	c.config.driver = _tx.drv
+	return c
+}
+
+// String implements the fmt.Stringer.
+func (c *Consent) String() string {
+	var builder strings.Builder
+	builder.WriteString("Consent(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", c.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(c.UserID)
+	builder.WriteString(", ")
+	builder.WriteString("document_id=")
+	builder.WriteString(c.DocumentID)
+	builder.WriteString(", ")
+	builder.WriteString("kind=")
+	builder.WriteString(fmt.Sprintf("%v", c.Kind))
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(c.Version)
+	builder.WriteString(", ")
+	builder.WriteString("accepted_at=")
+	builder.WriteString(c.AcceptedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("client_ip=")
+	builder.WriteString(c.ClientIP)
+	builder.WriteString(", ")
+	builder.WriteString("user_agent=")
+	builder.WriteString(c.UserAgent)
+	builder.WriteString(", ")
+	builder.WriteString("organization_id=")
+	builder.WriteString(c.OrganizationID)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Consents is a parsable slice of Consent.
+type Consents []*Consent
@@ -0,0 +1,623 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/consent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// ConsentCreate is the builder for creating a Consent entity.
+type ConsentCreate struct {
+	config
+	mutation *ConsentMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetUserID sets the "user_id" field.
+func (cc *ConsentCreate) SetUserID(s string) *ConsentCreate {
+	cc.mutation.SetUserID(s)
+	return cc
+}
+
+// SetDocumentID sets the "document_id" field.
+func (cc *ConsentCreate) SetDocumentID(s string) *ConsentCreate {
+	cc.mutation.SetDocumentID(s)
+	return cc
+}
+
+// SetKind sets the "kind" field.
+func (cc *ConsentCreate) SetKind(dk domain.PolicyKind) *ConsentCreate {
+	cc.mutation.SetKind(dk)
+	return cc
+}
+
+// SetVersion sets the "version" field.
+func (cc *ConsentCreate) SetVersion(s string) *ConsentCreate {
+	cc.mutation.SetVersion(s)
+	return cc
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (cc *ConsentCreate) SetAcceptedAt(t time.Time) *ConsentCreate {
+	cc.mutation.SetAcceptedAt(t)
+	return cc
+}
+
+// SetNillableAcceptedAt sets the "accepted_at" field if the given value is not nil.
+func (cc *ConsentCreate) SetNillableAcceptedAt(t *time.Time) *ConsentCreate {
+	if t != nil {
+		cc.SetAcceptedAt(*t)
+	}
+	return cc
+}
+
+// SetClientIP sets the "client_ip" field.
+func (cc *ConsentCreate) SetClientIP(s string) *ConsentCreate {
+	cc.mutation.SetClientIP(s)
+	return cc
+}
+
+// SetNillableClientIP sets the "client_ip" field if the given value is not nil.
+func (cc *ConsentCreate) SetNillableClientIP(s *string) *ConsentCreate {
+	if s != nil {
+		cc.SetClientIP(*s)
+	}
+	return cc
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (cc *ConsentCreate) SetUserAgent(s string) *ConsentCreate {
+	cc.mutation.SetUserAgent(s)
+	return cc
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (cc *ConsentCreate) SetNillableUserAgent(s *string) *ConsentCreate {
+	if s != nil {
+		cc.SetUserAgent(*s)
+	}
+	return cc
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (cc *ConsentCreate) SetOrganizationID(s string) *ConsentCreate {
+	cc.mutation.SetOrganizationID(s)
+	return cc
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (cc *ConsentCreate) SetNillableOrganizationID(s *string) *ConsentCreate {
+	if s != nil {
+		cc.SetOrganizationID(*s)
+	}
+	return cc
+}
+
+// SetID sets the "id" field.
+func (cc *ConsentCreate) SetID(s string) *ConsentCreate {
+	cc.mutation.SetID(s)
+	return cc
+}
+
+// Mutation returns the ConsentMutation object of the builder.
+func (cc *ConsentCreate) Mutation() *ConsentMutation {
+	return cc.mutation
+}
+
+// Save creates the Consent in the database.
+func (cc *ConsentCreate) Save(ctx context.Context) (*Consent, error) {
+	cc.defaults()
+	return withHooks(ctx, cc.sqlSave, cc.mutation, cc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (cc *ConsentCreate) SaveX(ctx context.Context) *Consent {
+	v, err := cc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cc *ConsentCreate) Exec(ctx context.Context) error {
+	_, err := cc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cc *ConsentCreate) ExecX(ctx context.Context) {
+	if err := cc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cc *ConsentCreate) defaults() {
+	if _, ok := cc.mutation.AcceptedAt(); !ok {
+		v := consent.DefaultAcceptedAt()
+		cc.mutation.SetAcceptedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cc *ConsentCreate) check() error {
+	if _, ok := cc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "Consent.user_id"`)}
+	}
+	if _, ok := cc.mutation.DocumentID(); !ok {
+		return &ValidationError{Name: "document_id", err: errors.New(`ent: missing required field "Consent.document_id"`)}
+	}
+	if _, ok := cc.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "Consent.kind"`)}
+	}
+	if _, ok := cc.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "Consent.version"`)}
+	}
+	if _, ok := cc.mutation.AcceptedAt(); !ok {
+		return &ValidationError{Name: "accepted_at", err: errors.New(`ent: missing required field "Consent.accepted_at"`)}
+	}
+	return nil
+}
+
+func (cc *ConsentCreate) sqlSave(ctx context.Context) (*Consent, error) {
+	if err := cc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := cc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, cc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected Consent.ID type: %T", _spec.ID.Value)
+		}
+	}
+	cc.mutation.id = &_node.ID
+	cc.mutation.done = true
+	return _node, nil
+}
+
+func (cc *ConsentCreate) createSpec() (*Consent, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Consent{config: cc.config}
+		_spec = sqlgraph.NewCreateSpec(consent.Table, sqlgraph.NewFieldSpec(consent.FieldID, field.TypeString))
+	)
+	_spec.OnConflict = cc.conflict
+	if id, ok := cc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := cc.mutation.UserID(); ok {
+		_spec.SetField(consent.FieldUserID, field.TypeString, value)
+		_node.UserID = value
+	}
+	if value, ok := cc.mutation.DocumentID(); ok {
+		_spec.SetField(consent.FieldDocumentID, field.TypeString, value)
+		_node.DocumentID = value
+	}
+	if value, ok := cc.mutation.Kind(); ok {
+		_spec.SetField(consent.FieldKind, field.TypeString, value)
+		_node.Kind = value
+	}
+	if value, ok := cc.mutation.Version(); ok {
+		_spec.SetField(consent.FieldVersion, field.TypeString, value)
+		_node.Version = value
+	}
+	if value, ok := cc.mutation.AcceptedAt(); ok {
+		_spec.SetField(consent.FieldAcceptedAt, field.TypeTime, value)
+		_node.AcceptedAt = value
+	}
+	if value, ok := cc.mutation.ClientIP(); ok {
+		_spec.SetField(consent.FieldClientIP, field.TypeString, value)
+		_node.ClientIP = value
+	}
+	if value, ok := cc.mutation.UserAgent(); ok {
+		_spec.SetField(consent.FieldUserAgent, field.TypeString, value)
+		_node.UserAgent = value
+	}
+	if value, ok := cc.mutation.OrganizationID(); ok {
+		_spec.SetField(consent.FieldOrganizationID, field.TypeString, value)
+		_node.OrganizationID = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Consent.Create().
+//		SetUserID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.ConsentUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (cc *ConsentCreate) OnConflict(opts ...sql.ConflictOption) *ConsentUpsertOne {
+	cc.conflict = opts
+	return &ConsentUpsertOne{
+		create: cc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Consent.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (cc *ConsentCreate) OnConflictColumns(columns ...string) *ConsentUpsertOne {
+	cc.conflict = append(cc.conflict, sql.ConflictColumns(columns...))
+	return &ConsentUpsertOne{
+		create: cc,
+	}
+}
+
+type (
+	// ConsentUpsertOne is the builder for "upsert"-ing
+	//  one Consent node.
+	ConsentUpsertOne struct {
+		create *ConsentCreate
+	}
+
+	// ConsentUpsert is the "OnConflict" setter.
+	ConsentUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.Consent.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(consent.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *ConsentUpsertOne) UpdateNewValues() *ConsentUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(consent.FieldID)
+		}
+		if _, exists := u.create.mutation.UserID(); exists {
+			s.SetIgnore(consent.FieldUserID)
+		}
+		if _, exists := u.create.mutation.DocumentID(); exists {
+			s.SetIgnore(consent.FieldDocumentID)
+		}
+		if _, exists := u.create.mutation.Kind(); exists {
+			s.SetIgnore(consent.FieldKind)
+		}
+		if _, exists := u.create.mutation.Version(); exists {
+			s.SetIgnore(consent.FieldVersion)
+		}
+		if _, exists := u.create.mutation.AcceptedAt(); exists {
+			s.SetIgnore(consent.FieldAcceptedAt)
+		}
+		if _, exists := u.create.mutation.ClientIP(); exists {
+			s.SetIgnore(consent.FieldClientIP)
+		}
+		if _, exists := u.create.mutation.UserAgent(); exists {
+			s.SetIgnore(consent.FieldUserAgent)
+		}
+		if _, exists := u.create.mutation.OrganizationID(); exists {
+			s.SetIgnore(consent.FieldOrganizationID)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Consent.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *ConsentUpsertOne) Ignore() *ConsentUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *ConsentUpsertOne) DoNothing() *ConsentUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the ConsentCreate.OnConflict
+// documentation for more info.
+func (u *ConsentUpsertOne) Update(set func(*ConsentUpsert)) *ConsentUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&ConsentUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// Exec executes the query.
+func (u *ConsentUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for ConsentCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *ConsentUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *ConsentUpsertOne) ID(ctx context.Context) (id string, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: ConsentUpsertOne.ID is not supported by MySQL driver. Use ConsentUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *ConsentUpsertOne) IDX(ctx context.Context) string {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// ConsentCreateBulk is the builder for creating many Consent entities in bulk.
+type ConsentCreateBulk struct {
+	config
+	err      error
+	builders []*ConsentCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the Consent entities in the database.
+func (ccb *ConsentCreateBulk) Save(ctx context.Context) ([]*Consent, error) {
+	if ccb.err != nil {
+		return nil, ccb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ccb.builders))
+	nodes := make([]*Consent, len(ccb.builders))
+	mutators := make([]Mutator, len(ccb.builders))
+	for i := range ccb.builders {
+		func(i int, root context.Context) {
+			builder := ccb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ConsentMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = ccb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ccb *ConsentCreateBulk) SaveX(ctx context.Context) []*Consent {
+	v, err := ccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ccb *ConsentCreateBulk) Exec(ctx context.Context) error {
+	_, err := ccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ccb *ConsentCreateBulk) ExecX(ctx context.Context) {
+	if err := ccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Consent.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.ConsentUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (ccb *ConsentCreateBulk) OnConflict(opts ...sql.ConflictOption) *ConsentUpsertBulk {
+	ccb.conflict = opts
+	return &ConsentUpsertBulk{
+		create: ccb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Consent.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (ccb *ConsentCreateBulk) OnConflictColumns(columns ...string) *ConsentUpsertBulk {
+	ccb.conflict = append(ccb.conflict, sql.ConflictColumns(columns...))
+	return &ConsentUpsertBulk{
+		create: ccb,
+	}
+}
+
+// ConsentUpsertBulk is the builder for "upsert"-ing
+// a bulk of Consent nodes.
+type ConsentUpsertBulk struct {
+	create *ConsentCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.Consent.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(consent.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *ConsentUpsertBulk) UpdateNewValues() *ConsentUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(consent.FieldID)
+			}
+			if _, exists := b.mutation.UserID(); exists {
+				s.SetIgnore(consent.FieldUserID)
+			}
+			if _, exists := b.mutation.DocumentID(); exists {
+				s.SetIgnore(consent.FieldDocumentID)
+			}
+			if _, exists := b.mutation.Kind(); exists {
+				s.SetIgnore(consent.FieldKind)
+			}
+			if _, exists := b.mutation.Version(); exists {
+				s.SetIgnore(consent.FieldVersion)
+			}
+			if _, exists := b.mutation.AcceptedAt(); exists {
+				s.SetIgnore(consent.FieldAcceptedAt)
+			}
+			if _, exists := b.mutation.ClientIP(); exists {
+				s.SetIgnore(consent.FieldClientIP)
+			}
+			if _, exists := b.mutation.UserAgent(); exists {
+				s.SetIgnore(consent.FieldUserAgent)
+			}
+			if _, exists := b.mutation.OrganizationID(); exists {
+				s.SetIgnore(consent.FieldOrganizationID)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Consent.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *ConsentUpsertBulk) Ignore() *ConsentUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *ConsentUpsertBulk) DoNothing() *ConsentUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the ConsentCreateBulk.OnConflict
+// documentation for more info.
+func (u *ConsentUpsertBulk) Update(set func(*ConsentUpsert)) *ConsentUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&ConsentUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// Exec executes the query.
+func (u *ConsentUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the ConsentCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for ConsentCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *ConsentUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,193 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/consent"
+	"github.com/Beriw98/user-management/ent/predicate"
+)
+
+// ConsentUpdate is the builder for updating Consent entities.
+type ConsentUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ConsentMutation
+}
+
+// Where appends a list predicates to the ConsentUpdate builder.
+func (cu *ConsentUpdate) Where(ps ...predicate.Consent) *ConsentUpdate {
+	cu.mutation.Where(ps...)
+	return cu
+}
+
+// Mutation returns the ConsentMutation object of the builder.
+func (cu *ConsentUpdate) Mutation() *ConsentMutation {
+	return cu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (cu *ConsentUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, cu.sqlSave, cu.mutation, cu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cu *ConsentUpdate) SaveX(ctx context.Context) int {
+	affected, err := cu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (cu *ConsentUpdate) Exec(ctx context.Context) error {
+	_, err := cu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cu *ConsentUpdate) ExecX(ctx context.Context) {
+	if err := cu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (cu *ConsentUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(consent.Table, consent.Columns, sqlgraph.NewFieldSpec(consent.FieldID, field.TypeString))
+	if ps := cu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if cu.mutation.ClientIPCleared() {
+		_spec.ClearField(consent.FieldClientIP, field.TypeString)
+	}
+	if cu.mutation.UserAgentCleared() {
+		_spec.ClearField(consent.FieldUserAgent, field.TypeString)
+	}
+	if cu.mutation.OrganizationIDCleared() {
+		_spec.ClearField(consent.FieldOrganizationID, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, cu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{consent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	cu.mutation.done = true
+	return n, nil
+}
+
+// ConsentUpdateOne is the builder for updating a single Consent entity.
+type ConsentUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ConsentMutation
+}
+
+// Mutation returns the ConsentMutation object of the builder.
+func (cuo *ConsentUpdateOne) Mutation() *ConsentMutation {
+	return cuo.mutation
+}
+
+// Where appends a list predicates to the ConsentUpdate builder.
+func (cuo *ConsentUpdateOne) Where(ps ...predicate.Consent) *ConsentUpdateOne {
+	cuo.mutation.Where(ps...)
+	return cuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (cuo *ConsentUpdateOne) Select(field string, fields ...string) *ConsentUpdateOne {
+	cuo.fields = append([]string{field}, fields...)
+	return cuo
+}
+
+// Save executes the query and returns the updated Consent entity.
+func (cuo *ConsentUpdateOne) Save(ctx context.Context) (*Consent, error) {
+	return withHooks(ctx, cuo.sqlSave, cuo.mutation, cuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cuo *ConsentUpdateOne) SaveX(ctx context.Context) *Consent {
+	node, err := cuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (cuo *ConsentUpdateOne) Exec(ctx context.Context) error {
+	_, err := cuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cuo *ConsentUpdateOne) ExecX(ctx context.Context) {
+	if err := cuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (cuo *ConsentUpdateOne) sqlSave(ctx context.Context) (_node *Consent, err error) {
+	_spec := sqlgraph.NewUpdateSpec(consent.Table, consent.Columns, sqlgraph.NewFieldSpec(consent.FieldID, field.TypeString))
+	id, ok := cuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Consent.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := cuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, consent.FieldID)
+		for _, f := range fields {
+			if !consent.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != consent.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := cuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if cuo.mutation.ClientIPCleared() {
+		_spec.ClearField(consent.FieldClientIP, field.TypeString)
+	}
+	if cuo.mutation.UserAgentCleared() {
+		_spec.ClearField(consent.FieldUserAgent, field.TypeString)
+	}
+	if cuo.mutation.OrganizationIDCleared() {
+		_spec.ClearField(consent.FieldOrganizationID, field.TypeString)
+	}
+	_node = &Consent{config: cuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, cuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{consent.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	cuo.mutation.done = true
+	return _node, nil
+}
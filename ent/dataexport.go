@@ -0,0 +1,210 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Beriw98/user-management/ent/dataexport"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// DataExport is the model entity for the DataExport schema.
+type DataExport struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID string `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID string `json:"user_id,omitempty"`
+	// Status holds the value of the "status" field.
+	Status domain.DataExportStatus `json:"status,omitempty"`
+	// Archive holds the value of the "archive" field.
+	Archive []byte `json:"-"`
+	// Size holds the value of the "size" field.
+	Size int64 `json:"size,omitempty"`
+	// Error holds the value of the "error" field.
+	Error string `json:"error,omitempty"`
+	// FinishedAt holds the value of the "finished_at" field.
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// OrganizationID holds the value of the "organization_id" field.
+	OrganizationID string `json:"organization_id,omitempty"`
+	selectValues   sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*DataExport) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case dataexport.FieldArchive:
+			values[i] = new([]byte)
+		case dataexport.FieldSize:
+			values[i] = new(sql.NullInt64)
+		case dataexport.FieldID, dataexport.FieldUserID, dataexport.FieldStatus, dataexport.FieldError, dataexport.FieldOrganizationID:
+			values[i] = new(sql.NullString)
+		case dataexport.FieldCreatedAt, dataexport.FieldUpdatedAt, dataexport.FieldFinishedAt, dataexport.FieldExpiresAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the DataExport fields.
+func (de *DataExport) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case dataexport.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				de.ID = value.String
+			}
+		case dataexport.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				de.CreatedAt = value.Time
+			}
+		case dataexport.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				de.UpdatedAt = value.Time
+			}
+		case dataexport.FieldUserID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				de.UserID = value.String
+			}
+		case dataexport.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				de.Status = domain.DataExportStatus(value.String)
+			}
+		case dataexport.FieldArchive:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field archive", values[i])
+			} else if value != nil {
+				de.Archive = *value
+			}
+		case dataexport.FieldSize:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field size", values[i])
+			} else if value.Valid {
+				de.Size = value.Int64
+			}
+		case dataexport.FieldError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error", values[i])
+			} else if value.Valid {
+				de.Error = value.String
+			}
+		case dataexport.FieldFinishedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field finished_at", values[i])
+			} else if value.Valid {
+				de.FinishedAt = new(time.Time)
+				*de.FinishedAt = value.Time
+			}
+		case dataexport.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				de.ExpiresAt = value.Time
+			}
+		case dataexport.FieldOrganizationID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field organization_id", values[i])
+			} else if value.Valid {
+				de.OrganizationID = value.String
+			}
+		default:
+			de.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the DataExport.
+// This includes values selected through modifiers, order, etc.
+func (de *DataExport) Value(name string) (ent.Value, error) {
+	return de.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this DataExport.
+// Note that you need to call DataExport.Unwrap() before calling this method if this DataExport
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (de *DataExport) Update() *DataExportUpdateOne {
+	return NewDataExportClient(de.config).UpdateOne(de)
+}
+
+// Unwrap unwraps the DataExport entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (de *DataExport) Unwrap() *DataExport {
+	_tx, ok := de.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: DataExport is not a transactional entity")
+	}
+	de.config.driver = _tx.drv
+	return de
+}
+
+// String implements the fmt.Stringer.
+func (de *DataExport) String() string {
+	var builder strings.Builder
+	builder.WriteString("DataExport(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", de.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(de.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(de.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("user_id=")
+	builder.WriteString(de.UserID)
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", de.Status))
+	builder.WriteString(", ")
+	builder.WriteString("archive=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("size=")
+	builder.WriteString(fmt.Sprintf("%v", de.Size))
+	builder.WriteString(", ")
+	builder.WriteString("error=")
+	builder.WriteString(de.Error)
+	builder.WriteString(", ")
+	if v := de.FinishedAt; v != nil {
+		builder.WriteString("finished_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(de.ExpiresAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("organization_id=")
+	builder.WriteString(de.OrganizationID)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// DataExports is a parsable slice of DataExport.
+type DataExports []*DataExport
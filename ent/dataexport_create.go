@@ -0,0 +1,1012 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/dataexport"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// DataExportCreate is the builder for creating a DataExport entity.
+type DataExportCreate struct {
+	config
+	mutation *DataExportMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (dec *DataExportCreate) SetCreatedAt(t time.Time) *DataExportCreate {
+	dec.mutation.SetCreatedAt(t)
+	return dec
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (dec *DataExportCreate) SetNillableCreatedAt(t *time.Time) *DataExportCreate {
+	if t != nil {
+		dec.SetCreatedAt(*t)
+	}
+	return dec
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (dec *DataExportCreate) SetUpdatedAt(t time.Time) *DataExportCreate {
+	dec.mutation.SetUpdatedAt(t)
+	return dec
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (dec *DataExportCreate) SetNillableUpdatedAt(t *time.Time) *DataExportCreate {
+	if t != nil {
+		dec.SetUpdatedAt(*t)
+	}
+	return dec
+}
+
+// SetUserID sets the "user_id" field.
+func (dec *DataExportCreate) SetUserID(s string) *DataExportCreate {
+	dec.mutation.SetUserID(s)
+	return dec
+}
+
+// SetStatus sets the "status" field.
+func (dec *DataExportCreate) SetStatus(des domain.DataExportStatus) *DataExportCreate {
+	dec.mutation.SetStatus(des)
+	return dec
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (dec *DataExportCreate) SetNillableStatus(des *domain.DataExportStatus) *DataExportCreate {
+	if des != nil {
+		dec.SetStatus(*des)
+	}
+	return dec
+}
+
+// SetArchive sets the "archive" field.
+func (dec *DataExportCreate) SetArchive(b []byte) *DataExportCreate {
+	dec.mutation.SetArchive(b)
+	return dec
+}
+
+// SetSize sets the "size" field.
+func (dec *DataExportCreate) SetSize(i int64) *DataExportCreate {
+	dec.mutation.SetSize(i)
+	return dec
+}
+
+// SetNillableSize sets the "size" field if the given value is not nil.
+func (dec *DataExportCreate) SetNillableSize(i *int64) *DataExportCreate {
+	if i != nil {
+		dec.SetSize(*i)
+	}
+	return dec
+}
+
+// SetError sets the "error" field.
+func (dec *DataExportCreate) SetError(s string) *DataExportCreate {
+	dec.mutation.SetError(s)
+	return dec
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (dec *DataExportCreate) SetNillableError(s *string) *DataExportCreate {
+	if s != nil {
+		dec.SetError(*s)
+	}
+	return dec
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (dec *DataExportCreate) SetFinishedAt(t time.Time) *DataExportCreate {
+	dec.mutation.SetFinishedAt(t)
+	return dec
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (dec *DataExportCreate) SetNillableFinishedAt(t *time.Time) *DataExportCreate {
+	if t != nil {
+		dec.SetFinishedAt(*t)
+	}
+	return dec
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (dec *DataExportCreate) SetExpiresAt(t time.Time) *DataExportCreate {
+	dec.mutation.SetExpiresAt(t)
+	return dec
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (dec *DataExportCreate) SetOrganizationID(s string) *DataExportCreate {
+	dec.mutation.SetOrganizationID(s)
+	return dec
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (dec *DataExportCreate) SetNillableOrganizationID(s *string) *DataExportCreate {
+	if s != nil {
+		dec.SetOrganizationID(*s)
+	}
+	return dec
+}
+
+// SetID sets the "id" field.
+func (dec *DataExportCreate) SetID(s string) *DataExportCreate {
+	dec.mutation.SetID(s)
+	return dec
+}
+
+// Mutation returns the DataExportMutation object of the builder.
+func (dec *DataExportCreate) Mutation() *DataExportMutation {
+	return dec.mutation
+}
+
+// Save creates the DataExport in the database.
+func (dec *DataExportCreate) Save(ctx context.Context) (*DataExport, error) {
+	dec.defaults()
+	return withHooks(ctx, dec.sqlSave, dec.mutation, dec.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (dec *DataExportCreate) SaveX(ctx context.Context) *DataExport {
+	v, err := dec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (dec *DataExportCreate) Exec(ctx context.Context) error {
+	_, err := dec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (dec *DataExportCreate) ExecX(ctx context.Context) {
+	if err := dec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (dec *DataExportCreate) defaults() {
+	if _, ok := dec.mutation.CreatedAt(); !ok {
+		v := dataexport.DefaultCreatedAt()
+		dec.mutation.SetCreatedAt(v)
+	}
+	if _, ok := dec.mutation.UpdatedAt(); !ok {
+		v := dataexport.DefaultUpdatedAt()
+		dec.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := dec.mutation.Status(); !ok {
+		v := dataexport.DefaultStatus
+		dec.mutation.SetStatus(v)
+	}
+	if _, ok := dec.mutation.Size(); !ok {
+		v := dataexport.DefaultSize
+		dec.mutation.SetSize(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (dec *DataExportCreate) check() error {
+	if _, ok := dec.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "DataExport.created_at"`)}
+	}
+	if _, ok := dec.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "DataExport.updated_at"`)}
+	}
+	if _, ok := dec.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "DataExport.user_id"`)}
+	}
+	if _, ok := dec.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "DataExport.status"`)}
+	}
+	if _, ok := dec.mutation.Size(); !ok {
+		return &ValidationError{Name: "size", err: errors.New(`ent: missing required field "DataExport.size"`)}
+	}
+	if _, ok := dec.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "DataExport.expires_at"`)}
+	}
+	return nil
+}
+
+func (dec *DataExportCreate) sqlSave(ctx context.Context) (*DataExport, error) {
+	if err := dec.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := dec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, dec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected DataExport.ID type: %T", _spec.ID.Value)
+		}
+	}
+	dec.mutation.id = &_node.ID
+	dec.mutation.done = true
+	return _node, nil
+}
+
+func (dec *DataExportCreate) createSpec() (*DataExport, *sqlgraph.CreateSpec) {
+	var (
+		_node = &DataExport{config: dec.config}
+		_spec = sqlgraph.NewCreateSpec(dataexport.Table, sqlgraph.NewFieldSpec(dataexport.FieldID, field.TypeString))
+	)
+	_spec.OnConflict = dec.conflict
+	if id, ok := dec.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := dec.mutation.CreatedAt(); ok {
+		_spec.SetField(dataexport.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := dec.mutation.UpdatedAt(); ok {
+		_spec.SetField(dataexport.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := dec.mutation.UserID(); ok {
+		_spec.SetField(dataexport.FieldUserID, field.TypeString, value)
+		_node.UserID = value
+	}
+	if value, ok := dec.mutation.Status(); ok {
+		_spec.SetField(dataexport.FieldStatus, field.TypeString, value)
+		_node.Status = value
+	}
+	if value, ok := dec.mutation.Archive(); ok {
+		_spec.SetField(dataexport.FieldArchive, field.TypeBytes, value)
+		_node.Archive = value
+	}
+	if value, ok := dec.mutation.Size(); ok {
+		_spec.SetField(dataexport.FieldSize, field.TypeInt64, value)
+		_node.Size = value
+	}
+	if value, ok := dec.mutation.Error(); ok {
+		_spec.SetField(dataexport.FieldError, field.TypeString, value)
+		_node.Error = value
+	}
+	if value, ok := dec.mutation.FinishedAt(); ok {
+		_spec.SetField(dataexport.FieldFinishedAt, field.TypeTime, value)
+		_node.FinishedAt = &value
+	}
+	if value, ok := dec.mutation.ExpiresAt(); ok {
+		_spec.SetField(dataexport.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := dec.mutation.OrganizationID(); ok {
+		_spec.SetField(dataexport.FieldOrganizationID, field.TypeString, value)
+		_node.OrganizationID = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.DataExport.Create().
+//		SetCreatedAt(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.DataExportUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (dec *DataExportCreate) OnConflict(opts ...sql.ConflictOption) *DataExportUpsertOne {
+	dec.conflict = opts
+	return &DataExportUpsertOne{
+		create: dec,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.DataExport.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (dec *DataExportCreate) OnConflictColumns(columns ...string) *DataExportUpsertOne {
+	dec.conflict = append(dec.conflict, sql.ConflictColumns(columns...))
+	return &DataExportUpsertOne{
+		create: dec,
+	}
+}
+
+type (
+	// DataExportUpsertOne is the builder for "upsert"-ing
+	//  one DataExport node.
+	DataExportUpsertOne struct {
+		create *DataExportCreate
+	}
+
+	// DataExportUpsert is the "OnConflict" setter.
+	DataExportUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *DataExportUpsert) SetUpdatedAt(v time.Time) *DataExportUpsert {
+	u.Set(dataexport.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *DataExportUpsert) UpdateUpdatedAt() *DataExportUpsert {
+	u.SetExcluded(dataexport.FieldUpdatedAt)
+	return u
+}
+
+// SetStatus sets the "status" field.
+func (u *DataExportUpsert) SetStatus(v domain.DataExportStatus) *DataExportUpsert {
+	u.Set(dataexport.FieldStatus, v)
+	return u
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *DataExportUpsert) UpdateStatus() *DataExportUpsert {
+	u.SetExcluded(dataexport.FieldStatus)
+	return u
+}
+
+// SetArchive sets the "archive" field.
+func (u *DataExportUpsert) SetArchive(v []byte) *DataExportUpsert {
+	u.Set(dataexport.FieldArchive, v)
+	return u
+}
+
+// UpdateArchive sets the "archive" field to the value that was provided on create.
+func (u *DataExportUpsert) UpdateArchive() *DataExportUpsert {
+	u.SetExcluded(dataexport.FieldArchive)
+	return u
+}
+
+// ClearArchive clears the value of the "archive" field.
+func (u *DataExportUpsert) ClearArchive() *DataExportUpsert {
+	u.SetNull(dataexport.FieldArchive)
+	return u
+}
+
+// SetSize sets the "size" field.
+func (u *DataExportUpsert) SetSize(v int64) *DataExportUpsert {
+	u.Set(dataexport.FieldSize, v)
+	return u
+}
+
+// UpdateSize sets the "size" field to the value that was provided on create.
+func (u *DataExportUpsert) UpdateSize() *DataExportUpsert {
+	u.SetExcluded(dataexport.FieldSize)
+	return u
+}
+
+// AddSize adds v to the "size" field.
+func (u *DataExportUpsert) AddSize(v int64) *DataExportUpsert {
+	u.Add(dataexport.FieldSize, v)
+	return u
+}
+
+// SetError sets the "error" field.
+func (u *DataExportUpsert) SetError(v string) *DataExportUpsert {
+	u.Set(dataexport.FieldError, v)
+	return u
+}
+
+// UpdateError sets the "error" field to the value that was provided on create.
+func (u *DataExportUpsert) UpdateError() *DataExportUpsert {
+	u.SetExcluded(dataexport.FieldError)
+	return u
+}
+
+// ClearError clears the value of the "error" field.
+func (u *DataExportUpsert) ClearError() *DataExportUpsert {
+	u.SetNull(dataexport.FieldError)
+	return u
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (u *DataExportUpsert) SetFinishedAt(v time.Time) *DataExportUpsert {
+	u.Set(dataexport.FieldFinishedAt, v)
+	return u
+}
+
+// UpdateFinishedAt sets the "finished_at" field to the value that was provided on create.
+func (u *DataExportUpsert) UpdateFinishedAt() *DataExportUpsert {
+	u.SetExcluded(dataexport.FieldFinishedAt)
+	return u
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (u *DataExportUpsert) ClearFinishedAt() *DataExportUpsert {
+	u.SetNull(dataexport.FieldFinishedAt)
+	return u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *DataExportUpsert) SetExpiresAt(v time.Time) *DataExportUpsert {
+	u.Set(dataexport.FieldExpiresAt, v)
+	return u
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *DataExportUpsert) UpdateExpiresAt() *DataExportUpsert {
+	u.SetExcluded(dataexport.FieldExpiresAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.DataExport.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(dataexport.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *DataExportUpsertOne) UpdateNewValues() *DataExportUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(dataexport.FieldID)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(dataexport.FieldCreatedAt)
+		}
+		if _, exists := u.create.mutation.UserID(); exists {
+			s.SetIgnore(dataexport.FieldUserID)
+		}
+		if _, exists := u.create.mutation.OrganizationID(); exists {
+			s.SetIgnore(dataexport.FieldOrganizationID)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.DataExport.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *DataExportUpsertOne) Ignore() *DataExportUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *DataExportUpsertOne) DoNothing() *DataExportUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the DataExportCreate.OnConflict
+// documentation for more info.
+func (u *DataExportUpsertOne) Update(set func(*DataExportUpsert)) *DataExportUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&DataExportUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *DataExportUpsertOne) SetUpdatedAt(v time.Time) *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *DataExportUpsertOne) UpdateUpdatedAt() *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetStatus sets the "status" field.
+func (u *DataExportUpsertOne) SetStatus(v domain.DataExportStatus) *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetStatus(v)
+	})
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *DataExportUpsertOne) UpdateStatus() *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateStatus()
+	})
+}
+
+// SetArchive sets the "archive" field.
+func (u *DataExportUpsertOne) SetArchive(v []byte) *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetArchive(v)
+	})
+}
+
+// UpdateArchive sets the "archive" field to the value that was provided on create.
+func (u *DataExportUpsertOne) UpdateArchive() *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateArchive()
+	})
+}
+
+// ClearArchive clears the value of the "archive" field.
+func (u *DataExportUpsertOne) ClearArchive() *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.ClearArchive()
+	})
+}
+
+// SetSize sets the "size" field.
+func (u *DataExportUpsertOne) SetSize(v int64) *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetSize(v)
+	})
+}
+
+// AddSize adds v to the "size" field.
+func (u *DataExportUpsertOne) AddSize(v int64) *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.AddSize(v)
+	})
+}
+
+// UpdateSize sets the "size" field to the value that was provided on create.
+func (u *DataExportUpsertOne) UpdateSize() *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateSize()
+	})
+}
+
+// SetError sets the "error" field.
+func (u *DataExportUpsertOne) SetError(v string) *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetError(v)
+	})
+}
+
+// UpdateError sets the "error" field to the value that was provided on create.
+func (u *DataExportUpsertOne) UpdateError() *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateError()
+	})
+}
+
+// ClearError clears the value of the "error" field.
+func (u *DataExportUpsertOne) ClearError() *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.ClearError()
+	})
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (u *DataExportUpsertOne) SetFinishedAt(v time.Time) *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetFinishedAt(v)
+	})
+}
+
+// UpdateFinishedAt sets the "finished_at" field to the value that was provided on create.
+func (u *DataExportUpsertOne) UpdateFinishedAt() *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateFinishedAt()
+	})
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (u *DataExportUpsertOne) ClearFinishedAt() *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.ClearFinishedAt()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *DataExportUpsertOne) SetExpiresAt(v time.Time) *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *DataExportUpsertOne) UpdateExpiresAt() *DataExportUpsertOne {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// Exec executes the query.
+func (u *DataExportUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for DataExportCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *DataExportUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *DataExportUpsertOne) ID(ctx context.Context) (id string, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: DataExportUpsertOne.ID is not supported by MySQL driver. Use DataExportUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *DataExportUpsertOne) IDX(ctx context.Context) string {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// DataExportCreateBulk is the builder for creating many DataExport entities in bulk.
+type DataExportCreateBulk struct {
+	config
+	err      error
+	builders []*DataExportCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the DataExport entities in the database.
+func (decb *DataExportCreateBulk) Save(ctx context.Context) ([]*DataExport, error) {
+	if decb.err != nil {
+		return nil, decb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(decb.builders))
+	nodes := make([]*DataExport, len(decb.builders))
+	mutators := make([]Mutator, len(decb.builders))
+	for i := range decb.builders {
+		func(i int, root context.Context) {
+			builder := decb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*DataExportMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, decb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = decb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, decb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, decb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (decb *DataExportCreateBulk) SaveX(ctx context.Context) []*DataExport {
+	v, err := decb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (decb *DataExportCreateBulk) Exec(ctx context.Context) error {
+	_, err := decb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (decb *DataExportCreateBulk) ExecX(ctx context.Context) {
+	if err := decb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.DataExport.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.DataExportUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (decb *DataExportCreateBulk) OnConflict(opts ...sql.ConflictOption) *DataExportUpsertBulk {
+	decb.conflict = opts
+	return &DataExportUpsertBulk{
+		create: decb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.DataExport.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (decb *DataExportCreateBulk) OnConflictColumns(columns ...string) *DataExportUpsertBulk {
+	decb.conflict = append(decb.conflict, sql.ConflictColumns(columns...))
+	return &DataExportUpsertBulk{
+		create: decb,
+	}
+}
+
+// DataExportUpsertBulk is the builder for "upsert"-ing
+// a bulk of DataExport nodes.
+type DataExportUpsertBulk struct {
+	create *DataExportCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.DataExport.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(dataexport.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *DataExportUpsertBulk) UpdateNewValues() *DataExportUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(dataexport.FieldID)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(dataexport.FieldCreatedAt)
+			}
+			if _, exists := b.mutation.UserID(); exists {
+				s.SetIgnore(dataexport.FieldUserID)
+			}
+			if _, exists := b.mutation.OrganizationID(); exists {
+				s.SetIgnore(dataexport.FieldOrganizationID)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.DataExport.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *DataExportUpsertBulk) Ignore() *DataExportUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *DataExportUpsertBulk) DoNothing() *DataExportUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the DataExportCreateBulk.OnConflict
+// documentation for more info.
+func (u *DataExportUpsertBulk) Update(set func(*DataExportUpsert)) *DataExportUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&DataExportUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *DataExportUpsertBulk) SetUpdatedAt(v time.Time) *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *DataExportUpsertBulk) UpdateUpdatedAt() *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetStatus sets the "status" field.
+func (u *DataExportUpsertBulk) SetStatus(v domain.DataExportStatus) *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetStatus(v)
+	})
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *DataExportUpsertBulk) UpdateStatus() *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateStatus()
+	})
+}
+
+// SetArchive sets the "archive" field.
+func (u *DataExportUpsertBulk) SetArchive(v []byte) *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetArchive(v)
+	})
+}
+
+// UpdateArchive sets the "archive" field to the value that was provided on create.
+func (u *DataExportUpsertBulk) UpdateArchive() *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateArchive()
+	})
+}
+
+// ClearArchive clears the value of the "archive" field.
+func (u *DataExportUpsertBulk) ClearArchive() *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.ClearArchive()
+	})
+}
+
+// SetSize sets the "size" field.
+func (u *DataExportUpsertBulk) SetSize(v int64) *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetSize(v)
+	})
+}
+
+// AddSize adds v to the "size" field.
+func (u *DataExportUpsertBulk) AddSize(v int64) *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.AddSize(v)
+	})
+}
+
+// UpdateSize sets the "size" field to the value that was provided on create.
+func (u *DataExportUpsertBulk) UpdateSize() *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateSize()
+	})
+}
+
+// SetError sets the "error" field.
+func (u *DataExportUpsertBulk) SetError(v string) *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetError(v)
+	})
+}
+
+// UpdateError sets the "error" field to the value that was provided on create.
+func (u *DataExportUpsertBulk) UpdateError() *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateError()
+	})
+}
+
+// ClearError clears the value of the "error" field.
+func (u *DataExportUpsertBulk) ClearError() *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.ClearError()
+	})
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (u *DataExportUpsertBulk) SetFinishedAt(v time.Time) *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetFinishedAt(v)
+	})
+}
+
+// UpdateFinishedAt sets the "finished_at" field to the value that was provided on create.
+func (u *DataExportUpsertBulk) UpdateFinishedAt() *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateFinishedAt()
+	})
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (u *DataExportUpsertBulk) ClearFinishedAt() *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.ClearFinishedAt()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *DataExportUpsertBulk) SetExpiresAt(v time.Time) *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *DataExportUpsertBulk) UpdateExpiresAt() *DataExportUpsertBulk {
+	return u.Update(func(s *DataExportUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// Exec executes the query.
+func (u *DataExportUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the DataExportCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for DataExportCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *DataExportUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
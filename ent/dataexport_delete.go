@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/dataexport"
+	"github.com/Beriw98/user-management/ent/predicate"
+)
+
+// DataExportDelete is the builder for deleting a DataExport entity.
+type DataExportDelete struct {
+	config
+	hooks    []Hook
+	mutation *DataExportMutation
+}
+
+// Where appends a list predicates to the DataExportDelete builder.
+func (ded *DataExportDelete) Where(ps ...predicate.DataExport) *DataExportDelete {
+	ded.mutation.Where(ps...)
+	return ded
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (ded *DataExportDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, ded.sqlExec, ded.mutation, ded.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ded *DataExportDelete) ExecX(ctx context.Context) int {
+	n, err := ded.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (ded *DataExportDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(dataexport.Table, sqlgraph.NewFieldSpec(dataexport.FieldID, field.TypeString))
+	if ps := ded.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, ded.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	ded.mutation.done = true
+	return affected, err
+}
+
+// DataExportDeleteOne is the builder for deleting a single DataExport entity.
+type DataExportDeleteOne struct {
+	ded *DataExportDelete
+}
+
+// Where appends a list predicates to the DataExportDelete builder.
+func (dedo *DataExportDeleteOne) Where(ps ...predicate.DataExport) *DataExportDeleteOne {
+	dedo.ded.mutation.Where(ps...)
+	return dedo
+}
+
+// Exec executes the deletion query.
+func (dedo *DataExportDeleteOne) Exec(ctx context.Context) error {
+	n, err := dedo.ded.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{dataexport.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (dedo *DataExportDeleteOne) ExecX(ctx context.Context) {
+	if err := dedo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,481 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/dataexport"
+	"github.com/Beriw98/user-management/ent/predicate"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// DataExportUpdate is the builder for updating DataExport entities.
+type DataExportUpdate struct {
+	config
+	hooks    []Hook
+	mutation *DataExportMutation
+}
+
+// Where appends a list predicates to the DataExportUpdate builder.
+func (deu *DataExportUpdate) Where(ps ...predicate.DataExport) *DataExportUpdate {
+	deu.mutation.Where(ps...)
+	return deu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (deu *DataExportUpdate) SetUpdatedAt(t time.Time) *DataExportUpdate {
+	deu.mutation.SetUpdatedAt(t)
+	return deu
+}
+
+// SetStatus sets the "status" field.
+func (deu *DataExportUpdate) SetStatus(des domain.DataExportStatus) *DataExportUpdate {
+	deu.mutation.SetStatus(des)
+	return deu
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (deu *DataExportUpdate) SetNillableStatus(des *domain.DataExportStatus) *DataExportUpdate {
+	if des != nil {
+		deu.SetStatus(*des)
+	}
+	return deu
+}
+
+// SetArchive sets the "archive" field.
+func (deu *DataExportUpdate) SetArchive(b []byte) *DataExportUpdate {
+	deu.mutation.SetArchive(b)
+	return deu
+}
+
+// ClearArchive clears the value of the "archive" field.
+func (deu *DataExportUpdate) ClearArchive() *DataExportUpdate {
+	deu.mutation.ClearArchive()
+	return deu
+}
+
+// SetSize sets the "size" field.
+func (deu *DataExportUpdate) SetSize(i int64) *DataExportUpdate {
+	deu.mutation.ResetSize()
+	deu.mutation.SetSize(i)
+	return deu
+}
+
+// SetNillableSize sets the "size" field if the given value is not nil.
+func (deu *DataExportUpdate) SetNillableSize(i *int64) *DataExportUpdate {
+	if i != nil {
+		deu.SetSize(*i)
+	}
+	return deu
+}
+
+// AddSize adds i to the "size" field.
+func (deu *DataExportUpdate) AddSize(i int64) *DataExportUpdate {
+	deu.mutation.AddSize(i)
+	return deu
+}
+
+// SetError sets the "error" field.
+func (deu *DataExportUpdate) SetError(s string) *DataExportUpdate {
+	deu.mutation.SetError(s)
+	return deu
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (deu *DataExportUpdate) SetNillableError(s *string) *DataExportUpdate {
+	if s != nil {
+		deu.SetError(*s)
+	}
+	return deu
+}
+
+// ClearError clears the value of the "error" field.
+func (deu *DataExportUpdate) ClearError() *DataExportUpdate {
+	deu.mutation.ClearError()
+	return deu
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (deu *DataExportUpdate) SetFinishedAt(t time.Time) *DataExportUpdate {
+	deu.mutation.SetFinishedAt(t)
+	return deu
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (deu *DataExportUpdate) SetNillableFinishedAt(t *time.Time) *DataExportUpdate {
+	if t != nil {
+		deu.SetFinishedAt(*t)
+	}
+	return deu
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (deu *DataExportUpdate) ClearFinishedAt() *DataExportUpdate {
+	deu.mutation.ClearFinishedAt()
+	return deu
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (deu *DataExportUpdate) SetExpiresAt(t time.Time) *DataExportUpdate {
+	deu.mutation.SetExpiresAt(t)
+	return deu
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (deu *DataExportUpdate) SetNillableExpiresAt(t *time.Time) *DataExportUpdate {
+	if t != nil {
+		deu.SetExpiresAt(*t)
+	}
+	return deu
+}
+
+// Mutation returns the DataExportMutation object of the builder.
+func (deu *DataExportUpdate) Mutation() *DataExportMutation {
+	return deu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (deu *DataExportUpdate) Save(ctx context.Context) (int, error) {
+	deu.defaults()
+	return withHooks(ctx, deu.sqlSave, deu.mutation, deu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (deu *DataExportUpdate) SaveX(ctx context.Context) int {
+	affected, err := deu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (deu *DataExportUpdate) Exec(ctx context.Context) error {
+	_, err := deu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (deu *DataExportUpdate) ExecX(ctx context.Context) {
+	if err := deu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (deu *DataExportUpdate) defaults() {
+	if _, ok := deu.mutation.UpdatedAt(); !ok {
+		v := dataexport.UpdateDefaultUpdatedAt()
+		deu.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (deu *DataExportUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(dataexport.Table, dataexport.Columns, sqlgraph.NewFieldSpec(dataexport.FieldID, field.TypeString))
+	if ps := deu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := deu.mutation.UpdatedAt(); ok {
+		_spec.SetField(dataexport.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := deu.mutation.Status(); ok {
+		_spec.SetField(dataexport.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := deu.mutation.Archive(); ok {
+		_spec.SetField(dataexport.FieldArchive, field.TypeBytes, value)
+	}
+	if deu.mutation.ArchiveCleared() {
+		_spec.ClearField(dataexport.FieldArchive, field.TypeBytes)
+	}
+	if value, ok := deu.mutation.Size(); ok {
+		_spec.SetField(dataexport.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := deu.mutation.AddedSize(); ok {
+		_spec.AddField(dataexport.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := deu.mutation.Error(); ok {
+		_spec.SetField(dataexport.FieldError, field.TypeString, value)
+	}
+	if deu.mutation.ErrorCleared() {
+		_spec.ClearField(dataexport.FieldError, field.TypeString)
+	}
+	if value, ok := deu.mutation.FinishedAt(); ok {
+		_spec.SetField(dataexport.FieldFinishedAt, field.TypeTime, value)
+	}
+	if deu.mutation.FinishedAtCleared() {
+		_spec.ClearField(dataexport.FieldFinishedAt, field.TypeTime)
+	}
+	if value, ok := deu.mutation.ExpiresAt(); ok {
+		_spec.SetField(dataexport.FieldExpiresAt, field.TypeTime, value)
+	}
+	if deu.mutation.OrganizationIDCleared() {
+		_spec.ClearField(dataexport.FieldOrganizationID, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, deu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{dataexport.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	deu.mutation.done = true
+	return n, nil
+}
+
+// DataExportUpdateOne is the builder for updating a single DataExport entity.
+type DataExportUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *DataExportMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (deuo *DataExportUpdateOne) SetUpdatedAt(t time.Time) *DataExportUpdateOne {
+	deuo.mutation.SetUpdatedAt(t)
+	return deuo
+}
+
+// SetStatus sets the "status" field.
+func (deuo *DataExportUpdateOne) SetStatus(des domain.DataExportStatus) *DataExportUpdateOne {
+	deuo.mutation.SetStatus(des)
+	return deuo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (deuo *DataExportUpdateOne) SetNillableStatus(des *domain.DataExportStatus) *DataExportUpdateOne {
+	if des != nil {
+		deuo.SetStatus(*des)
+	}
+	return deuo
+}
+
+// SetArchive sets the "archive" field.
+func (deuo *DataExportUpdateOne) SetArchive(b []byte) *DataExportUpdateOne {
+	deuo.mutation.SetArchive(b)
+	return deuo
+}
+
+// ClearArchive clears the value of the "archive" field.
+func (deuo *DataExportUpdateOne) ClearArchive() *DataExportUpdateOne {
+	deuo.mutation.ClearArchive()
+	return deuo
+}
+
+// SetSize sets the "size" field.
+func (deuo *DataExportUpdateOne) SetSize(i int64) *DataExportUpdateOne {
+	deuo.mutation.ResetSize()
+	deuo.mutation.SetSize(i)
+	return deuo
+}
+
+// SetNillableSize sets the "size" field if the given value is not nil.
+func (deuo *DataExportUpdateOne) SetNillableSize(i *int64) *DataExportUpdateOne {
+	if i != nil {
+		deuo.SetSize(*i)
+	}
+	return deuo
+}
+
+// AddSize adds i to the "size" field.
+func (deuo *DataExportUpdateOne) AddSize(i int64) *DataExportUpdateOne {
+	deuo.mutation.AddSize(i)
+	return deuo
+}
+
+// SetError sets the "error" field.
+func (deuo *DataExportUpdateOne) SetError(s string) *DataExportUpdateOne {
+	deuo.mutation.SetError(s)
+	return deuo
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (deuo *DataExportUpdateOne) SetNillableError(s *string) *DataExportUpdateOne {
+	if s != nil {
+		deuo.SetError(*s)
+	}
+	return deuo
+}
+
+// ClearError clears the value of the "error" field.
+func (deuo *DataExportUpdateOne) ClearError() *DataExportUpdateOne {
+	deuo.mutation.ClearError()
+	return deuo
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (deuo *DataExportUpdateOne) SetFinishedAt(t time.Time) *DataExportUpdateOne {
+	deuo.mutation.SetFinishedAt(t)
+	return deuo
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (deuo *DataExportUpdateOne) SetNillableFinishedAt(t *time.Time) *DataExportUpdateOne {
+	if t != nil {
+		deuo.SetFinishedAt(*t)
+	}
+	return deuo
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (deuo *DataExportUpdateOne) ClearFinishedAt() *DataExportUpdateOne {
+	deuo.mutation.ClearFinishedAt()
+	return deuo
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (deuo *DataExportUpdateOne) SetExpiresAt(t time.Time) *DataExportUpdateOne {
+	deuo.mutation.SetExpiresAt(t)
+	return deuo
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (deuo *DataExportUpdateOne) SetNillableExpiresAt(t *time.Time) *DataExportUpdateOne {
+	if t != nil {
+		deuo.SetExpiresAt(*t)
+	}
+	return deuo
+}
+
+// Mutation returns the DataExportMutation object of the builder.
+func (deuo *DataExportUpdateOne) Mutation() *DataExportMutation {
+	return deuo.mutation
+}
+
+// Where appends a list predicates to the DataExportUpdate builder.
+func (deuo *DataExportUpdateOne) Where(ps ...predicate.DataExport) *DataExportUpdateOne {
+	deuo.mutation.Where(ps...)
+	return deuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (deuo *DataExportUpdateOne) Select(field string, fields ...string) *DataExportUpdateOne {
+	deuo.fields = append([]string{field}, fields...)
+	return deuo
+}
+
+// Save executes the query and returns the updated DataExport entity.
+func (deuo *DataExportUpdateOne) Save(ctx context.Context) (*DataExport, error) {
+	deuo.defaults()
+	return withHooks(ctx, deuo.sqlSave, deuo.mutation, deuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (deuo *DataExportUpdateOne) SaveX(ctx context.Context) *DataExport {
+	node, err := deuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (deuo *DataExportUpdateOne) Exec(ctx context.Context) error {
+	_, err := deuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (deuo *DataExportUpdateOne) ExecX(ctx context.Context) {
+	if err := deuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (deuo *DataExportUpdateOne) defaults() {
+	if _, ok := deuo.mutation.UpdatedAt(); !ok {
+		v := dataexport.UpdateDefaultUpdatedAt()
+		deuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (deuo *DataExportUpdateOne) sqlSave(ctx context.Context) (_node *DataExport, err error) {
+	_spec := sqlgraph.NewUpdateSpec(dataexport.Table, dataexport.Columns, sqlgraph.NewFieldSpec(dataexport.FieldID, field.TypeString))
+	id, ok := deuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "DataExport.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := deuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, dataexport.FieldID)
+		for _, f := range fields {
+			if !dataexport.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != dataexport.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := deuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := deuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(dataexport.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := deuo.mutation.Status(); ok {
+		_spec.SetField(dataexport.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := deuo.mutation.Archive(); ok {
+		_spec.SetField(dataexport.FieldArchive, field.TypeBytes, value)
+	}
+	if deuo.mutation.ArchiveCleared() {
+		_spec.ClearField(dataexport.FieldArchive, field.TypeBytes)
+	}
+	if value, ok := deuo.mutation.Size(); ok {
+		_spec.SetField(dataexport.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := deuo.mutation.AddedSize(); ok {
+		_spec.AddField(dataexport.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := deuo.mutation.Error(); ok {
+		_spec.SetField(dataexport.FieldError, field.TypeString, value)
+	}
+	if deuo.mutation.ErrorCleared() {
+		_spec.ClearField(dataexport.FieldError, field.TypeString)
+	}
+	if value, ok := deuo.mutation.FinishedAt(); ok {
+		_spec.SetField(dataexport.FieldFinishedAt, field.TypeTime, value)
+	}
+	if deuo.mutation.FinishedAtCleared() {
+		_spec.ClearField(dataexport.FieldFinishedAt, field.TypeTime)
+	}
+	if value, ok := deuo.mutation.ExpiresAt(); ok {
+		_spec.SetField(dataexport.FieldExpiresAt, field.TypeTime, value)
+	}
+	if deuo.mutation.OrganizationIDCleared() {
+		_spec.ClearField(dataexport.FieldOrganizationID, field.TypeString)
+	}
+	_node = &DataExport{config: deuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, deuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{dataexport.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	deuo.mutation.done = true
+	return _node, nil
+}
@@ -12,7 +12,19 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/Beriw98/user-management/ent/auditevent"
+	"github.com/Beriw98/user-management/ent/consent"
+	"github.com/Beriw98/user-management/ent/dataexport"
+	"github.com/Beriw98/user-management/ent/erasuretombstone"
+	"github.com/Beriw98/user-management/ent/group"
+	"github.com/Beriw98/user-management/ent/importjob"
+	"github.com/Beriw98/user-management/ent/invitation"
+	"github.com/Beriw98/user-management/ent/organization"
+	"github.com/Beriw98/user-management/ent/outboxevent"
+	"github.com/Beriw98/user-management/ent/policydocument"
 	"github.com/Beriw98/user-management/ent/user"
+	"github.com/Beriw98/user-management/ent/webhookdelivery"
+	"github.com/Beriw98/user-management/ent/webhooksubscription"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -73,7 +85,19 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			user.Table: user.ValidColumn,
+			auditevent.Table:          auditevent.ValidColumn,
+			consent.Table:             consent.ValidColumn,
+			dataexport.Table:          dataexport.ValidColumn,
+			erasuretombstone.Table:    erasuretombstone.ValidColumn,
+			group.Table:               group.ValidColumn,
+			importjob.Table:           importjob.ValidColumn,
+			invitation.Table:          invitation.ValidColumn,
+			organization.Table:        organization.ValidColumn,
+			outboxevent.Table:         outboxevent.ValidColumn,
+			policydocument.Table:      policydocument.ValidColumn,
+			user.Table:                user.ValidColumn,
+			webhookdelivery.Table:     webhookdelivery.ValidColumn,
+			webhooksubscription.Table: webhooksubscription.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
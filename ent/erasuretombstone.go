@@ -0,0 +1,137 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Beriw98/user-management/ent/erasuretombstone"
+)
+
+// ErasureTombstone is the model entity for the ErasureTombstone schema.
+type ErasureTombstone struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID string `json:"id,omitempty"`
+	// EmailHash holds the value of the "email_hash" field.
+	EmailHash string `json:"email_hash,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID string `json:"user_id,omitempty"`
+	// ErasedAt holds the value of the "erased_at" field.
+	ErasedAt time.Time `json:"erased_at,omitempty"`
+	// OrganizationID holds the value of the "organization_id" field.
+	OrganizationID string `json:"organization_id,omitempty"`
+	selectValues   sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ErasureTombstone) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case erasuretombstone.FieldID, erasuretombstone.FieldEmailHash, erasuretombstone.FieldUserID, erasuretombstone.FieldOrganizationID:
+			values[i] = new(sql.NullString)
+		case erasuretombstone.FieldErasedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ErasureTombstone fields.
+func (et *ErasureTombstone) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case erasuretombstone.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				et.ID = value.String
+			}
+		case erasuretombstone.FieldEmailHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field email_hash", values[i])
+			} else if value.Valid {
+				et.EmailHash = value.String
+			}
+		case erasuretombstone.FieldUserID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				et.UserID = value.String
+			}
+		case erasuretombstone.FieldErasedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field erased_at", values[i])
+			} else if value.Valid {
+				et.ErasedAt = value.Time
+			}
+		case erasuretombstone.FieldOrganizationID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field organization_id", values[i])
+			} else if value.Valid {
+				et.OrganizationID = value.String
+			}
+		default:
+			et.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ErasureTombstone.
+// This includes values selected through modifiers, order, etc.
+func (et *ErasureTombstone) Value(name string) (ent.Value, error) {
+	return et.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this ErasureTombstone.
+// Note that you need to call ErasureTombstone.Unwrap() before calling this method if this ErasureTombstone
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (et *ErasureTombstone) Update() *ErasureTombstoneUpdateOne {
+	return NewErasureTombstoneClient(et.config).UpdateOne(et)
+}
+
+// Unwrap unwraps the ErasureTombstone entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (et *ErasureTombstone) Unwrap() *ErasureTombstone {
+	_tx, ok := et.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ErasureTombstone is not a transactional entity")
+	}
+	et.config.driver = _tx.drv
+	return et
+}
+
+// String implements the fmt.Stringer.
+func (et *ErasureTombstone) String() string {
+	var builder strings.Builder
+	builder.WriteString("ErasureTombstone(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", et.ID))
+	builder.WriteString("email_hash=")
+	builder.WriteString(et.EmailHash)
+	builder.WriteString(", ")
+	builder.WriteString("user_id=")
+	builder.WriteString(et.UserID)
+	builder.WriteString(", ")
+	builder.WriteString("erased_at=")
+	builder.WriteString(et.ErasedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("organization_id=")
+	builder.WriteString(et.OrganizationID)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ErasureTombstones is a parsable slice of ErasureTombstone.
+type ErasureTombstones []*ErasureTombstone
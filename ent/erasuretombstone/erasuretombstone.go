@@ -0,0 +1,76 @@
+// Code generated by ent, DO NOT EDIT.
+
+package erasuretombstone
+
+import (
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the erasuretombstone type in the database.
+	Label = "erasure_tombstone"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldEmailHash holds the string denoting the email_hash field in the database.
+	FieldEmailHash = "email_hash"
+	// FieldUserID holds the string denoting the user_id field in the database.
+	FieldUserID = "user_id"
+	// FieldErasedAt holds the string denoting the erased_at field in the database.
+	FieldErasedAt = "erased_at"
+	// FieldOrganizationID holds the string denoting the organization_id field in the database.
+	FieldOrganizationID = "organization_id"
+	// Table holds the table name of the erasuretombstone in the database.
+	Table = "erasure_tombstones"
+)
+
+// Columns holds all SQL columns for erasuretombstone fields.
+var Columns = []string{
+	FieldID,
+	FieldEmailHash,
+	FieldUserID,
+	FieldErasedAt,
+	FieldOrganizationID,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// EmailHashValidator is a validator for the "email_hash" field. It is called by the builders before save.
+	EmailHashValidator func(string) error
+)
+
+// OrderOption defines the ordering options for the ErasureTombstone queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByEmailHash orders the results by the email_hash field.
+func ByEmailHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEmailHash, opts...).ToFunc()
+}
+
+// ByUserID orders the results by the user_id field.
+func ByUserID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUserID, opts...).ToFunc()
+}
+
+// ByErasedAt orders the results by the erased_at field.
+func ByErasedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldErasedAt, opts...).ToFunc()
+}
+
+// ByOrganizationID orders the results by the organization_id field.
+func ByOrganizationID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOrganizationID, opts...).ToFunc()
+}
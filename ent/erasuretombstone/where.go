@@ -0,0 +1,345 @@
+// Code generated by ent, DO NOT EDIT.
+
+package erasuretombstone
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Beriw98/user-management/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldLTE(FieldID, id))
+}
+
+// IDEqualFold applies the EqualFold predicate on the ID field.
+func IDEqualFold(id string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEqualFold(FieldID, id))
+}
+
+// IDContainsFold applies the ContainsFold predicate on the ID field.
+func IDContainsFold(id string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldContainsFold(FieldID, id))
+}
+
+// EmailHash applies equality check predicate on the "email_hash" field. It's identical to EmailHashEQ.
+func EmailHash(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEQ(FieldEmailHash, v))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEQ(FieldUserID, v))
+}
+
+// ErasedAt applies equality check predicate on the "erased_at" field. It's identical to ErasedAtEQ.
+func ErasedAt(v time.Time) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEQ(FieldErasedAt, v))
+}
+
+// OrganizationID applies equality check predicate on the "organization_id" field. It's identical to OrganizationIDEQ.
+func OrganizationID(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEQ(FieldOrganizationID, v))
+}
+
+// EmailHashEQ applies the EQ predicate on the "email_hash" field.
+func EmailHashEQ(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEQ(FieldEmailHash, v))
+}
+
+// EmailHashNEQ applies the NEQ predicate on the "email_hash" field.
+func EmailHashNEQ(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldNEQ(FieldEmailHash, v))
+}
+
+// EmailHashIn applies the In predicate on the "email_hash" field.
+func EmailHashIn(vs ...string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldIn(FieldEmailHash, vs...))
+}
+
+// EmailHashNotIn applies the NotIn predicate on the "email_hash" field.
+func EmailHashNotIn(vs ...string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldNotIn(FieldEmailHash, vs...))
+}
+
+// EmailHashGT applies the GT predicate on the "email_hash" field.
+func EmailHashGT(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldGT(FieldEmailHash, v))
+}
+
+// EmailHashGTE applies the GTE predicate on the "email_hash" field.
+func EmailHashGTE(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldGTE(FieldEmailHash, v))
+}
+
+// EmailHashLT applies the LT predicate on the "email_hash" field.
+func EmailHashLT(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldLT(FieldEmailHash, v))
+}
+
+// EmailHashLTE applies the LTE predicate on the "email_hash" field.
+func EmailHashLTE(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldLTE(FieldEmailHash, v))
+}
+
+// EmailHashContains applies the Contains predicate on the "email_hash" field.
+func EmailHashContains(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldContains(FieldEmailHash, v))
+}
+
+// EmailHashHasPrefix applies the HasPrefix predicate on the "email_hash" field.
+func EmailHashHasPrefix(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldHasPrefix(FieldEmailHash, v))
+}
+
+// EmailHashHasSuffix applies the HasSuffix predicate on the "email_hash" field.
+func EmailHashHasSuffix(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldHasSuffix(FieldEmailHash, v))
+}
+
+// EmailHashEqualFold applies the EqualFold predicate on the "email_hash" field.
+func EmailHashEqualFold(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEqualFold(FieldEmailHash, v))
+}
+
+// EmailHashContainsFold applies the ContainsFold predicate on the "email_hash" field.
+func EmailHashContainsFold(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldContainsFold(FieldEmailHash, v))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// UserIDGT applies the GT predicate on the "user_id" field.
+func UserIDGT(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldGT(FieldUserID, v))
+}
+
+// UserIDGTE applies the GTE predicate on the "user_id" field.
+func UserIDGTE(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldGTE(FieldUserID, v))
+}
+
+// UserIDLT applies the LT predicate on the "user_id" field.
+func UserIDLT(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldLT(FieldUserID, v))
+}
+
+// UserIDLTE applies the LTE predicate on the "user_id" field.
+func UserIDLTE(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldLTE(FieldUserID, v))
+}
+
+// UserIDContains applies the Contains predicate on the "user_id" field.
+func UserIDContains(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldContains(FieldUserID, v))
+}
+
+// UserIDHasPrefix applies the HasPrefix predicate on the "user_id" field.
+func UserIDHasPrefix(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldHasPrefix(FieldUserID, v))
+}
+
+// UserIDHasSuffix applies the HasSuffix predicate on the "user_id" field.
+func UserIDHasSuffix(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldHasSuffix(FieldUserID, v))
+}
+
+// UserIDEqualFold applies the EqualFold predicate on the "user_id" field.
+func UserIDEqualFold(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEqualFold(FieldUserID, v))
+}
+
+// UserIDContainsFold applies the ContainsFold predicate on the "user_id" field.
+func UserIDContainsFold(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldContainsFold(FieldUserID, v))
+}
+
+// ErasedAtEQ applies the EQ predicate on the "erased_at" field.
+func ErasedAtEQ(v time.Time) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEQ(FieldErasedAt, v))
+}
+
+// ErasedAtNEQ applies the NEQ predicate on the "erased_at" field.
+func ErasedAtNEQ(v time.Time) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldNEQ(FieldErasedAt, v))
+}
+
+// ErasedAtIn applies the In predicate on the "erased_at" field.
+func ErasedAtIn(vs ...time.Time) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldIn(FieldErasedAt, vs...))
+}
+
+// ErasedAtNotIn applies the NotIn predicate on the "erased_at" field.
+func ErasedAtNotIn(vs ...time.Time) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldNotIn(FieldErasedAt, vs...))
+}
+
+// ErasedAtGT applies the GT predicate on the "erased_at" field.
+func ErasedAtGT(v time.Time) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldGT(FieldErasedAt, v))
+}
+
+// ErasedAtGTE applies the GTE predicate on the "erased_at" field.
+func ErasedAtGTE(v time.Time) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldGTE(FieldErasedAt, v))
+}
+
+// ErasedAtLT applies the LT predicate on the "erased_at" field.
+func ErasedAtLT(v time.Time) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldLT(FieldErasedAt, v))
+}
+
+// ErasedAtLTE applies the LTE predicate on the "erased_at" field.
+func ErasedAtLTE(v time.Time) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldLTE(FieldErasedAt, v))
+}
+
+// OrganizationIDEQ applies the EQ predicate on the "organization_id" field.
+func OrganizationIDEQ(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEQ(FieldOrganizationID, v))
+}
+
+// OrganizationIDNEQ applies the NEQ predicate on the "organization_id" field.
+func OrganizationIDNEQ(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldNEQ(FieldOrganizationID, v))
+}
+
+// OrganizationIDIn applies the In predicate on the "organization_id" field.
+func OrganizationIDIn(vs ...string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldIn(FieldOrganizationID, vs...))
+}
+
+// OrganizationIDNotIn applies the NotIn predicate on the "organization_id" field.
+func OrganizationIDNotIn(vs ...string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldNotIn(FieldOrganizationID, vs...))
+}
+
+// OrganizationIDGT applies the GT predicate on the "organization_id" field.
+func OrganizationIDGT(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldGT(FieldOrganizationID, v))
+}
+
+// OrganizationIDGTE applies the GTE predicate on the "organization_id" field.
+func OrganizationIDGTE(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldGTE(FieldOrganizationID, v))
+}
+
+// OrganizationIDLT applies the LT predicate on the "organization_id" field.
+func OrganizationIDLT(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldLT(FieldOrganizationID, v))
+}
+
+// OrganizationIDLTE applies the LTE predicate on the "organization_id" field.
+func OrganizationIDLTE(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldLTE(FieldOrganizationID, v))
+}
+
+// OrganizationIDContains applies the Contains predicate on the "organization_id" field.
+func OrganizationIDContains(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldContains(FieldOrganizationID, v))
+}
+
+// OrganizationIDHasPrefix applies the HasPrefix predicate on the "organization_id" field.
+func OrganizationIDHasPrefix(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldHasPrefix(FieldOrganizationID, v))
+}
+
+// OrganizationIDHasSuffix applies the HasSuffix predicate on the "organization_id" field.
+func OrganizationIDHasSuffix(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldHasSuffix(FieldOrganizationID, v))
+}
+
+// OrganizationIDIsNil applies the IsNil predicate on the "organization_id" field.
+func OrganizationIDIsNil() predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldIsNull(FieldOrganizationID))
+}
+
+// OrganizationIDNotNil applies the NotNil predicate on the "organization_id" field.
+func OrganizationIDNotNil() predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldNotNull(FieldOrganizationID))
+}
+
+// OrganizationIDEqualFold applies the EqualFold predicate on the "organization_id" field.
+func OrganizationIDEqualFold(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldEqualFold(FieldOrganizationID, v))
+}
+
+// OrganizationIDContainsFold applies the ContainsFold predicate on the "organization_id" field.
+func OrganizationIDContainsFold(v string) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.FieldContainsFold(FieldOrganizationID, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ErasureTombstone) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ErasureTombstone) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ErasureTombstone) predicate.ErasureTombstone {
+	return predicate.ErasureTombstone(sql.NotPredicates(p))
+}
@@ -0,0 +1,591 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/erasuretombstone"
+)
+
+// ErasureTombstoneCreate is the builder for creating a ErasureTombstone entity.
+type ErasureTombstoneCreate struct {
+	config
+	mutation *ErasureTombstoneMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetEmailHash sets the "email_hash" field.
+func (etc *ErasureTombstoneCreate) SetEmailHash(s string) *ErasureTombstoneCreate {
+	etc.mutation.SetEmailHash(s)
+	return etc
+}
+
+// SetUserID sets the "user_id" field.
+func (etc *ErasureTombstoneCreate) SetUserID(s string) *ErasureTombstoneCreate {
+	etc.mutation.SetUserID(s)
+	return etc
+}
+
+// SetErasedAt sets the "erased_at" field.
+func (etc *ErasureTombstoneCreate) SetErasedAt(t time.Time) *ErasureTombstoneCreate {
+	etc.mutation.SetErasedAt(t)
+	return etc
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (etc *ErasureTombstoneCreate) SetOrganizationID(s string) *ErasureTombstoneCreate {
+	etc.mutation.SetOrganizationID(s)
+	return etc
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (etc *ErasureTombstoneCreate) SetNillableOrganizationID(s *string) *ErasureTombstoneCreate {
+	if s != nil {
+		etc.SetOrganizationID(*s)
+	}
+	return etc
+}
+
+// SetID sets the "id" field.
+func (etc *ErasureTombstoneCreate) SetID(s string) *ErasureTombstoneCreate {
+	etc.mutation.SetID(s)
+	return etc
+}
+
+// Mutation returns the ErasureTombstoneMutation object of the builder.
+func (etc *ErasureTombstoneCreate) Mutation() *ErasureTombstoneMutation {
+	return etc.mutation
+}
+
+// Save creates the ErasureTombstone in the database.
+func (etc *ErasureTombstoneCreate) Save(ctx context.Context) (*ErasureTombstone, error) {
+	return withHooks(ctx, etc.sqlSave, etc.mutation, etc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (etc *ErasureTombstoneCreate) SaveX(ctx context.Context) *ErasureTombstone {
+	v, err := etc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (etc *ErasureTombstoneCreate) Exec(ctx context.Context) error {
+	_, err := etc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (etc *ErasureTombstoneCreate) ExecX(ctx context.Context) {
+	if err := etc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (etc *ErasureTombstoneCreate) check() error {
+	if _, ok := etc.mutation.EmailHash(); !ok {
+		return &ValidationError{Name: "email_hash", err: errors.New(`ent: missing required field "ErasureTombstone.email_hash"`)}
+	}
+	if v, ok := etc.mutation.EmailHash(); ok {
+		if err := erasuretombstone.EmailHashValidator(v); err != nil {
+			return &ValidationError{Name: "email_hash", err: fmt.Errorf(`ent: validator failed for field "ErasureTombstone.email_hash": %w`, err)}
+		}
+	}
+	if _, ok := etc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "ErasureTombstone.user_id"`)}
+	}
+	if _, ok := etc.mutation.ErasedAt(); !ok {
+		return &ValidationError{Name: "erased_at", err: errors.New(`ent: missing required field "ErasureTombstone.erased_at"`)}
+	}
+	return nil
+}
+
+func (etc *ErasureTombstoneCreate) sqlSave(ctx context.Context) (*ErasureTombstone, error) {
+	if err := etc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := etc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, etc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected ErasureTombstone.ID type: %T", _spec.ID.Value)
+		}
+	}
+	etc.mutation.id = &_node.ID
+	etc.mutation.done = true
+	return _node, nil
+}
+
+func (etc *ErasureTombstoneCreate) createSpec() (*ErasureTombstone, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ErasureTombstone{config: etc.config}
+		_spec = sqlgraph.NewCreateSpec(erasuretombstone.Table, sqlgraph.NewFieldSpec(erasuretombstone.FieldID, field.TypeString))
+	)
+	_spec.OnConflict = etc.conflict
+	if id, ok := etc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := etc.mutation.EmailHash(); ok {
+		_spec.SetField(erasuretombstone.FieldEmailHash, field.TypeString, value)
+		_node.EmailHash = value
+	}
+	if value, ok := etc.mutation.UserID(); ok {
+		_spec.SetField(erasuretombstone.FieldUserID, field.TypeString, value)
+		_node.UserID = value
+	}
+	if value, ok := etc.mutation.ErasedAt(); ok {
+		_spec.SetField(erasuretombstone.FieldErasedAt, field.TypeTime, value)
+		_node.ErasedAt = value
+	}
+	if value, ok := etc.mutation.OrganizationID(); ok {
+		_spec.SetField(erasuretombstone.FieldOrganizationID, field.TypeString, value)
+		_node.OrganizationID = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.ErasureTombstone.Create().
+//		SetEmailHash(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.ErasureTombstoneUpsert) {
+//			SetEmailHash(v+v).
+//		}).
+//		Exec(ctx)
+func (etc *ErasureTombstoneCreate) OnConflict(opts ...sql.ConflictOption) *ErasureTombstoneUpsertOne {
+	etc.conflict = opts
+	return &ErasureTombstoneUpsertOne{
+		create: etc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.ErasureTombstone.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (etc *ErasureTombstoneCreate) OnConflictColumns(columns ...string) *ErasureTombstoneUpsertOne {
+	etc.conflict = append(etc.conflict, sql.ConflictColumns(columns...))
+	return &ErasureTombstoneUpsertOne{
+		create: etc,
+	}
+}
+
+type (
+	// ErasureTombstoneUpsertOne is the builder for "upsert"-ing
+	//  one ErasureTombstone node.
+	ErasureTombstoneUpsertOne struct {
+		create *ErasureTombstoneCreate
+	}
+
+	// ErasureTombstoneUpsert is the "OnConflict" setter.
+	ErasureTombstoneUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUserID sets the "user_id" field.
+func (u *ErasureTombstoneUpsert) SetUserID(v string) *ErasureTombstoneUpsert {
+	u.Set(erasuretombstone.FieldUserID, v)
+	return u
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *ErasureTombstoneUpsert) UpdateUserID() *ErasureTombstoneUpsert {
+	u.SetExcluded(erasuretombstone.FieldUserID)
+	return u
+}
+
+// SetErasedAt sets the "erased_at" field.
+func (u *ErasureTombstoneUpsert) SetErasedAt(v time.Time) *ErasureTombstoneUpsert {
+	u.Set(erasuretombstone.FieldErasedAt, v)
+	return u
+}
+
+// UpdateErasedAt sets the "erased_at" field to the value that was provided on create.
+func (u *ErasureTombstoneUpsert) UpdateErasedAt() *ErasureTombstoneUpsert {
+	u.SetExcluded(erasuretombstone.FieldErasedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.ErasureTombstone.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(erasuretombstone.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *ErasureTombstoneUpsertOne) UpdateNewValues() *ErasureTombstoneUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(erasuretombstone.FieldID)
+		}
+		if _, exists := u.create.mutation.EmailHash(); exists {
+			s.SetIgnore(erasuretombstone.FieldEmailHash)
+		}
+		if _, exists := u.create.mutation.OrganizationID(); exists {
+			s.SetIgnore(erasuretombstone.FieldOrganizationID)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.ErasureTombstone.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *ErasureTombstoneUpsertOne) Ignore() *ErasureTombstoneUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *ErasureTombstoneUpsertOne) DoNothing() *ErasureTombstoneUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the ErasureTombstoneCreate.OnConflict
+// documentation for more info.
+func (u *ErasureTombstoneUpsertOne) Update(set func(*ErasureTombstoneUpsert)) *ErasureTombstoneUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&ErasureTombstoneUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *ErasureTombstoneUpsertOne) SetUserID(v string) *ErasureTombstoneUpsertOne {
+	return u.Update(func(s *ErasureTombstoneUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *ErasureTombstoneUpsertOne) UpdateUserID() *ErasureTombstoneUpsertOne {
+	return u.Update(func(s *ErasureTombstoneUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetErasedAt sets the "erased_at" field.
+func (u *ErasureTombstoneUpsertOne) SetErasedAt(v time.Time) *ErasureTombstoneUpsertOne {
+	return u.Update(func(s *ErasureTombstoneUpsert) {
+		s.SetErasedAt(v)
+	})
+}
+
+// UpdateErasedAt sets the "erased_at" field to the value that was provided on create.
+func (u *ErasureTombstoneUpsertOne) UpdateErasedAt() *ErasureTombstoneUpsertOne {
+	return u.Update(func(s *ErasureTombstoneUpsert) {
+		s.UpdateErasedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *ErasureTombstoneUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for ErasureTombstoneCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *ErasureTombstoneUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *ErasureTombstoneUpsertOne) ID(ctx context.Context) (id string, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: ErasureTombstoneUpsertOne.ID is not supported by MySQL driver. Use ErasureTombstoneUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *ErasureTombstoneUpsertOne) IDX(ctx context.Context) string {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// ErasureTombstoneCreateBulk is the builder for creating many ErasureTombstone entities in bulk.
+type ErasureTombstoneCreateBulk struct {
+	config
+	err      error
+	builders []*ErasureTombstoneCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the ErasureTombstone entities in the database.
+func (etcb *ErasureTombstoneCreateBulk) Save(ctx context.Context) ([]*ErasureTombstone, error) {
+	if etcb.err != nil {
+		return nil, etcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(etcb.builders))
+	nodes := make([]*ErasureTombstone, len(etcb.builders))
+	mutators := make([]Mutator, len(etcb.builders))
+	for i := range etcb.builders {
+		func(i int, root context.Context) {
+			builder := etcb.builders[i]
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ErasureTombstoneMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, etcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = etcb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, etcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, etcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (etcb *ErasureTombstoneCreateBulk) SaveX(ctx context.Context) []*ErasureTombstone {
+	v, err := etcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (etcb *ErasureTombstoneCreateBulk) Exec(ctx context.Context) error {
+	_, err := etcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (etcb *ErasureTombstoneCreateBulk) ExecX(ctx context.Context) {
+	if err := etcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.ErasureTombstone.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.ErasureTombstoneUpsert) {
+//			SetEmailHash(v+v).
+//		}).
+//		Exec(ctx)
+func (etcb *ErasureTombstoneCreateBulk) OnConflict(opts ...sql.ConflictOption) *ErasureTombstoneUpsertBulk {
+	etcb.conflict = opts
+	return &ErasureTombstoneUpsertBulk{
+		create: etcb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.ErasureTombstone.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (etcb *ErasureTombstoneCreateBulk) OnConflictColumns(columns ...string) *ErasureTombstoneUpsertBulk {
+	etcb.conflict = append(etcb.conflict, sql.ConflictColumns(columns...))
+	return &ErasureTombstoneUpsertBulk{
+		create: etcb,
+	}
+}
+
+// ErasureTombstoneUpsertBulk is the builder for "upsert"-ing
+// a bulk of ErasureTombstone nodes.
+type ErasureTombstoneUpsertBulk struct {
+	create *ErasureTombstoneCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.ErasureTombstone.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(erasuretombstone.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *ErasureTombstoneUpsertBulk) UpdateNewValues() *ErasureTombstoneUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(erasuretombstone.FieldID)
+			}
+			if _, exists := b.mutation.EmailHash(); exists {
+				s.SetIgnore(erasuretombstone.FieldEmailHash)
+			}
+			if _, exists := b.mutation.OrganizationID(); exists {
+				s.SetIgnore(erasuretombstone.FieldOrganizationID)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.ErasureTombstone.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *ErasureTombstoneUpsertBulk) Ignore() *ErasureTombstoneUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *ErasureTombstoneUpsertBulk) DoNothing() *ErasureTombstoneUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the ErasureTombstoneCreateBulk.OnConflict
+// documentation for more info.
+func (u *ErasureTombstoneUpsertBulk) Update(set func(*ErasureTombstoneUpsert)) *ErasureTombstoneUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&ErasureTombstoneUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *ErasureTombstoneUpsertBulk) SetUserID(v string) *ErasureTombstoneUpsertBulk {
+	return u.Update(func(s *ErasureTombstoneUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *ErasureTombstoneUpsertBulk) UpdateUserID() *ErasureTombstoneUpsertBulk {
+	return u.Update(func(s *ErasureTombstoneUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetErasedAt sets the "erased_at" field.
+func (u *ErasureTombstoneUpsertBulk) SetErasedAt(v time.Time) *ErasureTombstoneUpsertBulk {
+	return u.Update(func(s *ErasureTombstoneUpsert) {
+		s.SetErasedAt(v)
+	})
+}
+
+// UpdateErasedAt sets the "erased_at" field to the value that was provided on create.
+func (u *ErasureTombstoneUpsertBulk) UpdateErasedAt() *ErasureTombstoneUpsertBulk {
+	return u.Update(func(s *ErasureTombstoneUpsert) {
+		s.UpdateErasedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *ErasureTombstoneUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the ErasureTombstoneCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for ErasureTombstoneCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *ErasureTombstoneUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/erasuretombstone"
+	"github.com/Beriw98/user-management/ent/predicate"
+)
+
+// ErasureTombstoneDelete is the builder for deleting a ErasureTombstone entity.
+type ErasureTombstoneDelete struct {
+	config
+	hooks    []Hook
+	mutation *ErasureTombstoneMutation
+}
+
+// Where appends a list predicates to the ErasureTombstoneDelete builder.
+func (etd *ErasureTombstoneDelete) Where(ps ...predicate.ErasureTombstone) *ErasureTombstoneDelete {
+	etd.mutation.Where(ps...)
+	return etd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (etd *ErasureTombstoneDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, etd.sqlExec, etd.mutation, etd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (etd *ErasureTombstoneDelete) ExecX(ctx context.Context) int {
+	n, err := etd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (etd *ErasureTombstoneDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(erasuretombstone.Table, sqlgraph.NewFieldSpec(erasuretombstone.FieldID, field.TypeString))
+	if ps := etd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, etd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	etd.mutation.done = true
+	return affected, err
+}
+
+// ErasureTombstoneDeleteOne is the builder for deleting a single ErasureTombstone entity.
+type ErasureTombstoneDeleteOne struct {
+	etd *ErasureTombstoneDelete
+}
+
+// Where appends a list predicates to the ErasureTombstoneDelete builder.
+func (etdo *ErasureTombstoneDeleteOne) Where(ps ...predicate.ErasureTombstone) *ErasureTombstoneDeleteOne {
+	etdo.etd.mutation.Where(ps...)
+	return etdo
+}
+
+// Exec executes the deletion query.
+func (etdo *ErasureTombstoneDeleteOne) Exec(ctx context.Context) error {
+	n, err := etdo.etd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{erasuretombstone.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (etdo *ErasureTombstoneDeleteOne) ExecX(ctx context.Context) {
+	if err := etdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,564 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/erasuretombstone"
+	"github.com/Beriw98/user-management/ent/predicate"
+)
+
+// ErasureTombstoneQuery is the builder for querying ErasureTombstone entities.
+type ErasureTombstoneQuery struct {
+	config
+	ctx        *QueryContext
+	order      []erasuretombstone.OrderOption
+	inters     []Interceptor
+	predicates []predicate.ErasureTombstone
+	modifiers  []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ErasureTombstoneQuery builder.
+func (etq *ErasureTombstoneQuery) Where(ps ...predicate.ErasureTombstone) *ErasureTombstoneQuery {
+	etq.predicates = append(etq.predicates, ps...)
+	return etq
+}
+
+// Limit the number of records to be returned by this query.
+func (etq *ErasureTombstoneQuery) Limit(limit int) *ErasureTombstoneQuery {
+	etq.ctx.Limit = &limit
+	return etq
+}
+
+// Offset to start from.
+func (etq *ErasureTombstoneQuery) Offset(offset int) *ErasureTombstoneQuery {
+	etq.ctx.Offset = &offset
+	return etq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (etq *ErasureTombstoneQuery) Unique(unique bool) *ErasureTombstoneQuery {
+	etq.ctx.Unique = &unique
+	return etq
+}
+
+// Order specifies how the records should be ordered.
+func (etq *ErasureTombstoneQuery) Order(o ...erasuretombstone.OrderOption) *ErasureTombstoneQuery {
+	etq.order = append(etq.order, o...)
+	return etq
+}
+
+// First returns the first ErasureTombstone entity from the query.
+// Returns a *NotFoundError when no ErasureTombstone was found.
+func (etq *ErasureTombstoneQuery) First(ctx context.Context) (*ErasureTombstone, error) {
+	nodes, err := etq.Limit(1).All(setContextOp(ctx, etq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{erasuretombstone.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (etq *ErasureTombstoneQuery) FirstX(ctx context.Context) *ErasureTombstone {
+	node, err := etq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first ErasureTombstone ID from the query.
+// Returns a *NotFoundError when no ErasureTombstone ID was found.
+func (etq *ErasureTombstoneQuery) FirstID(ctx context.Context) (id string, err error) {
+	var ids []string
+	if ids, err = etq.Limit(1).IDs(setContextOp(ctx, etq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{erasuretombstone.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (etq *ErasureTombstoneQuery) FirstIDX(ctx context.Context) string {
+	id, err := etq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single ErasureTombstone entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one ErasureTombstone entity is found.
+// Returns a *NotFoundError when no ErasureTombstone entities are found.
+func (etq *ErasureTombstoneQuery) Only(ctx context.Context) (*ErasureTombstone, error) {
+	nodes, err := etq.Limit(2).All(setContextOp(ctx, etq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{erasuretombstone.Label}
+	default:
+		return nil, &NotSingularError{erasuretombstone.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (etq *ErasureTombstoneQuery) OnlyX(ctx context.Context) *ErasureTombstone {
+	node, err := etq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only ErasureTombstone ID in the query.
+// Returns a *NotSingularError when more than one ErasureTombstone ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (etq *ErasureTombstoneQuery) OnlyID(ctx context.Context) (id string, err error) {
+	var ids []string
+	if ids, err = etq.Limit(2).IDs(setContextOp(ctx, etq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{erasuretombstone.Label}
+	default:
+		err = &NotSingularError{erasuretombstone.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (etq *ErasureTombstoneQuery) OnlyIDX(ctx context.Context) string {
+	id, err := etq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of ErasureTombstones.
+func (etq *ErasureTombstoneQuery) All(ctx context.Context) ([]*ErasureTombstone, error) {
+	ctx = setContextOp(ctx, etq.ctx, ent.OpQueryAll)
+	if err := etq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*ErasureTombstone, *ErasureTombstoneQuery]()
+	return withInterceptors[[]*ErasureTombstone](ctx, etq, qr, etq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (etq *ErasureTombstoneQuery) AllX(ctx context.Context) []*ErasureTombstone {
+	nodes, err := etq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of ErasureTombstone IDs.
+func (etq *ErasureTombstoneQuery) IDs(ctx context.Context) (ids []string, err error) {
+	if etq.ctx.Unique == nil && etq.path != nil {
+		etq.Unique(true)
+	}
+	ctx = setContextOp(ctx, etq.ctx, ent.OpQueryIDs)
+	if err = etq.Select(erasuretombstone.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (etq *ErasureTombstoneQuery) IDsX(ctx context.Context) []string {
+	ids, err := etq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (etq *ErasureTombstoneQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, etq.ctx, ent.OpQueryCount)
+	if err := etq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, etq, querierCount[*ErasureTombstoneQuery](), etq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (etq *ErasureTombstoneQuery) CountX(ctx context.Context) int {
+	count, err := etq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (etq *ErasureTombstoneQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, etq.ctx, ent.OpQueryExist)
+	switch _, err := etq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (etq *ErasureTombstoneQuery) ExistX(ctx context.Context) bool {
+	exist, err := etq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ErasureTombstoneQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (etq *ErasureTombstoneQuery) Clone() *ErasureTombstoneQuery {
+	if etq == nil {
+		return nil
+	}
+	return &ErasureTombstoneQuery{
+		config:     etq.config,
+		ctx:        etq.ctx.Clone(),
+		order:      append([]erasuretombstone.OrderOption{}, etq.order...),
+		inters:     append([]Interceptor{}, etq.inters...),
+		predicates: append([]predicate.ErasureTombstone{}, etq.predicates...),
+		// clone intermediate query.
+		sql:  etq.sql.Clone(),
+		path: etq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		EmailHash string `json:"email_hash,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.ErasureTombstone.Query().
+//		GroupBy(erasuretombstone.FieldEmailHash).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (etq *ErasureTombstoneQuery) GroupBy(field string, fields ...string) *ErasureTombstoneGroupBy {
+	etq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &ErasureTombstoneGroupBy{build: etq}
+	grbuild.flds = &etq.ctx.Fields
+	grbuild.label = erasuretombstone.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		EmailHash string `json:"email_hash,omitempty"`
+//	}
+//
+//	client.ErasureTombstone.Query().
+//		Select(erasuretombstone.FieldEmailHash).
+//		Scan(ctx, &v)
+func (etq *ErasureTombstoneQuery) Select(fields ...string) *ErasureTombstoneSelect {
+	etq.ctx.Fields = append(etq.ctx.Fields, fields...)
+	sbuild := &ErasureTombstoneSelect{ErasureTombstoneQuery: etq}
+	sbuild.label = erasuretombstone.Label
+	sbuild.flds, sbuild.scan = &etq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a ErasureTombstoneSelect configured with the given aggregations.
+func (etq *ErasureTombstoneQuery) Aggregate(fns ...AggregateFunc) *ErasureTombstoneSelect {
+	return etq.Select().Aggregate(fns...)
+}
+
+func (etq *ErasureTombstoneQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range etq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, etq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range etq.ctx.Fields {
+		if !erasuretombstone.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if etq.path != nil {
+		prev, err := etq.path(ctx)
+		if err != nil {
+			return err
+		}
+		etq.sql = prev
+	}
+	return nil
+}
+
+func (etq *ErasureTombstoneQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*ErasureTombstone, error) {
+	var (
+		nodes = []*ErasureTombstone{}
+		_spec = etq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*ErasureTombstone).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &ErasureTombstone{config: etq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	if len(etq.modifiers) > 0 {
+		_spec.Modifiers = etq.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, etq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (etq *ErasureTombstoneQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := etq.querySpec()
+	if len(etq.modifiers) > 0 {
+		_spec.Modifiers = etq.modifiers
+	}
+	_spec.Node.Columns = etq.ctx.Fields
+	if len(etq.ctx.Fields) > 0 {
+		_spec.Unique = etq.ctx.Unique != nil && *etq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, etq.driver, _spec)
+}
+
+func (etq *ErasureTombstoneQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(erasuretombstone.Table, erasuretombstone.Columns, sqlgraph.NewFieldSpec(erasuretombstone.FieldID, field.TypeString))
+	_spec.From = etq.sql
+	if unique := etq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if etq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := etq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, erasuretombstone.FieldID)
+		for i := range fields {
+			if fields[i] != erasuretombstone.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := etq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := etq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := etq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := etq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (etq *ErasureTombstoneQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(etq.driver.Dialect())
+	t1 := builder.Table(erasuretombstone.Table)
+	columns := etq.ctx.Fields
+	if len(columns) == 0 {
+		columns = erasuretombstone.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if etq.sql != nil {
+		selector = etq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if etq.ctx.Unique != nil && *etq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, m := range etq.modifiers {
+		m(selector)
+	}
+	for _, p := range etq.predicates {
+		p(selector)
+	}
+	for _, p := range etq.order {
+		p(selector)
+	}
+	if offset := etq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := etq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ForUpdate locks the selected rows against concurrent updates, and prevent them from being
+// updated, deleted or "selected ... for update" by other sessions, until the transaction is
+// either committed or rolled-back.
+func (etq *ErasureTombstoneQuery) ForUpdate(opts ...sql.LockOption) *ErasureTombstoneQuery {
+	if etq.driver.Dialect() == dialect.Postgres {
+		etq.Unique(false)
+	}
+	etq.modifiers = append(etq.modifiers, func(s *sql.Selector) {
+		s.ForUpdate(opts...)
+	})
+	return etq
+}
+
+// ForShare behaves similarly to ForUpdate, except that it acquires a shared mode lock
+// on any rows that are read. Other sessions can read the rows, but cannot modify them
+// until your transaction commits.
+func (etq *ErasureTombstoneQuery) ForShare(opts ...sql.LockOption) *ErasureTombstoneQuery {
+	if etq.driver.Dialect() == dialect.Postgres {
+		etq.Unique(false)
+	}
+	etq.modifiers = append(etq.modifiers, func(s *sql.Selector) {
+		s.ForShare(opts...)
+	})
+	return etq
+}
+
+// ErasureTombstoneGroupBy is the group-by builder for ErasureTombstone entities.
+type ErasureTombstoneGroupBy struct {
+	selector
+	build *ErasureTombstoneQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (etgb *ErasureTombstoneGroupBy) Aggregate(fns ...AggregateFunc) *ErasureTombstoneGroupBy {
+	etgb.fns = append(etgb.fns, fns...)
+	return etgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (etgb *ErasureTombstoneGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, etgb.build.ctx, ent.OpQueryGroupBy)
+	if err := etgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ErasureTombstoneQuery, *ErasureTombstoneGroupBy](ctx, etgb.build, etgb, etgb.build.inters, v)
+}
+
+func (etgb *ErasureTombstoneGroupBy) sqlScan(ctx context.Context, root *ErasureTombstoneQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(etgb.fns))
+	for _, fn := range etgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*etgb.flds)+len(etgb.fns))
+		for _, f := range *etgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*etgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := etgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// ErasureTombstoneSelect is the builder for selecting fields of ErasureTombstone entities.
+type ErasureTombstoneSelect struct {
+	*ErasureTombstoneQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (ets *ErasureTombstoneSelect) Aggregate(fns ...AggregateFunc) *ErasureTombstoneSelect {
+	ets.fns = append(ets.fns, fns...)
+	return ets
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (ets *ErasureTombstoneSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, ets.ctx, ent.OpQuerySelect)
+	if err := ets.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ErasureTombstoneQuery, *ErasureTombstoneSelect](ctx, ets.ErasureTombstoneQuery, ets, ets.inters, v)
+}
+
+func (ets *ErasureTombstoneSelect) sqlScan(ctx context.Context, root *ErasureTombstoneQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(ets.fns))
+	for _, fn := range ets.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*ets.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := ets.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
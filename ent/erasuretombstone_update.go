@@ -0,0 +1,250 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/erasuretombstone"
+	"github.com/Beriw98/user-management/ent/predicate"
+)
+
+// ErasureTombstoneUpdate is the builder for updating ErasureTombstone entities.
+type ErasureTombstoneUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ErasureTombstoneMutation
+}
+
+// Where appends a list predicates to the ErasureTombstoneUpdate builder.
+func (etu *ErasureTombstoneUpdate) Where(ps ...predicate.ErasureTombstone) *ErasureTombstoneUpdate {
+	etu.mutation.Where(ps...)
+	return etu
+}
+
+// SetUserID sets the "user_id" field.
+func (etu *ErasureTombstoneUpdate) SetUserID(s string) *ErasureTombstoneUpdate {
+	etu.mutation.SetUserID(s)
+	return etu
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (etu *ErasureTombstoneUpdate) SetNillableUserID(s *string) *ErasureTombstoneUpdate {
+	if s != nil {
+		etu.SetUserID(*s)
+	}
+	return etu
+}
+
+// SetErasedAt sets the "erased_at" field.
+func (etu *ErasureTombstoneUpdate) SetErasedAt(t time.Time) *ErasureTombstoneUpdate {
+	etu.mutation.SetErasedAt(t)
+	return etu
+}
+
+// SetNillableErasedAt sets the "erased_at" field if the given value is not nil.
+func (etu *ErasureTombstoneUpdate) SetNillableErasedAt(t *time.Time) *ErasureTombstoneUpdate {
+	if t != nil {
+		etu.SetErasedAt(*t)
+	}
+	return etu
+}
+
+// Mutation returns the ErasureTombstoneMutation object of the builder.
+func (etu *ErasureTombstoneUpdate) Mutation() *ErasureTombstoneMutation {
+	return etu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (etu *ErasureTombstoneUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, etu.sqlSave, etu.mutation, etu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (etu *ErasureTombstoneUpdate) SaveX(ctx context.Context) int {
+	affected, err := etu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (etu *ErasureTombstoneUpdate) Exec(ctx context.Context) error {
+	_, err := etu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (etu *ErasureTombstoneUpdate) ExecX(ctx context.Context) {
+	if err := etu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (etu *ErasureTombstoneUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(erasuretombstone.Table, erasuretombstone.Columns, sqlgraph.NewFieldSpec(erasuretombstone.FieldID, field.TypeString))
+	if ps := etu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := etu.mutation.UserID(); ok {
+		_spec.SetField(erasuretombstone.FieldUserID, field.TypeString, value)
+	}
+	if value, ok := etu.mutation.ErasedAt(); ok {
+		_spec.SetField(erasuretombstone.FieldErasedAt, field.TypeTime, value)
+	}
+	if etu.mutation.OrganizationIDCleared() {
+		_spec.ClearField(erasuretombstone.FieldOrganizationID, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, etu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{erasuretombstone.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	etu.mutation.done = true
+	return n, nil
+}
+
+// ErasureTombstoneUpdateOne is the builder for updating a single ErasureTombstone entity.
+type ErasureTombstoneUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ErasureTombstoneMutation
+}
+
+// SetUserID sets the "user_id" field.
+func (etuo *ErasureTombstoneUpdateOne) SetUserID(s string) *ErasureTombstoneUpdateOne {
+	etuo.mutation.SetUserID(s)
+	return etuo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (etuo *ErasureTombstoneUpdateOne) SetNillableUserID(s *string) *ErasureTombstoneUpdateOne {
+	if s != nil {
+		etuo.SetUserID(*s)
+	}
+	return etuo
+}
+
+// SetErasedAt sets the "erased_at" field.
+func (etuo *ErasureTombstoneUpdateOne) SetErasedAt(t time.Time) *ErasureTombstoneUpdateOne {
+	etuo.mutation.SetErasedAt(t)
+	return etuo
+}
+
+// SetNillableErasedAt sets the "erased_at" field if the given value is not nil.
+func (etuo *ErasureTombstoneUpdateOne) SetNillableErasedAt(t *time.Time) *ErasureTombstoneUpdateOne {
+	if t != nil {
+		etuo.SetErasedAt(*t)
+	}
+	return etuo
+}
+
+// Mutation returns the ErasureTombstoneMutation object of the builder.
+func (etuo *ErasureTombstoneUpdateOne) Mutation() *ErasureTombstoneMutation {
+	return etuo.mutation
+}
+
+// Where appends a list predicates to the ErasureTombstoneUpdate builder.
+func (etuo *ErasureTombstoneUpdateOne) Where(ps ...predicate.ErasureTombstone) *ErasureTombstoneUpdateOne {
+	etuo.mutation.Where(ps...)
+	return etuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (etuo *ErasureTombstoneUpdateOne) Select(field string, fields ...string) *ErasureTombstoneUpdateOne {
+	etuo.fields = append([]string{field}, fields...)
+	return etuo
+}
+
+// Save executes the query and returns the updated ErasureTombstone entity.
+func (etuo *ErasureTombstoneUpdateOne) Save(ctx context.Context) (*ErasureTombstone, error) {
+	return withHooks(ctx, etuo.sqlSave, etuo.mutation, etuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (etuo *ErasureTombstoneUpdateOne) SaveX(ctx context.Context) *ErasureTombstone {
+	node, err := etuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (etuo *ErasureTombstoneUpdateOne) Exec(ctx context.Context) error {
+	_, err := etuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (etuo *ErasureTombstoneUpdateOne) ExecX(ctx context.Context) {
+	if err := etuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (etuo *ErasureTombstoneUpdateOne) sqlSave(ctx context.Context) (_node *ErasureTombstone, err error) {
+	_spec := sqlgraph.NewUpdateSpec(erasuretombstone.Table, erasuretombstone.Columns, sqlgraph.NewFieldSpec(erasuretombstone.FieldID, field.TypeString))
+	id, ok := etuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ErasureTombstone.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := etuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, erasuretombstone.FieldID)
+		for _, f := range fields {
+			if !erasuretombstone.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != erasuretombstone.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := etuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := etuo.mutation.UserID(); ok {
+		_spec.SetField(erasuretombstone.FieldUserID, field.TypeString, value)
+	}
+	if value, ok := etuo.mutation.ErasedAt(); ok {
+		_spec.SetField(erasuretombstone.FieldErasedAt, field.TypeTime, value)
+	}
+	if etuo.mutation.OrganizationIDCleared() {
+		_spec.ClearField(erasuretombstone.FieldOrganizationID, field.TypeString)
+	}
+	_node = &ErasureTombstone{config: etuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, etuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{erasuretombstone.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	etuo.mutation.done = true
+	return _node, nil
+}
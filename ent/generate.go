@@ -1,3 +1,3 @@
 package ent
 
-//go:generate go run -mod=mod entgo.io/ent/cmd/ent generate --target . ./../internal/infrastructure/database/entity
+//go:generate go run -mod=mod entgo.io/ent/cmd/ent generate --feature intercept,sql/execquery,sql/lock,sql/upsert --target . ./../internal/infrastructure/database/entity
@@ -0,0 +1,249 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Beriw98/user-management/ent/importjob"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// ImportJob is the model entity for the ImportJob schema.
+type ImportJob struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID string `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Format holds the value of the "format" field.
+	Format domain.ImportFormat `json:"format,omitempty"`
+	// DryRun holds the value of the "dry_run" field.
+	DryRun bool `json:"dry_run,omitempty"`
+	// Status holds the value of the "status" field.
+	Status domain.ImportStatus `json:"status,omitempty"`
+	// Processed holds the value of the "processed" field.
+	Processed int `json:"processed,omitempty"`
+	// Created holds the value of the "created" field.
+	Created int `json:"created,omitempty"`
+	// Invited holds the value of the "invited" field.
+	Invited int `json:"invited,omitempty"`
+	// Failed holds the value of the "failed" field.
+	Failed int `json:"failed,omitempty"`
+	// RowErrors holds the value of the "row_errors" field.
+	RowErrors []domain.ImportRowError `json:"row_errors,omitempty"`
+	// Error holds the value of the "error" field.
+	Error string `json:"error,omitempty"`
+	// FinishedAt holds the value of the "finished_at" field.
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	// OrganizationID holds the value of the "organization_id" field.
+	OrganizationID string `json:"organization_id,omitempty"`
+	selectValues   sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ImportJob) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case importjob.FieldRowErrors:
+			values[i] = new([]byte)
+		case importjob.FieldDryRun:
+			values[i] = new(sql.NullBool)
+		case importjob.FieldProcessed, importjob.FieldCreated, importjob.FieldInvited, importjob.FieldFailed:
+			values[i] = new(sql.NullInt64)
+		case importjob.FieldID, importjob.FieldFormat, importjob.FieldStatus, importjob.FieldError, importjob.FieldOrganizationID:
+			values[i] = new(sql.NullString)
+		case importjob.FieldCreatedAt, importjob.FieldUpdatedAt, importjob.FieldFinishedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ImportJob fields.
+func (ij *ImportJob) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case importjob.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				ij.ID = value.String
+			}
+		case importjob.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				ij.CreatedAt = value.Time
+			}
+		case importjob.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				ij.UpdatedAt = value.Time
+			}
+		case importjob.FieldFormat:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field format", values[i])
+			} else if value.Valid {
+				ij.Format = domain.ImportFormat(value.String)
+			}
+		case importjob.FieldDryRun:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field dry_run", values[i])
+			} else if value.Valid {
+				ij.DryRun = value.Bool
+			}
+		case importjob.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				ij.Status = domain.ImportStatus(value.String)
+			}
+		case importjob.FieldProcessed:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field processed", values[i])
+			} else if value.Valid {
+				ij.Processed = int(value.Int64)
+			}
+		case importjob.FieldCreated:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created", values[i])
+			} else if value.Valid {
+				ij.Created = int(value.Int64)
+			}
+		case importjob.FieldInvited:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field invited", values[i])
+			} else if value.Valid {
+				ij.Invited = int(value.Int64)
+			}
+		case importjob.FieldFailed:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field failed", values[i])
+			} else if value.Valid {
+				ij.Failed = int(value.Int64)
+			}
+		case importjob.FieldRowErrors:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field row_errors", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ij.RowErrors); err != nil {
+					return fmt.Errorf("unmarshal field row_errors: %w", err)
+				}
+			}
+		case importjob.FieldError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error", values[i])
+			} else if value.Valid {
+				ij.Error = value.String
+			}
+		case importjob.FieldFinishedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field finished_at", values[i])
+			} else if value.Valid {
+				ij.FinishedAt = new(time.Time)
+				*ij.FinishedAt = value.Time
+			}
+		case importjob.FieldOrganizationID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field organization_id", values[i])
+			} else if value.Valid {
+				ij.OrganizationID = value.String
+			}
+		default:
+			ij.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ImportJob.
+// This includes values selected through modifiers, order, etc.
+func (ij *ImportJob) Value(name string) (ent.Value, error) {
+	return ij.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this ImportJob.
+// Note that you need to call ImportJob.Unwrap() before calling this method if this ImportJob
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (ij *ImportJob) Update() *ImportJobUpdateOne {
+	return NewImportJobClient(ij.config).UpdateOne(ij)
+}
+
+// Unwrap unwraps the ImportJob entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (ij *ImportJob) Unwrap() *ImportJob {
+	_tx, ok := ij.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ImportJob is not a transactional entity")
+	}
+	ij.config.driver = _tx.drv
+	return ij
+}
+
+// String implements the fmt.Stringer.
+func (ij *ImportJob) String() string {
+	var builder strings.Builder
+	builder.WriteString("ImportJob(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", ij.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(ij.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(ij.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("format=")
+	builder.WriteString(fmt.Sprintf("%v", ij.Format))
+	builder.WriteString(", ")
+	builder.WriteString("dry_run=")
+	builder.WriteString(fmt.Sprintf("%v", ij.DryRun))
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", ij.Status))
+	builder.WriteString(", ")
+	builder.WriteString("processed=")
+	builder.WriteString(fmt.Sprintf("%v", ij.Processed))
+	builder.WriteString(", ")
+	builder.WriteString("created=")
+	builder.WriteString(fmt.Sprintf("%v", ij.Created))
+	builder.WriteString(", ")
+	builder.WriteString("invited=")
+	builder.WriteString(fmt.Sprintf("%v", ij.Invited))
+	builder.WriteString(", ")
+	builder.WriteString("failed=")
+	builder.WriteString(fmt.Sprintf("%v", ij.Failed))
+	builder.WriteString(", ")
+	builder.WriteString("row_errors=")
+	builder.WriteString(fmt.Sprintf("%v", ij.RowErrors))
+	builder.WriteString(", ")
+	builder.WriteString("error=")
+	builder.WriteString(ij.Error)
+	builder.WriteString(", ")
+	if v := ij.FinishedAt; v != nil {
+		builder.WriteString("finished_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("organization_id=")
+	builder.WriteString(ij.OrganizationID)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ImportJobs is a parsable slice of ImportJob.
+type ImportJobs []*ImportJob
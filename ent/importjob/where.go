@@ -0,0 +1,771 @@
+// Code generated by ent, DO NOT EDIT.
+
+package importjob
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Beriw98/user-management/ent/predicate"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLTE(FieldID, id))
+}
+
+// IDEqualFold applies the EqualFold predicate on the ID field.
+func IDEqualFold(id string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEqualFold(FieldID, id))
+}
+
+// IDContainsFold applies the ContainsFold predicate on the ID field.
+func IDContainsFold(id string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldContainsFold(FieldID, id))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// Format applies equality check predicate on the "format" field. It's identical to FormatEQ.
+func Format(v domain.ImportFormat) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldEQ(FieldFormat, vc))
+}
+
+// DryRun applies equality check predicate on the "dry_run" field. It's identical to DryRunEQ.
+func DryRun(v bool) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldDryRun, v))
+}
+
+// Status applies equality check predicate on the "status" field. It's identical to StatusEQ.
+func Status(v domain.ImportStatus) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldEQ(FieldStatus, vc))
+}
+
+// Processed applies equality check predicate on the "processed" field. It's identical to ProcessedEQ.
+func Processed(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldProcessed, v))
+}
+
+// Created applies equality check predicate on the "created" field. It's identical to CreatedEQ.
+func Created(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldCreated, v))
+}
+
+// Invited applies equality check predicate on the "invited" field. It's identical to InvitedEQ.
+func Invited(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldInvited, v))
+}
+
+// Failed applies equality check predicate on the "failed" field. It's identical to FailedEQ.
+func Failed(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldFailed, v))
+}
+
+// Error applies equality check predicate on the "error" field. It's identical to ErrorEQ.
+func Error(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldError, v))
+}
+
+// FinishedAt applies equality check predicate on the "finished_at" field. It's identical to FinishedAtEQ.
+func FinishedAt(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldFinishedAt, v))
+}
+
+// OrganizationID applies equality check predicate on the "organization_id" field. It's identical to OrganizationIDEQ.
+func OrganizationID(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldOrganizationID, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// FormatEQ applies the EQ predicate on the "format" field.
+func FormatEQ(v domain.ImportFormat) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldEQ(FieldFormat, vc))
+}
+
+// FormatNEQ applies the NEQ predicate on the "format" field.
+func FormatNEQ(v domain.ImportFormat) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldNEQ(FieldFormat, vc))
+}
+
+// FormatIn applies the In predicate on the "format" field.
+func FormatIn(vs ...domain.ImportFormat) predicate.ImportJob {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.ImportJob(sql.FieldIn(FieldFormat, v...))
+}
+
+// FormatNotIn applies the NotIn predicate on the "format" field.
+func FormatNotIn(vs ...domain.ImportFormat) predicate.ImportJob {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.ImportJob(sql.FieldNotIn(FieldFormat, v...))
+}
+
+// FormatGT applies the GT predicate on the "format" field.
+func FormatGT(v domain.ImportFormat) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldGT(FieldFormat, vc))
+}
+
+// FormatGTE applies the GTE predicate on the "format" field.
+func FormatGTE(v domain.ImportFormat) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldGTE(FieldFormat, vc))
+}
+
+// FormatLT applies the LT predicate on the "format" field.
+func FormatLT(v domain.ImportFormat) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldLT(FieldFormat, vc))
+}
+
+// FormatLTE applies the LTE predicate on the "format" field.
+func FormatLTE(v domain.ImportFormat) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldLTE(FieldFormat, vc))
+}
+
+// FormatContains applies the Contains predicate on the "format" field.
+func FormatContains(v domain.ImportFormat) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldContains(FieldFormat, vc))
+}
+
+// FormatHasPrefix applies the HasPrefix predicate on the "format" field.
+func FormatHasPrefix(v domain.ImportFormat) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldHasPrefix(FieldFormat, vc))
+}
+
+// FormatHasSuffix applies the HasSuffix predicate on the "format" field.
+func FormatHasSuffix(v domain.ImportFormat) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldHasSuffix(FieldFormat, vc))
+}
+
+// FormatEqualFold applies the EqualFold predicate on the "format" field.
+func FormatEqualFold(v domain.ImportFormat) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldEqualFold(FieldFormat, vc))
+}
+
+// FormatContainsFold applies the ContainsFold predicate on the "format" field.
+func FormatContainsFold(v domain.ImportFormat) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldContainsFold(FieldFormat, vc))
+}
+
+// DryRunEQ applies the EQ predicate on the "dry_run" field.
+func DryRunEQ(v bool) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldDryRun, v))
+}
+
+// DryRunNEQ applies the NEQ predicate on the "dry_run" field.
+func DryRunNEQ(v bool) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNEQ(FieldDryRun, v))
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v domain.ImportStatus) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldEQ(FieldStatus, vc))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v domain.ImportStatus) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldNEQ(FieldStatus, vc))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...domain.ImportStatus) predicate.ImportJob {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.ImportJob(sql.FieldIn(FieldStatus, v...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...domain.ImportStatus) predicate.ImportJob {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.ImportJob(sql.FieldNotIn(FieldStatus, v...))
+}
+
+// StatusGT applies the GT predicate on the "status" field.
+func StatusGT(v domain.ImportStatus) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldGT(FieldStatus, vc))
+}
+
+// StatusGTE applies the GTE predicate on the "status" field.
+func StatusGTE(v domain.ImportStatus) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldGTE(FieldStatus, vc))
+}
+
+// StatusLT applies the LT predicate on the "status" field.
+func StatusLT(v domain.ImportStatus) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldLT(FieldStatus, vc))
+}
+
+// StatusLTE applies the LTE predicate on the "status" field.
+func StatusLTE(v domain.ImportStatus) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldLTE(FieldStatus, vc))
+}
+
+// StatusContains applies the Contains predicate on the "status" field.
+func StatusContains(v domain.ImportStatus) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldContains(FieldStatus, vc))
+}
+
+// StatusHasPrefix applies the HasPrefix predicate on the "status" field.
+func StatusHasPrefix(v domain.ImportStatus) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldHasPrefix(FieldStatus, vc))
+}
+
+// StatusHasSuffix applies the HasSuffix predicate on the "status" field.
+func StatusHasSuffix(v domain.ImportStatus) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldHasSuffix(FieldStatus, vc))
+}
+
+// StatusEqualFold applies the EqualFold predicate on the "status" field.
+func StatusEqualFold(v domain.ImportStatus) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldEqualFold(FieldStatus, vc))
+}
+
+// StatusContainsFold applies the ContainsFold predicate on the "status" field.
+func StatusContainsFold(v domain.ImportStatus) predicate.ImportJob {
+	vc := string(v)
+	return predicate.ImportJob(sql.FieldContainsFold(FieldStatus, vc))
+}
+
+// ProcessedEQ applies the EQ predicate on the "processed" field.
+func ProcessedEQ(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldProcessed, v))
+}
+
+// ProcessedNEQ applies the NEQ predicate on the "processed" field.
+func ProcessedNEQ(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNEQ(FieldProcessed, v))
+}
+
+// ProcessedIn applies the In predicate on the "processed" field.
+func ProcessedIn(vs ...int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIn(FieldProcessed, vs...))
+}
+
+// ProcessedNotIn applies the NotIn predicate on the "processed" field.
+func ProcessedNotIn(vs ...int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotIn(FieldProcessed, vs...))
+}
+
+// ProcessedGT applies the GT predicate on the "processed" field.
+func ProcessedGT(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGT(FieldProcessed, v))
+}
+
+// ProcessedGTE applies the GTE predicate on the "processed" field.
+func ProcessedGTE(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGTE(FieldProcessed, v))
+}
+
+// ProcessedLT applies the LT predicate on the "processed" field.
+func ProcessedLT(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLT(FieldProcessed, v))
+}
+
+// ProcessedLTE applies the LTE predicate on the "processed" field.
+func ProcessedLTE(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLTE(FieldProcessed, v))
+}
+
+// CreatedEQ applies the EQ predicate on the "created" field.
+func CreatedEQ(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldCreated, v))
+}
+
+// CreatedNEQ applies the NEQ predicate on the "created" field.
+func CreatedNEQ(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNEQ(FieldCreated, v))
+}
+
+// CreatedIn applies the In predicate on the "created" field.
+func CreatedIn(vs ...int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIn(FieldCreated, vs...))
+}
+
+// CreatedNotIn applies the NotIn predicate on the "created" field.
+func CreatedNotIn(vs ...int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotIn(FieldCreated, vs...))
+}
+
+// CreatedGT applies the GT predicate on the "created" field.
+func CreatedGT(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGT(FieldCreated, v))
+}
+
+// CreatedGTE applies the GTE predicate on the "created" field.
+func CreatedGTE(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGTE(FieldCreated, v))
+}
+
+// CreatedLT applies the LT predicate on the "created" field.
+func CreatedLT(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLT(FieldCreated, v))
+}
+
+// CreatedLTE applies the LTE predicate on the "created" field.
+func CreatedLTE(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLTE(FieldCreated, v))
+}
+
+// InvitedEQ applies the EQ predicate on the "invited" field.
+func InvitedEQ(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldInvited, v))
+}
+
+// InvitedNEQ applies the NEQ predicate on the "invited" field.
+func InvitedNEQ(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNEQ(FieldInvited, v))
+}
+
+// InvitedIn applies the In predicate on the "invited" field.
+func InvitedIn(vs ...int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIn(FieldInvited, vs...))
+}
+
+// InvitedNotIn applies the NotIn predicate on the "invited" field.
+func InvitedNotIn(vs ...int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotIn(FieldInvited, vs...))
+}
+
+// InvitedGT applies the GT predicate on the "invited" field.
+func InvitedGT(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGT(FieldInvited, v))
+}
+
+// InvitedGTE applies the GTE predicate on the "invited" field.
+func InvitedGTE(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGTE(FieldInvited, v))
+}
+
+// InvitedLT applies the LT predicate on the "invited" field.
+func InvitedLT(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLT(FieldInvited, v))
+}
+
+// InvitedLTE applies the LTE predicate on the "invited" field.
+func InvitedLTE(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLTE(FieldInvited, v))
+}
+
+// FailedEQ applies the EQ predicate on the "failed" field.
+func FailedEQ(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldFailed, v))
+}
+
+// FailedNEQ applies the NEQ predicate on the "failed" field.
+func FailedNEQ(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNEQ(FieldFailed, v))
+}
+
+// FailedIn applies the In predicate on the "failed" field.
+func FailedIn(vs ...int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIn(FieldFailed, vs...))
+}
+
+// FailedNotIn applies the NotIn predicate on the "failed" field.
+func FailedNotIn(vs ...int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotIn(FieldFailed, vs...))
+}
+
+// FailedGT applies the GT predicate on the "failed" field.
+func FailedGT(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGT(FieldFailed, v))
+}
+
+// FailedGTE applies the GTE predicate on the "failed" field.
+func FailedGTE(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGTE(FieldFailed, v))
+}
+
+// FailedLT applies the LT predicate on the "failed" field.
+func FailedLT(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLT(FieldFailed, v))
+}
+
+// FailedLTE applies the LTE predicate on the "failed" field.
+func FailedLTE(v int) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLTE(FieldFailed, v))
+}
+
+// RowErrorsIsNil applies the IsNil predicate on the "row_errors" field.
+func RowErrorsIsNil() predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIsNull(FieldRowErrors))
+}
+
+// RowErrorsNotNil applies the NotNil predicate on the "row_errors" field.
+func RowErrorsNotNil() predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotNull(FieldRowErrors))
+}
+
+// ErrorEQ applies the EQ predicate on the "error" field.
+func ErrorEQ(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldError, v))
+}
+
+// ErrorNEQ applies the NEQ predicate on the "error" field.
+func ErrorNEQ(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNEQ(FieldError, v))
+}
+
+// ErrorIn applies the In predicate on the "error" field.
+func ErrorIn(vs ...string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIn(FieldError, vs...))
+}
+
+// ErrorNotIn applies the NotIn predicate on the "error" field.
+func ErrorNotIn(vs ...string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotIn(FieldError, vs...))
+}
+
+// ErrorGT applies the GT predicate on the "error" field.
+func ErrorGT(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGT(FieldError, v))
+}
+
+// ErrorGTE applies the GTE predicate on the "error" field.
+func ErrorGTE(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGTE(FieldError, v))
+}
+
+// ErrorLT applies the LT predicate on the "error" field.
+func ErrorLT(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLT(FieldError, v))
+}
+
+// ErrorLTE applies the LTE predicate on the "error" field.
+func ErrorLTE(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLTE(FieldError, v))
+}
+
+// ErrorContains applies the Contains predicate on the "error" field.
+func ErrorContains(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldContains(FieldError, v))
+}
+
+// ErrorHasPrefix applies the HasPrefix predicate on the "error" field.
+func ErrorHasPrefix(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldHasPrefix(FieldError, v))
+}
+
+// ErrorHasSuffix applies the HasSuffix predicate on the "error" field.
+func ErrorHasSuffix(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldHasSuffix(FieldError, v))
+}
+
+// ErrorIsNil applies the IsNil predicate on the "error" field.
+func ErrorIsNil() predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIsNull(FieldError))
+}
+
+// ErrorNotNil applies the NotNil predicate on the "error" field.
+func ErrorNotNil() predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotNull(FieldError))
+}
+
+// ErrorEqualFold applies the EqualFold predicate on the "error" field.
+func ErrorEqualFold(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEqualFold(FieldError, v))
+}
+
+// ErrorContainsFold applies the ContainsFold predicate on the "error" field.
+func ErrorContainsFold(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldContainsFold(FieldError, v))
+}
+
+// FinishedAtEQ applies the EQ predicate on the "finished_at" field.
+func FinishedAtEQ(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldFinishedAt, v))
+}
+
+// FinishedAtNEQ applies the NEQ predicate on the "finished_at" field.
+func FinishedAtNEQ(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNEQ(FieldFinishedAt, v))
+}
+
+// FinishedAtIn applies the In predicate on the "finished_at" field.
+func FinishedAtIn(vs ...time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIn(FieldFinishedAt, vs...))
+}
+
+// FinishedAtNotIn applies the NotIn predicate on the "finished_at" field.
+func FinishedAtNotIn(vs ...time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotIn(FieldFinishedAt, vs...))
+}
+
+// FinishedAtGT applies the GT predicate on the "finished_at" field.
+func FinishedAtGT(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGT(FieldFinishedAt, v))
+}
+
+// FinishedAtGTE applies the GTE predicate on the "finished_at" field.
+func FinishedAtGTE(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGTE(FieldFinishedAt, v))
+}
+
+// FinishedAtLT applies the LT predicate on the "finished_at" field.
+func FinishedAtLT(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLT(FieldFinishedAt, v))
+}
+
+// FinishedAtLTE applies the LTE predicate on the "finished_at" field.
+func FinishedAtLTE(v time.Time) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLTE(FieldFinishedAt, v))
+}
+
+// FinishedAtIsNil applies the IsNil predicate on the "finished_at" field.
+func FinishedAtIsNil() predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIsNull(FieldFinishedAt))
+}
+
+// FinishedAtNotNil applies the NotNil predicate on the "finished_at" field.
+func FinishedAtNotNil() predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotNull(FieldFinishedAt))
+}
+
+// OrganizationIDEQ applies the EQ predicate on the "organization_id" field.
+func OrganizationIDEQ(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEQ(FieldOrganizationID, v))
+}
+
+// OrganizationIDNEQ applies the NEQ predicate on the "organization_id" field.
+func OrganizationIDNEQ(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNEQ(FieldOrganizationID, v))
+}
+
+// OrganizationIDIn applies the In predicate on the "organization_id" field.
+func OrganizationIDIn(vs ...string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIn(FieldOrganizationID, vs...))
+}
+
+// OrganizationIDNotIn applies the NotIn predicate on the "organization_id" field.
+func OrganizationIDNotIn(vs ...string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotIn(FieldOrganizationID, vs...))
+}
+
+// OrganizationIDGT applies the GT predicate on the "organization_id" field.
+func OrganizationIDGT(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGT(FieldOrganizationID, v))
+}
+
+// OrganizationIDGTE applies the GTE predicate on the "organization_id" field.
+func OrganizationIDGTE(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldGTE(FieldOrganizationID, v))
+}
+
+// OrganizationIDLT applies the LT predicate on the "organization_id" field.
+func OrganizationIDLT(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLT(FieldOrganizationID, v))
+}
+
+// OrganizationIDLTE applies the LTE predicate on the "organization_id" field.
+func OrganizationIDLTE(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldLTE(FieldOrganizationID, v))
+}
+
+// OrganizationIDContains applies the Contains predicate on the "organization_id" field.
+func OrganizationIDContains(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldContains(FieldOrganizationID, v))
+}
+
+// OrganizationIDHasPrefix applies the HasPrefix predicate on the "organization_id" field.
+func OrganizationIDHasPrefix(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldHasPrefix(FieldOrganizationID, v))
+}
+
+// OrganizationIDHasSuffix applies the HasSuffix predicate on the "organization_id" field.
+func OrganizationIDHasSuffix(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldHasSuffix(FieldOrganizationID, v))
+}
+
+// OrganizationIDIsNil applies the IsNil predicate on the "organization_id" field.
+func OrganizationIDIsNil() predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldIsNull(FieldOrganizationID))
+}
+
+// OrganizationIDNotNil applies the NotNil predicate on the "organization_id" field.
+func OrganizationIDNotNil() predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldNotNull(FieldOrganizationID))
+}
+
+// OrganizationIDEqualFold applies the EqualFold predicate on the "organization_id" field.
+func OrganizationIDEqualFold(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldEqualFold(FieldOrganizationID, v))
+}
+
+// OrganizationIDContainsFold applies the ContainsFold predicate on the "organization_id" field.
+func OrganizationIDContainsFold(v string) predicate.ImportJob {
+	return predicate.ImportJob(sql.FieldContainsFold(FieldOrganizationID, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ImportJob) predicate.ImportJob {
+	return predicate.ImportJob(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ImportJob) predicate.ImportJob {
+	return predicate.ImportJob(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ImportJob) predicate.ImportJob {
+	return predicate.ImportJob(sql.NotPredicates(p))
+}
@@ -0,0 +1,1245 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/importjob"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// ImportJobCreate is the builder for creating a ImportJob entity.
+type ImportJobCreate struct {
+	config
+	mutation *ImportJobMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ijc *ImportJobCreate) SetCreatedAt(t time.Time) *ImportJobCreate {
+	ijc.mutation.SetCreatedAt(t)
+	return ijc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ijc *ImportJobCreate) SetNillableCreatedAt(t *time.Time) *ImportJobCreate {
+	if t != nil {
+		ijc.SetCreatedAt(*t)
+	}
+	return ijc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ijc *ImportJobCreate) SetUpdatedAt(t time.Time) *ImportJobCreate {
+	ijc.mutation.SetUpdatedAt(t)
+	return ijc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (ijc *ImportJobCreate) SetNillableUpdatedAt(t *time.Time) *ImportJobCreate {
+	if t != nil {
+		ijc.SetUpdatedAt(*t)
+	}
+	return ijc
+}
+
+// SetFormat sets the "format" field.
+func (ijc *ImportJobCreate) SetFormat(df domain.ImportFormat) *ImportJobCreate {
+	ijc.mutation.SetFormat(df)
+	return ijc
+}
+
+// SetDryRun sets the "dry_run" field.
+func (ijc *ImportJobCreate) SetDryRun(b bool) *ImportJobCreate {
+	ijc.mutation.SetDryRun(b)
+	return ijc
+}
+
+// SetNillableDryRun sets the "dry_run" field if the given value is not nil.
+func (ijc *ImportJobCreate) SetNillableDryRun(b *bool) *ImportJobCreate {
+	if b != nil {
+		ijc.SetDryRun(*b)
+	}
+	return ijc
+}
+
+// SetStatus sets the "status" field.
+func (ijc *ImportJobCreate) SetStatus(ds domain.ImportStatus) *ImportJobCreate {
+	ijc.mutation.SetStatus(ds)
+	return ijc
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (ijc *ImportJobCreate) SetNillableStatus(ds *domain.ImportStatus) *ImportJobCreate {
+	if ds != nil {
+		ijc.SetStatus(*ds)
+	}
+	return ijc
+}
+
+// SetProcessed sets the "processed" field.
+func (ijc *ImportJobCreate) SetProcessed(i int) *ImportJobCreate {
+	ijc.mutation.SetProcessed(i)
+	return ijc
+}
+
+// SetNillableProcessed sets the "processed" field if the given value is not nil.
+func (ijc *ImportJobCreate) SetNillableProcessed(i *int) *ImportJobCreate {
+	if i != nil {
+		ijc.SetProcessed(*i)
+	}
+	return ijc
+}
+
+// SetCreated sets the "created" field.
+func (ijc *ImportJobCreate) SetCreated(i int) *ImportJobCreate {
+	ijc.mutation.SetCreated(i)
+	return ijc
+}
+
+// SetNillableCreated sets the "created" field if the given value is not nil.
+func (ijc *ImportJobCreate) SetNillableCreated(i *int) *ImportJobCreate {
+	if i != nil {
+		ijc.SetCreated(*i)
+	}
+	return ijc
+}
+
+// SetInvited sets the "invited" field.
+func (ijc *ImportJobCreate) SetInvited(i int) *ImportJobCreate {
+	ijc.mutation.SetInvited(i)
+	return ijc
+}
+
+// SetNillableInvited sets the "invited" field if the given value is not nil.
+func (ijc *ImportJobCreate) SetNillableInvited(i *int) *ImportJobCreate {
+	if i != nil {
+		ijc.SetInvited(*i)
+	}
+	return ijc
+}
+
+// SetFailed sets the "failed" field.
+func (ijc *ImportJobCreate) SetFailed(i int) *ImportJobCreate {
+	ijc.mutation.SetFailed(i)
+	return ijc
+}
+
+// SetNillableFailed sets the "failed" field if the given value is not nil.
+func (ijc *ImportJobCreate) SetNillableFailed(i *int) *ImportJobCreate {
+	if i != nil {
+		ijc.SetFailed(*i)
+	}
+	return ijc
+}
+
+// SetRowErrors sets the "row_errors" field.
+func (ijc *ImportJobCreate) SetRowErrors(dre []domain.ImportRowError) *ImportJobCreate {
+	ijc.mutation.SetRowErrors(dre)
+	return ijc
+}
+
+// SetError sets the "error" field.
+func (ijc *ImportJobCreate) SetError(s string) *ImportJobCreate {
+	ijc.mutation.SetError(s)
+	return ijc
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (ijc *ImportJobCreate) SetNillableError(s *string) *ImportJobCreate {
+	if s != nil {
+		ijc.SetError(*s)
+	}
+	return ijc
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (ijc *ImportJobCreate) SetFinishedAt(t time.Time) *ImportJobCreate {
+	ijc.mutation.SetFinishedAt(t)
+	return ijc
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (ijc *ImportJobCreate) SetNillableFinishedAt(t *time.Time) *ImportJobCreate {
+	if t != nil {
+		ijc.SetFinishedAt(*t)
+	}
+	return ijc
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (ijc *ImportJobCreate) SetOrganizationID(s string) *ImportJobCreate {
+	ijc.mutation.SetOrganizationID(s)
+	return ijc
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (ijc *ImportJobCreate) SetNillableOrganizationID(s *string) *ImportJobCreate {
+	if s != nil {
+		ijc.SetOrganizationID(*s)
+	}
+	return ijc
+}
+
+// SetID sets the "id" field.
+func (ijc *ImportJobCreate) SetID(s string) *ImportJobCreate {
+	ijc.mutation.SetID(s)
+	return ijc
+}
+
+// Mutation returns the ImportJobMutation object of the builder.
+func (ijc *ImportJobCreate) Mutation() *ImportJobMutation {
+	return ijc.mutation
+}
+
+// Save creates the ImportJob in the database.
+func (ijc *ImportJobCreate) Save(ctx context.Context) (*ImportJob, error) {
+	ijc.defaults()
+	return withHooks(ctx, ijc.sqlSave, ijc.mutation, ijc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ijc *ImportJobCreate) SaveX(ctx context.Context) *ImportJob {
+	v, err := ijc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ijc *ImportJobCreate) Exec(ctx context.Context) error {
+	_, err := ijc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ijc *ImportJobCreate) ExecX(ctx context.Context) {
+	if err := ijc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ijc *ImportJobCreate) defaults() {
+	if _, ok := ijc.mutation.CreatedAt(); !ok {
+		v := importjob.DefaultCreatedAt()
+		ijc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := ijc.mutation.UpdatedAt(); !ok {
+		v := importjob.DefaultUpdatedAt()
+		ijc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := ijc.mutation.DryRun(); !ok {
+		v := importjob.DefaultDryRun
+		ijc.mutation.SetDryRun(v)
+	}
+	if _, ok := ijc.mutation.Status(); !ok {
+		v := importjob.DefaultStatus
+		ijc.mutation.SetStatus(v)
+	}
+	if _, ok := ijc.mutation.Processed(); !ok {
+		v := importjob.DefaultProcessed
+		ijc.mutation.SetProcessed(v)
+	}
+	if _, ok := ijc.mutation.Created(); !ok {
+		v := importjob.DefaultCreated
+		ijc.mutation.SetCreated(v)
+	}
+	if _, ok := ijc.mutation.Invited(); !ok {
+		v := importjob.DefaultInvited
+		ijc.mutation.SetInvited(v)
+	}
+	if _, ok := ijc.mutation.Failed(); !ok {
+		v := importjob.DefaultFailed
+		ijc.mutation.SetFailed(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ijc *ImportJobCreate) check() error {
+	if _, ok := ijc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ImportJob.created_at"`)}
+	}
+	if _, ok := ijc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "ImportJob.updated_at"`)}
+	}
+	if _, ok := ijc.mutation.Format(); !ok {
+		return &ValidationError{Name: "format", err: errors.New(`ent: missing required field "ImportJob.format"`)}
+	}
+	if _, ok := ijc.mutation.DryRun(); !ok {
+		return &ValidationError{Name: "dry_run", err: errors.New(`ent: missing required field "ImportJob.dry_run"`)}
+	}
+	if _, ok := ijc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "ImportJob.status"`)}
+	}
+	if _, ok := ijc.mutation.Processed(); !ok {
+		return &ValidationError{Name: "processed", err: errors.New(`ent: missing required field "ImportJob.processed"`)}
+	}
+	if _, ok := ijc.mutation.Created(); !ok {
+		return &ValidationError{Name: "created", err: errors.New(`ent: missing required field "ImportJob.created"`)}
+	}
+	if _, ok := ijc.mutation.Invited(); !ok {
+		return &ValidationError{Name: "invited", err: errors.New(`ent: missing required field "ImportJob.invited"`)}
+	}
+	if _, ok := ijc.mutation.Failed(); !ok {
+		return &ValidationError{Name: "failed", err: errors.New(`ent: missing required field "ImportJob.failed"`)}
+	}
+	return nil
+}
+
+func (ijc *ImportJobCreate) sqlSave(ctx context.Context) (*ImportJob, error) {
+	if err := ijc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ijc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ijc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected ImportJob.ID type: %T", _spec.ID.Value)
+		}
+	}
+	ijc.mutation.id = &_node.ID
+	ijc.mutation.done = true
+	return _node, nil
+}
+
+func (ijc *ImportJobCreate) createSpec() (*ImportJob, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ImportJob{config: ijc.config}
+		_spec = sqlgraph.NewCreateSpec(importjob.Table, sqlgraph.NewFieldSpec(importjob.FieldID, field.TypeString))
+	)
+	_spec.OnConflict = ijc.conflict
+	if id, ok := ijc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := ijc.mutation.CreatedAt(); ok {
+		_spec.SetField(importjob.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := ijc.mutation.UpdatedAt(); ok {
+		_spec.SetField(importjob.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := ijc.mutation.Format(); ok {
+		_spec.SetField(importjob.FieldFormat, field.TypeString, value)
+		_node.Format = value
+	}
+	if value, ok := ijc.mutation.DryRun(); ok {
+		_spec.SetField(importjob.FieldDryRun, field.TypeBool, value)
+		_node.DryRun = value
+	}
+	if value, ok := ijc.mutation.Status(); ok {
+		_spec.SetField(importjob.FieldStatus, field.TypeString, value)
+		_node.Status = value
+	}
+	if value, ok := ijc.mutation.Processed(); ok {
+		_spec.SetField(importjob.FieldProcessed, field.TypeInt, value)
+		_node.Processed = value
+	}
+	if value, ok := ijc.mutation.Created(); ok {
+		_spec.SetField(importjob.FieldCreated, field.TypeInt, value)
+		_node.Created = value
+	}
+	if value, ok := ijc.mutation.Invited(); ok {
+		_spec.SetField(importjob.FieldInvited, field.TypeInt, value)
+		_node.Invited = value
+	}
+	if value, ok := ijc.mutation.Failed(); ok {
+		_spec.SetField(importjob.FieldFailed, field.TypeInt, value)
+		_node.Failed = value
+	}
+	if value, ok := ijc.mutation.RowErrors(); ok {
+		_spec.SetField(importjob.FieldRowErrors, field.TypeJSON, value)
+		_node.RowErrors = value
+	}
+	if value, ok := ijc.mutation.Error(); ok {
+		_spec.SetField(importjob.FieldError, field.TypeString, value)
+		_node.Error = value
+	}
+	if value, ok := ijc.mutation.FinishedAt(); ok {
+		_spec.SetField(importjob.FieldFinishedAt, field.TypeTime, value)
+		_node.FinishedAt = &value
+	}
+	if value, ok := ijc.mutation.OrganizationID(); ok {
+		_spec.SetField(importjob.FieldOrganizationID, field.TypeString, value)
+		_node.OrganizationID = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.ImportJob.Create().
+//		SetCreatedAt(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.ImportJobUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (ijc *ImportJobCreate) OnConflict(opts ...sql.ConflictOption) *ImportJobUpsertOne {
+	ijc.conflict = opts
+	return &ImportJobUpsertOne{
+		create: ijc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.ImportJob.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (ijc *ImportJobCreate) OnConflictColumns(columns ...string) *ImportJobUpsertOne {
+	ijc.conflict = append(ijc.conflict, sql.ConflictColumns(columns...))
+	return &ImportJobUpsertOne{
+		create: ijc,
+	}
+}
+
+type (
+	// ImportJobUpsertOne is the builder for "upsert"-ing
+	//  one ImportJob node.
+	ImportJobUpsertOne struct {
+		create *ImportJobCreate
+	}
+
+	// ImportJobUpsert is the "OnConflict" setter.
+	ImportJobUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *ImportJobUpsert) SetUpdatedAt(v time.Time) *ImportJobUpsert {
+	u.Set(importjob.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *ImportJobUpsert) UpdateUpdatedAt() *ImportJobUpsert {
+	u.SetExcluded(importjob.FieldUpdatedAt)
+	return u
+}
+
+// SetStatus sets the "status" field.
+func (u *ImportJobUpsert) SetStatus(v domain.ImportStatus) *ImportJobUpsert {
+	u.Set(importjob.FieldStatus, v)
+	return u
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *ImportJobUpsert) UpdateStatus() *ImportJobUpsert {
+	u.SetExcluded(importjob.FieldStatus)
+	return u
+}
+
+// SetProcessed sets the "processed" field.
+func (u *ImportJobUpsert) SetProcessed(v int) *ImportJobUpsert {
+	u.Set(importjob.FieldProcessed, v)
+	return u
+}
+
+// UpdateProcessed sets the "processed" field to the value that was provided on create.
+func (u *ImportJobUpsert) UpdateProcessed() *ImportJobUpsert {
+	u.SetExcluded(importjob.FieldProcessed)
+	return u
+}
+
+// AddProcessed adds v to the "processed" field.
+func (u *ImportJobUpsert) AddProcessed(v int) *ImportJobUpsert {
+	u.Add(importjob.FieldProcessed, v)
+	return u
+}
+
+// SetCreated sets the "created" field.
+func (u *ImportJobUpsert) SetCreated(v int) *ImportJobUpsert {
+	u.Set(importjob.FieldCreated, v)
+	return u
+}
+
+// UpdateCreated sets the "created" field to the value that was provided on create.
+func (u *ImportJobUpsert) UpdateCreated() *ImportJobUpsert {
+	u.SetExcluded(importjob.FieldCreated)
+	return u
+}
+
+// AddCreated adds v to the "created" field.
+func (u *ImportJobUpsert) AddCreated(v int) *ImportJobUpsert {
+	u.Add(importjob.FieldCreated, v)
+	return u
+}
+
+// SetInvited sets the "invited" field.
+func (u *ImportJobUpsert) SetInvited(v int) *ImportJobUpsert {
+	u.Set(importjob.FieldInvited, v)
+	return u
+}
+
+// UpdateInvited sets the "invited" field to the value that was provided on create.
+func (u *ImportJobUpsert) UpdateInvited() *ImportJobUpsert {
+	u.SetExcluded(importjob.FieldInvited)
+	return u
+}
+
+// AddInvited adds v to the "invited" field.
+func (u *ImportJobUpsert) AddInvited(v int) *ImportJobUpsert {
+	u.Add(importjob.FieldInvited, v)
+	return u
+}
+
+// SetFailed sets the "failed" field.
+func (u *ImportJobUpsert) SetFailed(v int) *ImportJobUpsert {
+	u.Set(importjob.FieldFailed, v)
+	return u
+}
+
+// UpdateFailed sets the "failed" field to the value that was provided on create.
+func (u *ImportJobUpsert) UpdateFailed() *ImportJobUpsert {
+	u.SetExcluded(importjob.FieldFailed)
+	return u
+}
+
+// AddFailed adds v to the "failed" field.
+func (u *ImportJobUpsert) AddFailed(v int) *ImportJobUpsert {
+	u.Add(importjob.FieldFailed, v)
+	return u
+}
+
+// SetRowErrors sets the "row_errors" field.
+func (u *ImportJobUpsert) SetRowErrors(v []domain.ImportRowError) *ImportJobUpsert {
+	u.Set(importjob.FieldRowErrors, v)
+	return u
+}
+
+// UpdateRowErrors sets the "row_errors" field to the value that was provided on create.
+func (u *ImportJobUpsert) UpdateRowErrors() *ImportJobUpsert {
+	u.SetExcluded(importjob.FieldRowErrors)
+	return u
+}
+
+// ClearRowErrors clears the value of the "row_errors" field.
+func (u *ImportJobUpsert) ClearRowErrors() *ImportJobUpsert {
+	u.SetNull(importjob.FieldRowErrors)
+	return u
+}
+
+// SetError sets the "error" field.
+func (u *ImportJobUpsert) SetError(v string) *ImportJobUpsert {
+	u.Set(importjob.FieldError, v)
+	return u
+}
+
+// UpdateError sets the "error" field to the value that was provided on create.
+func (u *ImportJobUpsert) UpdateError() *ImportJobUpsert {
+	u.SetExcluded(importjob.FieldError)
+	return u
+}
+
+// ClearError clears the value of the "error" field.
+func (u *ImportJobUpsert) ClearError() *ImportJobUpsert {
+	u.SetNull(importjob.FieldError)
+	return u
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (u *ImportJobUpsert) SetFinishedAt(v time.Time) *ImportJobUpsert {
+	u.Set(importjob.FieldFinishedAt, v)
+	return u
+}
+
+// UpdateFinishedAt sets the "finished_at" field to the value that was provided on create.
+func (u *ImportJobUpsert) UpdateFinishedAt() *ImportJobUpsert {
+	u.SetExcluded(importjob.FieldFinishedAt)
+	return u
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (u *ImportJobUpsert) ClearFinishedAt() *ImportJobUpsert {
+	u.SetNull(importjob.FieldFinishedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.ImportJob.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(importjob.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *ImportJobUpsertOne) UpdateNewValues() *ImportJobUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(importjob.FieldID)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(importjob.FieldCreatedAt)
+		}
+		if _, exists := u.create.mutation.Format(); exists {
+			s.SetIgnore(importjob.FieldFormat)
+		}
+		if _, exists := u.create.mutation.DryRun(); exists {
+			s.SetIgnore(importjob.FieldDryRun)
+		}
+		if _, exists := u.create.mutation.OrganizationID(); exists {
+			s.SetIgnore(importjob.FieldOrganizationID)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.ImportJob.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *ImportJobUpsertOne) Ignore() *ImportJobUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *ImportJobUpsertOne) DoNothing() *ImportJobUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the ImportJobCreate.OnConflict
+// documentation for more info.
+func (u *ImportJobUpsertOne) Update(set func(*ImportJobUpsert)) *ImportJobUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&ImportJobUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *ImportJobUpsertOne) SetUpdatedAt(v time.Time) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *ImportJobUpsertOne) UpdateUpdatedAt() *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetStatus sets the "status" field.
+func (u *ImportJobUpsertOne) SetStatus(v domain.ImportStatus) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetStatus(v)
+	})
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *ImportJobUpsertOne) UpdateStatus() *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateStatus()
+	})
+}
+
+// SetProcessed sets the "processed" field.
+func (u *ImportJobUpsertOne) SetProcessed(v int) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetProcessed(v)
+	})
+}
+
+// AddProcessed adds v to the "processed" field.
+func (u *ImportJobUpsertOne) AddProcessed(v int) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.AddProcessed(v)
+	})
+}
+
+// UpdateProcessed sets the "processed" field to the value that was provided on create.
+func (u *ImportJobUpsertOne) UpdateProcessed() *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateProcessed()
+	})
+}
+
+// SetCreated sets the "created" field.
+func (u *ImportJobUpsertOne) SetCreated(v int) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetCreated(v)
+	})
+}
+
+// AddCreated adds v to the "created" field.
+func (u *ImportJobUpsertOne) AddCreated(v int) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.AddCreated(v)
+	})
+}
+
+// UpdateCreated sets the "created" field to the value that was provided on create.
+func (u *ImportJobUpsertOne) UpdateCreated() *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateCreated()
+	})
+}
+
+// SetInvited sets the "invited" field.
+func (u *ImportJobUpsertOne) SetInvited(v int) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetInvited(v)
+	})
+}
+
+// AddInvited adds v to the "invited" field.
+func (u *ImportJobUpsertOne) AddInvited(v int) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.AddInvited(v)
+	})
+}
+
+// UpdateInvited sets the "invited" field to the value that was provided on create.
+func (u *ImportJobUpsertOne) UpdateInvited() *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateInvited()
+	})
+}
+
+// SetFailed sets the "failed" field.
+func (u *ImportJobUpsertOne) SetFailed(v int) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetFailed(v)
+	})
+}
+
+// AddFailed adds v to the "failed" field.
+func (u *ImportJobUpsertOne) AddFailed(v int) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.AddFailed(v)
+	})
+}
+
+// UpdateFailed sets the "failed" field to the value that was provided on create.
+func (u *ImportJobUpsertOne) UpdateFailed() *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateFailed()
+	})
+}
+
+// SetRowErrors sets the "row_errors" field.
+func (u *ImportJobUpsertOne) SetRowErrors(v []domain.ImportRowError) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetRowErrors(v)
+	})
+}
+
+// UpdateRowErrors sets the "row_errors" field to the value that was provided on create.
+func (u *ImportJobUpsertOne) UpdateRowErrors() *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateRowErrors()
+	})
+}
+
+// ClearRowErrors clears the value of the "row_errors" field.
+func (u *ImportJobUpsertOne) ClearRowErrors() *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.ClearRowErrors()
+	})
+}
+
+// SetError sets the "error" field.
+func (u *ImportJobUpsertOne) SetError(v string) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetError(v)
+	})
+}
+
+// UpdateError sets the "error" field to the value that was provided on create.
+func (u *ImportJobUpsertOne) UpdateError() *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateError()
+	})
+}
+
+// ClearError clears the value of the "error" field.
+func (u *ImportJobUpsertOne) ClearError() *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.ClearError()
+	})
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (u *ImportJobUpsertOne) SetFinishedAt(v time.Time) *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetFinishedAt(v)
+	})
+}
+
+// UpdateFinishedAt sets the "finished_at" field to the value that was provided on create.
+func (u *ImportJobUpsertOne) UpdateFinishedAt() *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateFinishedAt()
+	})
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (u *ImportJobUpsertOne) ClearFinishedAt() *ImportJobUpsertOne {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.ClearFinishedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *ImportJobUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for ImportJobCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *ImportJobUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *ImportJobUpsertOne) ID(ctx context.Context) (id string, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: ImportJobUpsertOne.ID is not supported by MySQL driver. Use ImportJobUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *ImportJobUpsertOne) IDX(ctx context.Context) string {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// ImportJobCreateBulk is the builder for creating many ImportJob entities in bulk.
+type ImportJobCreateBulk struct {
+	config
+	err      error
+	builders []*ImportJobCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the ImportJob entities in the database.
+func (ijcb *ImportJobCreateBulk) Save(ctx context.Context) ([]*ImportJob, error) {
+	if ijcb.err != nil {
+		return nil, ijcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ijcb.builders))
+	nodes := make([]*ImportJob, len(ijcb.builders))
+	mutators := make([]Mutator, len(ijcb.builders))
+	for i := range ijcb.builders {
+		func(i int, root context.Context) {
+			builder := ijcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ImportJobMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ijcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = ijcb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ijcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ijcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ijcb *ImportJobCreateBulk) SaveX(ctx context.Context) []*ImportJob {
+	v, err := ijcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ijcb *ImportJobCreateBulk) Exec(ctx context.Context) error {
+	_, err := ijcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ijcb *ImportJobCreateBulk) ExecX(ctx context.Context) {
+	if err := ijcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.ImportJob.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.ImportJobUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (ijcb *ImportJobCreateBulk) OnConflict(opts ...sql.ConflictOption) *ImportJobUpsertBulk {
+	ijcb.conflict = opts
+	return &ImportJobUpsertBulk{
+		create: ijcb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.ImportJob.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (ijcb *ImportJobCreateBulk) OnConflictColumns(columns ...string) *ImportJobUpsertBulk {
+	ijcb.conflict = append(ijcb.conflict, sql.ConflictColumns(columns...))
+	return &ImportJobUpsertBulk{
+		create: ijcb,
+	}
+}
+
+// ImportJobUpsertBulk is the builder for "upsert"-ing
+// a bulk of ImportJob nodes.
+type ImportJobUpsertBulk struct {
+	create *ImportJobCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.ImportJob.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(importjob.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *ImportJobUpsertBulk) UpdateNewValues() *ImportJobUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(importjob.FieldID)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(importjob.FieldCreatedAt)
+			}
+			if _, exists := b.mutation.Format(); exists {
+				s.SetIgnore(importjob.FieldFormat)
+			}
+			if _, exists := b.mutation.DryRun(); exists {
+				s.SetIgnore(importjob.FieldDryRun)
+			}
+			if _, exists := b.mutation.OrganizationID(); exists {
+				s.SetIgnore(importjob.FieldOrganizationID)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.ImportJob.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *ImportJobUpsertBulk) Ignore() *ImportJobUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *ImportJobUpsertBulk) DoNothing() *ImportJobUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the ImportJobCreateBulk.OnConflict
+// documentation for more info.
+func (u *ImportJobUpsertBulk) Update(set func(*ImportJobUpsert)) *ImportJobUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&ImportJobUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *ImportJobUpsertBulk) SetUpdatedAt(v time.Time) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *ImportJobUpsertBulk) UpdateUpdatedAt() *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetStatus sets the "status" field.
+func (u *ImportJobUpsertBulk) SetStatus(v domain.ImportStatus) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetStatus(v)
+	})
+}
+
+// UpdateStatus sets the "status" field to the value that was provided on create.
+func (u *ImportJobUpsertBulk) UpdateStatus() *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateStatus()
+	})
+}
+
+// SetProcessed sets the "processed" field.
+func (u *ImportJobUpsertBulk) SetProcessed(v int) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetProcessed(v)
+	})
+}
+
+// AddProcessed adds v to the "processed" field.
+func (u *ImportJobUpsertBulk) AddProcessed(v int) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.AddProcessed(v)
+	})
+}
+
+// UpdateProcessed sets the "processed" field to the value that was provided on create.
+func (u *ImportJobUpsertBulk) UpdateProcessed() *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateProcessed()
+	})
+}
+
+// SetCreated sets the "created" field.
+func (u *ImportJobUpsertBulk) SetCreated(v int) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetCreated(v)
+	})
+}
+
+// AddCreated adds v to the "created" field.
+func (u *ImportJobUpsertBulk) AddCreated(v int) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.AddCreated(v)
+	})
+}
+
+// UpdateCreated sets the "created" field to the value that was provided on create.
+func (u *ImportJobUpsertBulk) UpdateCreated() *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateCreated()
+	})
+}
+
+// SetInvited sets the "invited" field.
+func (u *ImportJobUpsertBulk) SetInvited(v int) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetInvited(v)
+	})
+}
+
+// AddInvited adds v to the "invited" field.
+func (u *ImportJobUpsertBulk) AddInvited(v int) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.AddInvited(v)
+	})
+}
+
+// UpdateInvited sets the "invited" field to the value that was provided on create.
+func (u *ImportJobUpsertBulk) UpdateInvited() *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateInvited()
+	})
+}
+
+// SetFailed sets the "failed" field.
+func (u *ImportJobUpsertBulk) SetFailed(v int) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetFailed(v)
+	})
+}
+
+// AddFailed adds v to the "failed" field.
+func (u *ImportJobUpsertBulk) AddFailed(v int) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.AddFailed(v)
+	})
+}
+
+// UpdateFailed sets the "failed" field to the value that was provided on create.
+func (u *ImportJobUpsertBulk) UpdateFailed() *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateFailed()
+	})
+}
+
+// SetRowErrors sets the "row_errors" field.
+func (u *ImportJobUpsertBulk) SetRowErrors(v []domain.ImportRowError) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetRowErrors(v)
+	})
+}
+
+// UpdateRowErrors sets the "row_errors" field to the value that was provided on create.
+func (u *ImportJobUpsertBulk) UpdateRowErrors() *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateRowErrors()
+	})
+}
+
+// ClearRowErrors clears the value of the "row_errors" field.
+func (u *ImportJobUpsertBulk) ClearRowErrors() *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.ClearRowErrors()
+	})
+}
+
+// SetError sets the "error" field.
+func (u *ImportJobUpsertBulk) SetError(v string) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetError(v)
+	})
+}
+
+// UpdateError sets the "error" field to the value that was provided on create.
+func (u *ImportJobUpsertBulk) UpdateError() *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateError()
+	})
+}
+
+// ClearError clears the value of the "error" field.
+func (u *ImportJobUpsertBulk) ClearError() *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.ClearError()
+	})
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (u *ImportJobUpsertBulk) SetFinishedAt(v time.Time) *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.SetFinishedAt(v)
+	})
+}
+
+// UpdateFinishedAt sets the "finished_at" field to the value that was provided on create.
+func (u *ImportJobUpsertBulk) UpdateFinishedAt() *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.UpdateFinishedAt()
+	})
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (u *ImportJobUpsertBulk) ClearFinishedAt() *ImportJobUpsertBulk {
+	return u.Update(func(s *ImportJobUpsert) {
+		s.ClearFinishedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *ImportJobUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the ImportJobCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for ImportJobCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *ImportJobUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/importjob"
+	"github.com/Beriw98/user-management/ent/predicate"
+)
+
+// ImportJobDelete is the builder for deleting a ImportJob entity.
+type ImportJobDelete struct {
+	config
+	hooks    []Hook
+	mutation *ImportJobMutation
+}
+
+// Where appends a list predicates to the ImportJobDelete builder.
+func (ijd *ImportJobDelete) Where(ps ...predicate.ImportJob) *ImportJobDelete {
+	ijd.mutation.Where(ps...)
+	return ijd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (ijd *ImportJobDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, ijd.sqlExec, ijd.mutation, ijd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ijd *ImportJobDelete) ExecX(ctx context.Context) int {
+	n, err := ijd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (ijd *ImportJobDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(importjob.Table, sqlgraph.NewFieldSpec(importjob.FieldID, field.TypeString))
+	if ps := ijd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, ijd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	ijd.mutation.done = true
+	return affected, err
+}
+
+// ImportJobDeleteOne is the builder for deleting a single ImportJob entity.
+type ImportJobDeleteOne struct {
+	ijd *ImportJobDelete
+}
+
+// Where appends a list predicates to the ImportJobDelete builder.
+func (ijdo *ImportJobDeleteOne) Where(ps ...predicate.ImportJob) *ImportJobDeleteOne {
+	ijdo.ijd.mutation.Where(ps...)
+	return ijdo
+}
+
+// Exec executes the deletion query.
+func (ijdo *ImportJobDeleteOne) Exec(ctx context.Context) error {
+	n, err := ijdo.ijd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{importjob.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ijdo *ImportJobDeleteOne) ExecX(ctx context.Context) {
+	if err := ijdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,632 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/importjob"
+	"github.com/Beriw98/user-management/ent/predicate"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// ImportJobUpdate is the builder for updating ImportJob entities.
+type ImportJobUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ImportJobMutation
+}
+
+// Where appends a list predicates to the ImportJobUpdate builder.
+func (iju *ImportJobUpdate) Where(ps ...predicate.ImportJob) *ImportJobUpdate {
+	iju.mutation.Where(ps...)
+	return iju
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (iju *ImportJobUpdate) SetUpdatedAt(t time.Time) *ImportJobUpdate {
+	iju.mutation.SetUpdatedAt(t)
+	return iju
+}
+
+// SetStatus sets the "status" field.
+func (iju *ImportJobUpdate) SetStatus(ds domain.ImportStatus) *ImportJobUpdate {
+	iju.mutation.SetStatus(ds)
+	return iju
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (iju *ImportJobUpdate) SetNillableStatus(ds *domain.ImportStatus) *ImportJobUpdate {
+	if ds != nil {
+		iju.SetStatus(*ds)
+	}
+	return iju
+}
+
+// SetProcessed sets the "processed" field.
+func (iju *ImportJobUpdate) SetProcessed(i int) *ImportJobUpdate {
+	iju.mutation.ResetProcessed()
+	iju.mutation.SetProcessed(i)
+	return iju
+}
+
+// SetNillableProcessed sets the "processed" field if the given value is not nil.
+func (iju *ImportJobUpdate) SetNillableProcessed(i *int) *ImportJobUpdate {
+	if i != nil {
+		iju.SetProcessed(*i)
+	}
+	return iju
+}
+
+// AddProcessed adds i to the "processed" field.
+func (iju *ImportJobUpdate) AddProcessed(i int) *ImportJobUpdate {
+	iju.mutation.AddProcessed(i)
+	return iju
+}
+
+// SetCreated sets the "created" field.
+func (iju *ImportJobUpdate) SetCreated(i int) *ImportJobUpdate {
+	iju.mutation.ResetCreated()
+	iju.mutation.SetCreated(i)
+	return iju
+}
+
+// SetNillableCreated sets the "created" field if the given value is not nil.
+func (iju *ImportJobUpdate) SetNillableCreated(i *int) *ImportJobUpdate {
+	if i != nil {
+		iju.SetCreated(*i)
+	}
+	return iju
+}
+
+// AddCreated adds i to the "created" field.
+func (iju *ImportJobUpdate) AddCreated(i int) *ImportJobUpdate {
+	iju.mutation.AddCreated(i)
+	return iju
+}
+
+// SetInvited sets the "invited" field.
+func (iju *ImportJobUpdate) SetInvited(i int) *ImportJobUpdate {
+	iju.mutation.ResetInvited()
+	iju.mutation.SetInvited(i)
+	return iju
+}
+
+// SetNillableInvited sets the "invited" field if the given value is not nil.
+func (iju *ImportJobUpdate) SetNillableInvited(i *int) *ImportJobUpdate {
+	if i != nil {
+		iju.SetInvited(*i)
+	}
+	return iju
+}
+
+// AddInvited adds i to the "invited" field.
+func (iju *ImportJobUpdate) AddInvited(i int) *ImportJobUpdate {
+	iju.mutation.AddInvited(i)
+	return iju
+}
+
+// SetFailed sets the "failed" field.
+func (iju *ImportJobUpdate) SetFailed(i int) *ImportJobUpdate {
+	iju.mutation.ResetFailed()
+	iju.mutation.SetFailed(i)
+	return iju
+}
+
+// SetNillableFailed sets the "failed" field if the given value is not nil.
+func (iju *ImportJobUpdate) SetNillableFailed(i *int) *ImportJobUpdate {
+	if i != nil {
+		iju.SetFailed(*i)
+	}
+	return iju
+}
+
+// AddFailed adds i to the "failed" field.
+func (iju *ImportJobUpdate) AddFailed(i int) *ImportJobUpdate {
+	iju.mutation.AddFailed(i)
+	return iju
+}
+
+// SetRowErrors sets the "row_errors" field.
+func (iju *ImportJobUpdate) SetRowErrors(dre []domain.ImportRowError) *ImportJobUpdate {
+	iju.mutation.SetRowErrors(dre)
+	return iju
+}
+
+// AppendRowErrors appends dre to the "row_errors" field.
+func (iju *ImportJobUpdate) AppendRowErrors(dre []domain.ImportRowError) *ImportJobUpdate {
+	iju.mutation.AppendRowErrors(dre)
+	return iju
+}
+
+// ClearRowErrors clears the value of the "row_errors" field.
+func (iju *ImportJobUpdate) ClearRowErrors() *ImportJobUpdate {
+	iju.mutation.ClearRowErrors()
+	return iju
+}
+
+// SetError sets the "error" field.
+func (iju *ImportJobUpdate) SetError(s string) *ImportJobUpdate {
+	iju.mutation.SetError(s)
+	return iju
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (iju *ImportJobUpdate) SetNillableError(s *string) *ImportJobUpdate {
+	if s != nil {
+		iju.SetError(*s)
+	}
+	return iju
+}
+
+// ClearError clears the value of the "error" field.
+func (iju *ImportJobUpdate) ClearError() *ImportJobUpdate {
+	iju.mutation.ClearError()
+	return iju
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (iju *ImportJobUpdate) SetFinishedAt(t time.Time) *ImportJobUpdate {
+	iju.mutation.SetFinishedAt(t)
+	return iju
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (iju *ImportJobUpdate) SetNillableFinishedAt(t *time.Time) *ImportJobUpdate {
+	if t != nil {
+		iju.SetFinishedAt(*t)
+	}
+	return iju
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (iju *ImportJobUpdate) ClearFinishedAt() *ImportJobUpdate {
+	iju.mutation.ClearFinishedAt()
+	return iju
+}
+
+// Mutation returns the ImportJobMutation object of the builder.
+func (iju *ImportJobUpdate) Mutation() *ImportJobMutation {
+	return iju.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (iju *ImportJobUpdate) Save(ctx context.Context) (int, error) {
+	iju.defaults()
+	return withHooks(ctx, iju.sqlSave, iju.mutation, iju.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iju *ImportJobUpdate) SaveX(ctx context.Context) int {
+	affected, err := iju.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (iju *ImportJobUpdate) Exec(ctx context.Context) error {
+	_, err := iju.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iju *ImportJobUpdate) ExecX(ctx context.Context) {
+	if err := iju.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (iju *ImportJobUpdate) defaults() {
+	if _, ok := iju.mutation.UpdatedAt(); !ok {
+		v := importjob.UpdateDefaultUpdatedAt()
+		iju.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (iju *ImportJobUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(importjob.Table, importjob.Columns, sqlgraph.NewFieldSpec(importjob.FieldID, field.TypeString))
+	if ps := iju.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := iju.mutation.UpdatedAt(); ok {
+		_spec.SetField(importjob.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := iju.mutation.Status(); ok {
+		_spec.SetField(importjob.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := iju.mutation.Processed(); ok {
+		_spec.SetField(importjob.FieldProcessed, field.TypeInt, value)
+	}
+	if value, ok := iju.mutation.AddedProcessed(); ok {
+		_spec.AddField(importjob.FieldProcessed, field.TypeInt, value)
+	}
+	if value, ok := iju.mutation.Created(); ok {
+		_spec.SetField(importjob.FieldCreated, field.TypeInt, value)
+	}
+	if value, ok := iju.mutation.AddedCreated(); ok {
+		_spec.AddField(importjob.FieldCreated, field.TypeInt, value)
+	}
+	if value, ok := iju.mutation.Invited(); ok {
+		_spec.SetField(importjob.FieldInvited, field.TypeInt, value)
+	}
+	if value, ok := iju.mutation.AddedInvited(); ok {
+		_spec.AddField(importjob.FieldInvited, field.TypeInt, value)
+	}
+	if value, ok := iju.mutation.Failed(); ok {
+		_spec.SetField(importjob.FieldFailed, field.TypeInt, value)
+	}
+	if value, ok := iju.mutation.AddedFailed(); ok {
+		_spec.AddField(importjob.FieldFailed, field.TypeInt, value)
+	}
+	if value, ok := iju.mutation.RowErrors(); ok {
+		_spec.SetField(importjob.FieldRowErrors, field.TypeJSON, value)
+	}
+	if value, ok := iju.mutation.AppendedRowErrors(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, importjob.FieldRowErrors, value)
+		})
+	}
+	if iju.mutation.RowErrorsCleared() {
+		_spec.ClearField(importjob.FieldRowErrors, field.TypeJSON)
+	}
+	if value, ok := iju.mutation.Error(); ok {
+		_spec.SetField(importjob.FieldError, field.TypeString, value)
+	}
+	if iju.mutation.ErrorCleared() {
+		_spec.ClearField(importjob.FieldError, field.TypeString)
+	}
+	if value, ok := iju.mutation.FinishedAt(); ok {
+		_spec.SetField(importjob.FieldFinishedAt, field.TypeTime, value)
+	}
+	if iju.mutation.FinishedAtCleared() {
+		_spec.ClearField(importjob.FieldFinishedAt, field.TypeTime)
+	}
+	if iju.mutation.OrganizationIDCleared() {
+		_spec.ClearField(importjob.FieldOrganizationID, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, iju.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{importjob.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	iju.mutation.done = true
+	return n, nil
+}
+
+// ImportJobUpdateOne is the builder for updating a single ImportJob entity.
+type ImportJobUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ImportJobMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ijuo *ImportJobUpdateOne) SetUpdatedAt(t time.Time) *ImportJobUpdateOne {
+	ijuo.mutation.SetUpdatedAt(t)
+	return ijuo
+}
+
+// SetStatus sets the "status" field.
+func (ijuo *ImportJobUpdateOne) SetStatus(ds domain.ImportStatus) *ImportJobUpdateOne {
+	ijuo.mutation.SetStatus(ds)
+	return ijuo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (ijuo *ImportJobUpdateOne) SetNillableStatus(ds *domain.ImportStatus) *ImportJobUpdateOne {
+	if ds != nil {
+		ijuo.SetStatus(*ds)
+	}
+	return ijuo
+}
+
+// SetProcessed sets the "processed" field.
+func (ijuo *ImportJobUpdateOne) SetProcessed(i int) *ImportJobUpdateOne {
+	ijuo.mutation.ResetProcessed()
+	ijuo.mutation.SetProcessed(i)
+	return ijuo
+}
+
+// SetNillableProcessed sets the "processed" field if the given value is not nil.
+func (ijuo *ImportJobUpdateOne) SetNillableProcessed(i *int) *ImportJobUpdateOne {
+	if i != nil {
+		ijuo.SetProcessed(*i)
+	}
+	return ijuo
+}
+
+// AddProcessed adds i to the "processed" field.
+func (ijuo *ImportJobUpdateOne) AddProcessed(i int) *ImportJobUpdateOne {
+	ijuo.mutation.AddProcessed(i)
+	return ijuo
+}
+
+// SetCreated sets the "created" field.
+func (ijuo *ImportJobUpdateOne) SetCreated(i int) *ImportJobUpdateOne {
+	ijuo.mutation.ResetCreated()
+	ijuo.mutation.SetCreated(i)
+	return ijuo
+}
+
+// SetNillableCreated sets the "created" field if the given value is not nil.
+func (ijuo *ImportJobUpdateOne) SetNillableCreated(i *int) *ImportJobUpdateOne {
+	if i != nil {
+		ijuo.SetCreated(*i)
+	}
+	return ijuo
+}
+
+// AddCreated adds i to the "created" field.
+func (ijuo *ImportJobUpdateOne) AddCreated(i int) *ImportJobUpdateOne {
+	ijuo.mutation.AddCreated(i)
+	return ijuo
+}
+
+// SetInvited sets the "invited" field.
+func (ijuo *ImportJobUpdateOne) SetInvited(i int) *ImportJobUpdateOne {
+	ijuo.mutation.ResetInvited()
+	ijuo.mutation.SetInvited(i)
+	return ijuo
+}
+
+// SetNillableInvited sets the "invited" field if the given value is not nil.
+func (ijuo *ImportJobUpdateOne) SetNillableInvited(i *int) *ImportJobUpdateOne {
+	if i != nil {
+		ijuo.SetInvited(*i)
+	}
+	return ijuo
+}
+
+// AddInvited adds i to the "invited" field.
+func (ijuo *ImportJobUpdateOne) AddInvited(i int) *ImportJobUpdateOne {
+	ijuo.mutation.AddInvited(i)
+	return ijuo
+}
+
+// SetFailed sets the "failed" field.
+func (ijuo *ImportJobUpdateOne) SetFailed(i int) *ImportJobUpdateOne {
+	ijuo.mutation.ResetFailed()
+	ijuo.mutation.SetFailed(i)
+	return ijuo
+}
+
+// SetNillableFailed sets the "failed" field if the given value is not nil.
+func (ijuo *ImportJobUpdateOne) SetNillableFailed(i *int) *ImportJobUpdateOne {
+	if i != nil {
+		ijuo.SetFailed(*i)
+	}
+	return ijuo
+}
+
+// AddFailed adds i to the "failed" field.
+func (ijuo *ImportJobUpdateOne) AddFailed(i int) *ImportJobUpdateOne {
+	ijuo.mutation.AddFailed(i)
+	return ijuo
+}
+
+// SetRowErrors sets the "row_errors" field.
+func (ijuo *ImportJobUpdateOne) SetRowErrors(dre []domain.ImportRowError) *ImportJobUpdateOne {
+	ijuo.mutation.SetRowErrors(dre)
+	return ijuo
+}
+
+// AppendRowErrors appends dre to the "row_errors" field.
+func (ijuo *ImportJobUpdateOne) AppendRowErrors(dre []domain.ImportRowError) *ImportJobUpdateOne {
+	ijuo.mutation.AppendRowErrors(dre)
+	return ijuo
+}
+
+// ClearRowErrors clears the value of the "row_errors" field.
+func (ijuo *ImportJobUpdateOne) ClearRowErrors() *ImportJobUpdateOne {
+	ijuo.mutation.ClearRowErrors()
+	return ijuo
+}
+
+// SetError sets the "error" field.
+func (ijuo *ImportJobUpdateOne) SetError(s string) *ImportJobUpdateOne {
+	ijuo.mutation.SetError(s)
+	return ijuo
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (ijuo *ImportJobUpdateOne) SetNillableError(s *string) *ImportJobUpdateOne {
+	if s != nil {
+		ijuo.SetError(*s)
+	}
+	return ijuo
+}
+
+// ClearError clears the value of the "error" field.
+func (ijuo *ImportJobUpdateOne) ClearError() *ImportJobUpdateOne {
+	ijuo.mutation.ClearError()
+	return ijuo
+}
+
+// SetFinishedAt sets the "finished_at" field.
+func (ijuo *ImportJobUpdateOne) SetFinishedAt(t time.Time) *ImportJobUpdateOne {
+	ijuo.mutation.SetFinishedAt(t)
+	return ijuo
+}
+
+// SetNillableFinishedAt sets the "finished_at" field if the given value is not nil.
+func (ijuo *ImportJobUpdateOne) SetNillableFinishedAt(t *time.Time) *ImportJobUpdateOne {
+	if t != nil {
+		ijuo.SetFinishedAt(*t)
+	}
+	return ijuo
+}
+
+// ClearFinishedAt clears the value of the "finished_at" field.
+func (ijuo *ImportJobUpdateOne) ClearFinishedAt() *ImportJobUpdateOne {
+	ijuo.mutation.ClearFinishedAt()
+	return ijuo
+}
+
+// Mutation returns the ImportJobMutation object of the builder.
+func (ijuo *ImportJobUpdateOne) Mutation() *ImportJobMutation {
+	return ijuo.mutation
+}
+
+// Where appends a list predicates to the ImportJobUpdate builder.
+func (ijuo *ImportJobUpdateOne) Where(ps ...predicate.ImportJob) *ImportJobUpdateOne {
+	ijuo.mutation.Where(ps...)
+	return ijuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ijuo *ImportJobUpdateOne) Select(field string, fields ...string) *ImportJobUpdateOne {
+	ijuo.fields = append([]string{field}, fields...)
+	return ijuo
+}
+
+// Save executes the query and returns the updated ImportJob entity.
+func (ijuo *ImportJobUpdateOne) Save(ctx context.Context) (*ImportJob, error) {
+	ijuo.defaults()
+	return withHooks(ctx, ijuo.sqlSave, ijuo.mutation, ijuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ijuo *ImportJobUpdateOne) SaveX(ctx context.Context) *ImportJob {
+	node, err := ijuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ijuo *ImportJobUpdateOne) Exec(ctx context.Context) error {
+	_, err := ijuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ijuo *ImportJobUpdateOne) ExecX(ctx context.Context) {
+	if err := ijuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ijuo *ImportJobUpdateOne) defaults() {
+	if _, ok := ijuo.mutation.UpdatedAt(); !ok {
+		v := importjob.UpdateDefaultUpdatedAt()
+		ijuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (ijuo *ImportJobUpdateOne) sqlSave(ctx context.Context) (_node *ImportJob, err error) {
+	_spec := sqlgraph.NewUpdateSpec(importjob.Table, importjob.Columns, sqlgraph.NewFieldSpec(importjob.FieldID, field.TypeString))
+	id, ok := ijuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ImportJob.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ijuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, importjob.FieldID)
+		for _, f := range fields {
+			if !importjob.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != importjob.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ijuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ijuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(importjob.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := ijuo.mutation.Status(); ok {
+		_spec.SetField(importjob.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := ijuo.mutation.Processed(); ok {
+		_spec.SetField(importjob.FieldProcessed, field.TypeInt, value)
+	}
+	if value, ok := ijuo.mutation.AddedProcessed(); ok {
+		_spec.AddField(importjob.FieldProcessed, field.TypeInt, value)
+	}
+	if value, ok := ijuo.mutation.Created(); ok {
+		_spec.SetField(importjob.FieldCreated, field.TypeInt, value)
+	}
+	if value, ok := ijuo.mutation.AddedCreated(); ok {
+		_spec.AddField(importjob.FieldCreated, field.TypeInt, value)
+	}
+	if value, ok := ijuo.mutation.Invited(); ok {
+		_spec.SetField(importjob.FieldInvited, field.TypeInt, value)
+	}
+	if value, ok := ijuo.mutation.AddedInvited(); ok {
+		_spec.AddField(importjob.FieldInvited, field.TypeInt, value)
+	}
+	if value, ok := ijuo.mutation.Failed(); ok {
+		_spec.SetField(importjob.FieldFailed, field.TypeInt, value)
+	}
+	if value, ok := ijuo.mutation.AddedFailed(); ok {
+		_spec.AddField(importjob.FieldFailed, field.TypeInt, value)
+	}
+	if value, ok := ijuo.mutation.RowErrors(); ok {
+		_spec.SetField(importjob.FieldRowErrors, field.TypeJSON, value)
+	}
+	if value, ok := ijuo.mutation.AppendedRowErrors(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, importjob.FieldRowErrors, value)
+		})
+	}
+	if ijuo.mutation.RowErrorsCleared() {
+		_spec.ClearField(importjob.FieldRowErrors, field.TypeJSON)
+	}
+	if value, ok := ijuo.mutation.Error(); ok {
+		_spec.SetField(importjob.FieldError, field.TypeString, value)
+	}
+	if ijuo.mutation.ErrorCleared() {
+		_spec.ClearField(importjob.FieldError, field.TypeString)
+	}
+	if value, ok := ijuo.mutation.FinishedAt(); ok {
+		_spec.SetField(importjob.FieldFinishedAt, field.TypeTime, value)
+	}
+	if ijuo.mutation.FinishedAtCleared() {
+		_spec.ClearField(importjob.FieldFinishedAt, field.TypeTime)
+	}
+	if ijuo.mutation.OrganizationIDCleared() {
+		_spec.ClearField(importjob.FieldOrganizationID, field.TypeString)
+	}
+	_node = &ImportJob{config: ijuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ijuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{importjob.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ijuo.mutation.done = true
+	return _node, nil
+}
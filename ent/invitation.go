@@ -0,0 +1,236 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Beriw98/user-management/ent/invitation"
+)
+
+// Invitation is the model entity for the Invitation schema.
+type Invitation struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID string `json:"id,omitempty"`
+	// Email holds the value of the "email" field.
+	Email string `json:"email,omitempty"`
+	// EmailIndex holds the value of the "email_index" field.
+	EmailIndex *string `json:"email_index,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// Surname holds the value of the "surname" field.
+	Surname string `json:"surname,omitempty"`
+	// TokenHash holds the value of the "token_hash" field.
+	TokenHash string `json:"-"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// AcceptedAt holds the value of the "accepted_at" field.
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	// RevokedAt holds the value of the "revoked_at" field.
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// OrganizationID holds the value of the "organization_id" field.
+	OrganizationID string `json:"organization_id,omitempty"`
+	// GroupID holds the value of the "group_id" field.
+	GroupID *string `json:"group_id,omitempty"`
+	// InvitedBy holds the value of the "invited_by" field.
+	InvitedBy    string `json:"invited_by,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Invitation) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case invitation.FieldID, invitation.FieldEmail, invitation.FieldEmailIndex, invitation.FieldName, invitation.FieldSurname, invitation.FieldTokenHash, invitation.FieldOrganizationID, invitation.FieldGroupID, invitation.FieldInvitedBy:
+			values[i] = new(sql.NullString)
+		case invitation.FieldExpiresAt, invitation.FieldAcceptedAt, invitation.FieldRevokedAt, invitation.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Invitation fields.
+func (i *Invitation) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for j := range columns {
+		switch columns[j] {
+		case invitation.FieldID:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[j])
+			} else if value.Valid {
+				i.ID = value.String
+			}
+		case invitation.FieldEmail:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field email", values[j])
+			} else if value.Valid {
+				i.Email = value.String
+			}
+		case invitation.FieldEmailIndex:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field email_index", values[j])
+			} else if value.Valid {
+				i.EmailIndex = new(string)
+				*i.EmailIndex = value.String
+			}
+		case invitation.FieldName:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[j])
+			} else if value.Valid {
+				i.Name = value.String
+			}
+		case invitation.FieldSurname:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field surname", values[j])
+			} else if value.Valid {
+				i.Surname = value.String
+			}
+		case invitation.FieldTokenHash:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field token_hash", values[j])
+			} else if value.Valid {
+				i.TokenHash = value.String
+			}
+		case invitation.FieldExpiresAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[j])
+			} else if value.Valid {
+				i.ExpiresAt = value.Time
+			}
+		case invitation.FieldAcceptedAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field accepted_at", values[j])
+			} else if value.Valid {
+				i.AcceptedAt = new(time.Time)
+				*i.AcceptedAt = value.Time
+			}
+		case invitation.FieldRevokedAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field revoked_at", values[j])
+			} else if value.Valid {
+				i.RevokedAt = new(time.Time)
+				*i.RevokedAt = value.Time
+			}
+		case invitation.FieldCreatedAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[j])
+			} else if value.Valid {
+				i.CreatedAt = value.Time
+			}
+		case invitation.FieldOrganizationID:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field organization_id", values[j])
+			} else if value.Valid {
+				i.OrganizationID = value.String
+			}
+		case invitation.FieldGroupID:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field group_id", values[j])
+			} else if value.Valid {
+				i.GroupID = new(string)
+				*i.GroupID = value.String
+			}
+		case invitation.FieldInvitedBy:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field invited_by", values[j])
+			} else if value.Valid {
+				i.InvitedBy = value.String
+			}
+		default:
+			i.selectValues.Set(columns[j], values[j])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Invitation.
+// This includes values selected through modifiers, order, etc.
+func (i *Invitation) Value(name string) (ent.Value, error) {
+	return i.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Invitation.
+// Note that you need to call Invitation.Unwrap() before calling this method if this Invitation
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (i *Invitation) Update() *InvitationUpdateOne {
+	return NewInvitationClient(i.config).UpdateOne(i)
+}
+
+// Unwrap unwraps the Invitation entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (i *Invitation) Unwrap() *Invitation {
+	_tx, ok := i.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Invitation is not a transactional entity")
+	}
+	i.config.driver = _tx.drv
+	return i
+}
+
+// String implements the fmt.Stringer.
+func (i *Invitation) String() string {
+	var builder strings.Builder
+	builder.WriteString("Invitation(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", i.ID))
+	builder.WriteString("email=")
+	builder.WriteString(i.Email)
+	builder.WriteString(", ")
+	if v := i.EmailIndex; v != nil {
+		builder.WriteString("email_index=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(i.Name)
+	builder.WriteString(", ")
+	builder.WriteString("surname=")
+	builder.WriteString(i.Surname)
+	builder.WriteString(", ")
+	builder.WriteString("token_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(i.ExpiresAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := i.AcceptedAt; v != nil {
+		builder.WriteString("accepted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := i.RevokedAt; v != nil {
+		builder.WriteString("revoked_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(i.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("organization_id=")
+	builder.WriteString(i.OrganizationID)
+	builder.WriteString(", ")
+	if v := i.GroupID; v != nil {
+		builder.WriteString("group_id=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	builder.WriteString("invited_by=")
+	builder.WriteString(i.InvitedBy)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Invitations is a parsable slice of Invitation.
+type Invitations []*Invitation
@@ -0,0 +1,1017 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/invitation"
+)
+
+// InvitationCreate is the builder for creating a Invitation entity.
+type InvitationCreate struct {
+	config
+	mutation *InvitationMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetEmail sets the "email" field.
+func (ic *InvitationCreate) SetEmail(s string) *InvitationCreate {
+	ic.mutation.SetEmail(s)
+	return ic
+}
+
+// SetEmailIndex sets the "email_index" field.
+func (ic *InvitationCreate) SetEmailIndex(s string) *InvitationCreate {
+	ic.mutation.SetEmailIndex(s)
+	return ic
+}
+
+// SetNillableEmailIndex sets the "email_index" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableEmailIndex(s *string) *InvitationCreate {
+	if s != nil {
+		ic.SetEmailIndex(*s)
+	}
+	return ic
+}
+
+// SetName sets the "name" field.
+func (ic *InvitationCreate) SetName(s string) *InvitationCreate {
+	ic.mutation.SetName(s)
+	return ic
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableName(s *string) *InvitationCreate {
+	if s != nil {
+		ic.SetName(*s)
+	}
+	return ic
+}
+
+// SetSurname sets the "surname" field.
+func (ic *InvitationCreate) SetSurname(s string) *InvitationCreate {
+	ic.mutation.SetSurname(s)
+	return ic
+}
+
+// SetNillableSurname sets the "surname" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableSurname(s *string) *InvitationCreate {
+	if s != nil {
+		ic.SetSurname(*s)
+	}
+	return ic
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (ic *InvitationCreate) SetTokenHash(s string) *InvitationCreate {
+	ic.mutation.SetTokenHash(s)
+	return ic
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (ic *InvitationCreate) SetExpiresAt(t time.Time) *InvitationCreate {
+	ic.mutation.SetExpiresAt(t)
+	return ic
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (ic *InvitationCreate) SetAcceptedAt(t time.Time) *InvitationCreate {
+	ic.mutation.SetAcceptedAt(t)
+	return ic
+}
+
+// SetNillableAcceptedAt sets the "accepted_at" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableAcceptedAt(t *time.Time) *InvitationCreate {
+	if t != nil {
+		ic.SetAcceptedAt(*t)
+	}
+	return ic
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (ic *InvitationCreate) SetRevokedAt(t time.Time) *InvitationCreate {
+	ic.mutation.SetRevokedAt(t)
+	return ic
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableRevokedAt(t *time.Time) *InvitationCreate {
+	if t != nil {
+		ic.SetRevokedAt(*t)
+	}
+	return ic
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ic *InvitationCreate) SetCreatedAt(t time.Time) *InvitationCreate {
+	ic.mutation.SetCreatedAt(t)
+	return ic
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableCreatedAt(t *time.Time) *InvitationCreate {
+	if t != nil {
+		ic.SetCreatedAt(*t)
+	}
+	return ic
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (ic *InvitationCreate) SetOrganizationID(s string) *InvitationCreate {
+	ic.mutation.SetOrganizationID(s)
+	return ic
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableOrganizationID(s *string) *InvitationCreate {
+	if s != nil {
+		ic.SetOrganizationID(*s)
+	}
+	return ic
+}
+
+// SetGroupID sets the "group_id" field.
+func (ic *InvitationCreate) SetGroupID(s string) *InvitationCreate {
+	ic.mutation.SetGroupID(s)
+	return ic
+}
+
+// SetNillableGroupID sets the "group_id" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableGroupID(s *string) *InvitationCreate {
+	if s != nil {
+		ic.SetGroupID(*s)
+	}
+	return ic
+}
+
+// SetInvitedBy sets the "invited_by" field.
+func (ic *InvitationCreate) SetInvitedBy(s string) *InvitationCreate {
+	ic.mutation.SetInvitedBy(s)
+	return ic
+}
+
+// SetNillableInvitedBy sets the "invited_by" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableInvitedBy(s *string) *InvitationCreate {
+	if s != nil {
+		ic.SetInvitedBy(*s)
+	}
+	return ic
+}
+
+// SetID sets the "id" field.
+func (ic *InvitationCreate) SetID(s string) *InvitationCreate {
+	ic.mutation.SetID(s)
+	return ic
+}
+
+// Mutation returns the InvitationMutation object of the builder.
+func (ic *InvitationCreate) Mutation() *InvitationMutation {
+	return ic.mutation
+}
+
+// Save creates the Invitation in the database.
+func (ic *InvitationCreate) Save(ctx context.Context) (*Invitation, error) {
+	ic.defaults()
+	return withHooks(ctx, ic.sqlSave, ic.mutation, ic.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ic *InvitationCreate) SaveX(ctx context.Context) *Invitation {
+	v, err := ic.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ic *InvitationCreate) Exec(ctx context.Context) error {
+	_, err := ic.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ic *InvitationCreate) ExecX(ctx context.Context) {
+	if err := ic.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ic *InvitationCreate) defaults() {
+	if _, ok := ic.mutation.CreatedAt(); !ok {
+		v := invitation.DefaultCreatedAt()
+		ic.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ic *InvitationCreate) check() error {
+	if _, ok := ic.mutation.Email(); !ok {
+		return &ValidationError{Name: "email", err: errors.New(`ent: missing required field "Invitation.email"`)}
+	}
+	if v, ok := ic.mutation.Email(); ok {
+		if err := invitation.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "Invitation.email": %w`, err)}
+		}
+	}
+	if _, ok := ic.mutation.TokenHash(); !ok {
+		return &ValidationError{Name: "token_hash", err: errors.New(`ent: missing required field "Invitation.token_hash"`)}
+	}
+	if v, ok := ic.mutation.TokenHash(); ok {
+		if err := invitation.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "Invitation.token_hash": %w`, err)}
+		}
+	}
+	if _, ok := ic.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "Invitation.expires_at"`)}
+	}
+	if _, ok := ic.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Invitation.created_at"`)}
+	}
+	return nil
+}
+
+func (ic *InvitationCreate) sqlSave(ctx context.Context) (*Invitation, error) {
+	if err := ic.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ic.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ic.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected Invitation.ID type: %T", _spec.ID.Value)
+		}
+	}
+	ic.mutation.id = &_node.ID
+	ic.mutation.done = true
+	return _node, nil
+}
+
+func (ic *InvitationCreate) createSpec() (*Invitation, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Invitation{config: ic.config}
+		_spec = sqlgraph.NewCreateSpec(invitation.Table, sqlgraph.NewFieldSpec(invitation.FieldID, field.TypeString))
+	)
+	_spec.OnConflict = ic.conflict
+	if id, ok := ic.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := ic.mutation.Email(); ok {
+		_spec.SetField(invitation.FieldEmail, field.TypeString, value)
+		_node.Email = value
+	}
+	if value, ok := ic.mutation.EmailIndex(); ok {
+		_spec.SetField(invitation.FieldEmailIndex, field.TypeString, value)
+		_node.EmailIndex = &value
+	}
+	if value, ok := ic.mutation.Name(); ok {
+		_spec.SetField(invitation.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := ic.mutation.Surname(); ok {
+		_spec.SetField(invitation.FieldSurname, field.TypeString, value)
+		_node.Surname = value
+	}
+	if value, ok := ic.mutation.TokenHash(); ok {
+		_spec.SetField(invitation.FieldTokenHash, field.TypeString, value)
+		_node.TokenHash = value
+	}
+	if value, ok := ic.mutation.ExpiresAt(); ok {
+		_spec.SetField(invitation.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := ic.mutation.AcceptedAt(); ok {
+		_spec.SetField(invitation.FieldAcceptedAt, field.TypeTime, value)
+		_node.AcceptedAt = &value
+	}
+	if value, ok := ic.mutation.RevokedAt(); ok {
+		_spec.SetField(invitation.FieldRevokedAt, field.TypeTime, value)
+		_node.RevokedAt = &value
+	}
+	if value, ok := ic.mutation.CreatedAt(); ok {
+		_spec.SetField(invitation.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := ic.mutation.OrganizationID(); ok {
+		_spec.SetField(invitation.FieldOrganizationID, field.TypeString, value)
+		_node.OrganizationID = value
+	}
+	if value, ok := ic.mutation.GroupID(); ok {
+		_spec.SetField(invitation.FieldGroupID, field.TypeString, value)
+		_node.GroupID = &value
+	}
+	if value, ok := ic.mutation.InvitedBy(); ok {
+		_spec.SetField(invitation.FieldInvitedBy, field.TypeString, value)
+		_node.InvitedBy = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Invitation.Create().
+//		SetEmail(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.InvitationUpsert) {
+//			SetEmail(v+v).
+//		}).
+//		Exec(ctx)
+func (ic *InvitationCreate) OnConflict(opts ...sql.ConflictOption) *InvitationUpsertOne {
+	ic.conflict = opts
+	return &InvitationUpsertOne{
+		create: ic,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Invitation.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (ic *InvitationCreate) OnConflictColumns(columns ...string) *InvitationUpsertOne {
+	ic.conflict = append(ic.conflict, sql.ConflictColumns(columns...))
+	return &InvitationUpsertOne{
+		create: ic,
+	}
+}
+
+type (
+	// InvitationUpsertOne is the builder for "upsert"-ing
+	//  one Invitation node.
+	InvitationUpsertOne struct {
+		create *InvitationCreate
+	}
+
+	// InvitationUpsert is the "OnConflict" setter.
+	InvitationUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetName sets the "name" field.
+func (u *InvitationUpsert) SetName(v string) *InvitationUpsert {
+	u.Set(invitation.FieldName, v)
+	return u
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *InvitationUpsert) UpdateName() *InvitationUpsert {
+	u.SetExcluded(invitation.FieldName)
+	return u
+}
+
+// ClearName clears the value of the "name" field.
+func (u *InvitationUpsert) ClearName() *InvitationUpsert {
+	u.SetNull(invitation.FieldName)
+	return u
+}
+
+// SetSurname sets the "surname" field.
+func (u *InvitationUpsert) SetSurname(v string) *InvitationUpsert {
+	u.Set(invitation.FieldSurname, v)
+	return u
+}
+
+// UpdateSurname sets the "surname" field to the value that was provided on create.
+func (u *InvitationUpsert) UpdateSurname() *InvitationUpsert {
+	u.SetExcluded(invitation.FieldSurname)
+	return u
+}
+
+// ClearSurname clears the value of the "surname" field.
+func (u *InvitationUpsert) ClearSurname() *InvitationUpsert {
+	u.SetNull(invitation.FieldSurname)
+	return u
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (u *InvitationUpsert) SetTokenHash(v string) *InvitationUpsert {
+	u.Set(invitation.FieldTokenHash, v)
+	return u
+}
+
+// UpdateTokenHash sets the "token_hash" field to the value that was provided on create.
+func (u *InvitationUpsert) UpdateTokenHash() *InvitationUpsert {
+	u.SetExcluded(invitation.FieldTokenHash)
+	return u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *InvitationUpsert) SetExpiresAt(v time.Time) *InvitationUpsert {
+	u.Set(invitation.FieldExpiresAt, v)
+	return u
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *InvitationUpsert) UpdateExpiresAt() *InvitationUpsert {
+	u.SetExcluded(invitation.FieldExpiresAt)
+	return u
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (u *InvitationUpsert) SetAcceptedAt(v time.Time) *InvitationUpsert {
+	u.Set(invitation.FieldAcceptedAt, v)
+	return u
+}
+
+// UpdateAcceptedAt sets the "accepted_at" field to the value that was provided on create.
+func (u *InvitationUpsert) UpdateAcceptedAt() *InvitationUpsert {
+	u.SetExcluded(invitation.FieldAcceptedAt)
+	return u
+}
+
+// ClearAcceptedAt clears the value of the "accepted_at" field.
+func (u *InvitationUpsert) ClearAcceptedAt() *InvitationUpsert {
+	u.SetNull(invitation.FieldAcceptedAt)
+	return u
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (u *InvitationUpsert) SetRevokedAt(v time.Time) *InvitationUpsert {
+	u.Set(invitation.FieldRevokedAt, v)
+	return u
+}
+
+// UpdateRevokedAt sets the "revoked_at" field to the value that was provided on create.
+func (u *InvitationUpsert) UpdateRevokedAt() *InvitationUpsert {
+	u.SetExcluded(invitation.FieldRevokedAt)
+	return u
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (u *InvitationUpsert) ClearRevokedAt() *InvitationUpsert {
+	u.SetNull(invitation.FieldRevokedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.Invitation.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(invitation.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *InvitationUpsertOne) UpdateNewValues() *InvitationUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(invitation.FieldID)
+		}
+		if _, exists := u.create.mutation.Email(); exists {
+			s.SetIgnore(invitation.FieldEmail)
+		}
+		if _, exists := u.create.mutation.EmailIndex(); exists {
+			s.SetIgnore(invitation.FieldEmailIndex)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(invitation.FieldCreatedAt)
+		}
+		if _, exists := u.create.mutation.OrganizationID(); exists {
+			s.SetIgnore(invitation.FieldOrganizationID)
+		}
+		if _, exists := u.create.mutation.GroupID(); exists {
+			s.SetIgnore(invitation.FieldGroupID)
+		}
+		if _, exists := u.create.mutation.InvitedBy(); exists {
+			s.SetIgnore(invitation.FieldInvitedBy)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Invitation.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *InvitationUpsertOne) Ignore() *InvitationUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *InvitationUpsertOne) DoNothing() *InvitationUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the InvitationCreate.OnConflict
+// documentation for more info.
+func (u *InvitationUpsertOne) Update(set func(*InvitationUpsert)) *InvitationUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&InvitationUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetName sets the "name" field.
+func (u *InvitationUpsertOne) SetName(v string) *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *InvitationUpsertOne) UpdateName() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateName()
+	})
+}
+
+// ClearName clears the value of the "name" field.
+func (u *InvitationUpsertOne) ClearName() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.ClearName()
+	})
+}
+
+// SetSurname sets the "surname" field.
+func (u *InvitationUpsertOne) SetSurname(v string) *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetSurname(v)
+	})
+}
+
+// UpdateSurname sets the "surname" field to the value that was provided on create.
+func (u *InvitationUpsertOne) UpdateSurname() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateSurname()
+	})
+}
+
+// ClearSurname clears the value of the "surname" field.
+func (u *InvitationUpsertOne) ClearSurname() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.ClearSurname()
+	})
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (u *InvitationUpsertOne) SetTokenHash(v string) *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetTokenHash(v)
+	})
+}
+
+// UpdateTokenHash sets the "token_hash" field to the value that was provided on create.
+func (u *InvitationUpsertOne) UpdateTokenHash() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateTokenHash()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *InvitationUpsertOne) SetExpiresAt(v time.Time) *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *InvitationUpsertOne) UpdateExpiresAt() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (u *InvitationUpsertOne) SetAcceptedAt(v time.Time) *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetAcceptedAt(v)
+	})
+}
+
+// UpdateAcceptedAt sets the "accepted_at" field to the value that was provided on create.
+func (u *InvitationUpsertOne) UpdateAcceptedAt() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateAcceptedAt()
+	})
+}
+
+// ClearAcceptedAt clears the value of the "accepted_at" field.
+func (u *InvitationUpsertOne) ClearAcceptedAt() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.ClearAcceptedAt()
+	})
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (u *InvitationUpsertOne) SetRevokedAt(v time.Time) *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetRevokedAt(v)
+	})
+}
+
+// UpdateRevokedAt sets the "revoked_at" field to the value that was provided on create.
+func (u *InvitationUpsertOne) UpdateRevokedAt() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateRevokedAt()
+	})
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (u *InvitationUpsertOne) ClearRevokedAt() *InvitationUpsertOne {
+	return u.Update(func(s *InvitationUpsert) {
+		s.ClearRevokedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *InvitationUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for InvitationCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *InvitationUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *InvitationUpsertOne) ID(ctx context.Context) (id string, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: InvitationUpsertOne.ID is not supported by MySQL driver. Use InvitationUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *InvitationUpsertOne) IDX(ctx context.Context) string {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// InvitationCreateBulk is the builder for creating many Invitation entities in bulk.
+type InvitationCreateBulk struct {
+	config
+	err      error
+	builders []*InvitationCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the Invitation entities in the database.
+func (icb *InvitationCreateBulk) Save(ctx context.Context) ([]*Invitation, error) {
+	if icb.err != nil {
+		return nil, icb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(icb.builders))
+	nodes := make([]*Invitation, len(icb.builders))
+	mutators := make([]Mutator, len(icb.builders))
+	for i := range icb.builders {
+		func(i int, root context.Context) {
+			builder := icb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*InvitationMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, icb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = icb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, icb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, icb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (icb *InvitationCreateBulk) SaveX(ctx context.Context) []*Invitation {
+	v, err := icb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (icb *InvitationCreateBulk) Exec(ctx context.Context) error {
+	_, err := icb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (icb *InvitationCreateBulk) ExecX(ctx context.Context) {
+	if err := icb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.Invitation.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.InvitationUpsert) {
+//			SetEmail(v+v).
+//		}).
+//		Exec(ctx)
+func (icb *InvitationCreateBulk) OnConflict(opts ...sql.ConflictOption) *InvitationUpsertBulk {
+	icb.conflict = opts
+	return &InvitationUpsertBulk{
+		create: icb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.Invitation.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (icb *InvitationCreateBulk) OnConflictColumns(columns ...string) *InvitationUpsertBulk {
+	icb.conflict = append(icb.conflict, sql.ConflictColumns(columns...))
+	return &InvitationUpsertBulk{
+		create: icb,
+	}
+}
+
+// InvitationUpsertBulk is the builder for "upsert"-ing
+// a bulk of Invitation nodes.
+type InvitationUpsertBulk struct {
+	create *InvitationCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.Invitation.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(invitation.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *InvitationUpsertBulk) UpdateNewValues() *InvitationUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(invitation.FieldID)
+			}
+			if _, exists := b.mutation.Email(); exists {
+				s.SetIgnore(invitation.FieldEmail)
+			}
+			if _, exists := b.mutation.EmailIndex(); exists {
+				s.SetIgnore(invitation.FieldEmailIndex)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(invitation.FieldCreatedAt)
+			}
+			if _, exists := b.mutation.OrganizationID(); exists {
+				s.SetIgnore(invitation.FieldOrganizationID)
+			}
+			if _, exists := b.mutation.GroupID(); exists {
+				s.SetIgnore(invitation.FieldGroupID)
+			}
+			if _, exists := b.mutation.InvitedBy(); exists {
+				s.SetIgnore(invitation.FieldInvitedBy)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.Invitation.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *InvitationUpsertBulk) Ignore() *InvitationUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *InvitationUpsertBulk) DoNothing() *InvitationUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the InvitationCreateBulk.OnConflict
+// documentation for more info.
+func (u *InvitationUpsertBulk) Update(set func(*InvitationUpsert)) *InvitationUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&InvitationUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetName sets the "name" field.
+func (u *InvitationUpsertBulk) SetName(v string) *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetName(v)
+	})
+}
+
+// UpdateName sets the "name" field to the value that was provided on create.
+func (u *InvitationUpsertBulk) UpdateName() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateName()
+	})
+}
+
+// ClearName clears the value of the "name" field.
+func (u *InvitationUpsertBulk) ClearName() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.ClearName()
+	})
+}
+
+// SetSurname sets the "surname" field.
+func (u *InvitationUpsertBulk) SetSurname(v string) *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetSurname(v)
+	})
+}
+
+// UpdateSurname sets the "surname" field to the value that was provided on create.
+func (u *InvitationUpsertBulk) UpdateSurname() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateSurname()
+	})
+}
+
+// ClearSurname clears the value of the "surname" field.
+func (u *InvitationUpsertBulk) ClearSurname() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.ClearSurname()
+	})
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (u *InvitationUpsertBulk) SetTokenHash(v string) *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetTokenHash(v)
+	})
+}
+
+// UpdateTokenHash sets the "token_hash" field to the value that was provided on create.
+func (u *InvitationUpsertBulk) UpdateTokenHash() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateTokenHash()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *InvitationUpsertBulk) SetExpiresAt(v time.Time) *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *InvitationUpsertBulk) UpdateExpiresAt() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (u *InvitationUpsertBulk) SetAcceptedAt(v time.Time) *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetAcceptedAt(v)
+	})
+}
+
+// UpdateAcceptedAt sets the "accepted_at" field to the value that was provided on create.
+func (u *InvitationUpsertBulk) UpdateAcceptedAt() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateAcceptedAt()
+	})
+}
+
+// ClearAcceptedAt clears the value of the "accepted_at" field.
+func (u *InvitationUpsertBulk) ClearAcceptedAt() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.ClearAcceptedAt()
+	})
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (u *InvitationUpsertBulk) SetRevokedAt(v time.Time) *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.SetRevokedAt(v)
+	})
+}
+
+// UpdateRevokedAt sets the "revoked_at" field to the value that was provided on create.
+func (u *InvitationUpsertBulk) UpdateRevokedAt() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.UpdateRevokedAt()
+	})
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (u *InvitationUpsertBulk) ClearRevokedAt() *InvitationUpsertBulk {
+	return u.Update(func(s *InvitationUpsert) {
+		s.ClearRevokedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *InvitationUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the InvitationCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for InvitationCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *InvitationUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
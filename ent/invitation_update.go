@@ -0,0 +1,502 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/invitation"
+	"github.com/Beriw98/user-management/ent/predicate"
+)
+
+// InvitationUpdate is the builder for updating Invitation entities.
+type InvitationUpdate struct {
+	config
+	hooks    []Hook
+	mutation *InvitationMutation
+}
+
+// Where appends a list predicates to the InvitationUpdate builder.
+func (iu *InvitationUpdate) Where(ps ...predicate.Invitation) *InvitationUpdate {
+	iu.mutation.Where(ps...)
+	return iu
+}
+
+// SetName sets the "name" field.
+func (iu *InvitationUpdate) SetName(s string) *InvitationUpdate {
+	iu.mutation.SetName(s)
+	return iu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableName(s *string) *InvitationUpdate {
+	if s != nil {
+		iu.SetName(*s)
+	}
+	return iu
+}
+
+// ClearName clears the value of the "name" field.
+func (iu *InvitationUpdate) ClearName() *InvitationUpdate {
+	iu.mutation.ClearName()
+	return iu
+}
+
+// SetSurname sets the "surname" field.
+func (iu *InvitationUpdate) SetSurname(s string) *InvitationUpdate {
+	iu.mutation.SetSurname(s)
+	return iu
+}
+
+// SetNillableSurname sets the "surname" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableSurname(s *string) *InvitationUpdate {
+	if s != nil {
+		iu.SetSurname(*s)
+	}
+	return iu
+}
+
+// ClearSurname clears the value of the "surname" field.
+func (iu *InvitationUpdate) ClearSurname() *InvitationUpdate {
+	iu.mutation.ClearSurname()
+	return iu
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (iu *InvitationUpdate) SetTokenHash(s string) *InvitationUpdate {
+	iu.mutation.SetTokenHash(s)
+	return iu
+}
+
+// SetNillableTokenHash sets the "token_hash" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableTokenHash(s *string) *InvitationUpdate {
+	if s != nil {
+		iu.SetTokenHash(*s)
+	}
+	return iu
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (iu *InvitationUpdate) SetExpiresAt(t time.Time) *InvitationUpdate {
+	iu.mutation.SetExpiresAt(t)
+	return iu
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableExpiresAt(t *time.Time) *InvitationUpdate {
+	if t != nil {
+		iu.SetExpiresAt(*t)
+	}
+	return iu
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (iu *InvitationUpdate) SetAcceptedAt(t time.Time) *InvitationUpdate {
+	iu.mutation.SetAcceptedAt(t)
+	return iu
+}
+
+// SetNillableAcceptedAt sets the "accepted_at" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableAcceptedAt(t *time.Time) *InvitationUpdate {
+	if t != nil {
+		iu.SetAcceptedAt(*t)
+	}
+	return iu
+}
+
+// ClearAcceptedAt clears the value of the "accepted_at" field.
+func (iu *InvitationUpdate) ClearAcceptedAt() *InvitationUpdate {
+	iu.mutation.ClearAcceptedAt()
+	return iu
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (iu *InvitationUpdate) SetRevokedAt(t time.Time) *InvitationUpdate {
+	iu.mutation.SetRevokedAt(t)
+	return iu
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableRevokedAt(t *time.Time) *InvitationUpdate {
+	if t != nil {
+		iu.SetRevokedAt(*t)
+	}
+	return iu
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (iu *InvitationUpdate) ClearRevokedAt() *InvitationUpdate {
+	iu.mutation.ClearRevokedAt()
+	return iu
+}
+
+// Mutation returns the InvitationMutation object of the builder.
+func (iu *InvitationUpdate) Mutation() *InvitationMutation {
+	return iu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (iu *InvitationUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, iu.sqlSave, iu.mutation, iu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iu *InvitationUpdate) SaveX(ctx context.Context) int {
+	affected, err := iu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (iu *InvitationUpdate) Exec(ctx context.Context) error {
+	_, err := iu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iu *InvitationUpdate) ExecX(ctx context.Context) {
+	if err := iu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (iu *InvitationUpdate) check() error {
+	if v, ok := iu.mutation.TokenHash(); ok {
+		if err := invitation.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "Invitation.token_hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (iu *InvitationUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := iu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(invitation.Table, invitation.Columns, sqlgraph.NewFieldSpec(invitation.FieldID, field.TypeString))
+	if ps := iu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if iu.mutation.EmailIndexCleared() {
+		_spec.ClearField(invitation.FieldEmailIndex, field.TypeString)
+	}
+	if value, ok := iu.mutation.Name(); ok {
+		_spec.SetField(invitation.FieldName, field.TypeString, value)
+	}
+	if iu.mutation.NameCleared() {
+		_spec.ClearField(invitation.FieldName, field.TypeString)
+	}
+	if value, ok := iu.mutation.Surname(); ok {
+		_spec.SetField(invitation.FieldSurname, field.TypeString, value)
+	}
+	if iu.mutation.SurnameCleared() {
+		_spec.ClearField(invitation.FieldSurname, field.TypeString)
+	}
+	if value, ok := iu.mutation.TokenHash(); ok {
+		_spec.SetField(invitation.FieldTokenHash, field.TypeString, value)
+	}
+	if value, ok := iu.mutation.ExpiresAt(); ok {
+		_spec.SetField(invitation.FieldExpiresAt, field.TypeTime, value)
+	}
+	if value, ok := iu.mutation.AcceptedAt(); ok {
+		_spec.SetField(invitation.FieldAcceptedAt, field.TypeTime, value)
+	}
+	if iu.mutation.AcceptedAtCleared() {
+		_spec.ClearField(invitation.FieldAcceptedAt, field.TypeTime)
+	}
+	if value, ok := iu.mutation.RevokedAt(); ok {
+		_spec.SetField(invitation.FieldRevokedAt, field.TypeTime, value)
+	}
+	if iu.mutation.RevokedAtCleared() {
+		_spec.ClearField(invitation.FieldRevokedAt, field.TypeTime)
+	}
+	if iu.mutation.OrganizationIDCleared() {
+		_spec.ClearField(invitation.FieldOrganizationID, field.TypeString)
+	}
+	if iu.mutation.GroupIDCleared() {
+		_spec.ClearField(invitation.FieldGroupID, field.TypeString)
+	}
+	if iu.mutation.InvitedByCleared() {
+		_spec.ClearField(invitation.FieldInvitedBy, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, iu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{invitation.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	iu.mutation.done = true
+	return n, nil
+}
+
+// InvitationUpdateOne is the builder for updating a single Invitation entity.
+type InvitationUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *InvitationMutation
+}
+
+// SetName sets the "name" field.
+func (iuo *InvitationUpdateOne) SetName(s string) *InvitationUpdateOne {
+	iuo.mutation.SetName(s)
+	return iuo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableName(s *string) *InvitationUpdateOne {
+	if s != nil {
+		iuo.SetName(*s)
+	}
+	return iuo
+}
+
+// ClearName clears the value of the "name" field.
+func (iuo *InvitationUpdateOne) ClearName() *InvitationUpdateOne {
+	iuo.mutation.ClearName()
+	return iuo
+}
+
+// SetSurname sets the "surname" field.
+func (iuo *InvitationUpdateOne) SetSurname(s string) *InvitationUpdateOne {
+	iuo.mutation.SetSurname(s)
+	return iuo
+}
+
+// SetNillableSurname sets the "surname" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableSurname(s *string) *InvitationUpdateOne {
+	if s != nil {
+		iuo.SetSurname(*s)
+	}
+	return iuo
+}
+
+// ClearSurname clears the value of the "surname" field.
+func (iuo *InvitationUpdateOne) ClearSurname() *InvitationUpdateOne {
+	iuo.mutation.ClearSurname()
+	return iuo
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (iuo *InvitationUpdateOne) SetTokenHash(s string) *InvitationUpdateOne {
+	iuo.mutation.SetTokenHash(s)
+	return iuo
+}
+
+// SetNillableTokenHash sets the "token_hash" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableTokenHash(s *string) *InvitationUpdateOne {
+	if s != nil {
+		iuo.SetTokenHash(*s)
+	}
+	return iuo
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (iuo *InvitationUpdateOne) SetExpiresAt(t time.Time) *InvitationUpdateOne {
+	iuo.mutation.SetExpiresAt(t)
+	return iuo
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableExpiresAt(t *time.Time) *InvitationUpdateOne {
+	if t != nil {
+		iuo.SetExpiresAt(*t)
+	}
+	return iuo
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (iuo *InvitationUpdateOne) SetAcceptedAt(t time.Time) *InvitationUpdateOne {
+	iuo.mutation.SetAcceptedAt(t)
+	return iuo
+}
+
+// SetNillableAcceptedAt sets the "accepted_at" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableAcceptedAt(t *time.Time) *InvitationUpdateOne {
+	if t != nil {
+		iuo.SetAcceptedAt(*t)
+	}
+	return iuo
+}
+
+// ClearAcceptedAt clears the value of the "accepted_at" field.
+func (iuo *InvitationUpdateOne) ClearAcceptedAt() *InvitationUpdateOne {
+	iuo.mutation.ClearAcceptedAt()
+	return iuo
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (iuo *InvitationUpdateOne) SetRevokedAt(t time.Time) *InvitationUpdateOne {
+	iuo.mutation.SetRevokedAt(t)
+	return iuo
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableRevokedAt(t *time.Time) *InvitationUpdateOne {
+	if t != nil {
+		iuo.SetRevokedAt(*t)
+	}
+	return iuo
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (iuo *InvitationUpdateOne) ClearRevokedAt() *InvitationUpdateOne {
+	iuo.mutation.ClearRevokedAt()
+	return iuo
+}
+
+// Mutation returns the InvitationMutation object of the builder.
+func (iuo *InvitationUpdateOne) Mutation() *InvitationMutation {
+	return iuo.mutation
+}
+
+// Where appends a list predicates to the InvitationUpdate builder.
+func (iuo *InvitationUpdateOne) Where(ps ...predicate.Invitation) *InvitationUpdateOne {
+	iuo.mutation.Where(ps...)
+	return iuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (iuo *InvitationUpdateOne) Select(field string, fields ...string) *InvitationUpdateOne {
+	iuo.fields = append([]string{field}, fields...)
+	return iuo
+}
+
+// Save executes the query and returns the updated Invitation entity.
+func (iuo *InvitationUpdateOne) Save(ctx context.Context) (*Invitation, error) {
+	return withHooks(ctx, iuo.sqlSave, iuo.mutation, iuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iuo *InvitationUpdateOne) SaveX(ctx context.Context) *Invitation {
+	node, err := iuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (iuo *InvitationUpdateOne) Exec(ctx context.Context) error {
+	_, err := iuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iuo *InvitationUpdateOne) ExecX(ctx context.Context) {
+	if err := iuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (iuo *InvitationUpdateOne) check() error {
+	if v, ok := iuo.mutation.TokenHash(); ok {
+		if err := invitation.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "Invitation.token_hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (iuo *InvitationUpdateOne) sqlSave(ctx context.Context) (_node *Invitation, err error) {
+	if err := iuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(invitation.Table, invitation.Columns, sqlgraph.NewFieldSpec(invitation.FieldID, field.TypeString))
+	id, ok := iuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Invitation.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := iuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, invitation.FieldID)
+		for _, f := range fields {
+			if !invitation.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != invitation.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := iuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if iuo.mutation.EmailIndexCleared() {
+		_spec.ClearField(invitation.FieldEmailIndex, field.TypeString)
+	}
+	if value, ok := iuo.mutation.Name(); ok {
+		_spec.SetField(invitation.FieldName, field.TypeString, value)
+	}
+	if iuo.mutation.NameCleared() {
+		_spec.ClearField(invitation.FieldName, field.TypeString)
+	}
+	if value, ok := iuo.mutation.Surname(); ok {
+		_spec.SetField(invitation.FieldSurname, field.TypeString, value)
+	}
+	if iuo.mutation.SurnameCleared() {
+		_spec.ClearField(invitation.FieldSurname, field.TypeString)
+	}
+	if value, ok := iuo.mutation.TokenHash(); ok {
+		_spec.SetField(invitation.FieldTokenHash, field.TypeString, value)
+	}
+	if value, ok := iuo.mutation.ExpiresAt(); ok {
+		_spec.SetField(invitation.FieldExpiresAt, field.TypeTime, value)
+	}
+	if value, ok := iuo.mutation.AcceptedAt(); ok {
+		_spec.SetField(invitation.FieldAcceptedAt, field.TypeTime, value)
+	}
+	if iuo.mutation.AcceptedAtCleared() {
+		_spec.ClearField(invitation.FieldAcceptedAt, field.TypeTime)
+	}
+	if value, ok := iuo.mutation.RevokedAt(); ok {
+		_spec.SetField(invitation.FieldRevokedAt, field.TypeTime, value)
+	}
+	if iuo.mutation.RevokedAtCleared() {
+		_spec.ClearField(invitation.FieldRevokedAt, field.TypeTime)
+	}
+	if iuo.mutation.OrganizationIDCleared() {
+		_spec.ClearField(invitation.FieldOrganizationID, field.TypeString)
+	}
+	if iuo.mutation.GroupIDCleared() {
+		_spec.ClearField(invitation.FieldGroupID, field.TypeString)
+	}
+	if iuo.mutation.InvitedByCleared() {
+		_spec.ClearField(invitation.FieldInvitedBy, field.TypeString)
+	}
+	_node = &Invitation{config: iuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, iuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{invitation.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	iuo.mutation.done = true
+	return _node, nil
+}
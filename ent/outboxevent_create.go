@@ -0,0 +1,927 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/outboxevent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// OutboxEventCreate is the builder for creating a OutboxEvent entity.
+type OutboxEventCreate struct {
+	config
+	mutation *OutboxEventMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetEventID sets the "event_id" field.
+func (oec *OutboxEventCreate) SetEventID(s string) *OutboxEventCreate {
+	oec.mutation.SetEventID(s)
+	return oec
+}
+
+// SetType sets the "type" field.
+func (oec *OutboxEventCreate) SetType(dt domain.EventType) *OutboxEventCreate {
+	oec.mutation.SetType(dt)
+	return oec
+}
+
+// SetAggregateID sets the "aggregate_id" field.
+func (oec *OutboxEventCreate) SetAggregateID(s string) *OutboxEventCreate {
+	oec.mutation.SetAggregateID(s)
+	return oec
+}
+
+// SetOccurredAt sets the "occurred_at" field.
+func (oec *OutboxEventCreate) SetOccurredAt(t time.Time) *OutboxEventCreate {
+	oec.mutation.SetOccurredAt(t)
+	return oec
+}
+
+// SetData sets the "data" field.
+func (oec *OutboxEventCreate) SetData(jm json.RawMessage) *OutboxEventCreate {
+	oec.mutation.SetData(jm)
+	return oec
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (oec *OutboxEventCreate) SetPublishedAt(t time.Time) *OutboxEventCreate {
+	oec.mutation.SetPublishedAt(t)
+	return oec
+}
+
+// SetNillablePublishedAt sets the "published_at" field if the given value is not nil.
+func (oec *OutboxEventCreate) SetNillablePublishedAt(t *time.Time) *OutboxEventCreate {
+	if t != nil {
+		oec.SetPublishedAt(*t)
+	}
+	return oec
+}
+
+// SetAttempts sets the "attempts" field.
+func (oec *OutboxEventCreate) SetAttempts(i int) *OutboxEventCreate {
+	oec.mutation.SetAttempts(i)
+	return oec
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (oec *OutboxEventCreate) SetNillableAttempts(i *int) *OutboxEventCreate {
+	if i != nil {
+		oec.SetAttempts(*i)
+	}
+	return oec
+}
+
+// SetLastError sets the "last_error" field.
+func (oec *OutboxEventCreate) SetLastError(s string) *OutboxEventCreate {
+	oec.mutation.SetLastError(s)
+	return oec
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (oec *OutboxEventCreate) SetNillableLastError(s *string) *OutboxEventCreate {
+	if s != nil {
+		oec.SetLastError(*s)
+	}
+	return oec
+}
+
+// SetDeadAt sets the "dead_at" field.
+func (oec *OutboxEventCreate) SetDeadAt(t time.Time) *OutboxEventCreate {
+	oec.mutation.SetDeadAt(t)
+	return oec
+}
+
+// SetNillableDeadAt sets the "dead_at" field if the given value is not nil.
+func (oec *OutboxEventCreate) SetNillableDeadAt(t *time.Time) *OutboxEventCreate {
+	if t != nil {
+		oec.SetDeadAt(*t)
+	}
+	return oec
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (oec *OutboxEventCreate) SetOrganizationID(s string) *OutboxEventCreate {
+	oec.mutation.SetOrganizationID(s)
+	return oec
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (oec *OutboxEventCreate) SetNillableOrganizationID(s *string) *OutboxEventCreate {
+	if s != nil {
+		oec.SetOrganizationID(*s)
+	}
+	return oec
+}
+
+// SetID sets the "id" field.
+func (oec *OutboxEventCreate) SetID(i int64) *OutboxEventCreate {
+	oec.mutation.SetID(i)
+	return oec
+}
+
+// Mutation returns the OutboxEventMutation object of the builder.
+func (oec *OutboxEventCreate) Mutation() *OutboxEventMutation {
+	return oec.mutation
+}
+
+// Save creates the OutboxEvent in the database.
+func (oec *OutboxEventCreate) Save(ctx context.Context) (*OutboxEvent, error) {
+	oec.defaults()
+	return withHooks(ctx, oec.sqlSave, oec.mutation, oec.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (oec *OutboxEventCreate) SaveX(ctx context.Context) *OutboxEvent {
+	v, err := oec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (oec *OutboxEventCreate) Exec(ctx context.Context) error {
+	_, err := oec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (oec *OutboxEventCreate) ExecX(ctx context.Context) {
+	if err := oec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (oec *OutboxEventCreate) defaults() {
+	if _, ok := oec.mutation.Attempts(); !ok {
+		v := outboxevent.DefaultAttempts
+		oec.mutation.SetAttempts(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (oec *OutboxEventCreate) check() error {
+	if _, ok := oec.mutation.EventID(); !ok {
+		return &ValidationError{Name: "event_id", err: errors.New(`ent: missing required field "OutboxEvent.event_id"`)}
+	}
+	if v, ok := oec.mutation.EventID(); ok {
+		if err := outboxevent.EventIDValidator(v); err != nil {
+			return &ValidationError{Name: "event_id", err: fmt.Errorf(`ent: validator failed for field "OutboxEvent.event_id": %w`, err)}
+		}
+	}
+	if _, ok := oec.mutation.GetType(); !ok {
+		return &ValidationError{Name: "type", err: errors.New(`ent: missing required field "OutboxEvent.type"`)}
+	}
+	if v, ok := oec.mutation.GetType(); ok {
+		if err := outboxevent.TypeValidator(string(v)); err != nil {
+			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "OutboxEvent.type": %w`, err)}
+		}
+	}
+	if _, ok := oec.mutation.AggregateID(); !ok {
+		return &ValidationError{Name: "aggregate_id", err: errors.New(`ent: missing required field "OutboxEvent.aggregate_id"`)}
+	}
+	if v, ok := oec.mutation.AggregateID(); ok {
+		if err := outboxevent.AggregateIDValidator(v); err != nil {
+			return &ValidationError{Name: "aggregate_id", err: fmt.Errorf(`ent: validator failed for field "OutboxEvent.aggregate_id": %w`, err)}
+		}
+	}
+	if _, ok := oec.mutation.OccurredAt(); !ok {
+		return &ValidationError{Name: "occurred_at", err: errors.New(`ent: missing required field "OutboxEvent.occurred_at"`)}
+	}
+	if _, ok := oec.mutation.Data(); !ok {
+		return &ValidationError{Name: "data", err: errors.New(`ent: missing required field "OutboxEvent.data"`)}
+	}
+	if _, ok := oec.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New(`ent: missing required field "OutboxEvent.attempts"`)}
+	}
+	return nil
+}
+
+func (oec *OutboxEventCreate) sqlSave(ctx context.Context) (*OutboxEvent, error) {
+	if err := oec.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := oec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, oec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = int64(id)
+	}
+	oec.mutation.id = &_node.ID
+	oec.mutation.done = true
+	return _node, nil
+}
+
+func (oec *OutboxEventCreate) createSpec() (*OutboxEvent, *sqlgraph.CreateSpec) {
+	var (
+		_node = &OutboxEvent{config: oec.config}
+		_spec = sqlgraph.NewCreateSpec(outboxevent.Table, sqlgraph.NewFieldSpec(outboxevent.FieldID, field.TypeInt64))
+	)
+	_spec.OnConflict = oec.conflict
+	if id, ok := oec.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := oec.mutation.EventID(); ok {
+		_spec.SetField(outboxevent.FieldEventID, field.TypeString, value)
+		_node.EventID = value
+	}
+	if value, ok := oec.mutation.GetType(); ok {
+		_spec.SetField(outboxevent.FieldType, field.TypeString, value)
+		_node.Type = value
+	}
+	if value, ok := oec.mutation.AggregateID(); ok {
+		_spec.SetField(outboxevent.FieldAggregateID, field.TypeString, value)
+		_node.AggregateID = value
+	}
+	if value, ok := oec.mutation.OccurredAt(); ok {
+		_spec.SetField(outboxevent.FieldOccurredAt, field.TypeTime, value)
+		_node.OccurredAt = value
+	}
+	if value, ok := oec.mutation.Data(); ok {
+		_spec.SetField(outboxevent.FieldData, field.TypeJSON, value)
+		_node.Data = value
+	}
+	if value, ok := oec.mutation.PublishedAt(); ok {
+		_spec.SetField(outboxevent.FieldPublishedAt, field.TypeTime, value)
+		_node.PublishedAt = &value
+	}
+	if value, ok := oec.mutation.Attempts(); ok {
+		_spec.SetField(outboxevent.FieldAttempts, field.TypeInt, value)
+		_node.Attempts = value
+	}
+	if value, ok := oec.mutation.LastError(); ok {
+		_spec.SetField(outboxevent.FieldLastError, field.TypeString, value)
+		_node.LastError = value
+	}
+	if value, ok := oec.mutation.DeadAt(); ok {
+		_spec.SetField(outboxevent.FieldDeadAt, field.TypeTime, value)
+		_node.DeadAt = &value
+	}
+	if value, ok := oec.mutation.OrganizationID(); ok {
+		_spec.SetField(outboxevent.FieldOrganizationID, field.TypeString, value)
+		_node.OrganizationID = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.OutboxEvent.Create().
+//		SetEventID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.OutboxEventUpsert) {
+//			SetEventID(v+v).
+//		}).
+//		Exec(ctx)
+func (oec *OutboxEventCreate) OnConflict(opts ...sql.ConflictOption) *OutboxEventUpsertOne {
+	oec.conflict = opts
+	return &OutboxEventUpsertOne{
+		create: oec,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.OutboxEvent.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (oec *OutboxEventCreate) OnConflictColumns(columns ...string) *OutboxEventUpsertOne {
+	oec.conflict = append(oec.conflict, sql.ConflictColumns(columns...))
+	return &OutboxEventUpsertOne{
+		create: oec,
+	}
+}
+
+type (
+	// OutboxEventUpsertOne is the builder for "upsert"-ing
+	//  one OutboxEvent node.
+	OutboxEventUpsertOne struct {
+		create *OutboxEventCreate
+	}
+
+	// OutboxEventUpsert is the "OnConflict" setter.
+	OutboxEventUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetData sets the "data" field.
+func (u *OutboxEventUpsert) SetData(v json.RawMessage) *OutboxEventUpsert {
+	u.Set(outboxevent.FieldData, v)
+	return u
+}
+
+// UpdateData sets the "data" field to the value that was provided on create.
+func (u *OutboxEventUpsert) UpdateData() *OutboxEventUpsert {
+	u.SetExcluded(outboxevent.FieldData)
+	return u
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (u *OutboxEventUpsert) SetPublishedAt(v time.Time) *OutboxEventUpsert {
+	u.Set(outboxevent.FieldPublishedAt, v)
+	return u
+}
+
+// UpdatePublishedAt sets the "published_at" field to the value that was provided on create.
+func (u *OutboxEventUpsert) UpdatePublishedAt() *OutboxEventUpsert {
+	u.SetExcluded(outboxevent.FieldPublishedAt)
+	return u
+}
+
+// ClearPublishedAt clears the value of the "published_at" field.
+func (u *OutboxEventUpsert) ClearPublishedAt() *OutboxEventUpsert {
+	u.SetNull(outboxevent.FieldPublishedAt)
+	return u
+}
+
+// SetAttempts sets the "attempts" field.
+func (u *OutboxEventUpsert) SetAttempts(v int) *OutboxEventUpsert {
+	u.Set(outboxevent.FieldAttempts, v)
+	return u
+}
+
+// UpdateAttempts sets the "attempts" field to the value that was provided on create.
+func (u *OutboxEventUpsert) UpdateAttempts() *OutboxEventUpsert {
+	u.SetExcluded(outboxevent.FieldAttempts)
+	return u
+}
+
+// AddAttempts adds v to the "attempts" field.
+func (u *OutboxEventUpsert) AddAttempts(v int) *OutboxEventUpsert {
+	u.Add(outboxevent.FieldAttempts, v)
+	return u
+}
+
+// SetLastError sets the "last_error" field.
+func (u *OutboxEventUpsert) SetLastError(v string) *OutboxEventUpsert {
+	u.Set(outboxevent.FieldLastError, v)
+	return u
+}
+
+// UpdateLastError sets the "last_error" field to the value that was provided on create.
+func (u *OutboxEventUpsert) UpdateLastError() *OutboxEventUpsert {
+	u.SetExcluded(outboxevent.FieldLastError)
+	return u
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (u *OutboxEventUpsert) ClearLastError() *OutboxEventUpsert {
+	u.SetNull(outboxevent.FieldLastError)
+	return u
+}
+
+// SetDeadAt sets the "dead_at" field.
+func (u *OutboxEventUpsert) SetDeadAt(v time.Time) *OutboxEventUpsert {
+	u.Set(outboxevent.FieldDeadAt, v)
+	return u
+}
+
+// UpdateDeadAt sets the "dead_at" field to the value that was provided on create.
+func (u *OutboxEventUpsert) UpdateDeadAt() *OutboxEventUpsert {
+	u.SetExcluded(outboxevent.FieldDeadAt)
+	return u
+}
+
+// ClearDeadAt clears the value of the "dead_at" field.
+func (u *OutboxEventUpsert) ClearDeadAt() *OutboxEventUpsert {
+	u.SetNull(outboxevent.FieldDeadAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.OutboxEvent.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(outboxevent.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *OutboxEventUpsertOne) UpdateNewValues() *OutboxEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(outboxevent.FieldID)
+		}
+		if _, exists := u.create.mutation.EventID(); exists {
+			s.SetIgnore(outboxevent.FieldEventID)
+		}
+		if _, exists := u.create.mutation.GetType(); exists {
+			s.SetIgnore(outboxevent.FieldType)
+		}
+		if _, exists := u.create.mutation.AggregateID(); exists {
+			s.SetIgnore(outboxevent.FieldAggregateID)
+		}
+		if _, exists := u.create.mutation.OccurredAt(); exists {
+			s.SetIgnore(outboxevent.FieldOccurredAt)
+		}
+		if _, exists := u.create.mutation.OrganizationID(); exists {
+			s.SetIgnore(outboxevent.FieldOrganizationID)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.OutboxEvent.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *OutboxEventUpsertOne) Ignore() *OutboxEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *OutboxEventUpsertOne) DoNothing() *OutboxEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the OutboxEventCreate.OnConflict
+// documentation for more info.
+func (u *OutboxEventUpsertOne) Update(set func(*OutboxEventUpsert)) *OutboxEventUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&OutboxEventUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetData sets the "data" field.
+func (u *OutboxEventUpsertOne) SetData(v json.RawMessage) *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.SetData(v)
+	})
+}
+
+// UpdateData sets the "data" field to the value that was provided on create.
+func (u *OutboxEventUpsertOne) UpdateData() *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.UpdateData()
+	})
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (u *OutboxEventUpsertOne) SetPublishedAt(v time.Time) *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.SetPublishedAt(v)
+	})
+}
+
+// UpdatePublishedAt sets the "published_at" field to the value that was provided on create.
+func (u *OutboxEventUpsertOne) UpdatePublishedAt() *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.UpdatePublishedAt()
+	})
+}
+
+// ClearPublishedAt clears the value of the "published_at" field.
+func (u *OutboxEventUpsertOne) ClearPublishedAt() *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.ClearPublishedAt()
+	})
+}
+
+// SetAttempts sets the "attempts" field.
+func (u *OutboxEventUpsertOne) SetAttempts(v int) *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.SetAttempts(v)
+	})
+}
+
+// AddAttempts adds v to the "attempts" field.
+func (u *OutboxEventUpsertOne) AddAttempts(v int) *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.AddAttempts(v)
+	})
+}
+
+// UpdateAttempts sets the "attempts" field to the value that was provided on create.
+func (u *OutboxEventUpsertOne) UpdateAttempts() *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.UpdateAttempts()
+	})
+}
+
+// SetLastError sets the "last_error" field.
+func (u *OutboxEventUpsertOne) SetLastError(v string) *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.SetLastError(v)
+	})
+}
+
+// UpdateLastError sets the "last_error" field to the value that was provided on create.
+func (u *OutboxEventUpsertOne) UpdateLastError() *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.UpdateLastError()
+	})
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (u *OutboxEventUpsertOne) ClearLastError() *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.ClearLastError()
+	})
+}
+
+// SetDeadAt sets the "dead_at" field.
+func (u *OutboxEventUpsertOne) SetDeadAt(v time.Time) *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.SetDeadAt(v)
+	})
+}
+
+// UpdateDeadAt sets the "dead_at" field to the value that was provided on create.
+func (u *OutboxEventUpsertOne) UpdateDeadAt() *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.UpdateDeadAt()
+	})
+}
+
+// ClearDeadAt clears the value of the "dead_at" field.
+func (u *OutboxEventUpsertOne) ClearDeadAt() *OutboxEventUpsertOne {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.ClearDeadAt()
+	})
+}
+
+// Exec executes the query.
+func (u *OutboxEventUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for OutboxEventCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *OutboxEventUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *OutboxEventUpsertOne) ID(ctx context.Context) (id int64, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *OutboxEventUpsertOne) IDX(ctx context.Context) int64 {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// OutboxEventCreateBulk is the builder for creating many OutboxEvent entities in bulk.
+type OutboxEventCreateBulk struct {
+	config
+	err      error
+	builders []*OutboxEventCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the OutboxEvent entities in the database.
+func (oecb *OutboxEventCreateBulk) Save(ctx context.Context) ([]*OutboxEvent, error) {
+	if oecb.err != nil {
+		return nil, oecb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(oecb.builders))
+	nodes := make([]*OutboxEvent, len(oecb.builders))
+	mutators := make([]Mutator, len(oecb.builders))
+	for i := range oecb.builders {
+		func(i int, root context.Context) {
+			builder := oecb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*OutboxEventMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, oecb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = oecb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, oecb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, oecb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (oecb *OutboxEventCreateBulk) SaveX(ctx context.Context) []*OutboxEvent {
+	v, err := oecb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (oecb *OutboxEventCreateBulk) Exec(ctx context.Context) error {
+	_, err := oecb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (oecb *OutboxEventCreateBulk) ExecX(ctx context.Context) {
+	if err := oecb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.OutboxEvent.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.OutboxEventUpsert) {
+//			SetEventID(v+v).
+//		}).
+//		Exec(ctx)
+func (oecb *OutboxEventCreateBulk) OnConflict(opts ...sql.ConflictOption) *OutboxEventUpsertBulk {
+	oecb.conflict = opts
+	return &OutboxEventUpsertBulk{
+		create: oecb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.OutboxEvent.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (oecb *OutboxEventCreateBulk) OnConflictColumns(columns ...string) *OutboxEventUpsertBulk {
+	oecb.conflict = append(oecb.conflict, sql.ConflictColumns(columns...))
+	return &OutboxEventUpsertBulk{
+		create: oecb,
+	}
+}
+
+// OutboxEventUpsertBulk is the builder for "upsert"-ing
+// a bulk of OutboxEvent nodes.
+type OutboxEventUpsertBulk struct {
+	create *OutboxEventCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.OutboxEvent.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(outboxevent.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *OutboxEventUpsertBulk) UpdateNewValues() *OutboxEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(outboxevent.FieldID)
+			}
+			if _, exists := b.mutation.EventID(); exists {
+				s.SetIgnore(outboxevent.FieldEventID)
+			}
+			if _, exists := b.mutation.GetType(); exists {
+				s.SetIgnore(outboxevent.FieldType)
+			}
+			if _, exists := b.mutation.AggregateID(); exists {
+				s.SetIgnore(outboxevent.FieldAggregateID)
+			}
+			if _, exists := b.mutation.OccurredAt(); exists {
+				s.SetIgnore(outboxevent.FieldOccurredAt)
+			}
+			if _, exists := b.mutation.OrganizationID(); exists {
+				s.SetIgnore(outboxevent.FieldOrganizationID)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.OutboxEvent.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *OutboxEventUpsertBulk) Ignore() *OutboxEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *OutboxEventUpsertBulk) DoNothing() *OutboxEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the OutboxEventCreateBulk.OnConflict
+// documentation for more info.
+func (u *OutboxEventUpsertBulk) Update(set func(*OutboxEventUpsert)) *OutboxEventUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&OutboxEventUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetData sets the "data" field.
+func (u *OutboxEventUpsertBulk) SetData(v json.RawMessage) *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.SetData(v)
+	})
+}
+
+// UpdateData sets the "data" field to the value that was provided on create.
+func (u *OutboxEventUpsertBulk) UpdateData() *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.UpdateData()
+	})
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (u *OutboxEventUpsertBulk) SetPublishedAt(v time.Time) *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.SetPublishedAt(v)
+	})
+}
+
+// UpdatePublishedAt sets the "published_at" field to the value that was provided on create.
+func (u *OutboxEventUpsertBulk) UpdatePublishedAt() *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.UpdatePublishedAt()
+	})
+}
+
+// ClearPublishedAt clears the value of the "published_at" field.
+func (u *OutboxEventUpsertBulk) ClearPublishedAt() *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.ClearPublishedAt()
+	})
+}
+
+// SetAttempts sets the "attempts" field.
+func (u *OutboxEventUpsertBulk) SetAttempts(v int) *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.SetAttempts(v)
+	})
+}
+
+// AddAttempts adds v to the "attempts" field.
+func (u *OutboxEventUpsertBulk) AddAttempts(v int) *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.AddAttempts(v)
+	})
+}
+
+// UpdateAttempts sets the "attempts" field to the value that was provided on create.
+func (u *OutboxEventUpsertBulk) UpdateAttempts() *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.UpdateAttempts()
+	})
+}
+
+// SetLastError sets the "last_error" field.
+func (u *OutboxEventUpsertBulk) SetLastError(v string) *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.SetLastError(v)
+	})
+}
+
+// UpdateLastError sets the "last_error" field to the value that was provided on create.
+func (u *OutboxEventUpsertBulk) UpdateLastError() *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.UpdateLastError()
+	})
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (u *OutboxEventUpsertBulk) ClearLastError() *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.ClearLastError()
+	})
+}
+
+// SetDeadAt sets the "dead_at" field.
+func (u *OutboxEventUpsertBulk) SetDeadAt(v time.Time) *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.SetDeadAt(v)
+	})
+}
+
+// UpdateDeadAt sets the "dead_at" field to the value that was provided on create.
+func (u *OutboxEventUpsertBulk) UpdateDeadAt() *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.UpdateDeadAt()
+	})
+}
+
+// ClearDeadAt clears the value of the "dead_at" field.
+func (u *OutboxEventUpsertBulk) ClearDeadAt() *OutboxEventUpsertBulk {
+	return u.Update(func(s *OutboxEventUpsert) {
+		s.ClearDeadAt()
+	})
+}
+
+// Exec executes the query.
+func (u *OutboxEventUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the OutboxEventCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for OutboxEventCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *OutboxEventUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
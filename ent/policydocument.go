@@ -0,0 +1,151 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Beriw98/user-management/ent/policydocument"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// PolicyDocument is the model entity for the PolicyDocument schema.
+type PolicyDocument struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID string `json:"id,omitempty"`
+	// Kind holds the value of the "kind" field.
+	Kind domain.PolicyKind `json:"kind,omitempty"`
+	// Version holds the value of the "version" field.
+	Version string `json:"version,omitempty"`
+	// URL holds the value of the "url" field.
+	URL string `json:"url,omitempty"`
+	// Mandatory holds the value of the "mandatory" field.
+	Mandatory bool `json:"mandatory,omitempty"`
+	// PublishedAt holds the value of the "published_at" field.
+	PublishedAt  time.Time `json:"published_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*PolicyDocument) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case policydocument.FieldMandatory:
+			values[i] = new(sql.NullBool)
+		case policydocument.FieldID, policydocument.FieldKind, policydocument.FieldVersion, policydocument.FieldURL:
+			values[i] = new(sql.NullString)
+		case policydocument.FieldPublishedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the PolicyDocument fields.
+func (pd *PolicyDocument) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case policydocument.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				pd.ID = value.String
+			}
+		case policydocument.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				pd.Kind = domain.PolicyKind(value.String)
+			}
+		case policydocument.FieldVersion:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				pd.Version = value.String
+			}
+		case policydocument.FieldURL:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field url", values[i])
+			} else if value.Valid {
+				pd.URL = value.String
+			}
+		case policydocument.FieldMandatory:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field mandatory", values[i])
+			} else if value.Valid {
+				pd.Mandatory = value.Bool
+			}
+		case policydocument.FieldPublishedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field published_at", values[i])
+			} else if value.Valid {
+				pd.PublishedAt = value.Time
+			}
+		default:
+			pd.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the PolicyDocument.
+// This includes values selected through modifiers, order, etc.
+func (pd *PolicyDocument) Value(name string) (ent.Value, error) {
+	return pd.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this PolicyDocument.
+// Note that you need to call PolicyDocument.Unwrap() before calling this method if this PolicyDocument
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (pd *PolicyDocument) Update() *PolicyDocumentUpdateOne {
+	return NewPolicyDocumentClient(pd.config).UpdateOne(pd)
+}
+
+// Unwrap unwraps the PolicyDocument entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (pd *PolicyDocument) Unwrap() *PolicyDocument {
+	_tx, ok := pd.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: PolicyDocument is not a transactional entity")
+	}
+	pd.config.driver = _tx.drv
+	return pd
+}
+
+// String implements the fmt.Stringer.
+func (pd *PolicyDocument) String() string {
+	var builder strings.Builder
+	builder.WriteString("PolicyDocument(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", pd.ID))
+	builder.WriteString("kind=")
+	builder.WriteString(fmt.Sprintf("%v", pd.Kind))
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(pd.Version)
+	builder.WriteString(", ")
+	builder.WriteString("url=")
+	builder.WriteString(pd.URL)
+	builder.WriteString(", ")
+	builder.WriteString("mandatory=")
+	builder.WriteString(fmt.Sprintf("%v", pd.Mandatory))
+	builder.WriteString(", ")
+	builder.WriteString("published_at=")
+	builder.WriteString(pd.PublishedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// PolicyDocuments is a parsable slice of PolicyDocument.
+type PolicyDocuments []*PolicyDocument
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package policydocument
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the policydocument type in the database.
+	Label = "policy_document"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldKind holds the string denoting the kind field in the database.
+	FieldKind = "kind"
+	// FieldVersion holds the string denoting the version field in the database.
+	FieldVersion = "version"
+	// FieldURL holds the string denoting the url field in the database.
+	FieldURL = "url"
+	// FieldMandatory holds the string denoting the mandatory field in the database.
+	FieldMandatory = "mandatory"
+	// FieldPublishedAt holds the string denoting the published_at field in the database.
+	FieldPublishedAt = "published_at"
+	// Table holds the table name of the policydocument in the database.
+	Table = "policy_documents"
+)
+
+// Columns holds all SQL columns for policydocument fields.
+var Columns = []string{
+	FieldID,
+	FieldKind,
+	FieldVersion,
+	FieldURL,
+	FieldMandatory,
+	FieldPublishedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	VersionValidator func(string) error
+	// DefaultPublishedAt holds the default value on creation for the "published_at" field.
+	DefaultPublishedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the PolicyDocument queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByKind orders the results by the kind field.
+func ByKind(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldKind, opts...).ToFunc()
+}
+
+// ByVersion orders the results by the version field.
+func ByVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVersion, opts...).ToFunc()
+}
+
+// ByURL orders the results by the url field.
+func ByURL(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldURL, opts...).ToFunc()
+}
+
+// ByMandatory orders the results by the mandatory field.
+func ByMandatory(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMandatory, opts...).ToFunc()
+}
+
+// ByPublishedAt orders the results by the published_at field.
+func ByPublishedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPublishedAt, opts...).ToFunc()
+}
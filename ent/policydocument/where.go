@@ -0,0 +1,371 @@
+// Code generated by ent, DO NOT EDIT.
+
+package policydocument
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Beriw98/user-management/ent/predicate"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldLTE(FieldID, id))
+}
+
+// IDEqualFold applies the EqualFold predicate on the ID field.
+func IDEqualFold(id string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEqualFold(FieldID, id))
+}
+
+// IDContainsFold applies the ContainsFold predicate on the ID field.
+func IDContainsFold(id string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldContainsFold(FieldID, id))
+}
+
+// Kind applies equality check predicate on the "kind" field. It's identical to KindEQ.
+func Kind(v domain.PolicyKind) predicate.PolicyDocument {
+	vc := string(v)
+	return predicate.PolicyDocument(sql.FieldEQ(FieldKind, vc))
+}
+
+// Version applies equality check predicate on the "version" field. It's identical to VersionEQ.
+func Version(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEQ(FieldVersion, v))
+}
+
+// URL applies equality check predicate on the "url" field. It's identical to URLEQ.
+func URL(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEQ(FieldURL, v))
+}
+
+// Mandatory applies equality check predicate on the "mandatory" field. It's identical to MandatoryEQ.
+func Mandatory(v bool) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEQ(FieldMandatory, v))
+}
+
+// PublishedAt applies equality check predicate on the "published_at" field. It's identical to PublishedAtEQ.
+func PublishedAt(v time.Time) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEQ(FieldPublishedAt, v))
+}
+
+// KindEQ applies the EQ predicate on the "kind" field.
+func KindEQ(v domain.PolicyKind) predicate.PolicyDocument {
+	vc := string(v)
+	return predicate.PolicyDocument(sql.FieldEQ(FieldKind, vc))
+}
+
+// KindNEQ applies the NEQ predicate on the "kind" field.
+func KindNEQ(v domain.PolicyKind) predicate.PolicyDocument {
+	vc := string(v)
+	return predicate.PolicyDocument(sql.FieldNEQ(FieldKind, vc))
+}
+
+// KindIn applies the In predicate on the "kind" field.
+func KindIn(vs ...domain.PolicyKind) predicate.PolicyDocument {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.PolicyDocument(sql.FieldIn(FieldKind, v...))
+}
+
+// KindNotIn applies the NotIn predicate on the "kind" field.
+func KindNotIn(vs ...domain.PolicyKind) predicate.PolicyDocument {
+	v := make([]any, len(vs))
+	for i := range v {
+		v[i] = string(vs[i])
+	}
+	return predicate.PolicyDocument(sql.FieldNotIn(FieldKind, v...))
+}
+
+// KindGT applies the GT predicate on the "kind" field.
+func KindGT(v domain.PolicyKind) predicate.PolicyDocument {
+	vc := string(v)
+	return predicate.PolicyDocument(sql.FieldGT(FieldKind, vc))
+}
+
+// KindGTE applies the GTE predicate on the "kind" field.
+func KindGTE(v domain.PolicyKind) predicate.PolicyDocument {
+	vc := string(v)
+	return predicate.PolicyDocument(sql.FieldGTE(FieldKind, vc))
+}
+
+// KindLT applies the LT predicate on the "kind" field.
+func KindLT(v domain.PolicyKind) predicate.PolicyDocument {
+	vc := string(v)
+	return predicate.PolicyDocument(sql.FieldLT(FieldKind, vc))
+}
+
+// KindLTE applies the LTE predicate on the "kind" field.
+func KindLTE(v domain.PolicyKind) predicate.PolicyDocument {
+	vc := string(v)
+	return predicate.PolicyDocument(sql.FieldLTE(FieldKind, vc))
+}
+
+// KindContains applies the Contains predicate on the "kind" field.
+func KindContains(v domain.PolicyKind) predicate.PolicyDocument {
+	vc := string(v)
+	return predicate.PolicyDocument(sql.FieldContains(FieldKind, vc))
+}
+
+// KindHasPrefix applies the HasPrefix predicate on the "kind" field.
+func KindHasPrefix(v domain.PolicyKind) predicate.PolicyDocument {
+	vc := string(v)
+	return predicate.PolicyDocument(sql.FieldHasPrefix(FieldKind, vc))
+}
+
+// KindHasSuffix applies the HasSuffix predicate on the "kind" field.
+func KindHasSuffix(v domain.PolicyKind) predicate.PolicyDocument {
+	vc := string(v)
+	return predicate.PolicyDocument(sql.FieldHasSuffix(FieldKind, vc))
+}
+
+// KindEqualFold applies the EqualFold predicate on the "kind" field.
+func KindEqualFold(v domain.PolicyKind) predicate.PolicyDocument {
+	vc := string(v)
+	return predicate.PolicyDocument(sql.FieldEqualFold(FieldKind, vc))
+}
+
+// KindContainsFold applies the ContainsFold predicate on the "kind" field.
+func KindContainsFold(v domain.PolicyKind) predicate.PolicyDocument {
+	vc := string(v)
+	return predicate.PolicyDocument(sql.FieldContainsFold(FieldKind, vc))
+}
+
+// VersionEQ applies the EQ predicate on the "version" field.
+func VersionEQ(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEQ(FieldVersion, v))
+}
+
+// VersionNEQ applies the NEQ predicate on the "version" field.
+func VersionNEQ(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldNEQ(FieldVersion, v))
+}
+
+// VersionIn applies the In predicate on the "version" field.
+func VersionIn(vs ...string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldIn(FieldVersion, vs...))
+}
+
+// VersionNotIn applies the NotIn predicate on the "version" field.
+func VersionNotIn(vs ...string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldNotIn(FieldVersion, vs...))
+}
+
+// VersionGT applies the GT predicate on the "version" field.
+func VersionGT(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldGT(FieldVersion, v))
+}
+
+// VersionGTE applies the GTE predicate on the "version" field.
+func VersionGTE(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldGTE(FieldVersion, v))
+}
+
+// VersionLT applies the LT predicate on the "version" field.
+func VersionLT(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldLT(FieldVersion, v))
+}
+
+// VersionLTE applies the LTE predicate on the "version" field.
+func VersionLTE(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldLTE(FieldVersion, v))
+}
+
+// VersionContains applies the Contains predicate on the "version" field.
+func VersionContains(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldContains(FieldVersion, v))
+}
+
+// VersionHasPrefix applies the HasPrefix predicate on the "version" field.
+func VersionHasPrefix(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldHasPrefix(FieldVersion, v))
+}
+
+// VersionHasSuffix applies the HasSuffix predicate on the "version" field.
+func VersionHasSuffix(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldHasSuffix(FieldVersion, v))
+}
+
+// VersionEqualFold applies the EqualFold predicate on the "version" field.
+func VersionEqualFold(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEqualFold(FieldVersion, v))
+}
+
+// VersionContainsFold applies the ContainsFold predicate on the "version" field.
+func VersionContainsFold(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldContainsFold(FieldVersion, v))
+}
+
+// URLEQ applies the EQ predicate on the "url" field.
+func URLEQ(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEQ(FieldURL, v))
+}
+
+// URLNEQ applies the NEQ predicate on the "url" field.
+func URLNEQ(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldNEQ(FieldURL, v))
+}
+
+// URLIn applies the In predicate on the "url" field.
+func URLIn(vs ...string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldIn(FieldURL, vs...))
+}
+
+// URLNotIn applies the NotIn predicate on the "url" field.
+func URLNotIn(vs ...string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldNotIn(FieldURL, vs...))
+}
+
+// URLGT applies the GT predicate on the "url" field.
+func URLGT(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldGT(FieldURL, v))
+}
+
+// URLGTE applies the GTE predicate on the "url" field.
+func URLGTE(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldGTE(FieldURL, v))
+}
+
+// URLLT applies the LT predicate on the "url" field.
+func URLLT(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldLT(FieldURL, v))
+}
+
+// URLLTE applies the LTE predicate on the "url" field.
+func URLLTE(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldLTE(FieldURL, v))
+}
+
+// URLContains applies the Contains predicate on the "url" field.
+func URLContains(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldContains(FieldURL, v))
+}
+
+// URLHasPrefix applies the HasPrefix predicate on the "url" field.
+func URLHasPrefix(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldHasPrefix(FieldURL, v))
+}
+
+// URLHasSuffix applies the HasSuffix predicate on the "url" field.
+func URLHasSuffix(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldHasSuffix(FieldURL, v))
+}
+
+// URLEqualFold applies the EqualFold predicate on the "url" field.
+func URLEqualFold(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEqualFold(FieldURL, v))
+}
+
+// URLContainsFold applies the ContainsFold predicate on the "url" field.
+func URLContainsFold(v string) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldContainsFold(FieldURL, v))
+}
+
+// MandatoryEQ applies the EQ predicate on the "mandatory" field.
+func MandatoryEQ(v bool) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEQ(FieldMandatory, v))
+}
+
+// MandatoryNEQ applies the NEQ predicate on the "mandatory" field.
+func MandatoryNEQ(v bool) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldNEQ(FieldMandatory, v))
+}
+
+// PublishedAtEQ applies the EQ predicate on the "published_at" field.
+func PublishedAtEQ(v time.Time) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldEQ(FieldPublishedAt, v))
+}
+
+// PublishedAtNEQ applies the NEQ predicate on the "published_at" field.
+func PublishedAtNEQ(v time.Time) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldNEQ(FieldPublishedAt, v))
+}
+
+// PublishedAtIn applies the In predicate on the "published_at" field.
+func PublishedAtIn(vs ...time.Time) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldIn(FieldPublishedAt, vs...))
+}
+
+// PublishedAtNotIn applies the NotIn predicate on the "published_at" field.
+func PublishedAtNotIn(vs ...time.Time) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldNotIn(FieldPublishedAt, vs...))
+}
+
+// PublishedAtGT applies the GT predicate on the "published_at" field.
+func PublishedAtGT(v time.Time) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldGT(FieldPublishedAt, v))
+}
+
+// PublishedAtGTE applies the GTE predicate on the "published_at" field.
+func PublishedAtGTE(v time.Time) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldGTE(FieldPublishedAt, v))
+}
+
+// PublishedAtLT applies the LT predicate on the "published_at" field.
+func PublishedAtLT(v time.Time) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldLT(FieldPublishedAt, v))
+}
+
+// PublishedAtLTE applies the LTE predicate on the "published_at" field.
+func PublishedAtLTE(v time.Time) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.FieldLTE(FieldPublishedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.PolicyDocument) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.PolicyDocument) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.PolicyDocument) predicate.PolicyDocument {
+	return predicate.PolicyDocument(sql.NotPredicates(p))
+}
@@ -0,0 +1,556 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/policydocument"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// PolicyDocumentCreate is the builder for creating a PolicyDocument entity.
+type PolicyDocumentCreate struct {
+	config
+	mutation *PolicyDocumentMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetKind sets the "kind" field.
+func (pdc *PolicyDocumentCreate) SetKind(dk domain.PolicyKind) *PolicyDocumentCreate {
+	pdc.mutation.SetKind(dk)
+	return pdc
+}
+
+// SetVersion sets the "version" field.
+func (pdc *PolicyDocumentCreate) SetVersion(s string) *PolicyDocumentCreate {
+	pdc.mutation.SetVersion(s)
+	return pdc
+}
+
+// SetURL sets the "url" field.
+func (pdc *PolicyDocumentCreate) SetURL(s string) *PolicyDocumentCreate {
+	pdc.mutation.SetURL(s)
+	return pdc
+}
+
+// SetMandatory sets the "mandatory" field.
+func (pdc *PolicyDocumentCreate) SetMandatory(b bool) *PolicyDocumentCreate {
+	pdc.mutation.SetMandatory(b)
+	return pdc
+}
+
+// SetPublishedAt sets the "published_at" field.
+func (pdc *PolicyDocumentCreate) SetPublishedAt(t time.Time) *PolicyDocumentCreate {
+	pdc.mutation.SetPublishedAt(t)
+	return pdc
+}
+
+// SetNillablePublishedAt sets the "published_at" field if the given value is not nil.
+func (pdc *PolicyDocumentCreate) SetNillablePublishedAt(t *time.Time) *PolicyDocumentCreate {
+	if t != nil {
+		pdc.SetPublishedAt(*t)
+	}
+	return pdc
+}
+
+// SetID sets the "id" field.
+func (pdc *PolicyDocumentCreate) SetID(s string) *PolicyDocumentCreate {
+	pdc.mutation.SetID(s)
+	return pdc
+}
+
+// Mutation returns the PolicyDocumentMutation object of the builder.
+func (pdc *PolicyDocumentCreate) Mutation() *PolicyDocumentMutation {
+	return pdc.mutation
+}
+
+// Save creates the PolicyDocument in the database.
+func (pdc *PolicyDocumentCreate) Save(ctx context.Context) (*PolicyDocument, error) {
+	pdc.defaults()
+	return withHooks(ctx, pdc.sqlSave, pdc.mutation, pdc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (pdc *PolicyDocumentCreate) SaveX(ctx context.Context) *PolicyDocument {
+	v, err := pdc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pdc *PolicyDocumentCreate) Exec(ctx context.Context) error {
+	_, err := pdc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pdc *PolicyDocumentCreate) ExecX(ctx context.Context) {
+	if err := pdc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (pdc *PolicyDocumentCreate) defaults() {
+	if _, ok := pdc.mutation.PublishedAt(); !ok {
+		v := policydocument.DefaultPublishedAt()
+		pdc.mutation.SetPublishedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pdc *PolicyDocumentCreate) check() error {
+	if _, ok := pdc.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "PolicyDocument.kind"`)}
+	}
+	if _, ok := pdc.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "PolicyDocument.version"`)}
+	}
+	if v, ok := pdc.mutation.Version(); ok {
+		if err := policydocument.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "PolicyDocument.version": %w`, err)}
+		}
+	}
+	if _, ok := pdc.mutation.URL(); !ok {
+		return &ValidationError{Name: "url", err: errors.New(`ent: missing required field "PolicyDocument.url"`)}
+	}
+	if _, ok := pdc.mutation.Mandatory(); !ok {
+		return &ValidationError{Name: "mandatory", err: errors.New(`ent: missing required field "PolicyDocument.mandatory"`)}
+	}
+	if _, ok := pdc.mutation.PublishedAt(); !ok {
+		return &ValidationError{Name: "published_at", err: errors.New(`ent: missing required field "PolicyDocument.published_at"`)}
+	}
+	return nil
+}
+
+func (pdc *PolicyDocumentCreate) sqlSave(ctx context.Context) (*PolicyDocument, error) {
+	if err := pdc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := pdc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, pdc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected PolicyDocument.ID type: %T", _spec.ID.Value)
+		}
+	}
+	pdc.mutation.id = &_node.ID
+	pdc.mutation.done = true
+	return _node, nil
+}
+
+func (pdc *PolicyDocumentCreate) createSpec() (*PolicyDocument, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PolicyDocument{config: pdc.config}
+		_spec = sqlgraph.NewCreateSpec(policydocument.Table, sqlgraph.NewFieldSpec(policydocument.FieldID, field.TypeString))
+	)
+	_spec.OnConflict = pdc.conflict
+	if id, ok := pdc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := pdc.mutation.Kind(); ok {
+		_spec.SetField(policydocument.FieldKind, field.TypeString, value)
+		_node.Kind = value
+	}
+	if value, ok := pdc.mutation.Version(); ok {
+		_spec.SetField(policydocument.FieldVersion, field.TypeString, value)
+		_node.Version = value
+	}
+	if value, ok := pdc.mutation.URL(); ok {
+		_spec.SetField(policydocument.FieldURL, field.TypeString, value)
+		_node.URL = value
+	}
+	if value, ok := pdc.mutation.Mandatory(); ok {
+		_spec.SetField(policydocument.FieldMandatory, field.TypeBool, value)
+		_node.Mandatory = value
+	}
+	if value, ok := pdc.mutation.PublishedAt(); ok {
+		_spec.SetField(policydocument.FieldPublishedAt, field.TypeTime, value)
+		_node.PublishedAt = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.PolicyDocument.Create().
+//		SetKind(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.PolicyDocumentUpsert) {
+//			SetKind(v+v).
+//		}).
+//		Exec(ctx)
+func (pdc *PolicyDocumentCreate) OnConflict(opts ...sql.ConflictOption) *PolicyDocumentUpsertOne {
+	pdc.conflict = opts
+	return &PolicyDocumentUpsertOne{
+		create: pdc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.PolicyDocument.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (pdc *PolicyDocumentCreate) OnConflictColumns(columns ...string) *PolicyDocumentUpsertOne {
+	pdc.conflict = append(pdc.conflict, sql.ConflictColumns(columns...))
+	return &PolicyDocumentUpsertOne{
+		create: pdc,
+	}
+}
+
+type (
+	// PolicyDocumentUpsertOne is the builder for "upsert"-ing
+	//  one PolicyDocument node.
+	PolicyDocumentUpsertOne struct {
+		create *PolicyDocumentCreate
+	}
+
+	// PolicyDocumentUpsert is the "OnConflict" setter.
+	PolicyDocumentUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.PolicyDocument.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(policydocument.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *PolicyDocumentUpsertOne) UpdateNewValues() *PolicyDocumentUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(policydocument.FieldID)
+		}
+		if _, exists := u.create.mutation.Kind(); exists {
+			s.SetIgnore(policydocument.FieldKind)
+		}
+		if _, exists := u.create.mutation.Version(); exists {
+			s.SetIgnore(policydocument.FieldVersion)
+		}
+		if _, exists := u.create.mutation.URL(); exists {
+			s.SetIgnore(policydocument.FieldURL)
+		}
+		if _, exists := u.create.mutation.Mandatory(); exists {
+			s.SetIgnore(policydocument.FieldMandatory)
+		}
+		if _, exists := u.create.mutation.PublishedAt(); exists {
+			s.SetIgnore(policydocument.FieldPublishedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.PolicyDocument.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *PolicyDocumentUpsertOne) Ignore() *PolicyDocumentUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *PolicyDocumentUpsertOne) DoNothing() *PolicyDocumentUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the PolicyDocumentCreate.OnConflict
+// documentation for more info.
+func (u *PolicyDocumentUpsertOne) Update(set func(*PolicyDocumentUpsert)) *PolicyDocumentUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&PolicyDocumentUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// Exec executes the query.
+func (u *PolicyDocumentUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for PolicyDocumentCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *PolicyDocumentUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *PolicyDocumentUpsertOne) ID(ctx context.Context) (id string, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: PolicyDocumentUpsertOne.ID is not supported by MySQL driver. Use PolicyDocumentUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *PolicyDocumentUpsertOne) IDX(ctx context.Context) string {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// PolicyDocumentCreateBulk is the builder for creating many PolicyDocument entities in bulk.
+type PolicyDocumentCreateBulk struct {
+	config
+	err      error
+	builders []*PolicyDocumentCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the PolicyDocument entities in the database.
+func (pdcb *PolicyDocumentCreateBulk) Save(ctx context.Context) ([]*PolicyDocument, error) {
+	if pdcb.err != nil {
+		return nil, pdcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(pdcb.builders))
+	nodes := make([]*PolicyDocument, len(pdcb.builders))
+	mutators := make([]Mutator, len(pdcb.builders))
+	for i := range pdcb.builders {
+		func(i int, root context.Context) {
+			builder := pdcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PolicyDocumentMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, pdcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = pdcb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, pdcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, pdcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pdcb *PolicyDocumentCreateBulk) SaveX(ctx context.Context) []*PolicyDocument {
+	v, err := pdcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pdcb *PolicyDocumentCreateBulk) Exec(ctx context.Context) error {
+	_, err := pdcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pdcb *PolicyDocumentCreateBulk) ExecX(ctx context.Context) {
+	if err := pdcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.PolicyDocument.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.PolicyDocumentUpsert) {
+//			SetKind(v+v).
+//		}).
+//		Exec(ctx)
+func (pdcb *PolicyDocumentCreateBulk) OnConflict(opts ...sql.ConflictOption) *PolicyDocumentUpsertBulk {
+	pdcb.conflict = opts
+	return &PolicyDocumentUpsertBulk{
+		create: pdcb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.PolicyDocument.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (pdcb *PolicyDocumentCreateBulk) OnConflictColumns(columns ...string) *PolicyDocumentUpsertBulk {
+	pdcb.conflict = append(pdcb.conflict, sql.ConflictColumns(columns...))
+	return &PolicyDocumentUpsertBulk{
+		create: pdcb,
+	}
+}
+
+// PolicyDocumentUpsertBulk is the builder for "upsert"-ing
+// a bulk of PolicyDocument nodes.
+type PolicyDocumentUpsertBulk struct {
+	create *PolicyDocumentCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.PolicyDocument.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(policydocument.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *PolicyDocumentUpsertBulk) UpdateNewValues() *PolicyDocumentUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(policydocument.FieldID)
+			}
+			if _, exists := b.mutation.Kind(); exists {
+				s.SetIgnore(policydocument.FieldKind)
+			}
+			if _, exists := b.mutation.Version(); exists {
+				s.SetIgnore(policydocument.FieldVersion)
+			}
+			if _, exists := b.mutation.URL(); exists {
+				s.SetIgnore(policydocument.FieldURL)
+			}
+			if _, exists := b.mutation.Mandatory(); exists {
+				s.SetIgnore(policydocument.FieldMandatory)
+			}
+			if _, exists := b.mutation.PublishedAt(); exists {
+				s.SetIgnore(policydocument.FieldPublishedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.PolicyDocument.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *PolicyDocumentUpsertBulk) Ignore() *PolicyDocumentUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *PolicyDocumentUpsertBulk) DoNothing() *PolicyDocumentUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the PolicyDocumentCreateBulk.OnConflict
+// documentation for more info.
+func (u *PolicyDocumentUpsertBulk) Update(set func(*PolicyDocumentUpsert)) *PolicyDocumentUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&PolicyDocumentUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// Exec executes the query.
+func (u *PolicyDocumentUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the PolicyDocumentCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for PolicyDocumentCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *PolicyDocumentUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/policydocument"
+	"github.com/Beriw98/user-management/ent/predicate"
+)
+
+// PolicyDocumentDelete is the builder for deleting a PolicyDocument entity.
+type PolicyDocumentDelete struct {
+	config
+	hooks    []Hook
+	mutation *PolicyDocumentMutation
+}
+
+// Where appends a list predicates to the PolicyDocumentDelete builder.
+func (pdd *PolicyDocumentDelete) Where(ps ...predicate.PolicyDocument) *PolicyDocumentDelete {
+	pdd.mutation.Where(ps...)
+	return pdd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (pdd *PolicyDocumentDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, pdd.sqlExec, pdd.mutation, pdd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pdd *PolicyDocumentDelete) ExecX(ctx context.Context) int {
+	n, err := pdd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (pdd *PolicyDocumentDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(policydocument.Table, sqlgraph.NewFieldSpec(policydocument.FieldID, field.TypeString))
+	if ps := pdd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, pdd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	pdd.mutation.done = true
+	return affected, err
+}
+
+// PolicyDocumentDeleteOne is the builder for deleting a single PolicyDocument entity.
+type PolicyDocumentDeleteOne struct {
+	pdd *PolicyDocumentDelete
+}
+
+// Where appends a list predicates to the PolicyDocumentDelete builder.
+func (pddo *PolicyDocumentDeleteOne) Where(ps ...predicate.PolicyDocument) *PolicyDocumentDeleteOne {
+	pddo.pdd.mutation.Where(ps...)
+	return pddo
+}
+
+// Exec executes the deletion query.
+func (pddo *PolicyDocumentDeleteOne) Exec(ctx context.Context) error {
+	n, err := pddo.pdd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{policydocument.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pddo *PolicyDocumentDeleteOne) ExecX(ctx context.Context) {
+	if err := pddo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,175 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/policydocument"
+	"github.com/Beriw98/user-management/ent/predicate"
+)
+
+// PolicyDocumentUpdate is the builder for updating PolicyDocument entities.
+type PolicyDocumentUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PolicyDocumentMutation
+}
+
+// Where appends a list predicates to the PolicyDocumentUpdate builder.
+func (pdu *PolicyDocumentUpdate) Where(ps ...predicate.PolicyDocument) *PolicyDocumentUpdate {
+	pdu.mutation.Where(ps...)
+	return pdu
+}
+
+// Mutation returns the PolicyDocumentMutation object of the builder.
+func (pdu *PolicyDocumentUpdate) Mutation() *PolicyDocumentMutation {
+	return pdu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (pdu *PolicyDocumentUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, pdu.sqlSave, pdu.mutation, pdu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pdu *PolicyDocumentUpdate) SaveX(ctx context.Context) int {
+	affected, err := pdu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (pdu *PolicyDocumentUpdate) Exec(ctx context.Context) error {
+	_, err := pdu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pdu *PolicyDocumentUpdate) ExecX(ctx context.Context) {
+	if err := pdu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (pdu *PolicyDocumentUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(policydocument.Table, policydocument.Columns, sqlgraph.NewFieldSpec(policydocument.FieldID, field.TypeString))
+	if ps := pdu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, pdu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{policydocument.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	pdu.mutation.done = true
+	return n, nil
+}
+
+// PolicyDocumentUpdateOne is the builder for updating a single PolicyDocument entity.
+type PolicyDocumentUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PolicyDocumentMutation
+}
+
+// Mutation returns the PolicyDocumentMutation object of the builder.
+func (pduo *PolicyDocumentUpdateOne) Mutation() *PolicyDocumentMutation {
+	return pduo.mutation
+}
+
+// Where appends a list predicates to the PolicyDocumentUpdate builder.
+func (pduo *PolicyDocumentUpdateOne) Where(ps ...predicate.PolicyDocument) *PolicyDocumentUpdateOne {
+	pduo.mutation.Where(ps...)
+	return pduo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (pduo *PolicyDocumentUpdateOne) Select(field string, fields ...string) *PolicyDocumentUpdateOne {
+	pduo.fields = append([]string{field}, fields...)
+	return pduo
+}
+
+// Save executes the query and returns the updated PolicyDocument entity.
+func (pduo *PolicyDocumentUpdateOne) Save(ctx context.Context) (*PolicyDocument, error) {
+	return withHooks(ctx, pduo.sqlSave, pduo.mutation, pduo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pduo *PolicyDocumentUpdateOne) SaveX(ctx context.Context) *PolicyDocument {
+	node, err := pduo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (pduo *PolicyDocumentUpdateOne) Exec(ctx context.Context) error {
+	_, err := pduo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pduo *PolicyDocumentUpdateOne) ExecX(ctx context.Context) {
+	if err := pduo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (pduo *PolicyDocumentUpdateOne) sqlSave(ctx context.Context) (_node *PolicyDocument, err error) {
+	_spec := sqlgraph.NewUpdateSpec(policydocument.Table, policydocument.Columns, sqlgraph.NewFieldSpec(policydocument.FieldID, field.TypeString))
+	id, ok := pduo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "PolicyDocument.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := pduo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, policydocument.FieldID)
+		for _, f := range fields {
+			if !policydocument.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != policydocument.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := pduo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	_node = &PolicyDocument{config: pduo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, pduo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{policydocument.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	pduo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,545 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/predicate"
+	"github.com/Beriw98/user-management/ent/webhookdelivery"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// WebhookDeliveryUpdate is the builder for updating WebhookDelivery entities.
+type WebhookDeliveryUpdate struct {
+	config
+	hooks    []Hook
+	mutation *WebhookDeliveryMutation
+}
+
+// Where appends a list predicates to the WebhookDeliveryUpdate builder.
+func (wdu *WebhookDeliveryUpdate) Where(ps ...predicate.WebhookDelivery) *WebhookDeliveryUpdate {
+	wdu.mutation.Where(ps...)
+	return wdu
+}
+
+// SetPayload sets the "payload" field.
+func (wdu *WebhookDeliveryUpdate) SetPayload(jm json.RawMessage) *WebhookDeliveryUpdate {
+	wdu.mutation.SetPayload(jm)
+	return wdu
+}
+
+// AppendPayload appends jm to the "payload" field.
+func (wdu *WebhookDeliveryUpdate) AppendPayload(jm json.RawMessage) *WebhookDeliveryUpdate {
+	wdu.mutation.AppendPayload(jm)
+	return wdu
+}
+
+// SetStatus sets the "status" field.
+func (wdu *WebhookDeliveryUpdate) SetStatus(ds domain.DeliveryStatus) *WebhookDeliveryUpdate {
+	wdu.mutation.SetStatus(ds)
+	return wdu
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableStatus(ds *domain.DeliveryStatus) *WebhookDeliveryUpdate {
+	if ds != nil {
+		wdu.SetStatus(*ds)
+	}
+	return wdu
+}
+
+// SetAttempts sets the "attempts" field.
+func (wdu *WebhookDeliveryUpdate) SetAttempts(i int) *WebhookDeliveryUpdate {
+	wdu.mutation.ResetAttempts()
+	wdu.mutation.SetAttempts(i)
+	return wdu
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableAttempts(i *int) *WebhookDeliveryUpdate {
+	if i != nil {
+		wdu.SetAttempts(*i)
+	}
+	return wdu
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (wdu *WebhookDeliveryUpdate) AddAttempts(i int) *WebhookDeliveryUpdate {
+	wdu.mutation.AddAttempts(i)
+	return wdu
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (wdu *WebhookDeliveryUpdate) SetNextAttemptAt(t time.Time) *WebhookDeliveryUpdate {
+	wdu.mutation.SetNextAttemptAt(t)
+	return wdu
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableNextAttemptAt(t *time.Time) *WebhookDeliveryUpdate {
+	if t != nil {
+		wdu.SetNextAttemptAt(*t)
+	}
+	return wdu
+}
+
+// SetLastAttemptAt sets the "last_attempt_at" field.
+func (wdu *WebhookDeliveryUpdate) SetLastAttemptAt(t time.Time) *WebhookDeliveryUpdate {
+	wdu.mutation.SetLastAttemptAt(t)
+	return wdu
+}
+
+// SetNillableLastAttemptAt sets the "last_attempt_at" field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableLastAttemptAt(t *time.Time) *WebhookDeliveryUpdate {
+	if t != nil {
+		wdu.SetLastAttemptAt(*t)
+	}
+	return wdu
+}
+
+// ClearLastAttemptAt clears the value of the "last_attempt_at" field.
+func (wdu *WebhookDeliveryUpdate) ClearLastAttemptAt() *WebhookDeliveryUpdate {
+	wdu.mutation.ClearLastAttemptAt()
+	return wdu
+}
+
+// SetLastStatusCode sets the "last_status_code" field.
+func (wdu *WebhookDeliveryUpdate) SetLastStatusCode(i int) *WebhookDeliveryUpdate {
+	wdu.mutation.ResetLastStatusCode()
+	wdu.mutation.SetLastStatusCode(i)
+	return wdu
+}
+
+// SetNillableLastStatusCode sets the "last_status_code" field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableLastStatusCode(i *int) *WebhookDeliveryUpdate {
+	if i != nil {
+		wdu.SetLastStatusCode(*i)
+	}
+	return wdu
+}
+
+// AddLastStatusCode adds i to the "last_status_code" field.
+func (wdu *WebhookDeliveryUpdate) AddLastStatusCode(i int) *WebhookDeliveryUpdate {
+	wdu.mutation.AddLastStatusCode(i)
+	return wdu
+}
+
+// ClearLastStatusCode clears the value of the "last_status_code" field.
+func (wdu *WebhookDeliveryUpdate) ClearLastStatusCode() *WebhookDeliveryUpdate {
+	wdu.mutation.ClearLastStatusCode()
+	return wdu
+}
+
+// SetLastError sets the "last_error" field.
+func (wdu *WebhookDeliveryUpdate) SetLastError(s string) *WebhookDeliveryUpdate {
+	wdu.mutation.SetLastError(s)
+	return wdu
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (wdu *WebhookDeliveryUpdate) SetNillableLastError(s *string) *WebhookDeliveryUpdate {
+	if s != nil {
+		wdu.SetLastError(*s)
+	}
+	return wdu
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (wdu *WebhookDeliveryUpdate) ClearLastError() *WebhookDeliveryUpdate {
+	wdu.mutation.ClearLastError()
+	return wdu
+}
+
+// Mutation returns the WebhookDeliveryMutation object of the builder.
+func (wdu *WebhookDeliveryUpdate) Mutation() *WebhookDeliveryMutation {
+	return wdu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (wdu *WebhookDeliveryUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, wdu.sqlSave, wdu.mutation, wdu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (wdu *WebhookDeliveryUpdate) SaveX(ctx context.Context) int {
+	affected, err := wdu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (wdu *WebhookDeliveryUpdate) Exec(ctx context.Context) error {
+	_, err := wdu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (wdu *WebhookDeliveryUpdate) ExecX(ctx context.Context) {
+	if err := wdu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (wdu *WebhookDeliveryUpdate) check() error {
+	if wdu.mutation.SubscriptionCleared() && len(wdu.mutation.SubscriptionIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "WebhookDelivery.subscription"`)
+	}
+	return nil
+}
+
+func (wdu *WebhookDeliveryUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := wdu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(webhookdelivery.Table, webhookdelivery.Columns, sqlgraph.NewFieldSpec(webhookdelivery.FieldID, field.TypeInt64))
+	if ps := wdu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := wdu.mutation.Payload(); ok {
+		_spec.SetField(webhookdelivery.FieldPayload, field.TypeJSON, value)
+	}
+	if value, ok := wdu.mutation.AppendedPayload(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, webhookdelivery.FieldPayload, value)
+		})
+	}
+	if value, ok := wdu.mutation.Status(); ok {
+		_spec.SetField(webhookdelivery.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := wdu.mutation.Attempts(); ok {
+		_spec.SetField(webhookdelivery.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := wdu.mutation.AddedAttempts(); ok {
+		_spec.AddField(webhookdelivery.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := wdu.mutation.NextAttemptAt(); ok {
+		_spec.SetField(webhookdelivery.FieldNextAttemptAt, field.TypeTime, value)
+	}
+	if value, ok := wdu.mutation.LastAttemptAt(); ok {
+		_spec.SetField(webhookdelivery.FieldLastAttemptAt, field.TypeTime, value)
+	}
+	if wdu.mutation.LastAttemptAtCleared() {
+		_spec.ClearField(webhookdelivery.FieldLastAttemptAt, field.TypeTime)
+	}
+	if value, ok := wdu.mutation.LastStatusCode(); ok {
+		_spec.SetField(webhookdelivery.FieldLastStatusCode, field.TypeInt, value)
+	}
+	if value, ok := wdu.mutation.AddedLastStatusCode(); ok {
+		_spec.AddField(webhookdelivery.FieldLastStatusCode, field.TypeInt, value)
+	}
+	if wdu.mutation.LastStatusCodeCleared() {
+		_spec.ClearField(webhookdelivery.FieldLastStatusCode, field.TypeInt)
+	}
+	if value, ok := wdu.mutation.LastError(); ok {
+		_spec.SetField(webhookdelivery.FieldLastError, field.TypeString, value)
+	}
+	if wdu.mutation.LastErrorCleared() {
+		_spec.ClearField(webhookdelivery.FieldLastError, field.TypeString)
+	}
+	if wdu.mutation.OrganizationIDCleared() {
+		_spec.ClearField(webhookdelivery.FieldOrganizationID, field.TypeString)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, wdu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{webhookdelivery.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	wdu.mutation.done = true
+	return n, nil
+}
+
+// WebhookDeliveryUpdateOne is the builder for updating a single WebhookDelivery entity.
+type WebhookDeliveryUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *WebhookDeliveryMutation
+}
+
+// SetPayload sets the "payload" field.
+func (wduo *WebhookDeliveryUpdateOne) SetPayload(jm json.RawMessage) *WebhookDeliveryUpdateOne {
+	wduo.mutation.SetPayload(jm)
+	return wduo
+}
+
+// AppendPayload appends jm to the "payload" field.
+func (wduo *WebhookDeliveryUpdateOne) AppendPayload(jm json.RawMessage) *WebhookDeliveryUpdateOne {
+	wduo.mutation.AppendPayload(jm)
+	return wduo
+}
+
+// SetStatus sets the "status" field.
+func (wduo *WebhookDeliveryUpdateOne) SetStatus(ds domain.DeliveryStatus) *WebhookDeliveryUpdateOne {
+	wduo.mutation.SetStatus(ds)
+	return wduo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableStatus(ds *domain.DeliveryStatus) *WebhookDeliveryUpdateOne {
+	if ds != nil {
+		wduo.SetStatus(*ds)
+	}
+	return wduo
+}
+
+// SetAttempts sets the "attempts" field.
+func (wduo *WebhookDeliveryUpdateOne) SetAttempts(i int) *WebhookDeliveryUpdateOne {
+	wduo.mutation.ResetAttempts()
+	wduo.mutation.SetAttempts(i)
+	return wduo
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableAttempts(i *int) *WebhookDeliveryUpdateOne {
+	if i != nil {
+		wduo.SetAttempts(*i)
+	}
+	return wduo
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (wduo *WebhookDeliveryUpdateOne) AddAttempts(i int) *WebhookDeliveryUpdateOne {
+	wduo.mutation.AddAttempts(i)
+	return wduo
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (wduo *WebhookDeliveryUpdateOne) SetNextAttemptAt(t time.Time) *WebhookDeliveryUpdateOne {
+	wduo.mutation.SetNextAttemptAt(t)
+	return wduo
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableNextAttemptAt(t *time.Time) *WebhookDeliveryUpdateOne {
+	if t != nil {
+		wduo.SetNextAttemptAt(*t)
+	}
+	return wduo
+}
+
+// SetLastAttemptAt sets the "last_attempt_at" field.
+func (wduo *WebhookDeliveryUpdateOne) SetLastAttemptAt(t time.Time) *WebhookDeliveryUpdateOne {
+	wduo.mutation.SetLastAttemptAt(t)
+	return wduo
+}
+
+// SetNillableLastAttemptAt sets the "last_attempt_at" field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableLastAttemptAt(t *time.Time) *WebhookDeliveryUpdateOne {
+	if t != nil {
+		wduo.SetLastAttemptAt(*t)
+	}
+	return wduo
+}
+
+// ClearLastAttemptAt clears the value of the "last_attempt_at" field.
+func (wduo *WebhookDeliveryUpdateOne) ClearLastAttemptAt() *WebhookDeliveryUpdateOne {
+	wduo.mutation.ClearLastAttemptAt()
+	return wduo
+}
+
+// SetLastStatusCode sets the "last_status_code" field.
+func (wduo *WebhookDeliveryUpdateOne) SetLastStatusCode(i int) *WebhookDeliveryUpdateOne {
+	wduo.mutation.ResetLastStatusCode()
+	wduo.mutation.SetLastStatusCode(i)
+	return wduo
+}
+
+// SetNillableLastStatusCode sets the "last_status_code" field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableLastStatusCode(i *int) *WebhookDeliveryUpdateOne {
+	if i != nil {
+		wduo.SetLastStatusCode(*i)
+	}
+	return wduo
+}
+
+// AddLastStatusCode adds i to the "last_status_code" field.
+func (wduo *WebhookDeliveryUpdateOne) AddLastStatusCode(i int) *WebhookDeliveryUpdateOne {
+	wduo.mutation.AddLastStatusCode(i)
+	return wduo
+}
+
+// ClearLastStatusCode clears the value of the "last_status_code" field.
+func (wduo *WebhookDeliveryUpdateOne) ClearLastStatusCode() *WebhookDeliveryUpdateOne {
+	wduo.mutation.ClearLastStatusCode()
+	return wduo
+}
+
+// SetLastError sets the "last_error" field.
+func (wduo *WebhookDeliveryUpdateOne) SetLastError(s string) *WebhookDeliveryUpdateOne {
+	wduo.mutation.SetLastError(s)
+	return wduo
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (wduo *WebhookDeliveryUpdateOne) SetNillableLastError(s *string) *WebhookDeliveryUpdateOne {
+	if s != nil {
+		wduo.SetLastError(*s)
+	}
+	return wduo
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (wduo *WebhookDeliveryUpdateOne) ClearLastError() *WebhookDeliveryUpdateOne {
+	wduo.mutation.ClearLastError()
+	return wduo
+}
+
+// Mutation returns the WebhookDeliveryMutation object of the builder.
+func (wduo *WebhookDeliveryUpdateOne) Mutation() *WebhookDeliveryMutation {
+	return wduo.mutation
+}
+
+// Where appends a list predicates to the WebhookDeliveryUpdate builder.
+func (wduo *WebhookDeliveryUpdateOne) Where(ps ...predicate.WebhookDelivery) *WebhookDeliveryUpdateOne {
+	wduo.mutation.Where(ps...)
+	return wduo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (wduo *WebhookDeliveryUpdateOne) Select(field string, fields ...string) *WebhookDeliveryUpdateOne {
+	wduo.fields = append([]string{field}, fields...)
+	return wduo
+}
+
+// Save executes the query and returns the updated WebhookDelivery entity.
+func (wduo *WebhookDeliveryUpdateOne) Save(ctx context.Context) (*WebhookDelivery, error) {
+	return withHooks(ctx, wduo.sqlSave, wduo.mutation, wduo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (wduo *WebhookDeliveryUpdateOne) SaveX(ctx context.Context) *WebhookDelivery {
+	node, err := wduo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (wduo *WebhookDeliveryUpdateOne) Exec(ctx context.Context) error {
+	_, err := wduo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (wduo *WebhookDeliveryUpdateOne) ExecX(ctx context.Context) {
+	if err := wduo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (wduo *WebhookDeliveryUpdateOne) check() error {
+	if wduo.mutation.SubscriptionCleared() && len(wduo.mutation.SubscriptionIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "WebhookDelivery.subscription"`)
+	}
+	return nil
+}
+
+func (wduo *WebhookDeliveryUpdateOne) sqlSave(ctx context.Context) (_node *WebhookDelivery, err error) {
+	if err := wduo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(webhookdelivery.Table, webhookdelivery.Columns, sqlgraph.NewFieldSpec(webhookdelivery.FieldID, field.TypeInt64))
+	id, ok := wduo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "WebhookDelivery.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := wduo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, webhookdelivery.FieldID)
+		for _, f := range fields {
+			if !webhookdelivery.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != webhookdelivery.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := wduo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := wduo.mutation.Payload(); ok {
+		_spec.SetField(webhookdelivery.FieldPayload, field.TypeJSON, value)
+	}
+	if value, ok := wduo.mutation.AppendedPayload(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, webhookdelivery.FieldPayload, value)
+		})
+	}
+	if value, ok := wduo.mutation.Status(); ok {
+		_spec.SetField(webhookdelivery.FieldStatus, field.TypeString, value)
+	}
+	if value, ok := wduo.mutation.Attempts(); ok {
+		_spec.SetField(webhookdelivery.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := wduo.mutation.AddedAttempts(); ok {
+		_spec.AddField(webhookdelivery.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := wduo.mutation.NextAttemptAt(); ok {
+		_spec.SetField(webhookdelivery.FieldNextAttemptAt, field.TypeTime, value)
+	}
+	if value, ok := wduo.mutation.LastAttemptAt(); ok {
+		_spec.SetField(webhookdelivery.FieldLastAttemptAt, field.TypeTime, value)
+	}
+	if wduo.mutation.LastAttemptAtCleared() {
+		_spec.ClearField(webhookdelivery.FieldLastAttemptAt, field.TypeTime)
+	}
+	if value, ok := wduo.mutation.LastStatusCode(); ok {
+		_spec.SetField(webhookdelivery.FieldLastStatusCode, field.TypeInt, value)
+	}
+	if value, ok := wduo.mutation.AddedLastStatusCode(); ok {
+		_spec.AddField(webhookdelivery.FieldLastStatusCode, field.TypeInt, value)
+	}
+	if wduo.mutation.LastStatusCodeCleared() {
+		_spec.ClearField(webhookdelivery.FieldLastStatusCode, field.TypeInt)
+	}
+	if value, ok := wduo.mutation.LastError(); ok {
+		_spec.SetField(webhookdelivery.FieldLastError, field.TypeString, value)
+	}
+	if wduo.mutation.LastErrorCleared() {
+		_spec.ClearField(webhookdelivery.FieldLastError, field.TypeString)
+	}
+	if wduo.mutation.OrganizationIDCleared() {
+		_spec.ClearField(webhookdelivery.FieldOrganizationID, field.TypeString)
+	}
+	_node = &WebhookDelivery{config: wduo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, wduo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{webhookdelivery.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	wduo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,203 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Beriw98/user-management/ent/webhooksubscription"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// WebhookSubscription is the model entity for the WebhookSubscription schema.
+type WebhookSubscription struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID string `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// URL holds the value of the "url" field.
+	URL string `json:"url,omitempty"`
+	// Secret holds the value of the "secret" field.
+	Secret string `json:"-"`
+	// EventTypes holds the value of the "event_types" field.
+	EventTypes []domain.EventType `json:"event_types,omitempty"`
+	// Active holds the value of the "active" field.
+	Active bool `json:"active,omitempty"`
+	// OrganizationID holds the value of the "organization_id" field.
+	OrganizationID string `json:"organization_id,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the WebhookSubscriptionQuery when eager-loading is set.
+	Edges        WebhookSubscriptionEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// WebhookSubscriptionEdges holds the relations/edges for other nodes in the graph.
+type WebhookSubscriptionEdges struct {
+	// Deliveries holds the value of the deliveries edge.
+	Deliveries []*WebhookDelivery `json:"deliveries,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// DeliveriesOrErr returns the Deliveries value or an error if the edge
+// was not loaded in eager-loading.
+func (e WebhookSubscriptionEdges) DeliveriesOrErr() ([]*WebhookDelivery, error) {
+	if e.loadedTypes[0] {
+		return e.Deliveries, nil
+	}
+	return nil, &NotLoadedError{edge: "deliveries"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*WebhookSubscription) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case webhooksubscription.FieldEventTypes:
+			values[i] = new([]byte)
+		case webhooksubscription.FieldActive:
+			values[i] = new(sql.NullBool)
+		case webhooksubscription.FieldID, webhooksubscription.FieldURL, webhooksubscription.FieldSecret, webhooksubscription.FieldOrganizationID:
+			values[i] = new(sql.NullString)
+		case webhooksubscription.FieldCreatedAt, webhooksubscription.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the WebhookSubscription fields.
+func (ws *WebhookSubscription) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case webhooksubscription.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				ws.ID = value.String
+			}
+		case webhooksubscription.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				ws.CreatedAt = value.Time
+			}
+		case webhooksubscription.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				ws.UpdatedAt = value.Time
+			}
+		case webhooksubscription.FieldURL:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field url", values[i])
+			} else if value.Valid {
+				ws.URL = value.String
+			}
+		case webhooksubscription.FieldSecret:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field secret", values[i])
+			} else if value.Valid {
+				ws.Secret = value.String
+			}
+		case webhooksubscription.FieldEventTypes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field event_types", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &ws.EventTypes); err != nil {
+					return fmt.Errorf("unmarshal field event_types: %w", err)
+				}
+			}
+		case webhooksubscription.FieldActive:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field active", values[i])
+			} else if value.Valid {
+				ws.Active = value.Bool
+			}
+		case webhooksubscription.FieldOrganizationID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field organization_id", values[i])
+			} else if value.Valid {
+				ws.OrganizationID = value.String
+			}
+		default:
+			ws.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the WebhookSubscription.
+// This includes values selected through modifiers, order, etc.
+func (ws *WebhookSubscription) Value(name string) (ent.Value, error) {
+	return ws.selectValues.Get(name)
+}
+
+// QueryDeliveries queries the "deliveries" edge of the WebhookSubscription entity.
+func (ws *WebhookSubscription) QueryDeliveries() *WebhookDeliveryQuery {
+	return NewWebhookSubscriptionClient(ws.config).QueryDeliveries(ws)
+}
+
+// Update returns a builder for updating this WebhookSubscription.
+// Note that you need to call WebhookSubscription.Unwrap() before calling this method if this WebhookSubscription
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (ws *WebhookSubscription) Update() *WebhookSubscriptionUpdateOne {
+	return NewWebhookSubscriptionClient(ws.config).UpdateOne(ws)
+}
+
+// Unwrap unwraps the WebhookSubscription entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (ws *WebhookSubscription) Unwrap() *WebhookSubscription {
+	_tx, ok := ws.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: WebhookSubscription is not a transactional entity")
+	}
+	ws.config.driver = _tx.drv
+	return ws
+}
+
+// String implements the fmt.Stringer.
+func (ws *WebhookSubscription) String() string {
+	var builder strings.Builder
+	builder.WriteString("WebhookSubscription(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", ws.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(ws.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(ws.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("url=")
+	builder.WriteString(ws.URL)
+	builder.WriteString(", ")
+	builder.WriteString("secret=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("event_types=")
+	builder.WriteString(fmt.Sprintf("%v", ws.EventTypes))
+	builder.WriteString(", ")
+	builder.WriteString("active=")
+	builder.WriteString(fmt.Sprintf("%v", ws.Active))
+	builder.WriteString(", ")
+	builder.WriteString("organization_id=")
+	builder.WriteString(ws.OrganizationID)
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// WebhookSubscriptions is a parsable slice of WebhookSubscription.
+type WebhookSubscriptions []*WebhookSubscription
@@ -0,0 +1,847 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/webhookdelivery"
+	"github.com/Beriw98/user-management/ent/webhooksubscription"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// WebhookSubscriptionCreate is the builder for creating a WebhookSubscription entity.
+type WebhookSubscriptionCreate struct {
+	config
+	mutation *WebhookSubscriptionMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (wsc *WebhookSubscriptionCreate) SetCreatedAt(t time.Time) *WebhookSubscriptionCreate {
+	wsc.mutation.SetCreatedAt(t)
+	return wsc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (wsc *WebhookSubscriptionCreate) SetNillableCreatedAt(t *time.Time) *WebhookSubscriptionCreate {
+	if t != nil {
+		wsc.SetCreatedAt(*t)
+	}
+	return wsc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (wsc *WebhookSubscriptionCreate) SetUpdatedAt(t time.Time) *WebhookSubscriptionCreate {
+	wsc.mutation.SetUpdatedAt(t)
+	return wsc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (wsc *WebhookSubscriptionCreate) SetNillableUpdatedAt(t *time.Time) *WebhookSubscriptionCreate {
+	if t != nil {
+		wsc.SetUpdatedAt(*t)
+	}
+	return wsc
+}
+
+// SetURL sets the "url" field.
+func (wsc *WebhookSubscriptionCreate) SetURL(s string) *WebhookSubscriptionCreate {
+	wsc.mutation.SetURL(s)
+	return wsc
+}
+
+// SetSecret sets the "secret" field.
+func (wsc *WebhookSubscriptionCreate) SetSecret(s string) *WebhookSubscriptionCreate {
+	wsc.mutation.SetSecret(s)
+	return wsc
+}
+
+// SetEventTypes sets the "event_types" field.
+func (wsc *WebhookSubscriptionCreate) SetEventTypes(dt []domain.EventType) *WebhookSubscriptionCreate {
+	wsc.mutation.SetEventTypes(dt)
+	return wsc
+}
+
+// SetActive sets the "active" field.
+func (wsc *WebhookSubscriptionCreate) SetActive(b bool) *WebhookSubscriptionCreate {
+	wsc.mutation.SetActive(b)
+	return wsc
+}
+
+// SetNillableActive sets the "active" field if the given value is not nil.
+func (wsc *WebhookSubscriptionCreate) SetNillableActive(b *bool) *WebhookSubscriptionCreate {
+	if b != nil {
+		wsc.SetActive(*b)
+	}
+	return wsc
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (wsc *WebhookSubscriptionCreate) SetOrganizationID(s string) *WebhookSubscriptionCreate {
+	wsc.mutation.SetOrganizationID(s)
+	return wsc
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (wsc *WebhookSubscriptionCreate) SetNillableOrganizationID(s *string) *WebhookSubscriptionCreate {
+	if s != nil {
+		wsc.SetOrganizationID(*s)
+	}
+	return wsc
+}
+
+// SetID sets the "id" field.
+func (wsc *WebhookSubscriptionCreate) SetID(s string) *WebhookSubscriptionCreate {
+	wsc.mutation.SetID(s)
+	return wsc
+}
+
+// AddDeliveryIDs adds the "deliveries" edge to the WebhookDelivery entity by IDs.
+func (wsc *WebhookSubscriptionCreate) AddDeliveryIDs(ids ...int64) *WebhookSubscriptionCreate {
+	wsc.mutation.AddDeliveryIDs(ids...)
+	return wsc
+}
+
+// AddDeliveries adds the "deliveries" edges to the WebhookDelivery entity.
+func (wsc *WebhookSubscriptionCreate) AddDeliveries(w ...*WebhookDelivery) *WebhookSubscriptionCreate {
+	ids := make([]int64, len(w))
+	for i := range w {
+		ids[i] = w[i].ID
+	}
+	return wsc.AddDeliveryIDs(ids...)
+}
+
+// Mutation returns the WebhookSubscriptionMutation object of the builder.
+func (wsc *WebhookSubscriptionCreate) Mutation() *WebhookSubscriptionMutation {
+	return wsc.mutation
+}
+
+// Save creates the WebhookSubscription in the database.
+func (wsc *WebhookSubscriptionCreate) Save(ctx context.Context) (*WebhookSubscription, error) {
+	wsc.defaults()
+	return withHooks(ctx, wsc.sqlSave, wsc.mutation, wsc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (wsc *WebhookSubscriptionCreate) SaveX(ctx context.Context) *WebhookSubscription {
+	v, err := wsc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (wsc *WebhookSubscriptionCreate) Exec(ctx context.Context) error {
+	_, err := wsc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (wsc *WebhookSubscriptionCreate) ExecX(ctx context.Context) {
+	if err := wsc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (wsc *WebhookSubscriptionCreate) defaults() {
+	if _, ok := wsc.mutation.CreatedAt(); !ok {
+		v := webhooksubscription.DefaultCreatedAt()
+		wsc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := wsc.mutation.UpdatedAt(); !ok {
+		v := webhooksubscription.DefaultUpdatedAt()
+		wsc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := wsc.mutation.Active(); !ok {
+		v := webhooksubscription.DefaultActive
+		wsc.mutation.SetActive(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (wsc *WebhookSubscriptionCreate) check() error {
+	if _, ok := wsc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "WebhookSubscription.created_at"`)}
+	}
+	if _, ok := wsc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "WebhookSubscription.updated_at"`)}
+	}
+	if _, ok := wsc.mutation.URL(); !ok {
+		return &ValidationError{Name: "url", err: errors.New(`ent: missing required field "WebhookSubscription.url"`)}
+	}
+	if v, ok := wsc.mutation.URL(); ok {
+		if err := webhooksubscription.URLValidator(v); err != nil {
+			return &ValidationError{Name: "url", err: fmt.Errorf(`ent: validator failed for field "WebhookSubscription.url": %w`, err)}
+		}
+	}
+	if _, ok := wsc.mutation.Secret(); !ok {
+		return &ValidationError{Name: "secret", err: errors.New(`ent: missing required field "WebhookSubscription.secret"`)}
+	}
+	if v, ok := wsc.mutation.Secret(); ok {
+		if err := webhooksubscription.SecretValidator(v); err != nil {
+			return &ValidationError{Name: "secret", err: fmt.Errorf(`ent: validator failed for field "WebhookSubscription.secret": %w`, err)}
+		}
+	}
+	if _, ok := wsc.mutation.Active(); !ok {
+		return &ValidationError{Name: "active", err: errors.New(`ent: missing required field "WebhookSubscription.active"`)}
+	}
+	return nil
+}
+
+func (wsc *WebhookSubscriptionCreate) sqlSave(ctx context.Context) (*WebhookSubscription, error) {
+	if err := wsc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := wsc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, wsc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected WebhookSubscription.ID type: %T", _spec.ID.Value)
+		}
+	}
+	wsc.mutation.id = &_node.ID
+	wsc.mutation.done = true
+	return _node, nil
+}
+
+func (wsc *WebhookSubscriptionCreate) createSpec() (*WebhookSubscription, *sqlgraph.CreateSpec) {
+	var (
+		_node = &WebhookSubscription{config: wsc.config}
+		_spec = sqlgraph.NewCreateSpec(webhooksubscription.Table, sqlgraph.NewFieldSpec(webhooksubscription.FieldID, field.TypeString))
+	)
+	_spec.OnConflict = wsc.conflict
+	if id, ok := wsc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := wsc.mutation.CreatedAt(); ok {
+		_spec.SetField(webhooksubscription.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := wsc.mutation.UpdatedAt(); ok {
+		_spec.SetField(webhooksubscription.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := wsc.mutation.URL(); ok {
+		_spec.SetField(webhooksubscription.FieldURL, field.TypeString, value)
+		_node.URL = value
+	}
+	if value, ok := wsc.mutation.Secret(); ok {
+		_spec.SetField(webhooksubscription.FieldSecret, field.TypeString, value)
+		_node.Secret = value
+	}
+	if value, ok := wsc.mutation.EventTypes(); ok {
+		_spec.SetField(webhooksubscription.FieldEventTypes, field.TypeJSON, value)
+		_node.EventTypes = value
+	}
+	if value, ok := wsc.mutation.Active(); ok {
+		_spec.SetField(webhooksubscription.FieldActive, field.TypeBool, value)
+		_node.Active = value
+	}
+	if value, ok := wsc.mutation.OrganizationID(); ok {
+		_spec.SetField(webhooksubscription.FieldOrganizationID, field.TypeString, value)
+		_node.OrganizationID = value
+	}
+	if nodes := wsc.mutation.DeliveriesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   webhooksubscription.DeliveriesTable,
+			Columns: []string{webhooksubscription.DeliveriesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(webhookdelivery.FieldID, field.TypeInt64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.WebhookSubscription.Create().
+//		SetCreatedAt(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.WebhookSubscriptionUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (wsc *WebhookSubscriptionCreate) OnConflict(opts ...sql.ConflictOption) *WebhookSubscriptionUpsertOne {
+	wsc.conflict = opts
+	return &WebhookSubscriptionUpsertOne{
+		create: wsc,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.WebhookSubscription.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (wsc *WebhookSubscriptionCreate) OnConflictColumns(columns ...string) *WebhookSubscriptionUpsertOne {
+	wsc.conflict = append(wsc.conflict, sql.ConflictColumns(columns...))
+	return &WebhookSubscriptionUpsertOne{
+		create: wsc,
+	}
+}
+
+type (
+	// WebhookSubscriptionUpsertOne is the builder for "upsert"-ing
+	//  one WebhookSubscription node.
+	WebhookSubscriptionUpsertOne struct {
+		create *WebhookSubscriptionCreate
+	}
+
+	// WebhookSubscriptionUpsert is the "OnConflict" setter.
+	WebhookSubscriptionUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *WebhookSubscriptionUpsert) SetUpdatedAt(v time.Time) *WebhookSubscriptionUpsert {
+	u.Set(webhooksubscription.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsert) UpdateUpdatedAt() *WebhookSubscriptionUpsert {
+	u.SetExcluded(webhooksubscription.FieldUpdatedAt)
+	return u
+}
+
+// SetURL sets the "url" field.
+func (u *WebhookSubscriptionUpsert) SetURL(v string) *WebhookSubscriptionUpsert {
+	u.Set(webhooksubscription.FieldURL, v)
+	return u
+}
+
+// UpdateURL sets the "url" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsert) UpdateURL() *WebhookSubscriptionUpsert {
+	u.SetExcluded(webhooksubscription.FieldURL)
+	return u
+}
+
+// SetSecret sets the "secret" field.
+func (u *WebhookSubscriptionUpsert) SetSecret(v string) *WebhookSubscriptionUpsert {
+	u.Set(webhooksubscription.FieldSecret, v)
+	return u
+}
+
+// UpdateSecret sets the "secret" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsert) UpdateSecret() *WebhookSubscriptionUpsert {
+	u.SetExcluded(webhooksubscription.FieldSecret)
+	return u
+}
+
+// SetEventTypes sets the "event_types" field.
+func (u *WebhookSubscriptionUpsert) SetEventTypes(v []domain.EventType) *WebhookSubscriptionUpsert {
+	u.Set(webhooksubscription.FieldEventTypes, v)
+	return u
+}
+
+// UpdateEventTypes sets the "event_types" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsert) UpdateEventTypes() *WebhookSubscriptionUpsert {
+	u.SetExcluded(webhooksubscription.FieldEventTypes)
+	return u
+}
+
+// ClearEventTypes clears the value of the "event_types" field.
+func (u *WebhookSubscriptionUpsert) ClearEventTypes() *WebhookSubscriptionUpsert {
+	u.SetNull(webhooksubscription.FieldEventTypes)
+	return u
+}
+
+// SetActive sets the "active" field.
+func (u *WebhookSubscriptionUpsert) SetActive(v bool) *WebhookSubscriptionUpsert {
+	u.Set(webhooksubscription.FieldActive, v)
+	return u
+}
+
+// UpdateActive sets the "active" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsert) UpdateActive() *WebhookSubscriptionUpsert {
+	u.SetExcluded(webhooksubscription.FieldActive)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create except the ID field.
+// Using this option is equivalent to using:
+//
+//	client.WebhookSubscription.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(webhooksubscription.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *WebhookSubscriptionUpsertOne) UpdateNewValues() *WebhookSubscriptionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.ID(); exists {
+			s.SetIgnore(webhooksubscription.FieldID)
+		}
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(webhooksubscription.FieldCreatedAt)
+		}
+		if _, exists := u.create.mutation.OrganizationID(); exists {
+			s.SetIgnore(webhooksubscription.FieldOrganizationID)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.WebhookSubscription.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *WebhookSubscriptionUpsertOne) Ignore() *WebhookSubscriptionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *WebhookSubscriptionUpsertOne) DoNothing() *WebhookSubscriptionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the WebhookSubscriptionCreate.OnConflict
+// documentation for more info.
+func (u *WebhookSubscriptionUpsertOne) Update(set func(*WebhookSubscriptionUpsert)) *WebhookSubscriptionUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&WebhookSubscriptionUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *WebhookSubscriptionUpsertOne) SetUpdatedAt(v time.Time) *WebhookSubscriptionUpsertOne {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsertOne) UpdateUpdatedAt() *WebhookSubscriptionUpsertOne {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetURL sets the "url" field.
+func (u *WebhookSubscriptionUpsertOne) SetURL(v string) *WebhookSubscriptionUpsertOne {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.SetURL(v)
+	})
+}
+
+// UpdateURL sets the "url" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsertOne) UpdateURL() *WebhookSubscriptionUpsertOne {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.UpdateURL()
+	})
+}
+
+// SetSecret sets the "secret" field.
+func (u *WebhookSubscriptionUpsertOne) SetSecret(v string) *WebhookSubscriptionUpsertOne {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.SetSecret(v)
+	})
+}
+
+// UpdateSecret sets the "secret" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsertOne) UpdateSecret() *WebhookSubscriptionUpsertOne {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.UpdateSecret()
+	})
+}
+
+// SetEventTypes sets the "event_types" field.
+func (u *WebhookSubscriptionUpsertOne) SetEventTypes(v []domain.EventType) *WebhookSubscriptionUpsertOne {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.SetEventTypes(v)
+	})
+}
+
+// UpdateEventTypes sets the "event_types" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsertOne) UpdateEventTypes() *WebhookSubscriptionUpsertOne {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.UpdateEventTypes()
+	})
+}
+
+// ClearEventTypes clears the value of the "event_types" field.
+func (u *WebhookSubscriptionUpsertOne) ClearEventTypes() *WebhookSubscriptionUpsertOne {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.ClearEventTypes()
+	})
+}
+
+// SetActive sets the "active" field.
+func (u *WebhookSubscriptionUpsertOne) SetActive(v bool) *WebhookSubscriptionUpsertOne {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.SetActive(v)
+	})
+}
+
+// UpdateActive sets the "active" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsertOne) UpdateActive() *WebhookSubscriptionUpsertOne {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.UpdateActive()
+	})
+}
+
+// Exec executes the query.
+func (u *WebhookSubscriptionUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for WebhookSubscriptionCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *WebhookSubscriptionUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *WebhookSubscriptionUpsertOne) ID(ctx context.Context) (id string, err error) {
+	if u.create.driver.Dialect() == dialect.MySQL {
+		// In case of "ON CONFLICT", there is no way to get back non-numeric ID
+		// fields from the database since MySQL does not support the RETURNING clause.
+		return id, errors.New("ent: WebhookSubscriptionUpsertOne.ID is not supported by MySQL driver. Use WebhookSubscriptionUpsertOne.Exec instead")
+	}
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *WebhookSubscriptionUpsertOne) IDX(ctx context.Context) string {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// WebhookSubscriptionCreateBulk is the builder for creating many WebhookSubscription entities in bulk.
+type WebhookSubscriptionCreateBulk struct {
+	config
+	err      error
+	builders []*WebhookSubscriptionCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the WebhookSubscription entities in the database.
+func (wscb *WebhookSubscriptionCreateBulk) Save(ctx context.Context) ([]*WebhookSubscription, error) {
+	if wscb.err != nil {
+		return nil, wscb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(wscb.builders))
+	nodes := make([]*WebhookSubscription, len(wscb.builders))
+	mutators := make([]Mutator, len(wscb.builders))
+	for i := range wscb.builders {
+		func(i int, root context.Context) {
+			builder := wscb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*WebhookSubscriptionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, wscb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = wscb.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, wscb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, wscb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (wscb *WebhookSubscriptionCreateBulk) SaveX(ctx context.Context) []*WebhookSubscription {
+	v, err := wscb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (wscb *WebhookSubscriptionCreateBulk) Exec(ctx context.Context) error {
+	_, err := wscb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (wscb *WebhookSubscriptionCreateBulk) ExecX(ctx context.Context) {
+	if err := wscb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.WebhookSubscription.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.WebhookSubscriptionUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (wscb *WebhookSubscriptionCreateBulk) OnConflict(opts ...sql.ConflictOption) *WebhookSubscriptionUpsertBulk {
+	wscb.conflict = opts
+	return &WebhookSubscriptionUpsertBulk{
+		create: wscb,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.WebhookSubscription.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (wscb *WebhookSubscriptionCreateBulk) OnConflictColumns(columns ...string) *WebhookSubscriptionUpsertBulk {
+	wscb.conflict = append(wscb.conflict, sql.ConflictColumns(columns...))
+	return &WebhookSubscriptionUpsertBulk{
+		create: wscb,
+	}
+}
+
+// WebhookSubscriptionUpsertBulk is the builder for "upsert"-ing
+// a bulk of WebhookSubscription nodes.
+type WebhookSubscriptionUpsertBulk struct {
+	create *WebhookSubscriptionCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.WebhookSubscription.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//			sql.ResolveWith(func(u *sql.UpdateSet) {
+//				u.SetIgnore(webhooksubscription.FieldID)
+//			}),
+//		).
+//		Exec(ctx)
+func (u *WebhookSubscriptionUpsertBulk) UpdateNewValues() *WebhookSubscriptionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.ID(); exists {
+				s.SetIgnore(webhooksubscription.FieldID)
+			}
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(webhooksubscription.FieldCreatedAt)
+			}
+			if _, exists := b.mutation.OrganizationID(); exists {
+				s.SetIgnore(webhooksubscription.FieldOrganizationID)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.WebhookSubscription.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *WebhookSubscriptionUpsertBulk) Ignore() *WebhookSubscriptionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *WebhookSubscriptionUpsertBulk) DoNothing() *WebhookSubscriptionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the WebhookSubscriptionCreateBulk.OnConflict
+// documentation for more info.
+func (u *WebhookSubscriptionUpsertBulk) Update(set func(*WebhookSubscriptionUpsert)) *WebhookSubscriptionUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&WebhookSubscriptionUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *WebhookSubscriptionUpsertBulk) SetUpdatedAt(v time.Time) *WebhookSubscriptionUpsertBulk {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsertBulk) UpdateUpdatedAt() *WebhookSubscriptionUpsertBulk {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetURL sets the "url" field.
+func (u *WebhookSubscriptionUpsertBulk) SetURL(v string) *WebhookSubscriptionUpsertBulk {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.SetURL(v)
+	})
+}
+
+// UpdateURL sets the "url" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsertBulk) UpdateURL() *WebhookSubscriptionUpsertBulk {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.UpdateURL()
+	})
+}
+
+// SetSecret sets the "secret" field.
+func (u *WebhookSubscriptionUpsertBulk) SetSecret(v string) *WebhookSubscriptionUpsertBulk {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.SetSecret(v)
+	})
+}
+
+// UpdateSecret sets the "secret" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsertBulk) UpdateSecret() *WebhookSubscriptionUpsertBulk {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.UpdateSecret()
+	})
+}
+
+// SetEventTypes sets the "event_types" field.
+func (u *WebhookSubscriptionUpsertBulk) SetEventTypes(v []domain.EventType) *WebhookSubscriptionUpsertBulk {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.SetEventTypes(v)
+	})
+}
+
+// UpdateEventTypes sets the "event_types" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsertBulk) UpdateEventTypes() *WebhookSubscriptionUpsertBulk {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.UpdateEventTypes()
+	})
+}
+
+// ClearEventTypes clears the value of the "event_types" field.
+func (u *WebhookSubscriptionUpsertBulk) ClearEventTypes() *WebhookSubscriptionUpsertBulk {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.ClearEventTypes()
+	})
+}
+
+// SetActive sets the "active" field.
+func (u *WebhookSubscriptionUpsertBulk) SetActive(v bool) *WebhookSubscriptionUpsertBulk {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.SetActive(v)
+	})
+}
+
+// UpdateActive sets the "active" field to the value that was provided on create.
+func (u *WebhookSubscriptionUpsertBulk) UpdateActive() *WebhookSubscriptionUpsertBulk {
+	return u.Update(func(s *WebhookSubscriptionUpsert) {
+		s.UpdateActive()
+	})
+}
+
+// Exec executes the query.
+func (u *WebhookSubscriptionUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the WebhookSubscriptionCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for WebhookSubscriptionCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *WebhookSubscriptionUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
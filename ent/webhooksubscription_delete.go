@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Beriw98/user-management/ent/predicate"
+	"github.com/Beriw98/user-management/ent/webhooksubscription"
+)
+
+// WebhookSubscriptionDelete is the builder for deleting a WebhookSubscription entity.
+type WebhookSubscriptionDelete struct {
+	config
+	hooks    []Hook
+	mutation *WebhookSubscriptionMutation
+}
+
+// Where appends a list predicates to the WebhookSubscriptionDelete builder.
+func (wsd *WebhookSubscriptionDelete) Where(ps ...predicate.WebhookSubscription) *WebhookSubscriptionDelete {
+	wsd.mutation.Where(ps...)
+	return wsd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (wsd *WebhookSubscriptionDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, wsd.sqlExec, wsd.mutation, wsd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (wsd *WebhookSubscriptionDelete) ExecX(ctx context.Context) int {
+	n, err := wsd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (wsd *WebhookSubscriptionDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(webhooksubscription.Table, sqlgraph.NewFieldSpec(webhooksubscription.FieldID, field.TypeString))
+	if ps := wsd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, wsd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	wsd.mutation.done = true
+	return affected, err
+}
+
+// WebhookSubscriptionDeleteOne is the builder for deleting a single WebhookSubscription entity.
+type WebhookSubscriptionDeleteOne struct {
+	wsd *WebhookSubscriptionDelete
+}
+
+// Where appends a list predicates to the WebhookSubscriptionDelete builder.
+func (wsdo *WebhookSubscriptionDeleteOne) Where(ps ...predicate.WebhookSubscription) *WebhookSubscriptionDeleteOne {
+	wsdo.wsd.mutation.Where(ps...)
+	return wsdo
+}
+
+// Exec executes the deletion query.
+func (wsdo *WebhookSubscriptionDeleteOne) Exec(ctx context.Context) error {
+	n, err := wsdo.wsd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{webhooksubscription.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (wsdo *WebhookSubscriptionDeleteOne) ExecX(ctx context.Context) {
+	if err := wsdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,99 @@
+package dataexport
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// Source is a subsystem holding data about users. ExportData returns what it
+// holds about user, encoded as JSON in the archive.
+type Source interface {
+	ExportData(ctx context.Context, user domain.User) (any, error)
+}
+
+// SourceFunc adapts a function to a Source.
+type SourceFunc func(ctx context.Context, user domain.User) (any, error)
+
+func (f SourceFunc) ExportData(ctx context.Context, user domain.User) (any, error) {
+	return f(ctx, user)
+}
+
+// Registry assembles the data subject access archive of a user from the
+// sources registered by every subsystem, a JSON file per category.
+type Registry struct {
+	categories []string
+	sources    map[string]Source
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		sources: make(map[string]Source),
+	}
+}
+
+// Register adds source to the archive as `<category>.json`. It panics if the
+// category is already registered.
+func (r *Registry) Register(category string, source Source) {
+	if _, ok := r.sources[category]; ok {
+		panic(fmt.Sprintf("dataexport: category %q registered twice", category))
+	}
+
+	r.categories = append(r.categories, category)
+	r.sources[category] = source
+}
+
+// Categories returns the registered categories in registration order.
+func (r *Registry) Categories() []string {
+	return append([]string(nil), r.categories...)
+}
+
+type manifest struct {
+	UserID      string    `json:"user_id"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Categories  []string  `json:"categories"`
+}
+
+// Write writes the ZIP archive of user to w, with a `manifest.json` listing
+// the categories.
+func (r *Registry) Write(ctx context.Context, user domain.User, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	if err := writeJSON(zw, "manifest.json", manifest{
+		UserID:      user.ID,
+		GeneratedAt: time.Now().UTC(),
+		Categories:  r.categories,
+	}); err != nil {
+		return err
+	}
+
+	for _, category := range r.categories {
+		data, err := r.sources[category].ExportData(ctx, user)
+		if err != nil {
+			return fmt.Errorf("exporting %s: %w", category, err)
+		}
+
+		if err = writeJSON(zw, category+".json", data); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func writeJSON(zw *zip.Writer, name string, v any) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(v)
+}
@@ -0,0 +1,85 @@
+package dataexport_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/dataexport"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+func TestRegistry_Write(t *testing.T) {
+	user := domain.User{ID: "1", Email: "test@test.pl"}
+
+	read := func(t *testing.T, archive []byte) map[string]string {
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		assert.NoError(t, err)
+
+		files := make(map[string]string)
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			assert.NoError(t, err)
+			b, err := io.ReadAll(rc)
+			assert.NoError(t, err)
+			_ = rc.Close()
+			files[f.Name] = string(b)
+		}
+
+		return files
+	}
+
+	t.Run("Write", func(t *testing.T) {
+		r := dataexport.NewRegistry()
+		r.Register("profile", dataexport.SourceFunc(func(_ context.Context, u domain.User) (any, error) {
+			return map[string]string{"email": u.Email}, nil
+		}))
+		r.Register("sessions", dataexport.SourceFunc(func(context.Context, domain.User) (any, error) {
+			return []string{}, nil
+		}))
+
+		var buf bytes.Buffer
+		err := r.Write(context.Background(), user, &buf)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"profile", "sessions"}, r.Categories())
+
+		files := read(t, buf.Bytes())
+		assert.Len(t, files, 3)
+		assert.JSONEq(t, `{"email":"test@test.pl"}`, files["profile.json"])
+		assert.JSONEq(t, `[]`, files["sessions.json"])
+
+		var m struct {
+			UserID     string   `json:"user_id"`
+			Categories []string `json:"categories"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(files["manifest.json"]), &m))
+		assert.Equal(t, "1", m.UserID)
+		assert.Equal(t, []string{"profile", "sessions"}, m.Categories)
+	})
+
+	t.Run("Source error", func(t *testing.T) {
+		r := dataexport.NewRegistry()
+		r.Register("profile", dataexport.SourceFunc(func(context.Context, domain.User) (any, error) {
+			return nil, assert.AnError
+		}))
+
+		err := r.Write(context.Background(), user, io.Discard)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "exporting profile")
+	})
+
+	t.Run("Registered twice", func(t *testing.T) {
+		r := dataexport.NewRegistry()
+		source := dataexport.SourceFunc(func(context.Context, domain.User) (any, error) { return nil, nil })
+		r.Register("profile", source)
+
+		assert.Panics(t, func() { r.Register("profile", source) })
+	})
+}
@@ -0,0 +1,78 @@
+package domain
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditActionCreate         AuditAction = "create"
+	AuditActionUpdate         AuditAction = "update"
+	AuditActionPasswordChange AuditAction = "password_change"
+	AuditActionDelete         AuditAction = "delete"
+	AuditActionRestore        AuditAction = "restore"
+	AuditActionErase          AuditAction = "erase"
+)
+
+const AuditTargetUser = "user"
+
+// AuditEvent records who did what to which resource. Events are chained by
+// hash, each Hash covering the event and the Hash of the previous one.
+type AuditEvent struct {
+	ID         int64
+	OccurredAt time.Time
+	Actor      string
+	Action     AuditAction
+	TargetType string
+	TargetID   string
+	Changes    map[string]Change
+	RequestID  string
+	ClientIP   string
+	UserAgent  string
+	// Certificate is only set on erasures.
+	Certificate *ErasureCertificate
+	PrevHash    string
+	Hash        string
+}
+
+// Change is the value of a field before and after a mutation, nil when the
+// field did not exist.
+type Change struct {
+	Before any `json:"before,omitempty"`
+	After  any `json:"after,omitempty"`
+}
+
+// UserChanges diffs the non-secret fields of a user, before or after is nil
+// for creates and deletes.
+func UserChanges(before, after *User) map[string]Change {
+	fields := func(u *User) map[string]any {
+		if u == nil {
+			return map[string]any{}
+		}
+
+		return map[string]any{
+			"name":    u.Name,
+			"surname": u.Surname,
+			"email":   u.Email,
+		}
+	}
+
+	b, a := fields(before), fields(after)
+
+	changes := make(map[string]Change)
+	for _, f := range []string{"name", "surname", "email"} {
+		if b[f] != a[f] {
+			changes[f] = Change{Before: b[f], After: a[f]}
+		}
+	}
+
+	return changes
+}
+
+// AuditChainStatus is the result of verifying the hash chain of the audit
+// log. BrokenAt is the ID of the first event that does not match its hash or
+// does not link to the previous event.
+type AuditChainStatus struct {
+	Valid    bool
+	Checked  int
+	BrokenAt int64
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+type PolicyKind string
+
+const (
+	PolicyTermsOfService PolicyKind = "terms_of_service"
+	PolicyPrivacy        PolicyKind = "privacy_policy"
+)
+
+// PolicyDocument is a published version of a policy users accept. Every
+// user has to accept a new mandatory version, or a later one, again.
+type PolicyDocument struct {
+	ID          string
+	Kind        PolicyKind
+	Version     string
+	URL         string
+	Mandatory   bool
+	PublishedAt time.Time
+}
+
+// Consent is the proof that a user accepted a policy document.
+type Consent struct {
+	ID         string
+	UserID     string
+	DocumentID string
+	Kind       PolicyKind
+	Version    string
+	AcceptedAt time.Time
+	ClientIP   string
+	UserAgent  string
+}
+
+// CurrentPolicies returns the latest document of every kind, by kind, given
+// documents in publication order.
+func CurrentPolicies(docs []PolicyDocument) []PolicyDocument {
+	latest := make(map[PolicyKind]PolicyDocument)
+	for _, d := range docs {
+		latest[d.Kind] = d
+	}
+
+	return sortedPolicies(latest)
+}
+
+// PendingPolicies returns the current documents, by kind, the user has to
+// accept: those of the kinds whose latest mandatory version was published
+// after the latest version the user accepted. docs are in publication order.
+func PendingPolicies(docs []PolicyDocument, consents []Consent) []PolicyDocument {
+	accepted := make(map[string]bool, len(consents))
+	for _, c := range consents {
+		accepted[c.DocumentID] = true
+	}
+
+	latest := make(map[PolicyKind]PolicyDocument)
+	done := make(map[PolicyKind]bool)
+	pending := make(map[PolicyKind]PolicyDocument)
+	for i := len(docs) - 1; i >= 0; i-- {
+		d := docs[i]
+		if _, ok := latest[d.Kind]; !ok {
+			latest[d.Kind] = d
+		}
+
+		if done[d.Kind] {
+			continue
+		}
+
+		switch {
+		case accepted[d.ID]:
+			done[d.Kind] = true
+		case d.Mandatory:
+			done[d.Kind] = true
+			pending[d.Kind] = latest[d.Kind]
+		}
+	}
+
+	return sortedPolicies(pending)
+}
+
+func sortedPolicies(byKind map[PolicyKind]PolicyDocument) []PolicyDocument {
+	res := make([]PolicyDocument, 0, len(byKind))
+	for _, d := range byKind {
+		res = append(res, d)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Kind < res[j].Kind
+	})
+
+	return res
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+type DataExportStatus string
+
+const (
+	DataExportPending DataExportStatus = "pending"
+	DataExportReady   DataExportStatus = "ready"
+	DataExportFailed  DataExportStatus = "failed"
+)
+
+// DataExport is an archive of all the data held about a user, generated for
+// a data subject access request. It is deleted once it expires.
+type DataExport struct {
+	ID         string
+	UserID     string
+	Status     DataExportStatus
+	Size       int64
+	Error      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt *time.Time
+	ExpiresAt  time.Time
+}
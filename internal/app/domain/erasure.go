@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+type ErasureMethod string
+
+const (
+	ErasureDeleted       ErasureMethod = "deleted"
+	ErasurePseudonymized ErasureMethod = "pseudonymized"
+)
+
+// ErasureRecords is how many records a data holder erased with Method.
+type ErasureRecords struct {
+	Holder  string        `json:"holder"`
+	Method  ErasureMethod `json:"method"`
+	Records int           `json:"records"`
+}
+
+// ErasureCertificate attests that the data of a user was erased. It is kept
+// in the audit log, which is why it only identifies the email by its salted
+// hash.
+type ErasureCertificate struct {
+	UserID    string           `json:"user_id"`
+	EmailHash string           `json:"email_hash"`
+	ErasedAt  time.Time        `json:"erased_at"`
+	Records   []ErasureRecords `json:"records"`
+	// AuditRedactions maps the IDs of the audit events that were
+	// pseudonymized to their hash once pseudonymized, their Hash being the
+	// one of the original event.
+	AuditRedactions map[int64]string `json:"audit_redactions,omitempty"`
+	Signature       string           `json:"signature,omitempty"`
+}
+
+// Sign returns the HMAC-SHA256 of the certificate without its signature.
+func (c ErasureCertificate) Sign(secret []byte) string {
+	c.Signature = ""
+	b, _ := json.Marshal(c)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(b)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether the certificate was signed with secret.
+func (c ErasureCertificate) Verify(secret []byte) bool {
+	return hmac.Equal([]byte(c.Signature), []byte(c.Sign(secret)))
+}
+
+// UserPseudonym replaces the personal data of an erased user in the records
+// that are retained.
+func UserPseudonym(id string) string {
+	return "erased:" + id
+}
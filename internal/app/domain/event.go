@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+type EventType string
+
+const (
+	UserCreated         EventType = "user.created"
+	UserUpdated         EventType = "user.updated"
+	UserEmailChanged    EventType = "user.email_changed"
+	UserPasswordChanged EventType = "user.password_changed"
+	UserDeleted         EventType = "user.deleted"
+	UserRestored        EventType = "user.restored"
+	UserErased          EventType = "user.erased"
+)
+
+// UserEventTypes are all the types of user events.
+var UserEventTypes = []EventType{UserCreated, UserUpdated, UserEmailChanged, UserPasswordChanged, UserDeleted, UserRestored, UserErased}
+
+// Event is a domain event published to other services through the outbox.
+// Events of the same aggregate are published in the order of their Sequence.
+// Attempts is the number of times it was published or failed to be.
+// OrganizationID is the organization of the aggregate, set by the outbox.
+type Event struct {
+	ID             string
+	Sequence       int64
+	Type           EventType
+	AggregateID    string
+	OccurredAt     time.Time
+	Data           json.RawMessage
+	Attempts       int
+	OrganizationID string
+}
+
+// UserEventDataVersion is the schema version of UserEventData, to be bumped
+// on any change consumers could break on.
+const UserEventDataVersion = 1
+
+// UserEventData is the payload of user events, it never carries the password.
+type UserEventData struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	Surname  string `json:"surname,omitempty"`
+	Email    string `json:"email,omitempty"`
+	OldEmail string `json:"old_email,omitempty"`
+}
+
+// NewEvent stamps data with a fresh ID and the current time.
+func NewEvent(t EventType, aggregateID string, data any) Event {
+	b, _ := json.Marshal(data)
+
+	return Event{
+		ID:          xid.New().String(),
+		Type:        t,
+		AggregateID: aggregateID,
+		OccurredAt:  time.Now().UTC(),
+		Data:        b,
+	}
+}
+
+// UserEvents returns the events describing the transition of a user from
+// before to after, nil before for creates and nil after for deletes.
+func UserEvents(before, after *User) []Event {
+	switch {
+	case before == nil && after == nil:
+		return nil
+	case before == nil:
+		return []Event{NewEvent(UserCreated, after.ID, userEventData(after))}
+	case after == nil:
+		return []Event{NewEvent(UserDeleted, before.ID, UserEventData{ID: before.ID})}
+	}
+
+	var events []Event
+	if len(UserChanges(before, after)) > 0 {
+		events = append(events, NewEvent(UserUpdated, after.ID, userEventData(after)))
+	}
+
+	if before.Email != after.Email {
+		events = append(events, NewEvent(UserEmailChanged, after.ID, UserEventData{ID: after.ID, Email: after.Email, OldEmail: before.Email}))
+	}
+
+	if before.Password != after.Password {
+		events = append(events, NewEvent(UserPasswordChanged, after.ID, UserEventData{ID: after.ID}))
+	}
+
+	return events
+}
+
+// PseudonymizeUserEventData replaces the personal data of data, a
+// UserEventData, with pseudonym. It reports whether there was any.
+func PseudonymizeUserEventData(data json.RawMessage, pseudonym string) (json.RawMessage, bool, error) {
+	var d UserEventData
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, false, err
+	}
+
+	before := d
+	for _, f := range []*string{&d.Name, &d.Surname, &d.Email, &d.OldEmail} {
+		if *f != "" {
+			*f = pseudonym
+		}
+	}
+
+	if d == before {
+		return data, false, nil
+	}
+
+	b, err := json.Marshal(d)
+
+	return b, true, err
+}
+
+func userEventData(u *User) UserEventData {
+	return UserEventData{
+		ID:      u.ID,
+		Name:    u.Name,
+		Surname: u.Surname,
+		Email:   u.Email,
+	}
+}
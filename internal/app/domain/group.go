@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrGroupCycle is returned when a group would end up nested in itself.
+var ErrGroupCycle = errors.New("group cycle")
+
+// Group organises users, e.g. in teams and departments. Groups nest: the
+// members of a group are inherited by its parent, and the parent of that.
+type Group struct {
+	ID          string
+	Name        string
+	Description string
+	// ParentID is empty for top level groups.
+	ParentID  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// GroupMembership is a group a user belongs to, either directly or through
+// the subgroup Via they are a direct member of.
+type GroupMembership struct {
+	Group     Group
+	Inherited bool
+	Via       string
+}
+
+// GroupMemberships returns the direct memberships of a user to the direct
+// groups, followed by the ones they inherit from the ancestors of these
+// groups, found in groups. Every group only appears once, inherited through
+// the closest subgroup.
+func GroupMemberships(direct []Group, groups map[string]Group) []GroupMembership {
+	seen := make(map[string]bool, len(groups))
+	res := make([]GroupMembership, 0, len(direct))
+	for _, g := range direct {
+		seen[g.ID] = true
+		res = append(res, GroupMembership{Group: g})
+	}
+
+	// Breadth first, so that groups are inherited through the closest
+	// subgroup the user is a direct member of.
+	level := res
+	for len(level) > 0 {
+		var next []GroupMembership
+		for _, m := range level {
+			parent, ok := groups[m.Group.ParentID]
+			if !ok || seen[parent.ID] {
+				continue
+			}
+
+			seen[parent.ID] = true
+			via := m.Via
+			if !m.Inherited {
+				via = m.Group.ID
+			}
+			next = append(next, GroupMembership{Group: parent, Inherited: true, Via: via})
+		}
+
+		res = append(res, next...)
+		level = next
+	}
+
+	return res
+}
@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+type ImportStatus string
+
+const (
+	ImportRunning   ImportStatus = "running"
+	ImportSucceeded ImportStatus = "succeeded"
+	ImportFailed    ImportStatus = "failed"
+)
+
+type ImportFormat string
+
+const (
+	ImportCSV    ImportFormat = "csv"
+	ImportNDJSON ImportFormat = "ndjson"
+)
+
+// ImportJob tracks the progress of a bulk import of users. A dry run only
+// validates the rows, Created and Invited then count what would be done.
+type ImportJob struct {
+	ID        string
+	Format    ImportFormat
+	DryRun    bool
+	Status    ImportStatus
+	Processed int
+	Created   int
+	Invited   int
+	Failed    int
+	// Errors holds the errors of the first failed rows.
+	Errors []ImportRowError
+	// Error is why the whole import failed, if it did.
+	Error      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+// ImportRowError reports why a row was not imported, rows being numbered
+// from 1 without the CSV header.
+type ImportRowError struct {
+	Row    int                `json:"row"`
+	Email  string             `json:"email,omitempty"`
+	Code   string             `json:"code"`
+	Detail string             `json:"detail,omitempty"`
+	Errors []ImportFieldError `json:"errors,omitempty"`
+	// EmailIndex is the blind index of Email while it is stored encrypted.
+	EmailIndex string `json:"email_index,omitempty"`
+}
+
+type ImportFieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// Invitation lets someone set up their own account. Only the hash of its
+// token is stored, the token itself is only ever part of the invite link.
+type Invitation struct {
+	ID             string
+	OrganizationID string
+	Email          string
+	Name           string
+	Surname        string
+	GroupID        string
+	InvitedBy      string
+	TokenHash      string
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	AcceptedAt     *time.Time
+	RevokedAt      *time.Time
+}
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationRevoked  InvitationStatus = "revoked"
+	InvitationExpired  InvitationStatus = "expired"
+)
+
+// Status returns the status of the invitation at now.
+func (i Invitation) Status(now time.Time) InvitationStatus {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationAccepted
+	case i.RevokedAt != nil:
+		return InvitationRevoked
+	case !now.Before(i.ExpiresAt):
+		return InvitationExpired
+	}
+	return InvitationPending
+}
+
+// NewInvitationToken returns a random token and its hash.
+func NewInvitationToken() (string, string) {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	return token, InvitationTokenHash(token)
+}
+
+func InvitationTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// Organization is a tenant: a customer company whose users are isolated from
+// the users of other organizations. Its slug is its subdomain.
+type Organization struct {
+	ID        string
+	Name      string
+	Slug      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
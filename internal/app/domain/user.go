@@ -1,9 +1,30 @@
 package domain
 
+import (
+	"errors"
+	"time"
+)
+
+// ErrVersionMismatch is returned when a user has been modified since the
+// version that a change was based on.
+var ErrVersionMismatch = errors.New("version mismatch")
+
+// ErrSearchUnavailable is returned by searches over encrypted personal data.
+var ErrSearchUnavailable = errors.New("search unavailable")
+
+// ErrUserNotFound is returned when a user referenced by another entity does
+// not exist, or is deleted.
+var ErrUserNotFound = errors.New("user not found")
+
 type User struct {
-	ID       string
-	Name     string
-	Surname  string
-	Email    string
-	Password string
+	ID        string
+	Name      string
+	Surname   string
+	Email     string
+	Password  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Version is incremented by every change of the user.
+	Version   int
+	DeletedAt *time.Time
 }
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// WebhookSubscription is a partner endpoint called back on events. An empty
+// EventTypes subscribes to all of them.
+type WebhookSubscription struct {
+	ID         string
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Wants reports whether events of type t are delivered to the subscription.
+func (s WebhookSubscription) Wants(t EventType) bool {
+	return s.Active && (len(s.EventTypes) == 0 || slices.Contains(s.EventTypes, t))
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryDead is a delivery given up on after too many attempts, it is
+	// only retried when redelivered manually.
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// WebhookDelivery is an event to be sent to a subscription along with the
+// outcome of the last attempt.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID string
+	EventID        string
+	EventType      EventType
+	Payload        json.RawMessage
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	// Subscription is loaded along with due deliveries.
+	Subscription *WebhookSubscription
+}
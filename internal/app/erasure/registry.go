@@ -0,0 +1,65 @@
+package erasure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// Holder is a subsystem holding data about users. Erase deletes or
+// pseudonymizes what it holds about user, returning how many records it
+// changed. It must use ctx, all holders share the transaction of the
+// erasure.
+type Holder interface {
+	Erase(ctx context.Context, user domain.User) (int, error)
+}
+
+// HolderFunc adapts a function to a Holder.
+type HolderFunc func(ctx context.Context, user domain.User) (int, error)
+
+func (f HolderFunc) Erase(ctx context.Context, user domain.User) (int, error) {
+	return f(ctx, user)
+}
+
+type registration struct {
+	name   string
+	method domain.ErasureMethod
+	holder Holder
+}
+
+// Registry erases a user from the holders registered by every subsystem.
+type Registry struct {
+	holders []registration
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds holder, erasing with method. It panics if name is already
+// registered.
+func (r *Registry) Register(name string, method domain.ErasureMethod, holder Holder) {
+	for _, h := range r.holders {
+		if h.name == name {
+			panic(fmt.Sprintf("erasure: holder %q registered twice", name))
+		}
+	}
+
+	r.holders = append(r.holders, registration{name: name, method: method, holder: holder})
+}
+
+// Erase runs the holders in registration order, stopping at the first error.
+func (r *Registry) Erase(ctx context.Context, user domain.User) ([]domain.ErasureRecords, error) {
+	records := make([]domain.ErasureRecords, 0, len(r.holders))
+	for _, h := range r.holders {
+		n, err := h.holder.Erase(ctx, user)
+		if err != nil {
+			return nil, fmt.Errorf("erasing %s: %w", h.name, err)
+		}
+
+		records = append(records, domain.ErasureRecords{Holder: h.name, Method: h.method, Records: n})
+	}
+
+	return records, nil
+}
@@ -0,0 +1,63 @@
+package erasure_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/erasure"
+)
+
+func TestRegistry_Erase(t *testing.T) {
+	user := domain.User{ID: "1", Email: "test@test.pl"}
+
+	t.Run("Erase", func(t *testing.T) {
+		var order []string
+		holder := func(name string, n int) erasure.Holder {
+			return erasure.HolderFunc(func(_ context.Context, u domain.User) (int, error) {
+				assert.Equal(t, user, u)
+				order = append(order, name)
+				return n, nil
+			})
+		}
+
+		r := erasure.NewRegistry()
+		r.Register("users", domain.ErasureDeleted, holder("users", 1))
+		r.Register("outbox_events", domain.ErasurePseudonymized, holder("outbox_events", 3))
+
+		records, err := r.Erase(context.Background(), user)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"users", "outbox_events"}, order)
+		assert.Equal(t, []domain.ErasureRecords{
+			{Holder: "users", Method: domain.ErasureDeleted, Records: 1},
+			{Holder: "outbox_events", Method: domain.ErasurePseudonymized, Records: 3},
+		}, records)
+	})
+
+	t.Run("Holder error", func(t *testing.T) {
+		r := erasure.NewRegistry()
+		r.Register("users", domain.ErasureDeleted, erasure.HolderFunc(func(context.Context, domain.User) (int, error) {
+			return 0, assert.AnError
+		}))
+		r.Register("invitations", domain.ErasureDeleted, erasure.HolderFunc(func(context.Context, domain.User) (int, error) {
+			t.Error("holder run after an error")
+			return 0, nil
+		}))
+
+		_, err := r.Erase(context.Background(), user)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.ErrorContains(t, err, "erasing users")
+	})
+
+	t.Run("Registered twice", func(t *testing.T) {
+		r := erasure.NewRegistry()
+		holder := erasure.HolderFunc(func(context.Context, domain.User) (int, error) { return 0, nil })
+		r.Register("users", domain.ErasureDeleted, holder)
+
+		assert.Panics(t, func() { r.Register("users", domain.ErasurePseudonymized, holder) })
+	})
+}
@@ -0,0 +1,72 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Beriw98/user-management/internal/app/tenant"
+)
+
+type dataExportPurger interface {
+	Purge(ctx context.Context, expiredBefore time.Time) (int, error)
+	FailStale(ctx context.Context, updatedBefore time.Time, reason string) (int, error)
+}
+
+// DataExportPurge deletes the data exports, and so the personal data in
+// their archives, once they expire. It also fails the exports pending for
+// longer than staleAfter, the instance generating them having stopped, so
+// the next request starts a new one.
+type DataExportPurge struct {
+	exports    dataExportPurger
+	interval   time.Duration
+	staleAfter time.Duration
+}
+
+func NewDataExportPurge(exports dataExportPurger, interval, staleAfter time.Duration) *DataExportPurge {
+	return &DataExportPurge{
+		exports:    exports,
+		interval:   interval,
+		staleAfter: staleAfter,
+	}
+}
+
+// Run purges the exports of every organization right away and then every
+// interval until ctx is done.
+func (p *DataExportPurge) Run(ctx context.Context) {
+	ctx = tenant.AllTenants(ctx)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *DataExportPurge) purge(ctx context.Context) {
+	l := slog.Default().With("job", "DataExportPurge")
+
+	n, err := p.exports.FailStale(ctx, time.Now().Add(-p.staleAfter), http.StatusText(http.StatusInternalServerError))
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+	} else if n > 0 {
+		l.WarnContext(ctx, "failed stale data exports", "count", n)
+	}
+
+	n, err = p.exports.Purge(ctx, time.Now())
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return
+	}
+
+	if n > 0 {
+		l.InfoContext(ctx, "purged expired data exports", "count", n)
+	}
+}
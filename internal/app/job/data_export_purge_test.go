@@ -0,0 +1,58 @@
+package job_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/internal/app/job"
+)
+
+type exportPurgerMock struct {
+	purgerMock
+}
+
+func (p *exportPurgerMock) FailStale(ctx context.Context, updatedBefore time.Time, reason string) (int, error) {
+	args := p.Called(ctx, updatedBefore, reason)
+	return args.Int(0), args.Error(1)
+}
+
+func TestDataExportPurge_Run(t *testing.T) {
+	t.Run("Purges expired", func(t *testing.T) {
+		pm := new(exportPurgerMock)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		start := time.Now()
+
+		pm.On("FailStale", allTenants, mock.MatchedBy(func(before time.Time) bool {
+			return !before.Before(start.Add(-time.Hour)) && !before.After(time.Now().Add(-time.Hour))
+		}), http.StatusText(http.StatusInternalServerError)).Return(1, nil).Once()
+		pm.On("Purge", allTenants, mock.MatchedBy(func(before time.Time) bool {
+			return !before.Before(start) && !before.After(time.Now())
+		})).Return(1, nil).Run(func(mock.Arguments) { cancel() }).Once()
+
+		job.NewDataExportPurge(pm, time.Hour, time.Hour).Run(ctx)
+
+		pm.AssertExpectations(t)
+	})
+
+	t.Run("Keeps running after error", func(t *testing.T) {
+		pm := new(exportPurgerMock)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		pm.On("FailStale", allTenants, mock.Anything, mock.Anything).Return(0, assert.AnError).Once()
+		pm.On("FailStale", allTenants, mock.Anything, mock.Anything).Return(0, nil).Once()
+		pm.On("Purge", allTenants, mock.Anything).Return(0, assert.AnError).Once()
+		pm.On("Purge", allTenants, mock.Anything).Return(0, nil).Run(func(mock.Arguments) { cancel() }).Once()
+
+		job.NewDataExportPurge(pm, time.Millisecond, time.Hour).Run(ctx)
+
+		pm.AssertExpectations(t)
+	})
+}
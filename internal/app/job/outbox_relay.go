@@ -0,0 +1,161 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+)
+
+// Publisher delivers events to other services. Publish may be called more
+// than once for the same event, consumers deduplicate by its ID.
+type Publisher interface {
+	Publish(ctx context.Context, event domain.Event) error
+}
+
+type transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type outbox interface {
+	TryLock(ctx context.Context) (bool, error)
+	Pending(ctx context.Context, limit int) ([]domain.Event, error)
+	MarkPublished(ctx context.Context, sequences ...int64) error
+	MarkFailed(ctx context.Context, sequence int64, cause error) error
+	MarkDead(ctx context.Context, sequence int64, cause error) error
+	Prune(ctx context.Context, publishedBefore time.Time) (int, error)
+}
+
+// OutboxRelay publishes the events stored in the outbox, an event failing
+// maxAttempts times is dead and no longer published. The notifier, if any, is
+// only told of the events once they are marked published, in the relay
+// transaction, so it never gets an event that failed or died.
+type OutboxRelay struct {
+	tx          transactor
+	outbox      outbox
+	publisher   Publisher
+	notifier    Publisher
+	interval    time.Duration
+	batchSize   int
+	retention   time.Duration
+	maxAttempts int
+}
+
+func NewOutboxRelay(tx transactor, outbox outbox, publisher, notifier Publisher, interval time.Duration, batchSize int, retention time.Duration, maxAttempts int) *OutboxRelay {
+	return &OutboxRelay{
+		tx:          tx,
+		outbox:      outbox,
+		publisher:   publisher,
+		notifier:    notifier,
+		interval:    interval,
+		batchSize:   batchSize,
+		retention:   retention,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Run relays the events of every organization every interval until ctx is
+// done, right away again while full batches come back. It is safe to run on
+// every instance, only the one holding the relay lock publishes.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ctx = tenant.AllTenants(ctx)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if r.relay(ctx) == r.batchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relay publishes a batch, returning the number of events it went through.
+// When an event fails the later events of its aggregate are held back until
+// it is published or dead, so every aggregate's events are delivered in
+// order.
+func (r *OutboxRelay) relay(ctx context.Context) int {
+	l := slog.Default().With("job", "OutboxRelay")
+
+	var n int
+	err := r.tx.InTx(ctx, func(ctx context.Context) error {
+		locked, err := r.outbox.TryLock(ctx)
+		if err != nil || !locked {
+			return err
+		}
+
+		events, err := r.outbox.Pending(ctx, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		failed := make(map[string]struct{})
+		published := make([]domain.Event, 0, len(events))
+		for _, event := range events {
+			if _, ok := failed[event.AggregateID]; ok {
+				continue
+			}
+
+			if err = r.publisher.Publish(ctx, event); err != nil {
+				failed[event.AggregateID] = struct{}{}
+
+				if event.Attempts+1 >= r.maxAttempts {
+					l.ErrorContext(ctx, "outbox event is dead", "event", event.ID, "type", event.Type, "error", err)
+					err = r.outbox.MarkDead(ctx, event.Sequence, err)
+				} else {
+					l.WarnContext(ctx, "publishing event failed", "event", event.ID, "type", event.Type, "error", err)
+					err = r.outbox.MarkFailed(ctx, event.Sequence, err)
+				}
+
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			published = append(published, event)
+		}
+
+		sequences := make([]int64, 0, len(published))
+		for _, event := range published {
+			sequences = append(sequences, event.Sequence)
+		}
+
+		if err = r.outbox.MarkPublished(ctx, sequences...); err != nil {
+			return err
+		}
+
+		if r.notifier != nil {
+			for _, event := range published {
+				if err = r.notifier.Publish(ctx, event); err != nil {
+					return err
+				}
+			}
+		}
+
+		if _, err = r.outbox.Prune(ctx, time.Now().Add(-r.retention)); err != nil {
+			return err
+		}
+
+		n = len(published)
+		if len(failed) > 0 {
+			// Do not spin on a failing publisher, retry on the next tick.
+			n = 0
+		}
+
+		return nil
+	})
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return 0
+	}
+
+	return n
+}
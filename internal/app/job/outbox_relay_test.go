@@ -0,0 +1,173 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/job"
+)
+
+type transactorMock struct{}
+
+func (transactorMock) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type outboxMock struct {
+	mock.Mock
+}
+
+func (o *outboxMock) TryLock(ctx context.Context) (bool, error) {
+	args := o.Called(ctx)
+	return args.Bool(0), args.Error(1)
+}
+
+func (o *outboxMock) Pending(ctx context.Context, limit int) ([]domain.Event, error) {
+	args := o.Called(ctx, limit)
+	return args.Get(0).([]domain.Event), args.Error(1)
+}
+
+func (o *outboxMock) MarkPublished(ctx context.Context, sequences ...int64) error {
+	args := o.Called(ctx, sequences)
+	return args.Error(0)
+}
+
+func (o *outboxMock) MarkFailed(ctx context.Context, sequence int64, cause error) error {
+	args := o.Called(ctx, sequence, cause)
+	return args.Error(0)
+}
+
+func (o *outboxMock) MarkDead(ctx context.Context, sequence int64, cause error) error {
+	args := o.Called(ctx, sequence, cause)
+	return args.Error(0)
+}
+
+func (o *outboxMock) Prune(ctx context.Context, publishedBefore time.Time) (int, error) {
+	args := o.Called(ctx, publishedBefore)
+	return args.Int(0), args.Error(1)
+}
+
+type publisherMock struct {
+	mock.Mock
+}
+
+func (p *publisherMock) Publish(ctx context.Context, event domain.Event) error {
+	args := p.Called(ctx, event)
+	return args.Error(0)
+}
+
+func TestOutboxRelay_Run(t *testing.T) {
+	t.Run("Publishes in order and holds back after a failure", func(t *testing.T) {
+		om, pm := new(outboxMock), new(publisherMock)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := []domain.Event{
+			{Sequence: 1, AggregateID: "a"},
+			{Sequence: 2, AggregateID: "b"},
+			{Sequence: 3, AggregateID: "a"},
+			{Sequence: 4, AggregateID: "b"},
+		}
+
+		om.On("TryLock", allTenants).Return(true, nil).Once()
+		om.On("Pending", allTenants, 10).Return(events, nil).Once()
+		pm.On("Publish", allTenants, events[0]).Return(nil).Once()
+		pm.On("Publish", allTenants, events[1]).Return(assert.AnError).Once()
+		pm.On("Publish", allTenants, events[2]).Return(nil).Once()
+		om.On("MarkFailed", allTenants, int64(2), assert.AnError).Return(nil).Once()
+		om.On("MarkPublished", allTenants, []int64{1, 3}).Return(nil).Once()
+		om.On("Prune", allTenants, mock.Anything).Return(0, nil).Run(func(mock.Arguments) { cancel() }).Once()
+
+		job.NewOutboxRelay(transactorMock{}, om, pm, nil, time.Hour, 10, time.Hour, 3).Run(ctx)
+
+		om.AssertExpectations(t)
+		pm.AssertExpectations(t)
+	})
+
+	t.Run("Notifies only of published events", func(t *testing.T) {
+		om, pm, nm := new(outboxMock), new(publisherMock), new(publisherMock)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := []domain.Event{
+			{Sequence: 1, AggregateID: "a"},
+			{Sequence: 2, AggregateID: "b"},
+		}
+
+		om.On("TryLock", allTenants).Return(true, nil).Once()
+		om.On("Pending", allTenants, 10).Return(events, nil).Once()
+		pm.On("Publish", allTenants, events[0]).Return(assert.AnError).Once()
+		pm.On("Publish", allTenants, events[1]).Return(nil).Once()
+		om.On("MarkFailed", allTenants, int64(1), assert.AnError).Return(nil).Once()
+		markPublished := om.On("MarkPublished", allTenants, []int64{2}).Return(nil).Once()
+		nm.On("Publish", allTenants, events[1]).Return(nil).Once().NotBefore(markPublished)
+		om.On("Prune", allTenants, mock.Anything).Return(0, nil).Run(func(mock.Arguments) { cancel() }).Once()
+
+		job.NewOutboxRelay(transactorMock{}, om, pm, nm, time.Hour, 10, time.Hour, 3).Run(ctx)
+
+		om.AssertExpectations(t)
+		pm.AssertExpectations(t)
+		nm.AssertExpectations(t)
+		nm.AssertNotCalled(t, "Publish", mock.Anything, events[0])
+	})
+
+	t.Run("Marks events out of attempts dead", func(t *testing.T) {
+		om, pm := new(outboxMock), new(publisherMock)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events := []domain.Event{{Sequence: 1, AggregateID: "a", Attempts: 2}}
+
+		om.On("TryLock", allTenants).Return(true, nil).Once()
+		om.On("Pending", allTenants, 10).Return(events, nil).Once()
+		pm.On("Publish", allTenants, events[0]).Return(assert.AnError).Once()
+		om.On("MarkDead", allTenants, int64(1), assert.AnError).Return(nil).Once()
+		om.On("MarkPublished", allTenants, []int64{}).Return(nil).Once()
+		om.On("Prune", allTenants, mock.Anything).Return(0, nil).Run(func(mock.Arguments) { cancel() }).Once()
+
+		job.NewOutboxRelay(transactorMock{}, om, pm, nil, time.Hour, 10, time.Hour, 3).Run(ctx)
+
+		om.AssertExpectations(t)
+		om.AssertNotCalled(t, "MarkFailed", mock.Anything, mock.Anything, mock.Anything)
+		pm.AssertExpectations(t)
+	})
+
+	t.Run("Drains full batches", func(t *testing.T) {
+		om, pm := new(outboxMock), new(publisherMock)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		first, second := []domain.Event{{Sequence: 1}}, []domain.Event{}
+
+		om.On("TryLock", allTenants).Return(true, nil).Twice()
+		om.On("Pending", allTenants, 1).Return(first, nil).Once()
+		om.On("Pending", allTenants, 1).Return(second, nil).Once()
+		pm.On("Publish", allTenants, first[0]).Return(nil).Once()
+		om.On("MarkPublished", allTenants, []int64{1}).Return(nil).Once()
+		om.On("MarkPublished", allTenants, []int64{}).Return(nil).Run(func(mock.Arguments) { cancel() }).Once()
+		om.On("Prune", allTenants, mock.Anything).Return(0, nil).Twice()
+
+		job.NewOutboxRelay(transactorMock{}, om, pm, nil, time.Hour, 1, time.Hour, 3).Run(ctx)
+
+		om.AssertExpectations(t)
+		pm.AssertExpectations(t)
+	})
+
+	t.Run("Skips when another instance relays", func(t *testing.T) {
+		om, pm := new(outboxMock), new(publisherMock)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		om.On("TryLock", allTenants).Return(false, nil).Run(func(mock.Arguments) { cancel() }).Once()
+
+		job.NewOutboxRelay(transactorMock{}, om, pm, nil, time.Hour, 10, time.Hour, 3).Run(ctx)
+
+		om.AssertExpectations(t)
+		pm.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+}
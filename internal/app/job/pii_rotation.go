@@ -0,0 +1,60 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Beriw98/user-management/internal/app/tenant"
+)
+
+type piiRotator interface {
+	RotatePII(ctx context.Context, after string, limit int) (string, int, error)
+}
+
+// PIIRotation encrypts the personal data of users again with the active
+// master key, see cmd/pii-rotate.
+type PIIRotation struct {
+	tx        transactor
+	users     piiRotator
+	batchSize int
+}
+
+func NewPIIRotation(tx transactor, users piiRotator, batchSize int) *PIIRotation {
+	return &PIIRotation{
+		tx:        tx,
+		users:     users,
+		batchSize: batchSize,
+	}
+}
+
+// Rotate goes through all users of every organization, a batch per
+// transaction so the service keeps running meanwhile, and returns how many
+// were encrypted again. It can be run again after a failure, users already
+// rotated are skipped.
+func (r *PIIRotation) Rotate(ctx context.Context) (int, error) {
+	l := slog.Default().With("job", "PIIRotation")
+	ctx = tenant.AllTenants(ctx)
+
+	var (
+		after string
+		total int
+	)
+	for {
+		var n int
+		err := r.tx.InTx(ctx, func(ctx context.Context) error {
+			var err error
+			after, n, err = r.users.RotatePII(ctx, after, r.batchSize)
+			return err
+		})
+		if err != nil {
+			return total, err
+		}
+
+		total += n
+		if after == "" {
+			return total, nil
+		}
+
+		l.InfoContext(ctx, "rotated a batch of users", "rotated", total, "after", after)
+	}
+}
@@ -0,0 +1,57 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/internal/app/job"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+)
+
+type piiRotatorMock struct {
+	mock.Mock
+}
+
+func (p *piiRotatorMock) RotatePII(ctx context.Context, after string, limit int) (string, int, error) {
+	args := p.Called(ctx, after, limit)
+	return args.String(0), args.Int(1), args.Error(2)
+}
+
+// allTenants matches contexts spanning every organization.
+var allTenants = mock.MatchedBy(func(ctx context.Context) bool {
+	_, all, _ := tenant.Scope(ctx)
+	return all
+})
+
+func TestPIIRotation_Rotate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Goes through every batch", func(t *testing.T) {
+		users := &piiRotatorMock{}
+		users.On("RotatePII", allTenants, "", 2).Return("b", 2, nil).Once()
+		users.On("RotatePII", allTenants, "b", 2).Return("c", 1, nil).Once()
+		users.On("RotatePII", allTenants, "c", 2).Return("", 0, nil).Once()
+
+		n, err := job.NewPIIRotation(transactorMock{}, users, 2).Rotate(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+		users.AssertExpectations(t)
+	})
+
+	t.Run("Stops at the first error", func(t *testing.T) {
+		users := &piiRotatorMock{}
+		users.On("RotatePII", allTenants, "", 2).Return("b", 2, nil).Once()
+		users.On("RotatePII", allTenants, "b", 2).Return("", 0, errors.New("db down")).Once()
+
+		n, err := job.NewPIIRotation(transactorMock{}, users, 2).Rotate(ctx)
+
+		assert.EqualError(t, err, "db down")
+		assert.Equal(t, 2, n)
+		users.AssertExpectations(t)
+	})
+}
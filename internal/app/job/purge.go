@@ -0,0 +1,58 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type userPurger interface {
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+// UserPurge removes users that have been soft deleted for longer than the
+// retention window.
+type UserPurge struct {
+	users     userPurger
+	retention time.Duration
+	interval  time.Duration
+}
+
+func NewUserPurge(users userPurger, retention, interval time.Duration) *UserPurge {
+	return &UserPurge{
+		users:     users,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Run purges right away and then every interval until ctx is done. Purging is
+// idempotent, so it is safe to run on every instance.
+func (p *UserPurge) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *UserPurge) purge(ctx context.Context) {
+	l := slog.Default().With("job", "UserPurge")
+
+	n, err := p.users.Purge(ctx, time.Now().Add(-p.retention))
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return
+	}
+
+	if n > 0 {
+		l.InfoContext(ctx, "purged deleted users", "count", n)
+	}
+}
@@ -0,0 +1,53 @@
+package job_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/internal/app/job"
+)
+
+type purgerMock struct {
+	mock.Mock
+}
+
+func (p *purgerMock) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	args := p.Called(ctx, deletedBefore)
+	return args.Int(0), args.Error(1)
+}
+
+func TestUserPurge_Run(t *testing.T) {
+	t.Run("Purges past retention", func(t *testing.T) {
+		pm := new(purgerMock)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		retention := 30 * 24 * time.Hour
+		start := time.Now()
+
+		pm.On("Purge", ctx, mock.MatchedBy(func(before time.Time) bool {
+			return !before.Before(start.Add(-retention)) && !before.After(time.Now().Add(-retention))
+		})).Return(2, nil).Run(func(mock.Arguments) { cancel() }).Once()
+
+		job.NewUserPurge(pm, retention, time.Hour).Run(ctx)
+
+		pm.AssertExpectations(t)
+	})
+
+	t.Run("Keeps running after error", func(t *testing.T) {
+		pm := new(purgerMock)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		pm.On("Purge", ctx, mock.Anything).Return(0, assert.AnError).Once()
+		pm.On("Purge", ctx, mock.Anything).Return(0, nil).Run(func(mock.Arguments) { cancel() }).Once()
+
+		job.NewUserPurge(pm, time.Hour, time.Millisecond).Run(ctx)
+
+		pm.AssertExpectations(t)
+	})
+}
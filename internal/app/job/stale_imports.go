@@ -0,0 +1,62 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Beriw98/user-management/internal/app/tenant"
+)
+
+type staleImportFailer interface {
+	FailStale(ctx context.Context, updatedBefore time.Time, reason string) (int, error)
+}
+
+// StaleImports fails the imports that made no progress for staleAfter, the
+// instance running them having stopped or crashed before they were done.
+type StaleImports struct {
+	imports    staleImportFailer
+	staleAfter time.Duration
+	interval   time.Duration
+}
+
+func NewStaleImports(imports staleImportFailer, staleAfter, interval time.Duration) *StaleImports {
+	return &StaleImports{
+		imports:    imports,
+		staleAfter: staleAfter,
+		interval:   interval,
+	}
+}
+
+// Run checks the imports of every organization right away and then every
+// interval until ctx is done. It is safe to run on every instance.
+func (s *StaleImports) Run(ctx context.Context) {
+	ctx = tenant.AllTenants(ctx)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.fail(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *StaleImports) fail(ctx context.Context) {
+	l := slog.Default().With("job", "StaleImports")
+
+	n, err := s.imports.FailStale(ctx, time.Now().Add(-s.staleAfter), http.StatusText(http.StatusInternalServerError))
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return
+	}
+
+	if n > 0 {
+		l.WarnContext(ctx, "failed stale imports", "count", n)
+	}
+}
@@ -0,0 +1,53 @@
+package job_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/internal/app/job"
+)
+
+type staleFailerMock struct {
+	mock.Mock
+}
+
+func (s *staleFailerMock) FailStale(ctx context.Context, updatedBefore time.Time, reason string) (int, error) {
+	args := s.Called(ctx, updatedBefore, reason)
+	return args.Int(0), args.Error(1)
+}
+
+func TestStaleImports_Run(t *testing.T) {
+	t.Run("Fails stale", func(t *testing.T) {
+		sm := new(staleFailerMock)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		start := time.Now()
+
+		sm.On("FailStale", allTenants, mock.MatchedBy(func(before time.Time) bool {
+			return !before.Before(start.Add(-time.Hour)) && !before.After(time.Now().Add(-time.Hour))
+		}), http.StatusText(http.StatusInternalServerError)).Return(1, nil).Run(func(mock.Arguments) { cancel() }).Once()
+
+		job.NewStaleImports(sm, time.Hour, time.Hour).Run(ctx)
+
+		sm.AssertExpectations(t)
+	})
+
+	t.Run("Keeps running after error", func(t *testing.T) {
+		sm := new(staleFailerMock)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sm.On("FailStale", allTenants, mock.Anything, mock.Anything).Return(0, assert.AnError).Once()
+		sm.On("FailStale", allTenants, mock.Anything, mock.Anything).Return(0, nil).Run(func(mock.Arguments) { cancel() }).Once()
+
+		job.NewStaleImports(sm, time.Hour, time.Millisecond).Run(ctx)
+
+		sm.AssertExpectations(t)
+	})
+}
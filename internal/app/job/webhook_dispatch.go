@@ -0,0 +1,142 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+)
+
+// maxWebhookBackoff caps the delay between two attempts of a delivery.
+const maxWebhookBackoff = 6 * time.Hour
+
+type webhookDeliveries interface {
+	Claim(ctx context.Context, limit int, until time.Time) ([]domain.WebhookDelivery, error)
+	SaveAttempt(ctx context.Context, d domain.WebhookDelivery) error
+}
+
+type webhookSender interface {
+	Send(ctx context.Context, d domain.WebhookDelivery) (int, error)
+}
+
+// WebhookDispatch sends due webhook deliveries, retrying failed ones with
+// exponential backoff until they run out of attempts and are dead. Claimed
+// deliveries are leased for lease, which has to outlast sending a batch.
+type WebhookDispatch struct {
+	tx          transactor
+	deliveries  webhookDeliveries
+	sender      webhookSender
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	backoff     time.Duration
+	lease       time.Duration
+}
+
+func NewWebhookDispatch(tx transactor, deliveries webhookDeliveries, sender webhookSender, interval time.Duration, batchSize, maxAttempts int, backoff, lease time.Duration) *WebhookDispatch {
+	return &WebhookDispatch{
+		tx:          tx,
+		deliveries:  deliveries,
+		sender:      sender,
+		interval:    interval,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		lease:       lease,
+	}
+}
+
+// Run dispatches the deliveries of every organization every interval until
+// ctx is done, right away again while full batches come back. Instances claim
+// the deliveries they send, so it is safe to run on all of them.
+func (w *WebhookDispatch) Run(ctx context.Context) {
+	ctx = tenant.AllTenants(ctx)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if w.dispatch(ctx) == w.batchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch sends a batch, returning the number of deliveries it claimed.
+// Claiming is the only work done in a transaction, deliveries are sent
+// without holding locks and each attempt is saved on its own, so a failed
+// save only gets its delivery sent again once the lease is over.
+func (w *WebhookDispatch) dispatch(ctx context.Context) int {
+	l := slog.Default().With("job", "WebhookDispatch")
+
+	var due []domain.WebhookDelivery
+	err := w.tx.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		due, err = w.deliveries.Claim(ctx, w.batchSize, time.Now().Add(w.lease))
+		return err
+	})
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return 0
+	}
+
+	for _, d := range due {
+		if ctx.Err() != nil {
+			// The rest is sent once the lease is over.
+			return 0
+		}
+
+		d = w.attempt(ctx, d)
+		if d.Status == domain.DeliveryDead {
+			l.WarnContext(ctx, "webhook delivery is dead", "delivery", d.ID, "subscription", d.SubscriptionID, "error", d.LastError)
+		}
+
+		if err = w.deliveries.SaveAttempt(ctx, d); err != nil {
+			l.ErrorContext(ctx, err.Error(), "delivery", d.ID)
+		}
+	}
+
+	return len(due)
+}
+
+// attempt sends d and returns it updated with the outcome.
+func (w *WebhookDispatch) attempt(ctx context.Context, d domain.WebhookDelivery) domain.WebhookDelivery {
+	now := time.Now()
+
+	code, err := w.sender.Send(ctx, d)
+
+	d.Attempts++
+	d.LastAttemptAt = &now
+	d.LastStatusCode = code
+	d.LastError = ""
+
+	switch {
+	case err == nil:
+		d.Status = domain.DeliverySucceeded
+	case d.Attempts >= w.maxAttempts:
+		d.Status = domain.DeliveryDead
+		d.LastError = err.Error()
+	default:
+		d.LastError = err.Error()
+		d.NextAttemptAt = now.Add(w.delay(d.Attempts))
+	}
+
+	return d
+}
+
+// delay doubles the backoff with every failed attempt.
+func (w *WebhookDispatch) delay(attempts int) time.Duration {
+	d := w.backoff
+	for i := 1; i < attempts && d < maxWebhookBackoff; i++ {
+		d *= 2
+	}
+
+	return min(d, maxWebhookBackoff)
+}
@@ -0,0 +1,214 @@
+package job_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/job"
+	"github.com/Beriw98/user-management/internal/infrastructure/webhook"
+)
+
+// deliveriesStub serves a single delivery while it is due and keeps the
+// attempts saved.
+type deliveriesStub struct {
+	delivery domain.WebhookDelivery
+	saved    []domain.WebhookDelivery
+	cancel   func(domain.WebhookDelivery) bool
+	stop     context.CancelFunc
+}
+
+func (d *deliveriesStub) Claim(context.Context, int, time.Time) ([]domain.WebhookDelivery, error) {
+	if d.delivery.Status != domain.DeliveryPending {
+		return nil, nil
+	}
+	return []domain.WebhookDelivery{d.delivery}, nil
+}
+
+func (d *deliveriesStub) SaveAttempt(_ context.Context, delivery domain.WebhookDelivery) error {
+	// Make retries due right away.
+	delivery.NextAttemptAt = time.Time{}
+	d.delivery = delivery
+	d.saved = append(d.saved, delivery)
+	if d.cancel(delivery) {
+		d.stop()
+	}
+	return nil
+}
+
+func TestWebhookDispatch_Run(t *testing.T) {
+	run := func(t *testing.T, handler http.HandlerFunc, maxAttempts int, done func(domain.WebhookDelivery) bool) []domain.WebhookDelivery {
+		t.Helper()
+
+		srv := httptest.NewServer(handler)
+		defer srv.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		store := &deliveriesStub{
+			delivery: domain.WebhookDelivery{
+				ID:           1,
+				Status:       domain.DeliveryPending,
+				Payload:      []byte(`{}`),
+				Subscription: &domain.WebhookSubscription{URL: srv.URL, Secret: "secret"},
+			},
+			cancel: done,
+			stop:   cancel,
+		}
+
+		job.NewWebhookDispatch(transactorMock{}, store, webhook.NewSender(time.Second, true), time.Millisecond, 1, maxAttempts, time.Minute, time.Minute).Run(ctx)
+
+		return store.saved
+	}
+
+	t.Run("Retries until delivered", func(t *testing.T) {
+		var calls atomic.Int32
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}
+
+		saved := run(t, handler, 5, func(d domain.WebhookDelivery) bool { return d.Status != domain.DeliveryPending })
+
+		assert.Len(t, saved, 3)
+		assert.Equal(t, http.StatusInternalServerError, saved[0].LastStatusCode)
+		assert.NotEmpty(t, saved[0].LastError)
+
+		last := saved[2]
+		assert.Equal(t, domain.DeliverySucceeded, last.Status)
+		assert.Equal(t, 3, last.Attempts)
+		assert.Equal(t, http.StatusOK, last.LastStatusCode)
+		assert.Empty(t, last.LastError)
+	})
+
+	t.Run("Dead after max attempts", func(t *testing.T) {
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusGone)
+		}
+
+		saved := run(t, handler, 2, func(d domain.WebhookDelivery) bool { return d.Status != domain.DeliveryPending })
+
+		assert.Len(t, saved, 2)
+		assert.Equal(t, domain.DeliveryDead, saved[1].Status)
+		assert.Equal(t, http.StatusGone, saved[1].LastStatusCode)
+	})
+}
+
+type dueStub struct {
+	deliveries []domain.WebhookDelivery
+	saved      domain.WebhookDelivery
+}
+
+func (d *dueStub) Claim(context.Context, int, time.Time) ([]domain.WebhookDelivery, error) {
+	return d.deliveries, nil
+}
+
+func (d *dueStub) SaveAttempt(_ context.Context, delivery domain.WebhookDelivery) error {
+	d.saved = delivery
+	return nil
+}
+
+type failingSender struct {
+	cancel context.CancelFunc
+}
+
+func (s failingSender) Send(context.Context, domain.WebhookDelivery) (int, error) {
+	s.cancel()
+	return 0, assert.AnError
+}
+
+func TestWebhookDispatch_Backoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		delay    time.Duration
+	}{
+		{name: "First retry", attempts: 0, delay: time.Minute},
+		{name: "Doubled", attempts: 3, delay: 8 * time.Minute},
+		{name: "Capped", attempts: 20, delay: 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			store := &dueStub{deliveries: []domain.WebhookDelivery{{ID: 1, Status: domain.DeliveryPending, Attempts: tt.attempts}}}
+			start := time.Now()
+
+			job.NewWebhookDispatch(transactorMock{}, store, failingSender{cancel}, time.Hour, 10, 100, time.Minute, time.Minute).Run(ctx)
+
+			assert.Equal(t, domain.DeliveryPending, store.saved.Status)
+			assert.WithinDuration(t, start.Add(tt.delay), store.saved.NextAttemptAt, time.Second)
+		})
+	}
+}
+
+// txFlag reports whether a transaction is open.
+type txFlag struct {
+	open bool
+}
+
+func (f *txFlag) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.open = true
+	defer func() { f.open = false }()
+	return fn(ctx)
+}
+
+type failingSaves struct {
+	deliveries []domain.WebhookDelivery
+	saves      int
+}
+
+func (f *failingSaves) Claim(context.Context, int, time.Time) ([]domain.WebhookDelivery, error) {
+	deliveries := f.deliveries
+	f.deliveries = nil
+	return deliveries, nil
+}
+
+func (f *failingSaves) SaveAttempt(context.Context, domain.WebhookDelivery) error {
+	f.saves++
+	return assert.AnError
+}
+
+type outsideTxSender struct {
+	tx     *txFlag
+	sent   []int64
+	cancel context.CancelFunc
+	total  int
+}
+
+func (s *outsideTxSender) Send(_ context.Context, d domain.WebhookDelivery) (int, error) {
+	if s.tx.open {
+		return 0, assert.AnError
+	}
+
+	s.sent = append(s.sent, d.ID)
+	if len(s.sent) == s.total {
+		s.cancel()
+	}
+	return http.StatusOK, nil
+}
+
+func TestWebhookDispatch_SendsOutsideTx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tx := new(txFlag)
+	store := &failingSaves{deliveries: []domain.WebhookDelivery{{ID: 1}, {ID: 2}}}
+	sender := &outsideTxSender{tx: tx, cancel: cancel, total: 2}
+
+	job.NewWebhookDispatch(tx, store, sender, time.Hour, 10, 5, time.Minute, time.Minute).Run(ctx)
+
+	// A failed save doesn't stop the batch nor send its delivery again.
+	assert.Equal(t, []int64{1, 2}, sender.sent)
+	assert.Equal(t, 2, store.saves)
+}
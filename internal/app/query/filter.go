@@ -0,0 +1,268 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidFilter   = errors.New("invalid filter")
+	ErrUnknownField    = errors.New("unknown field")
+	ErrUnknownOperator = errors.New("unknown operator")
+)
+
+type Operator string
+
+const (
+	Eq Operator = "eq"
+	Ne Operator = "ne"
+	Gt Operator = "gt"
+	Ge Operator = "ge"
+	Lt Operator = "lt"
+	Le Operator = "le"
+	Sw Operator = "sw"
+	Ew Operator = "ew"
+	Co Operator = "co"
+)
+
+// Expr is a node of a parsed filter: Condition, And or Or.
+type Expr interface {
+	expr()
+}
+
+type Condition struct {
+	Field    string
+	Operator Operator
+	Value    string
+}
+
+type And []Expr
+
+type Or []Expr
+
+func (Condition) expr() {}
+func (And) expr()       {}
+func (Or) expr()        {}
+
+// Fields whitelists the fields of a resource and the operators each of them
+// supports.
+type Fields map[string][]Operator
+
+// List narrows and orders a listing.
+type List struct {
+	Filter Expr
+	Sort   []Sort
+	// IncludeDeleted lists soft deleted resources too.
+	IncludeDeleted bool
+}
+
+// FieldError reports the offending field or operator of a filter.
+type FieldError struct {
+	Err   error
+	Field string
+	Value string
+}
+
+func (e *FieldError) Error() string {
+	if e.Value != "" {
+		return fmt.Sprintf("%s: %q on field %q", e.Err, e.Value, e.Field)
+	}
+
+	return fmt.Sprintf("%s: %q", e.Err, e.Field)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ParseFilter parses expressions such as
+//
+//	email sw "a" and (surname eq "Doe" or name co "J")
+//
+// Conditions are `<field> <operator> "<value>"`, values are double quoted
+// with `\"` and `\\` escapes. `and` binds tighter than `or`. An empty string
+// yields a nil Expr.
+func ParseFilter(s string, fields Fields) (Expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	p := &parser{tokens: tokens, fields: fields}
+
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, p.tokens[p.pos].text)
+	}
+
+	return e, nil
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+
+	rs := []rune(s)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")"})
+			i++
+		case r == '"':
+			var b strings.Builder
+			i++
+			for ; i < len(rs) && rs[i] != '"'; i++ {
+				if rs[i] == '\\' {
+					i++
+					if i == len(rs) || (rs[i] != '"' && rs[i] != '\\') {
+						return nil, fmt.Errorf("%w: invalid escape sequence", ErrInvalidFilter)
+					}
+				}
+				b.WriteRune(rs[i])
+			}
+
+			if i == len(rs) {
+				return nil, fmt.Errorf("%w: unterminated string", ErrInvalidFilter)
+			}
+			i++
+
+			tokens = append(tokens, token{kind: tokenString, text: b.String()})
+		default:
+			start := i
+			for i < len(rs) && !unicode.IsSpace(rs[i]) && !strings.ContainsRune(`()"`, rs[i]) {
+				i++
+			}
+
+			tokens = append(tokens, token{kind: tokenWord, text: string(rs[start:i])})
+		}
+	}
+
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	fields Fields
+}
+
+func (p *parser) or() (Expr, error) {
+	return p.list("or", p.and, func(es []Expr) Expr { return Or(es) })
+}
+
+func (p *parser) and() (Expr, error) {
+	return p.list("and", p.factor, func(es []Expr) Expr { return And(es) })
+}
+
+func (p *parser) list(keyword string, next func() (Expr, error), combine func([]Expr) Expr) (Expr, error) {
+	e, err := next()
+	if err != nil {
+		return nil, err
+	}
+
+	es := []Expr{e}
+	for p.keyword(keyword) {
+		p.pos++
+
+		if e, err = next(); err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+
+	if len(es) == 1 {
+		return es[0], nil
+	}
+
+	return combine(es), nil
+}
+
+func (p *parser) factor() (Expr, error) {
+	t, ok := p.next()
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected end of filter", ErrInvalidFilter)
+	}
+
+	if t.kind == tokenLParen {
+		e, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if t, ok = p.next(); !ok || t.kind != tokenRParen {
+			return nil, fmt.Errorf("%w: missing closing parenthesis", ErrInvalidFilter)
+		}
+
+		return e, nil
+	}
+
+	if t.kind != tokenWord {
+		return nil, fmt.Errorf("%w: expected field, got %q", ErrInvalidFilter, t.text)
+	}
+
+	ops, ok := p.fields[t.text]
+	if !ok {
+		return nil, &FieldError{Err: ErrUnknownField, Field: t.text}
+	}
+
+	op, ok := p.next()
+	if !ok || op.kind != tokenWord {
+		return nil, fmt.Errorf("%w: expected operator after %q", ErrInvalidFilter, t.text)
+	}
+
+	if !slices.Contains(ops, Operator(strings.ToLower(op.text))) {
+		return nil, &FieldError{Err: ErrUnknownOperator, Field: t.text, Value: op.text}
+	}
+
+	v, ok := p.next()
+	if !ok || v.kind != tokenString {
+		return nil, fmt.Errorf("%w: expected quoted value after %q", ErrInvalidFilter, op.text)
+	}
+
+	return Condition{Field: t.text, Operator: Operator(strings.ToLower(op.text)), Value: v.text}, nil
+}
+
+func (p *parser) next() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+
+	t := p.tokens[p.pos]
+	p.pos++
+
+	return t, true
+}
+
+func (p *parser) keyword(k string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenWord && strings.EqualFold(p.tokens[p.pos].text, k)
+}
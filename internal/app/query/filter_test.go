@@ -0,0 +1,126 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/query"
+)
+
+var fields = query.Fields{
+	"id":      {query.Eq},
+	"name":    {query.Eq, query.Sw},
+	"surname": {query.Eq, query.Ne},
+	"email":   {query.Eq, query.Sw, query.Ew},
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   query.Expr
+	}{
+		{
+			name:   "Empty",
+			filter: " ",
+			want:   nil,
+		},
+		{
+			name:   "Condition",
+			filter: `email sw "a"`,
+			want:   query.Condition{Field: "email", Operator: query.Sw, Value: "a"},
+		},
+		{
+			name:   "And binds tighter than or",
+			filter: `email sw "a" AND surname eq "Doe" or name eq "John"`,
+			want: query.Or{
+				query.And{
+					query.Condition{Field: "email", Operator: query.Sw, Value: "a"},
+					query.Condition{Field: "surname", Operator: query.Eq, Value: "Doe"},
+				},
+				query.Condition{Field: "name", Operator: query.Eq, Value: "John"},
+			},
+		},
+		{
+			name:   "Parentheses",
+			filter: `email sw "a" and (surname eq "Doe" or name eq "John")`,
+			want: query.And{
+				query.Condition{Field: "email", Operator: query.Sw, Value: "a"},
+				query.Or{
+					query.Condition{Field: "surname", Operator: query.Eq, Value: "Doe"},
+					query.Condition{Field: "name", Operator: query.Eq, Value: "John"},
+				},
+			},
+		},
+		{
+			name:   "Escapes",
+			filter: `name eq "say \"hi\" \\ (or not)"`,
+			want:   query.Condition{Field: "name", Operator: query.Eq, Value: `say "hi" \ (or not)`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := query.ParseFilter(tt.filter, fields)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		err    error
+	}{
+		{name: "Unknown field", filter: `password eq "x"`, err: query.ErrUnknownField},
+		{name: "Unknown operator", filter: `email co "x"`, err: query.ErrUnknownOperator},
+		{name: "Operator not allowed for field", filter: `id sw "x"`, err: query.ErrUnknownOperator},
+		{name: "Missing value", filter: `email eq`, err: query.ErrInvalidFilter},
+		{name: "Unquoted value", filter: `email eq x`, err: query.ErrInvalidFilter},
+		{name: "Unterminated string", filter: `email eq "x`, err: query.ErrInvalidFilter},
+		{name: "Invalid escape", filter: `email eq "\x"`, err: query.ErrInvalidFilter},
+		{name: "Dangling and", filter: `email eq "x" and`, err: query.ErrInvalidFilter},
+		{name: "Missing parenthesis", filter: `(email eq "x"`, err: query.ErrInvalidFilter},
+		{name: "Trailing token", filter: `email eq "x" "y"`, err: query.ErrInvalidFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := query.ParseFilter(tt.filter, fields)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	t.Run("ParseSort", func(t *testing.T) {
+		got, err := query.ParseSort("surname,-name, +email", fields)
+		assert.NoError(t, err)
+		assert.Equal(t, []query.Sort{{Field: "surname"}, {Field: "name", Desc: true}, {Field: "email"}}, got)
+		assert.Equal(t, "surname,-name,email", query.FormatSort(got))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		got, err := query.ParseSort("", fields)
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		_, err := query.ParseSort("-password", fields)
+		assert.ErrorIs(t, err, query.ErrUnknownField)
+	})
+
+	t.Run("Duplicate field", func(t *testing.T) {
+		_, err := query.ParseSort("name,-name", fields)
+		assert.ErrorIs(t, err, query.ErrInvalidSort)
+	})
+
+	t.Run("Empty field", func(t *testing.T) {
+		_, err := query.ParseSort("name,,email", fields)
+		assert.ErrorIs(t, err, query.ErrInvalidSort)
+	})
+}
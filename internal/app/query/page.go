@@ -0,0 +1,26 @@
+package query
+
+import "errors"
+
+var ErrInvalidPage = errors.New("invalid page")
+
+// Page selects a keyset page. After and Before hold the sort key values of
+// the boundary row followed by its ID and are exclusive. At most one of them
+// is set.
+type Page struct {
+	After  []string
+	Before []string
+	Limit  int
+}
+
+func (p Page) Backward() bool {
+	return len(p.Before) > 0
+}
+
+// PageInfo describes a returned page. First and Last hold the sort keys of
+// its boundary rows, to be used as Before and After of adjacent pages.
+type PageInfo struct {
+	HasMore bool
+	First   []string
+	Last    []string
+}
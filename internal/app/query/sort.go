@@ -0,0 +1,62 @@
+package query
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidSort = errors.New("invalid sort")
+
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort parses a comma separated list of fields, a `-` prefix sorts the
+// field in descending order, e.g. `surname,-name`.
+func ParseSort(s string, fields Fields) ([]Sort, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var sorts []Sort
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+
+		field, desc := strings.CutPrefix(part, "-")
+		if !desc {
+			field = strings.TrimPrefix(field, "+")
+		}
+
+		if field == "" {
+			return nil, &FieldError{Err: ErrInvalidSort, Field: part}
+		}
+
+		if _, ok := fields[field]; !ok {
+			return nil, &FieldError{Err: ErrUnknownField, Field: field}
+		}
+
+		if slices.ContainsFunc(sorts, func(s Sort) bool { return s.Field == field }) {
+			return nil, &FieldError{Err: ErrInvalidSort, Field: field}
+		}
+
+		sorts = append(sorts, Sort{Field: field, Desc: desc})
+	}
+
+	return sorts, nil
+}
+
+// FormatSort is the inverse of ParseSort.
+func FormatSort(sorts []Sort) string {
+	parts := make([]string, 0, len(sorts))
+	for _, s := range sorts {
+		if s.Desc {
+			parts = append(parts, "-"+s.Field)
+		} else {
+			parts = append(parts, s.Field)
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
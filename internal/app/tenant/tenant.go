@@ -0,0 +1,44 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrMissing is returned by tenant scoped queries made without a tenant in
+// their context.
+var ErrMissing = errors.New("no tenant in context")
+
+type key struct{}
+
+type allKey struct{}
+
+// NewContext returns a context scoped to the organization id.
+func NewContext(parent context.Context, id string) context.Context {
+	return context.WithValue(parent, key{}, id)
+}
+
+// FromContext returns the organization ctx is scoped to, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(key{}).(string)
+	return id, ok && id != ""
+}
+
+// AllTenants returns a context under which tenant scoped queries span every
+// organization, for maintenance such as purging deleted users. The tenant
+// of parent, if any, still applies.
+func AllTenants(parent context.Context) context.Context {
+	return context.WithValue(parent, allKey{}, true)
+}
+
+// Scope returns the organization ctx is scoped to. all is true when it spans
+// every organization instead, and ok false when it is scoped to none.
+func Scope(ctx context.Context) (id string, all, ok bool) {
+	if id, ok := FromContext(ctx); ok {
+		return id, false, true
+	}
+
+	all, _ = ctx.Value(allKey{}).(bool)
+
+	return "", all, all
+}
@@ -0,0 +1,36 @@
+package tenant_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/tenant"
+)
+
+func TestScope(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		id   string
+		all  bool
+		ok   bool
+	}{
+		{name: "None", ctx: context.Background()},
+		{name: "Tenant", ctx: tenant.NewContext(context.Background(), "acme"), id: "acme", ok: true},
+		{name: "All tenants", ctx: tenant.AllTenants(context.Background()), all: true, ok: true},
+		{name: "Tenant wins", ctx: tenant.AllTenants(tenant.NewContext(context.Background(), "acme")), id: "acme", ok: true},
+		{name: "Empty tenant", ctx: tenant.NewContext(context.Background(), "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, all, ok := tenant.Scope(tt.ctx)
+
+			assert.Equal(t, tt.id, id)
+			assert.Equal(t, tt.all, all)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
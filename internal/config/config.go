@@ -2,12 +2,108 @@ package config
 
 import (
 	"errors"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
 	DatabaseURI string
+	LocalesPath string
+	// PageSizeMax caps the `limit` query param of list endpoints.
+	PageSizeMax int
+	// CursorSecret signs pagination cursors, it has to be shared by all
+	// instances of the service.
+	CursorSecret string
+	// UserRetention is how long soft deleted users can be restored before
+	// the purge job removes them for good, which it checks every
+	// UserPurgeInterval.
+	UserRetention     time.Duration
+	UserPurgeInterval time.Duration
+	// CacheControl maps route paths, such as `/users/:id`, to the
+	// Cache-Control header of their GET responses.
+	CacheControl map[string]string
+	// OutboxPollInterval is how often the relay looks for unpublished
+	// events, taking up to OutboxBatchSize at a time. Published events are
+	// kept for OutboxRetention. An event failing OutboxMaxAttempts times is
+	// dead and no longer published.
+	OutboxPollInterval time.Duration
+	OutboxBatchSize    int
+	OutboxRetention    time.Duration
+	OutboxMaxAttempts  int
+	// WebhookPollInterval is how often due webhook deliveries are sent,
+	// WebhookBatchSize at a time, each waiting up to WebhookTimeout for a
+	// response. Failed deliveries are retried after WebhookBackoff, doubled
+	// on every attempt, until WebhookMaxAttempts. Deliveries are not sent to
+	// internal addresses unless WebhookAllowPrivate is set.
+	WebhookPollInterval time.Duration
+	WebhookBatchSize    int
+	WebhookTimeout      time.Duration
+	WebhookBackoff      time.Duration
+	WebhookMaxAttempts  int
+	WebhookAllowPrivate bool
+	// Broker is the kind of message broker (`nats`, `kafka` or `memory`)
+	// events are published to as CloudEvents, in CloudEventsMode, they are
+	// only logged if it is empty. BrokerURL is comma separated for several
+	// Kafka brokers.
+	Broker            string
+	BrokerURL         string
+	BrokerTopic       string
+	CloudEventsMode   string
+	CloudEventsSource string
+	// ImportMaxSize caps the size in bytes of bulk imports of users. The
+	// people imported without a password, like those invited on their own,
+	// are sent a link made of InvitationURL and a token valid for
+	// InvitationTTL by default. Imports making no progress for
+	// ImportStaleAfter are failed, their instance having stopped.
+	ImportMaxSize    int64
+	ImportStaleAfter time.Duration
+	InvitationURL    string
+	InvitationTTL    time.Duration
+	// DataExportSecret signs the download links of data subject access
+	// exports, which are valid for DataExportLinkTTL. Archives are kept for
+	// DataExportRetention, checked every DataExportPurgeInterval, a request
+	// waits up to DataExportWait for its archive to be ready. Exports pending
+	// for DataExportStaleAfter are failed, their instance having stopped.
+	DataExportSecret        string
+	DataExportLinkTTL       time.Duration
+	DataExportRetention     time.Duration
+	DataExportPurgeInterval time.Duration
+	DataExportWait          time.Duration
+	DataExportStaleAfter    time.Duration
+	// ErasureSecret signs erasure certificates, ErasureSalt salts the hash
+	// of the emails of erased users kept to block their re-import.
+	ErasureSecret string
+	ErasureSalt   string
+	// PIIMasterKeys are the master keys wrapping the data keys of the
+	// encrypted personal data of users, as comma separated `id:key` pairs
+	// with base64 32 byte keys, the first one being active. PIIKMSFile is
+	// the key file of a local KMS used instead. Personal data is stored in
+	// cleartext unless either is set. PIIIndexKey keys the blind index of
+	// encrypted emails. PIIRotationBatchSize is the number of users
+	// cmd/pii-rotate encrypts again per transaction.
+	PIIMasterKeys        string
+	PIIKMSFile           string
+	PIIIndexKey          string
+	PIIRotationBatchSize int
+	// BatchMaxSize caps the number of operations of a batch of users.
+	BatchMaxSize int
+	// SSEHeartbeat is how often idle event streams get a comment keeping
+	// proxies from closing them.
+	SSEHeartbeat time.Duration
+	// TenantDomain is the domain whose subdomains are the slugs of
+	// organizations. TenantTokenSecret verifies the HS256 bearer tokens
+	// naming the organization of requests in their TenantTokenClaim.
+	// Requests naming no organization are made for TenantDefault, or
+	// rejected if it is empty.
+	TenantDomain      string
+	TenantTokenSecret string
+	TenantTokenClaim  string
+	TenantDefault     string
+	// TrustedProxies are the comma separated CIDRs of the reverse proxies
+	// whose X-Forwarded-For header names the client IP kept by audit events
+	// and consents. The IP of the connection is used if it is empty.
+	TrustedProxies string
 }
 
 func New() *Config {
@@ -17,6 +113,41 @@ func New() *Config {
 	vpr.SetConfigName("config")
 	vpr.AddConfigPath(".")
 	vpr.SetConfigType("yaml")
+	vpr.SetDefault("page_size_max", 100)
+	vpr.SetDefault("user_retention", 30*24*time.Hour)
+	vpr.SetDefault("user_purge_interval", time.Hour)
+	vpr.SetDefault("cache_control", map[string]string{
+		"/users":        "private, no-cache",
+		"/users/:id":    "private, no-cache",
+		"/users/search": "private, no-cache",
+	})
+
+	vpr.SetDefault("outbox_poll_interval", time.Second)
+	vpr.SetDefault("outbox_batch_size", 100)
+	vpr.SetDefault("outbox_retention", 7*24*time.Hour)
+	vpr.SetDefault("webhook_poll_interval", time.Second)
+	vpr.SetDefault("webhook_batch_size", 20)
+	vpr.SetDefault("webhook_timeout", 10*time.Second)
+	vpr.SetDefault("webhook_backoff", 30*time.Second)
+	vpr.SetDefault("outbox_max_attempts", 20)
+	vpr.SetDefault("webhook_max_attempts", 10)
+	vpr.SetDefault("broker_topic", "user-events")
+	vpr.SetDefault("cloudevents_mode", "binary")
+	vpr.SetDefault("cloudevents_source", "/user-management")
+	vpr.SetDefault("import_max_size", 100<<20)
+	vpr.SetDefault("import_stale_after", 15*time.Minute)
+	vpr.SetDefault("invitation_url", "http://localhost:8080/invitations/")
+	vpr.SetDefault("invitation_ttl", 7*24*time.Hour)
+	vpr.SetDefault("batch_max_size", 100)
+	vpr.SetDefault("data_export_link_ttl", 15*time.Minute)
+	vpr.SetDefault("data_export_retention", 24*time.Hour)
+	vpr.SetDefault("data_export_purge_interval", time.Hour)
+	vpr.SetDefault("data_export_wait", 5*time.Second)
+	vpr.SetDefault("data_export_stale_after", 15*time.Minute)
+	vpr.SetDefault("sse_heartbeat", 15*time.Second)
+	vpr.SetDefault("pii_rotation_batch_size", 500)
+	vpr.SetDefault("tenant_token_claim", "org_id")
+	vpr.SetDefault("tenant_default", "default")
 
 	if err := vpr.ReadInConfig(); err != nil {
 		var configFileNotFoundError viper.ConfigFileNotFoundError
@@ -26,6 +157,62 @@ func New() *Config {
 	}
 
 	return &Config{
-		DatabaseURI: vpr.GetString("database_uri"),
+		DatabaseURI:  vpr.GetString("database_uri"),
+		LocalesPath:  vpr.GetString("locales_path"),
+		PageSizeMax:  vpr.GetInt("page_size_max"),
+		CursorSecret: vpr.GetString("cursor_secret"),
+
+		UserRetention:     vpr.GetDuration("user_retention"),
+		UserPurgeInterval: vpr.GetDuration("user_purge_interval"),
+		CacheControl:      vpr.GetStringMapString("cache_control"),
+
+		OutboxPollInterval: vpr.GetDuration("outbox_poll_interval"),
+		OutboxBatchSize:    vpr.GetInt("outbox_batch_size"),
+		OutboxRetention:    vpr.GetDuration("outbox_retention"),
+		OutboxMaxAttempts:  vpr.GetInt("outbox_max_attempts"),
+
+		WebhookPollInterval: vpr.GetDuration("webhook_poll_interval"),
+		WebhookBatchSize:    vpr.GetInt("webhook_batch_size"),
+		WebhookTimeout:      vpr.GetDuration("webhook_timeout"),
+		WebhookBackoff:      vpr.GetDuration("webhook_backoff"),
+		WebhookMaxAttempts:  vpr.GetInt("webhook_max_attempts"),
+		WebhookAllowPrivate: vpr.GetBool("webhook_allow_private"),
+
+		Broker:            vpr.GetString("broker"),
+		BrokerURL:         vpr.GetString("broker_url"),
+		BrokerTopic:       vpr.GetString("broker_topic"),
+		CloudEventsMode:   vpr.GetString("cloudevents_mode"),
+		CloudEventsSource: vpr.GetString("cloudevents_source"),
+
+		ImportMaxSize:    vpr.GetInt64("import_max_size"),
+		ImportStaleAfter: vpr.GetDuration("import_stale_after"),
+		InvitationURL:    vpr.GetString("invitation_url"),
+		InvitationTTL:    vpr.GetDuration("invitation_ttl"),
+
+		BatchMaxSize: vpr.GetInt("batch_max_size"),
+
+		DataExportSecret:        vpr.GetString("data_export_secret"),
+		DataExportLinkTTL:       vpr.GetDuration("data_export_link_ttl"),
+		DataExportRetention:     vpr.GetDuration("data_export_retention"),
+		DataExportPurgeInterval: vpr.GetDuration("data_export_purge_interval"),
+		DataExportWait:          vpr.GetDuration("data_export_wait"),
+		DataExportStaleAfter:    vpr.GetDuration("data_export_stale_after"),
+
+		ErasureSecret: vpr.GetString("erasure_secret"),
+		ErasureSalt:   vpr.GetString("erasure_salt"),
+
+		PIIMasterKeys:        vpr.GetString("pii_master_keys"),
+		PIIKMSFile:           vpr.GetString("pii_kms_file"),
+		PIIIndexKey:          vpr.GetString("pii_index_key"),
+		PIIRotationBatchSize: vpr.GetInt("pii_rotation_batch_size"),
+
+		SSEHeartbeat: vpr.GetDuration("sse_heartbeat"),
+
+		TenantDomain:      vpr.GetString("tenant_domain"),
+		TenantTokenSecret: vpr.GetString("tenant_token_secret"),
+		TenantTokenClaim:  vpr.GetString("tenant_token_claim"),
+		TenantDefault:     vpr.GetString("tenant_default"),
+
+		TrustedProxies: vpr.GetString("trusted_proxies"),
 	}
 }
@@ -2,8 +2,10 @@ package container
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
+	"time"
 
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
@@ -11,16 +13,50 @@ import (
 	"github.com/jackc/pgx/v5/stdlib"
 
 	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/dataexport"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/erasure"
+	"github.com/Beriw98/user-management/internal/app/job"
 	"github.com/Beriw98/user-management/internal/config"
 	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+	"github.com/Beriw98/user-management/internal/infrastructure/encryption"
 	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/cursor"
+	"github.com/Beriw98/user-management/internal/infrastructure/mail"
+	"github.com/Beriw98/user-management/internal/infrastructure/messaging"
+	"github.com/Beriw98/user-management/internal/infrastructure/stream"
+	"github.com/Beriw98/user-management/internal/infrastructure/webhook"
 )
 
 type Container struct {
-	DB             *ent.Client
-	Logger         *slog.Logger
-	UserRepository *repository.User
-	UserHandler    *handler.UserHTTPHandler
+	Config          *config.Config
+	DB              *ent.Client
+	Logger          *slog.Logger
+	UserRepository  *repository.User
+	Organizations   *repository.Organization
+	UserHandler     *handler.UserHTTPHandler
+	SearchHandler   *handler.UserSearchHTTPHandler
+	ExportHandler   *handler.UserExportHTTPHandler
+	AuditHandler    *handler.AuditHTTPHandler
+	WebhookHandler  *handler.WebhookHTTPHandler
+	EventsHandler   *handler.UserEventsHTTPHandler
+	DataExport      *handler.DataExportHTTPHandler
+	Erasure         *handler.ErasureHTTPHandler
+	Consent         *handler.ConsentHTTPHandler
+	GroupHandler    *handler.GroupHTTPHandler
+	Organization    *handler.OrganizationHTTPHandler
+	UserPurge       *job.UserPurge
+	DataExportPurge *job.DataExportPurge
+	StaleImports    *job.StaleImports
+	OutboxRelay     *job.OutboxRelay
+	WebhookDispatch *job.WebhookDispatch
+	EventListener   *stream.Listener
+	// PIIRotation and LocalKMS are nil unless personal data is encrypted,
+	// respectively with a local KMS.
+	PIIRotation *job.PIIRotation
+	LocalKMS    *encryption.LocalKMS
+	// Broker is nil unless configured.
+	Broker messaging.Broker
 }
 
 func NewContainer(cfg *config.Config) (*Container, error) {
@@ -36,19 +72,202 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	if err != nil {
 		return nil, err
 	}
-	drv := sql.OpenDB(dialect.Postgres, stdlib.OpenDB(*pool.Config().ConnConfig))
+	// The database checks the organization of every transaction too, see
+	// repository.TenantDriver.
+	drv := repository.NewTenantDriver(sql.OpenDB(dialect.Postgres, stdlib.OpenDB(*pool.Config().ConnConfig)))
 	client := ent.NewClient(ent.Driver(drv))
 
-	userRepository := repository.NewUserRepository(client)
-	userHandler := handler.NewUserHTTPHandler(userRepository)
-
 	l := slog.New(slog.NewTextHandler(os.Stdout, nil))
 	slog.SetDefault(l)
 
+	var cursors *cursor.Codec
+	if cfg.CursorSecret != "" {
+		cursors = cursor.NewCodec([]byte(cfg.CursorSecret))
+	} else {
+		l.Warn("CURSOR_SECRET is not set, pagination cursors are valid for this instance only")
+	}
+
+	transactor := repository.NewTransactor(client)
+	repository.IsolateTenants(client)
+	organizationRepository := repository.NewOrganizationRepository(client)
+
+	var (
+		kms      encryption.KMS
+		localKMS *encryption.LocalKMS
+	)
+	switch {
+	case cfg.PIIMasterKeys != "":
+		if kms, err = encryption.ParseKeyring(cfg.PIIMasterKeys); err != nil {
+			return nil, err
+		}
+	case cfg.PIIKMSFile != "":
+		if localKMS, err = encryption.NewLocalKMS(cfg.PIIKMSFile); err != nil {
+			return nil, err
+		}
+		kms = localKMS
+	default:
+		l.Warn("PII_MASTER_KEYS and PII_KMS_FILE are not set, the personal data of users is stored in cleartext")
+	}
+
+	var (
+		userRepository       *repository.User
+		importJobRepository  *repository.ImportJob
+		invitationRepository *repository.Invitation
+		piiRotation          *job.PIIRotation
+	)
+	if kms != nil {
+		if cfg.PIIIndexKey == "" {
+			return nil, errors.New("PII_INDEX_KEY is required to encrypt personal data")
+		}
+
+		cipher := encryption.NewCipher(kms, []byte(cfg.PIIIndexKey))
+		repository.EncryptPIICopies(client, cipher)
+		userRepository = repository.NewEncryptedUserRepository(client, cipher)
+		importJobRepository = repository.NewEncryptedImportJobRepository(client, cipher)
+		invitationRepository = repository.NewEncryptedInvitationRepository(client, cipher)
+		piiRotation = job.NewPIIRotation(transactor, userRepository, cfg.PIIRotationBatchSize)
+	} else {
+		userRepository = repository.NewUserRepository(client)
+		importJobRepository = repository.NewImportJobRepository(client)
+		invitationRepository = repository.NewInvitationRepository(client)
+	}
+	auditEventRepository := repository.NewAuditEventRepository(client)
+	outbox := repository.NewOutboxRepository(client)
+	webhookRepository := repository.NewWebhookRepository(client)
+	dataExportRepository := repository.NewDataExportRepository(client)
+	policyRepository := repository.NewPolicyDocumentRepository(client)
+	consentRepository := repository.NewConsentRepository(client)
+	groupRepository := repository.NewGroupRepository(client)
+	if cfg.ErasureSalt == "" {
+		l.Warn("ERASURE_SALT is not set, the email hashes of erased users are unsalted")
+	}
+	tombstones := repository.NewErasureTombstoneRepository(client, []byte(cfg.ErasureSalt))
+	mailer := mail.NewLogMailer()
+	userHandler := handler.NewUserHTTPHandler(
+		userRepository,
+		handler.WithPagination(cursors, cfg.PageSizeMax),
+		handler.WithAudit(transactor, auditEventRepository),
+		handler.WithOutbox(transactor, outbox),
+		handler.WithImport(
+			importJobRepository,
+			invitationRepository,
+			mailer,
+			cfg.InvitationURL,
+			cfg.InvitationTTL,
+			cfg.ImportMaxSize,
+		),
+		handler.WithInvitations(invitationRepository, mailer, groupRepository, cfg.InvitationURL, cfg.InvitationTTL),
+		handler.WithBatch(transactor, cfg.BatchMaxSize),
+		handler.WithErasureTombstones(tombstones),
+		handler.WithConsents(policyRepository, consentRepository),
+	)
+	consentHandler := handler.NewConsentHTTPHandler(userRepository, policyRepository, consentRepository)
+	groupHandler := handler.NewGroupHTTPHandler(groupRepository, userRepository, transactor, cursors, cfg.PageSizeMax)
+	organizationHandler := handler.NewOrganizationHTTPHandler(organizationRepository)
+	if cfg.DataExportSecret == "" {
+		l.Warn("DATA_EXPORT_SECRET is not set, data export download links are valid for this instance only")
+	}
+
+	dataSources := dataexport.NewRegistry()
+	dataSources.Register("profile", handler.ProfileSource)
+	dataSources.Register("audit_events", handler.AuditEventSource(auditEventRepository))
+	dataSources.Register("invitations", handler.InvitationSource(invitationRepository))
+	dataSources.Register("consents", handler.ConsentSource(consentRepository))
+	dataSources.Register("groups", handler.GroupSource(groupRepository))
+	dataExportHandler := handler.NewDataExportHTTPHandler(
+		userRepository,
+		dataExportRepository,
+		dataSources,
+		[]byte(cfg.DataExportSecret),
+		cfg.DataExportLinkTTL,
+		cfg.DataExportRetention,
+		cfg.DataExportWait,
+	)
+	if cfg.ErasureSecret == "" {
+		l.Warn("ERASURE_SECRET is not set, erasure certificates can only be verified by this instance")
+	}
+
+	holders := erasure.NewRegistry()
+	holders.Register("users", domain.ErasureDeleted, userRepository)
+	holders.Register("invitations", domain.ErasureDeleted, invitationRepository)
+	holders.Register("data_exports", domain.ErasureDeleted, dataExportRepository)
+	holders.Register("consents", domain.ErasureDeleted, consentRepository)
+	holders.Register("import_jobs", domain.ErasurePseudonymized, importJobRepository)
+	holders.Register("outbox_events", domain.ErasurePseudonymized, outbox)
+	holders.Register("webhook_deliveries", domain.ErasurePseudonymized, webhookRepository)
+	erasureHandler := handler.NewErasureHTTPHandler(
+		userRepository,
+		transactor,
+		auditEventRepository,
+		outbox,
+		holders,
+		tombstones,
+		[]byte(cfg.ErasureSecret),
+	)
+	dataExportPurge := job.NewDataExportPurge(dataExportRepository, cfg.DataExportPurgeInterval, cfg.DataExportStaleAfter)
+	staleImports := job.NewStaleImports(importJobRepository, cfg.ImportStaleAfter, cfg.ImportStaleAfter/3)
+	searchHandler := handler.NewUserSearchHTTPHandler(userRepository, cfg.PageSizeMax)
+	exportHandler := handler.NewUserExportHTTPHandler(userRepository)
+	auditHandler := handler.NewAuditHTTPHandler(auditEventRepository, cursors, cfg.PageSizeMax)
+	userPurge := job.NewUserPurge(userRepository, cfg.UserRetention, cfg.UserPurgeInterval)
+	webhookHandler := handler.NewWebhookHTTPHandler(webhookRepository, cfg.PageSizeMax)
+	eventStream := repository.NewEventStreamRepository(client)
+	hub := stream.NewHub()
+	eventsHandler := handler.NewUserEventsHTTPHandler(eventStream, hub, cfg.SSEHeartbeat)
+	eventListener := stream.NewListener(pool, repository.EventChannel, hub)
+	var broker messaging.Broker
+	var brokerPublisher job.Publisher = messaging.NewLogPublisher()
+	if cfg.Broker != "" {
+		mode, err := messaging.ParseMode(cfg.CloudEventsMode)
+		if err != nil {
+			return nil, err
+		}
+
+		if broker, err = messaging.NewBroker(cfg.Broker, cfg.BrokerURL, cfg.BrokerTopic, mode); err != nil {
+			return nil, err
+		}
+		brokerPublisher = messaging.NewCloudEventPublisher(broker, cfg.CloudEventsSource)
+	}
+
+	publisher := messaging.NewFanout(brokerPublisher, webhookRepository)
+	outboxRelay := job.NewOutboxRelay(transactor, outbox, publisher, eventStream, cfg.OutboxPollInterval, cfg.OutboxBatchSize, cfg.OutboxRetention, cfg.OutboxMaxAttempts)
+	webhookDispatch := job.NewWebhookDispatch(
+		transactor,
+		webhookRepository,
+		webhook.NewSender(cfg.WebhookTimeout, cfg.WebhookAllowPrivate),
+		cfg.WebhookPollInterval,
+		cfg.WebhookBatchSize,
+		cfg.WebhookMaxAttempts,
+		cfg.WebhookBackoff,
+		// Deliveries of a batch are sent one after the other.
+		cfg.WebhookTimeout*time.Duration(cfg.WebhookBatchSize)+time.Minute,
+	)
+
 	return &Container{
-		DB:             client,
-		UserRepository: userRepository,
-		UserHandler:    userHandler,
-		Logger:         l,
+		Config:          cfg,
+		DB:              client,
+		UserRepository:  userRepository,
+		Organizations:   organizationRepository,
+		UserHandler:     userHandler,
+		SearchHandler:   searchHandler,
+		ExportHandler:   exportHandler,
+		AuditHandler:    auditHandler,
+		WebhookHandler:  webhookHandler,
+		EventsHandler:   eventsHandler,
+		DataExport:      dataExportHandler,
+		Erasure:         erasureHandler,
+		Consent:         consentHandler,
+		GroupHandler:    groupHandler,
+		Organization:    organizationHandler,
+		UserPurge:       userPurge,
+		DataExportPurge: dataExportPurge,
+		StaleImports:    staleImports,
+		OutboxRelay:     outboxRelay,
+		WebhookDispatch: webhookDispatch,
+		EventListener:   eventListener,
+		PIIRotation:     piiRotation,
+		LocalKMS:        localKMS,
+		Broker:          broker,
+		Logger:          l,
 	}, nil
 }
@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	"github.com/Beriw98/user-management/ent/hook"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+type AuditEvent struct {
+	ent.Schema
+}
+
+// Fields of the AuditEvent. The auto incremented id orders the hash chain.
+func (AuditEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id"),
+		field.Time("occurred_at").
+			Immutable(),
+		field.String("actor").
+			Immutable().
+			Default(""),
+		field.String("action").
+			GoType(domain.AuditAction("")).
+			Immutable().
+			NotEmpty(),
+		field.String("target_type").
+			Immutable().
+			NotEmpty(),
+		field.String("target_id").
+			Immutable().
+			NotEmpty(),
+		field.JSON("changes", map[string]domain.Change{}).
+			Immutable().
+			Optional(),
+		field.String("request_id").
+			Immutable().
+			Default(""),
+		field.String("client_ip").
+			Immutable().
+			Default(""),
+		field.String("user_agent").
+			Immutable().
+			Default(""),
+		field.JSON("certificate", &domain.ErasureCertificate{}).
+			Immutable().
+			Optional(),
+		field.String("prev_hash").
+			Immutable(),
+		field.String("hash").
+			Immutable().
+			Unique(),
+		// OrganizationID is set from the tenant of the context, see
+		// repository.IsolateTenants.
+		field.String("organization_id").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Indexes of the AuditEvent. Every organization has a hash chain of its own.
+func (AuditEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("organization_id", "id"),
+		index.Fields("target_type", "target_id"),
+		index.Fields("actor"),
+	}
+}
+
+// Hooks of the AuditEvent. The log is append-only, the database enforces it
+// too, see migrations. Only erasures pseudonymize events, bypassing ent.
+func (AuditEvent) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.Reject(ent.OpUpdate | ent.OpUpdateOne | ent.OpDelete | ent.OpDeleteOne),
+	}
+}
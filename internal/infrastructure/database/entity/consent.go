@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// Consent records that a user accepted a policy document, every acceptance
+// is kept as proof.
+type Consent struct {
+	ent.Schema
+}
+
+// Fields of the Consent.
+func (Consent) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Immutable(),
+		field.String("user_id").
+			Immutable(),
+		field.String("document_id").
+			Immutable(),
+		field.String("kind").
+			GoType(domain.PolicyKind("")).
+			Immutable(),
+		field.String("version").
+			Immutable(),
+		field.Time("accepted_at").
+			Immutable().
+			Default(time.Now),
+		field.String("client_ip").
+			Immutable().
+			Optional(),
+		field.String("user_agent").
+			Immutable().
+			Optional(),
+		// OrganizationID is set from the tenant of the context, see
+		// repository.IsolateTenants.
+		field.String("organization_id").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Indexes of the Consent.
+func (Consent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id"),
+	}
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+type DataExport struct {
+	ent.Schema
+}
+
+// Fields of the DataExport.
+func (DataExport) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Immutable(),
+		field.String("user_id").
+			Immutable(),
+		field.String("status").
+			GoType(domain.DataExportStatus("")).
+			Default(string(domain.DataExportPending)),
+		field.Bytes("archive").
+			Optional().
+			Sensitive(),
+		field.Int64("size").
+			Default(0),
+		field.String("error").
+			Optional(),
+		field.Time("finished_at").
+			Optional().
+			Nillable(),
+		field.Time("expires_at"),
+		// OrganizationID is set from the tenant of the context, see
+		// repository.IsolateTenants.
+		field.String("organization_id").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Indexes of the DataExport.
+func (DataExport) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id"),
+		index.Fields("expires_at"),
+	}
+}
+
+// Mixin of the DataExport.
+func (DataExport) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ErasureTombstone remembers that a user was erased, by the salted hash of
+// their email only.
+type ErasureTombstone struct {
+	ent.Schema
+}
+
+// Fields of the ErasureTombstone.
+func (ErasureTombstone) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Immutable(),
+		field.String("email_hash").
+			Immutable().
+			NotEmpty(),
+		field.String("user_id"),
+		field.Time("erased_at"),
+		// OrganizationID is set from the tenant of the context, see
+		// repository.IsolateTenants.
+		field.String("organization_id").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Indexes of the ErasureTombstone. The same email may be erased in every
+// organization.
+func (ErasureTombstone) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("organization_id", "email_hash").
+			Unique(),
+	}
+}
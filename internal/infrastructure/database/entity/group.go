@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+type Group struct {
+	ent.Schema
+}
+
+// Mixin of the Group.
+func (Group) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
+
+// Fields of the Group.
+func (Group) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Immutable(),
+		field.String("name").
+			NotEmpty(),
+		field.String("description").
+			Optional(),
+		field.String("parent_id").
+			Optional().
+			Nillable(),
+		// OrganizationID is set from the tenant of the context, see
+		// repository.IsolateTenants.
+		field.String("organization_id").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Edges of the Group. Members of a group are members of its parent too,
+// see repository.Group.ListByUser.
+func (Group) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("members", User.Type),
+		edge.To("children", Group.Type).
+			From("parent").
+			Unique().
+			Field("parent_id").
+			Annotations(entsql.OnDelete(entsql.SetNull)),
+	}
+}
+
+// Indexes of the Group. Names only have to be unique within an organization.
+func (Group) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("organization_id", "name").
+			Unique(),
+		index.Fields("parent_id"),
+	}
+}
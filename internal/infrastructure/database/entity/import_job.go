@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+type ImportJob struct {
+	ent.Schema
+}
+
+// Fields of the ImportJob.
+func (ImportJob) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Immutable(),
+		field.String("format").
+			GoType(domain.ImportFormat("")).
+			Immutable(),
+		field.Bool("dry_run").
+			Immutable().
+			Default(false),
+		field.String("status").
+			GoType(domain.ImportStatus("")).
+			Default(string(domain.ImportRunning)),
+		field.Int("processed").
+			Default(0),
+		field.Int("created").
+			Default(0),
+		field.Int("invited").
+			Default(0),
+		field.Int("failed").
+			Default(0),
+		field.JSON("row_errors", []domain.ImportRowError{}).
+			Optional(),
+		field.String("error").
+			Optional(),
+		field.Time("finished_at").
+			Optional().
+			Nillable(),
+		// OrganizationID is set from the tenant of the context, see
+		// repository.IsolateTenants.
+		field.String("organization_id").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Indexes of the ImportJob.
+func (ImportJob) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("organization_id"),
+	}
+}
+
+// Mixin of the ImportJob.
+func (ImportJob) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
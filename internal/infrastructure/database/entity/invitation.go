@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+type Invitation struct {
+	ent.Schema
+}
+
+// Fields of the Invitation.
+func (Invitation) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Immutable(),
+		field.String("email").
+			Immutable().
+			NotEmpty(),
+		// EmailIndex is the blind index of the email when it is encrypted,
+		// see repository.EncryptPIICopies.
+		field.String("email_index").
+			Optional().
+			Nillable().
+			Immutable(),
+		field.String("name").
+			Optional(),
+		field.String("surname").
+			Optional(),
+		// TokenHash changes when the invitation is sent again.
+		field.String("token_hash").
+			Sensitive().
+			NotEmpty(),
+		field.Time("expires_at"),
+		field.Time("accepted_at").
+			Optional().
+			Nillable(),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now),
+		field.String("organization_id").
+			Optional().
+			Immutable(),
+		// GroupID is the group the invitee joins once they accept.
+		field.String("group_id").
+			Optional().
+			Nillable().
+			Immutable(),
+		field.String("invited_by").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Indexes of the Invitation.
+func (Invitation) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("token_hash").
+			Unique(),
+		index.Fields("email"),
+		index.Fields("email_index"),
+		index.Fields("organization_id"),
+	}
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"encoding/json"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+type OutboxEvent struct {
+	ent.Schema
+}
+
+// Fields of the OutboxEvent. The auto incremented id is the order events are
+// published in.
+func (OutboxEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id"),
+		field.String("event_id").
+			Immutable().
+			Unique().
+			NotEmpty(),
+		field.String("type").
+			GoType(domain.EventType("")).
+			Immutable().
+			NotEmpty(),
+		field.String("aggregate_id").
+			Immutable().
+			NotEmpty(),
+		field.Time("occurred_at").
+			Immutable(),
+		// Only pseudonymized by erasures.
+		field.JSON("data", json.RawMessage{}),
+		field.Time("published_at").
+			Optional().
+			Nillable(),
+		field.Int("attempts").
+			Default(0),
+		field.String("last_error").
+			Optional(),
+		// Set once the event ran out of attempts, it is no longer published.
+		field.Time("dead_at").
+			Optional().
+			Nillable(),
+		// OrganizationID is set from the tenant of the context, see
+		// repository.IsolateTenants.
+		field.String("organization_id").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Indexes of the OutboxEvent.
+func (OutboxEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("id").
+			StorageKey("outbox_events_pending_idx").
+			Annotations(entsql.IndexWhere("published_at IS NULL AND dead_at IS NULL")),
+		index.Fields("aggregate_id", "id").
+			StorageKey("outbox_events_failed_idx").
+			Annotations(entsql.IndexWhere("published_at IS NULL AND dead_at IS NULL AND attempts > 0")),
+		index.Fields("organization_id", "id"),
+	}
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// PolicyDocument is a version of a policy users accept, it never changes once
+// published.
+type PolicyDocument struct {
+	ent.Schema
+}
+
+// Fields of the PolicyDocument.
+func (PolicyDocument) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Immutable(),
+		field.String("kind").
+			GoType(domain.PolicyKind("")).
+			Immutable(),
+		field.String("version").
+			Immutable().
+			NotEmpty(),
+		field.String("url").
+			Immutable(),
+		field.Bool("mandatory").
+			Immutable(),
+		field.Time("published_at").
+			Immutable().
+			Default(time.Now),
+	}
+}
+
+// Indexes of the PolicyDocument.
+func (PolicyDocument) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("kind", "version").
+			Unique(),
+	}
+}
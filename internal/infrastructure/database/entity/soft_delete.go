@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+
+	gen "github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/hook"
+	"github.com/Beriw98/user-management/ent/intercept"
+)
+
+// SoftDeleteMixin turns deletes into setting `deleted_at` and hides deleted
+// rows from queries and updates, unless the context comes from
+// SkipSoftDelete.
+type SoftDeleteMixin struct {
+	mixin.Schema
+}
+
+const FieldDeletedAt = "deleted_at"
+
+type softDeleteKey struct{}
+
+// SkipSoftDelete returns a context under which deleted rows are visible and
+// deletes remove rows for good.
+func SkipSoftDelete(parent context.Context) context.Context {
+	return context.WithValue(parent, softDeleteKey{}, true)
+}
+
+func skipSoftDelete(ctx context.Context) bool {
+	skip, _ := ctx.Value(softDeleteKey{}).(bool)
+	return skip
+}
+
+// Fields of the SoftDeleteMixin.
+func (SoftDeleteMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time(FieldDeletedAt).
+			Optional().
+			Nillable(),
+	}
+}
+
+// Interceptors of the SoftDeleteMixin.
+func (d SoftDeleteMixin) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		intercept.TraverseFunc(func(ctx context.Context, q intercept.Query) error {
+			if !skipSoftDelete(ctx) {
+				d.p(q)
+			}
+			return nil
+		}),
+	}
+}
+
+// Hooks of the SoftDeleteMixin.
+func (d SoftDeleteMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+					if skipSoftDelete(ctx) {
+						return next.Mutate(ctx, m)
+					}
+
+					mx, ok := m.(interface {
+						SetOp(ent.Op)
+						Client() *gen.Client
+						SetDeletedAt(time.Time)
+						WhereP(...func(*sql.Selector))
+					})
+					if !ok {
+						return nil, fmt.Errorf("unexpected mutation type %T", m)
+					}
+
+					// The update hook below hides the rows deleted already.
+					mx.SetOp(ent.OpUpdate)
+					mx.SetDeletedAt(time.Now())
+
+					return mx.Client().Mutate(ctx, m)
+				})
+			},
+			ent.OpDeleteOne|ent.OpDelete,
+		),
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+					if skipSoftDelete(ctx) {
+						return next.Mutate(ctx, m)
+					}
+
+					mx, ok := m.(interface {
+						WhereP(...func(*sql.Selector))
+					})
+					if !ok {
+						return nil, fmt.Errorf("unexpected mutation type %T", m)
+					}
+
+					d.p(mx)
+
+					return next.Mutate(ctx, m)
+				})
+			},
+			ent.OpUpdateOne|ent.OpUpdate,
+		),
+	}
+}
+
+func (SoftDeleteMixin) p(w interface{ WhereP(...func(*sql.Selector)) }) {
+	w.WhereP(sql.FieldIsNull(FieldDeletedAt))
+}
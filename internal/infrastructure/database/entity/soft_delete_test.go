@@ -0,0 +1,20 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/infrastructure/database/entity"
+)
+
+func TestSoftDeleteMixin_Fields(t *testing.T) {
+	t.Run("Fields", func(t *testing.T) {
+		got := entity.SoftDeleteMixin{}.Fields()
+
+		assert.Len(t, got, 1)
+		assert.Equal(t, entity.FieldDeletedAt, got[0].Descriptor().Name)
+		assert.True(t, got[0].Descriptor().Optional)
+		assert.True(t, got[0].Descriptor().Nillable)
+	})
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+)
+
+// TimeMixin records when rows are created and last updated.
+type TimeMixin struct {
+	mixin.Schema
+}
+
+const (
+	FieldCreatedAt = "created_at"
+	FieldUpdatedAt = "updated_at"
+)
+
+// Fields of the TimeMixin.
+func (TimeMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time(FieldCreatedAt).
+			Immutable().
+			Default(time.Now),
+		field.Time(FieldUpdatedAt).
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
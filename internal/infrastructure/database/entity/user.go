@@ -2,7 +2,10 @@ package entity
 
 import (
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 	"github.com/rs/xid"
 )
 
@@ -10,6 +13,15 @@ type User struct {
 	ent.Schema
 }
 
+// Mixin of the User.
+func (User) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+		VersionMixin{},
+		SoftDeleteMixin{},
+	}
+}
+
 // Fields of the User.
 func (User) Fields() []ent.Field {
 	return []ent.Field{
@@ -18,9 +30,44 @@ func (User) Fields() []ent.Field {
 		field.String("name"),
 		field.String("surname"),
 		field.String("email").
-			Unique().
 			NotEmpty(),
 		field.String("password").
 			NotEmpty(),
+		// EmailIndex is the blind index of the email when it is encrypted,
+		// see repository.EncryptUserPII.
+		field.String("email_index").
+			Optional().
+			Nillable(),
+		// OrganizationID is set from the tenant of the context, see
+		// repository.IsolateTenants.
+		field.String("organization_id").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Edges of the User.
+func (User) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("groups", Group.Type).
+			Ref("members"),
+		edge.From("organization", Organization.Type).
+			Ref("users").
+			Field("organization_id").
+			Unique().
+			Immutable(),
+	}
+}
+
+// Indexes of the User. Emails only have to be unique among the users of an
+// organization that are not deleted, encrypted ones by their blind index.
+func (User) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("organization_id", "email").
+			Unique().
+			Annotations(entsql.IndexWhere(FieldDeletedAt + " IS NULL")),
+		index.Fields("organization_id", "email_index").
+			Unique().
+			Annotations(entsql.IndexWhere(FieldDeletedAt + " IS NULL")),
 	}
 }
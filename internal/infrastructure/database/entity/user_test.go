@@ -3,6 +3,7 @@ package entity_test
 import (
 	"testing"
 
+	"entgo.io/ent"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/Beriw98/user-management/internal/infrastructure/database/entity"
@@ -13,11 +14,35 @@ func TestUser_Fields(t *testing.T) {
 		u := entity.User{}
 		got := u.Fields()
 
-		assert.Len(t, got, 5)
+		assert.Len(t, got, 7)
 		assert.Equal(t, "id", got[0].Descriptor().Name)
 		assert.Equal(t, "name", got[1].Descriptor().Name)
 		assert.Equal(t, "surname", got[2].Descriptor().Name)
 		assert.Equal(t, "email", got[3].Descriptor().Name)
 		assert.Equal(t, "password", got[4].Descriptor().Name)
+		assert.Equal(t, "email_index", got[5].Descriptor().Name)
+		assert.Equal(t, "organization_id", got[6].Descriptor().Name)
+	})
+}
+
+func TestUser_Mixin(t *testing.T) {
+	t.Run("Mixin", func(t *testing.T) {
+		got := entity.User{}.Mixin()
+
+		assert.Equal(t, []ent.Mixin{
+			entity.TimeMixin{},
+			entity.VersionMixin{},
+			entity.SoftDeleteMixin{},
+		}, got)
+	})
+}
+
+func TestVersionMixin_Fields(t *testing.T) {
+	t.Run("Fields", func(t *testing.T) {
+		got := entity.VersionMixin{}.Fields()
+
+		assert.Len(t, got, 1)
+		assert.Equal(t, entity.FieldVersion, got[0].Descriptor().Name)
+		assert.Equal(t, 1, got[0].Descriptor().Default)
 	})
 }
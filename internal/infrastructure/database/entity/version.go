@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+
+	"github.com/Beriw98/user-management/ent/hook"
+)
+
+// VersionMixin adds a version incremented by every update, used for
+// optimistic concurrency control and ETags.
+type VersionMixin struct {
+	mixin.Schema
+}
+
+const FieldVersion = "version"
+
+// Fields of the VersionMixin.
+func (VersionMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int(FieldVersion).
+			Default(1).
+			Positive(),
+	}
+}
+
+// Hooks of the VersionMixin.
+func (VersionMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+					mx, ok := m.(interface {
+						AddVersion(int)
+						AddedVersion() (int, bool)
+					})
+					if !ok {
+						return nil, fmt.Errorf("unexpected mutation type %T", m)
+					}
+
+					if _, ok = mx.AddedVersion(); !ok {
+						mx.AddVersion(1)
+					}
+
+					return next.Mutate(ctx, m)
+				})
+			},
+			ent.OpUpdateOne|ent.OpUpdate,
+		),
+	}
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+type WebhookDelivery struct {
+	ent.Schema
+}
+
+// Fields of the WebhookDelivery.
+func (WebhookDelivery) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id"),
+		field.String("subscription_id").
+			Immutable(),
+		field.String("event_id").
+			Immutable().
+			NotEmpty(),
+		field.String("event_type").
+			GoType(domain.EventType("")).
+			Immutable().
+			NotEmpty(),
+		// Only pseudonymized by erasures.
+		field.JSON("payload", json.RawMessage{}),
+		field.String("status").
+			GoType(domain.DeliveryStatus("")).
+			Default(string(domain.DeliveryPending)),
+		field.Int("attempts").
+			Default(0),
+		field.Time("next_attempt_at").
+			Default(time.Now),
+		field.Time("last_attempt_at").
+			Optional().
+			Nillable(),
+		field.Int("last_status_code").
+			Optional(),
+		field.String("last_error").
+			Optional(),
+		field.Time("created_at").
+			Immutable().
+			Default(time.Now),
+		// OrganizationID is the one of the subscription, see
+		// repository.IsolateTenants.
+		field.String("organization_id").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Edges of the WebhookDelivery.
+func (WebhookDelivery) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("subscription", WebhookSubscription.Type).
+			Ref("deliveries").
+			Field("subscription_id").
+			Unique().
+			Required().
+			Immutable(),
+	}
+}
+
+// Indexes of the WebhookDelivery. Redelivering an event does not enqueue it
+// again, it resets its delivery.
+func (WebhookDelivery) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("subscription_id", "event_id").
+			Unique(),
+		index.Fields("status", "next_attempt_at"),
+	}
+}
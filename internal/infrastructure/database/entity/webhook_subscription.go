@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+type WebhookSubscription struct {
+	ent.Schema
+}
+
+// Fields of the WebhookSubscription.
+func (WebhookSubscription) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			Immutable(),
+		field.String("url").
+			NotEmpty(),
+		field.String("secret").
+			Sensitive().
+			NotEmpty(),
+		field.JSON("event_types", []domain.EventType{}).
+			Optional(),
+		field.Bool("active").
+			Default(true),
+		// OrganizationID is set from the tenant of the context, see
+		// repository.IsolateTenants.
+		field.String("organization_id").
+			Optional().
+			Immutable(),
+	}
+}
+
+// Edges of the WebhookSubscription.
+func (WebhookSubscription) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("deliveries", WebhookDelivery.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Indexes of the WebhookSubscription.
+func (WebhookSubscription) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("organization_id"),
+	}
+}
+
+// Mixin of the WebhookSubscription.
+func (WebhookSubscription) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
@@ -0,0 +1,367 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/auditevent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+)
+
+// auditChainLock is the key of the advisory locks serializing the appends to
+// the chain of an organization, so that every event links to the one
+// appended before it.
+const auditChainLock = 0x61756469
+
+const auditVerifyBatch = 500
+
+// genesisHash is the PrevHash of the first event.
+var genesisHash = strings.Repeat("0", 64)
+
+type AuditEvent struct {
+	client *ent.Client
+}
+
+func NewAuditEventRepository(client *ent.Client) *AuditEvent {
+	return &AuditEvent{
+		client: client,
+	}
+}
+
+// Append adds event to the end of the hash chain of the organization of ctx.
+// It joins the transaction in ctx, so the event is only recorded if the
+// change it describes is.
+func (a *AuditEvent) Append(ctx context.Context, event domain.AuditEvent) error {
+	tx := ent.TxFromContext(ctx)
+	if tx == nil {
+		return NewTransactor(a.client).InTx(ctx, func(ctx context.Context) error {
+			return a.Append(ctx, event)
+		})
+	}
+
+	id, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrMissing
+	}
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1, hashtext($2))", auditChainLock, id); err != nil {
+		return err
+	}
+
+	event.PrevHash = genesisHash
+
+	last, err := tx.AuditEvent.Query().
+		Where(auditevent.OrganizationID(id)).
+		Order(auditevent.ByID(sql.OrderDesc())).
+		First(ctx)
+	switch {
+	case err == nil:
+		event.PrevHash = last.Hash
+	case !ent.IsNotFound(err):
+		return err
+	}
+
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	// Postgres keeps microseconds, the hash has to survive the round trip.
+	event.OccurredAt = event.OccurredAt.UTC().Truncate(time.Microsecond)
+	event.Hash = auditHash(event)
+
+	create := tx.AuditEvent.Create().
+		SetOccurredAt(event.OccurredAt).
+		SetActor(event.Actor).
+		SetAction(event.Action).
+		SetTargetType(event.TargetType).
+		SetTargetID(event.TargetID).
+		SetChanges(event.Changes).
+		SetRequestID(event.RequestID).
+		SetClientIP(event.ClientIP).
+		SetUserAgent(event.UserAgent).
+		SetPrevHash(event.PrevHash).
+		SetHash(event.Hash).
+		SetOrganizationID(id)
+	if event.Certificate != nil {
+		create = create.SetCertificate(event.Certificate)
+	}
+
+	return create.Exec(ctx)
+}
+
+// List returns up to page.Limit events matching filter, newest first.
+// Only forward pages are supported.
+func (a *AuditEvent) List(ctx context.Context, filter query.Expr, page query.Page) ([]domain.AuditEvent, query.PageInfo, error) {
+	var info query.PageInfo
+
+	q := a.client.AuditEvent.Query()
+	if filter != nil {
+		p, err := auditEventPredicate(filter)
+		if err != nil {
+			return nil, info, err
+		}
+		q = q.Where(p)
+	}
+
+	if len(page.After) > 0 {
+		id, err := auditEventID(page.After)
+		if err != nil {
+			return nil, info, err
+		}
+		q = q.Where(auditevent.IDLT(id))
+	}
+
+	events, err := q.Order(auditevent.ByID(sql.OrderDesc())).Limit(page.Limit + 1).All(ctx)
+	if err != nil {
+		return nil, info, err
+	}
+
+	info.HasMore = len(events) > page.Limit
+	if info.HasMore {
+		events = events[:page.Limit]
+	}
+
+	if len(events) > 0 {
+		info.First = []string{strconv.FormatInt(events[0].ID, 10)}
+		info.Last = []string{strconv.FormatInt(events[len(events)-1].ID, 10)}
+	}
+
+	res := make([]domain.AuditEvent, 0, len(events))
+	for _, e := range events {
+		res = append(res, auditEventFromEntity(e))
+	}
+
+	return res, info, nil
+}
+
+// Verify walks the whole chain of the organization of ctx, recomputing the
+// hash of every event. Events pseudonymized by an erasure keep their original
+// hash to link the chain, their content has to match the hash recorded by the
+// erasure certificate.
+func (a *AuditEvent) Verify(ctx context.Context) (domain.AuditChainStatus, error) {
+	status := domain.AuditChainStatus{Valid: true}
+
+	redactions, err := a.redactions(ctx)
+	if err != nil {
+		return status, err
+	}
+
+	prev, after := genesisHash, int64(0)
+	for {
+		events, err := a.client.AuditEvent.Query().
+			Where(auditevent.IDGT(after)).
+			Order(auditevent.ByID()).
+			Limit(auditVerifyBatch).
+			All(ctx)
+		if err != nil {
+			return status, err
+		}
+
+		for _, e := range events {
+			event := auditEventFromEntity(e)
+			hash := auditHash(event)
+			if event.PrevHash != prev || (event.Hash != hash && redactions[event.ID] != hash) {
+				status.Valid = false
+				status.BrokenAt = event.ID
+				return status, nil
+			}
+
+			status.Checked++
+			prev, after = event.Hash, event.ID
+		}
+
+		if len(events) < auditVerifyBatch {
+			return status, nil
+		}
+	}
+}
+
+// Pseudonymize replaces the personal data of userID in the events it is the
+// target of, and the client of the requests it made itself. It returns the
+// new hash of the events that changed, to be recorded by the erasure
+// certificate. It must run in the transaction appending the certificate.
+func (a *AuditEvent) Pseudonymize(ctx context.Context, userID string) (map[int64]string, error) {
+	tx := ent.TxFromContext(ctx)
+	if tx == nil {
+		return nil, ErrNoTx
+	}
+
+	events, err := tx.AuditEvent.Query().
+		Where(auditevent.Or(
+			auditevent.And(auditevent.TargetType(domain.AuditTargetUser), auditevent.TargetID(userID)),
+			auditevent.Actor(userID),
+		)).
+		Order(auditevent.ByID()).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pseudonym := domain.UserPseudonym(userID)
+	redactions := make(map[int64]string)
+	for _, e := range events {
+		event := auditEventFromEntity(e)
+		redacted := pseudonymizeAuditEvent(event, userID, pseudonym)
+		if reflect.DeepEqual(event, redacted) {
+			continue
+		}
+
+		if len(redactions) == 0 {
+			if _, err = tx.ExecContext(ctx, "SELECT set_config('audit.erasure', 'on', true)"); err != nil {
+				return nil, err
+			}
+		}
+
+		var changes any
+		if redacted.Changes != nil {
+			b, err := json.Marshal(redacted.Changes)
+			if err != nil {
+				return nil, err
+			}
+			changes = string(b)
+		}
+
+		// The ent hooks reject any update of the log.
+		if _, err = tx.ExecContext(ctx,
+			"UPDATE audit_events SET changes = $1, client_ip = $2, user_agent = $3 WHERE id = $4",
+			changes, redacted.ClientIP, redacted.UserAgent, redacted.ID,
+		); err != nil {
+			return nil, err
+		}
+
+		redactions[event.ID] = auditHash(redacted)
+	}
+
+	return redactions, nil
+}
+
+// redactions returns the hashes of the pseudonymized events by ID, as
+// recorded by the erasure certificates. Later erasures of the same event
+// override earlier ones.
+func (a *AuditEvent) redactions(ctx context.Context) (map[int64]string, error) {
+	events, err := a.client.AuditEvent.Query().
+		Where(auditevent.ActionEQ(domain.AuditActionErase), auditevent.CertificateNotNil()).
+		Order(auditevent.ByID()).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	redactions := make(map[int64]string)
+	for _, e := range events {
+		if e.Certificate == nil {
+			continue
+		}
+
+		for id, hash := range e.Certificate.AuditRedactions {
+			redactions[id] = hash
+		}
+	}
+
+	return redactions, nil
+}
+
+// pseudonymizeAuditEvent replaces the values of the changes of events
+// targeting userID, and the client of the requests userID or someone
+// anonymous made to it.
+func pseudonymizeAuditEvent(event domain.AuditEvent, userID, pseudonym string) domain.AuditEvent {
+	target := event.TargetType == domain.AuditTargetUser && event.TargetID == userID
+
+	if target && len(event.Changes) > 0 {
+		changes := make(map[string]domain.Change, len(event.Changes))
+		for field, c := range event.Changes {
+			if c.Before != nil {
+				c.Before = pseudonym
+			}
+			if c.After != nil {
+				c.After = pseudonym
+			}
+			changes[field] = c
+		}
+		event.Changes = changes
+	}
+
+	if event.Actor == userID || (target && event.Actor == "") {
+		event.ClientIP = ""
+		event.UserAgent = ""
+	}
+
+	return event
+}
+
+// auditHash covers every field of the event but the ID, which is only known
+// after the insert. The previous hash links it into the chain.
+func auditHash(e domain.AuditEvent) string {
+	b, _ := json.Marshal(struct {
+		PrevHash   string                   `json:"prev_hash"`
+		OccurredAt time.Time                `json:"occurred_at"`
+		Actor      string                   `json:"actor"`
+		Action     domain.AuditAction       `json:"action"`
+		TargetType string                   `json:"target_type"`
+		TargetID   string                   `json:"target_id"`
+		Changes    map[string]domain.Change `json:"changes"`
+		RequestID  string                   `json:"request_id"`
+		ClientIP   string                   `json:"client_ip"`
+		UserAgent  string                   `json:"user_agent"`
+		// Omitted when nil so events predating certificates keep their hash.
+		Certificate *domain.ErasureCertificate `json:"certificate,omitempty"`
+	}{
+		PrevHash:    e.PrevHash,
+		OccurredAt:  e.OccurredAt.UTC(),
+		Actor:       e.Actor,
+		Action:      e.Action,
+		TargetType:  e.TargetType,
+		TargetID:    e.TargetID,
+		Changes:     e.Changes,
+		RequestID:   e.RequestID,
+		ClientIP:    e.ClientIP,
+		UserAgent:   e.UserAgent,
+		Certificate: e.Certificate,
+	})
+
+	sum := sha256.Sum256(b)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func auditEventFromEntity(e *ent.AuditEvent) domain.AuditEvent {
+	return domain.AuditEvent{
+		ID:          e.ID,
+		OccurredAt:  e.OccurredAt,
+		Actor:       e.Actor,
+		Action:      e.Action,
+		TargetType:  e.TargetType,
+		TargetID:    e.TargetID,
+		Changes:     e.Changes,
+		RequestID:   e.RequestID,
+		ClientIP:    e.ClientIP,
+		UserAgent:   e.UserAgent,
+		Certificate: e.Certificate,
+		PrevHash:    e.PrevHash,
+		Hash:        e.Hash,
+	}
+}
+
+func auditEventID(keys []string) (int64, error) {
+	if len(keys) != 1 {
+		return 0, fmt.Errorf("%w: expected 1 key, got %d", query.ErrInvalidPage, len(keys))
+	}
+
+	id, err := strconv.ParseInt(keys[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", query.ErrInvalidPage, err)
+	}
+
+	return id, nil
+}
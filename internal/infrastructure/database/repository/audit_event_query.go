@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Beriw98/user-management/ent/auditevent"
+	"github.com/Beriw98/user-management/ent/predicate"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+)
+
+// auditEventFields maps the filterable fields to the predicates generated by
+// ent, parsing the values of non-string fields.
+var auditEventFields = map[string]map[query.Operator]func(string) (predicate.AuditEvent, error){
+	auditevent.FieldID: {
+		query.Eq: auditEventInt(auditevent.IDEQ),
+		query.Gt: auditEventInt(auditevent.IDGT),
+		query.Ge: auditEventInt(auditevent.IDGTE),
+		query.Lt: auditEventInt(auditevent.IDLT),
+		query.Le: auditEventInt(auditevent.IDLTE),
+	},
+	auditevent.FieldOccurredAt: {
+		query.Gt: auditEventTime(auditevent.OccurredAtGT),
+		query.Ge: auditEventTime(auditevent.OccurredAtGTE),
+		query.Lt: auditEventTime(auditevent.OccurredAtLT),
+		query.Le: auditEventTime(auditevent.OccurredAtLTE),
+	},
+	auditevent.FieldActor: {
+		query.Eq: auditEventString(auditevent.ActorEQ),
+		query.Ne: auditEventString(auditevent.ActorNEQ),
+	},
+	auditevent.FieldAction: {
+		query.Eq: auditEventString(func(v string) predicate.AuditEvent { return auditevent.ActionEQ(domain.AuditAction(v)) }),
+		query.Ne: auditEventString(func(v string) predicate.AuditEvent { return auditevent.ActionNEQ(domain.AuditAction(v)) }),
+	},
+	auditevent.FieldTargetType: {
+		query.Eq: auditEventString(auditevent.TargetTypeEQ),
+	},
+	auditevent.FieldTargetID: {
+		query.Eq: auditEventString(auditevent.TargetIDEQ),
+		query.Ne: auditEventString(auditevent.TargetIDNEQ),
+	},
+	auditevent.FieldRequestID: {
+		query.Eq: auditEventString(auditevent.RequestIDEQ),
+	},
+}
+
+func auditEventString(p func(string) predicate.AuditEvent) func(string) (predicate.AuditEvent, error) {
+	return func(v string) (predicate.AuditEvent, error) {
+		return p(v), nil
+	}
+}
+
+func auditEventInt(p func(int64) predicate.AuditEvent) func(string) (predicate.AuditEvent, error) {
+	return func(v string) (predicate.AuditEvent, error) {
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not an integer", query.ErrInvalidFilter, v)
+		}
+		return p(i), nil
+	}
+}
+
+func auditEventTime(p func(time.Time) predicate.AuditEvent) func(string) (predicate.AuditEvent, error) {
+	return func(v string) (predicate.AuditEvent, error) {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not an RFC 3339 time", query.ErrInvalidFilter, v)
+		}
+		return p(t), nil
+	}
+}
+
+func auditEventPredicate(e query.Expr) (predicate.AuditEvent, error) {
+	switch e := e.(type) {
+	case query.Condition:
+		ops, ok := auditEventFields[e.Field]
+		if !ok {
+			return nil, &query.FieldError{Err: query.ErrUnknownField, Field: e.Field}
+		}
+
+		op, ok := ops[e.Operator]
+		if !ok {
+			return nil, &query.FieldError{Err: query.ErrUnknownOperator, Field: e.Field, Value: string(e.Operator)}
+		}
+
+		return op(e.Value)
+	case query.And:
+		ps, err := auditEventPredicates(e)
+		if err != nil {
+			return nil, err
+		}
+
+		return auditevent.And(ps...), nil
+	case query.Or:
+		ps, err := auditEventPredicates(e)
+		if err != nil {
+			return nil, err
+		}
+
+		return auditevent.Or(ps...), nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported expression %T", query.ErrInvalidFilter, e)
+	}
+}
+
+func auditEventPredicates(es []query.Expr) ([]predicate.AuditEvent, error) {
+	ps := make([]predicate.AuditEvent, 0, len(es))
+	for _, e := range es {
+		p, err := auditEventPredicate(e)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+
+	return ps, nil
+}
@@ -0,0 +1,292 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+var auditEventColumns = []string{"id", "occurred_at", "actor", "action", "target_type", "target_id", "changes", "request_id", "client_ip", "user_agent", "prev_hash", "hash"}
+
+// captureArg matches any argument and keeps it.
+type captureArg struct {
+	value *string
+}
+
+func (a captureArg) Match(v driver.Value) bool {
+	switch v := v.(type) {
+	case string:
+		*a.value = v
+	case []byte:
+		*a.value = string(v)
+	}
+	return true
+}
+
+func appendAuditEvent(t *testing.T, prevHash string, event domain.AuditEvent) string {
+	t.Helper()
+
+	client, mock := mockDbClient()
+	repo := repository.NewAuditEventRepository(client)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SELECT pg_advisory_xact_lock\(\$1, hashtext\(\$2\)\)`).
+		WithArgs(sqlmock.AnyArg(), "acme").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	rows := sqlmock.NewRows(auditEventColumns)
+	if prevHash != "" {
+		rows.AddRow(1, time.Now(), "", "create", "user", "1", nil, "", "", "", strings.Repeat("0", 64), prevHash)
+	}
+	mock.ExpectQuery(`SELECT .* FROM "audit_events" WHERE "audit_events"."organization_id" = \$1 ORDER BY "audit_events"."id" DESC LIMIT 1`).
+		WithArgs("acme").
+		WillReturnRows(rows)
+
+	if prevHash == "" {
+		prevHash = strings.Repeat("0", 64)
+	}
+
+	var hash string
+	mock.ExpectQuery(`INSERT INTO "audit_events"`).
+		WithArgs(event.OccurredAt, event.Actor, event.Action, event.TargetType, event.TargetID, sqlmock.AnyArg(), event.RequestID, event.ClientIP, event.UserAgent, prevHash, captureArg{&hash}, "acme").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.Append(tenant.NewContext(context.Background(), "acme"), event))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	return hash
+}
+
+func TestAuditEvent_Append(t *testing.T) {
+	event := domain.AuditEvent{
+		OccurredAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Actor:      "admin",
+		Action:     domain.AuditActionUpdate,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   "1",
+		Changes:    map[string]domain.Change{"email": {Before: "a@test.pl", After: "b@test.pl"}},
+		RequestID:  "req",
+		ClientIP:   "127.0.0.1",
+		UserAgent:  "test",
+	}
+
+	t.Run("First event", func(t *testing.T) {
+		assert.Len(t, appendAuditEvent(t, "", event), 64)
+	})
+
+	t.Run("Chained event", func(t *testing.T) {
+		first := appendAuditEvent(t, "", event)
+		chained := appendAuditEvent(t, strings.Repeat("a", 64), event)
+
+		assert.NotEqual(t, first, chained)
+		assert.Equal(t, chained, appendAuditEvent(t, strings.Repeat("a", 64), event))
+	})
+
+	t.Run("Append error", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewAuditEventRepository(client)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT pg_advisory_xact_lock").
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, repo.Append(tenant.NewContext(context.Background(), "acme"), event), assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Without tenant", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewAuditEventRepository(client)
+
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		assert.ErrorIs(t, repo.Append(context.Background(), event), tenant.ErrMissing)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAuditEvent_Verify(t *testing.T) {
+	occurredAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	event := domain.AuditEvent{
+		OccurredAt: occurredAt,
+		Actor:      "admin",
+		Action:     domain.AuditActionCreate,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   "1",
+	}
+	hash := appendAuditEvent(t, "", event)
+
+	row := func(rows *sqlmock.Rows, actor string) *sqlmock.Rows {
+		return rows.AddRow(1, occurredAt, actor, "create", "user", "1", nil, "", "", "", strings.Repeat("0", 64), hash)
+	}
+
+	t.Run("Valid chain", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewAuditEventRepository(client)
+
+		mock.ExpectQuery(`SELECT .* FROM "audit_events" WHERE "audit_events"."action" = \$1 AND "audit_events"."certificate" IS NOT NULL ORDER BY "audit_events"."id"`).
+			WithArgs(domain.AuditActionErase).
+			WillReturnRows(sqlmock.NewRows(auditEventColumns))
+		mock.ExpectQuery(`SELECT .* FROM "audit_events" WHERE "audit_events"."id" > \$1 ORDER BY "audit_events"."id" LIMIT 500`).
+			WithArgs(0).
+			WillReturnRows(row(sqlmock.NewRows(auditEventColumns), "admin"))
+
+		status, err := repo.Verify(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AuditChainStatus{Valid: true, Checked: 1}, status)
+	})
+
+	t.Run("Tampered event", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewAuditEventRepository(client)
+
+		mock.ExpectQuery(`SELECT .* FROM "audit_events" WHERE "audit_events"."action"`).
+			WillReturnRows(sqlmock.NewRows(auditEventColumns))
+		mock.ExpectQuery(`SELECT .* FROM "audit_events"`).
+			WillReturnRows(row(sqlmock.NewRows(auditEventColumns), "someone else"))
+
+		status, err := repo.Verify(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, domain.AuditChainStatus{BrokenAt: 1}, status)
+	})
+}
+
+func TestAuditEvent_List(t *testing.T) {
+	t.Run("List filtered", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewAuditEventRepository(client)
+
+		rows := sqlmock.NewRows(auditEventColumns)
+		for _, id := range []int64{9, 8, 7} {
+			rows.AddRow(id, time.Now(), "admin", "update", "user", "1", nil, "", "", "", "", "")
+		}
+
+		mock.ExpectQuery(`SELECT .* FROM "audit_events" WHERE "audit_events"."target_id" = \$1 AND "audit_events"."id" < \$2 ORDER BY "audit_events"."id" DESC LIMIT 3`).
+			WithArgs("1", 10).
+			WillReturnRows(rows)
+
+		filter := query.Condition{Field: "target_id", Operator: query.Eq, Value: "1"}
+		events, info, err := repo.List(context.Background(), filter, query.Page{Limit: 2, After: []string{"10"}})
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.True(t, info.HasMore)
+		assert.Equal(t, []string{"8"}, info.Last)
+	})
+
+	t.Run("List invalid filter value", func(t *testing.T) {
+		client, _ := mockDbClient()
+		repo := repository.NewAuditEventRepository(client)
+
+		filter := query.Condition{Field: "occurred_at", Operator: query.Gt, Value: "yesterday"}
+		_, _, err := repo.List(context.Background(), filter, query.Page{Limit: 2})
+		assert.ErrorIs(t, err, query.ErrInvalidFilter)
+	})
+
+	t.Run("List invalid cursor", func(t *testing.T) {
+		client, _ := mockDbClient()
+		repo := repository.NewAuditEventRepository(client)
+
+		_, _, err := repo.List(context.Background(), nil, query.Page{Limit: 2, After: []string{"x"}})
+		assert.ErrorIs(t, err, query.ErrInvalidPage)
+	})
+}
+
+func TestAuditEvent_Pseudonymize(t *testing.T) {
+	occurredAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	event := domain.AuditEvent{
+		OccurredAt: occurredAt,
+		Action:     domain.AuditActionCreate,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   "1",
+		Changes:    map[string]domain.Change{"email": {After: "john@doe.com"}},
+		ClientIP:   "127.0.0.1",
+		UserAgent:  "test",
+	}
+	hash := appendAuditEvent(t, "", event)
+	genesis := strings.Repeat("0", 64)
+	columns := []string{"id", "occurred_at", "actor", "action", "target_type", "target_id", "changes", "request_id", "client_ip", "user_agent", "certificate", "prev_hash", "hash"}
+
+	// pseudonymize returns the hash of the event once pseudonymized.
+	pseudonymize := func(t *testing.T) string {
+		t.Helper()
+
+		client, mock := mockDbClient()
+		repo := repository.NewAuditEventRepository(client)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "audit_events" WHERE \("audit_events"."target_type" = \$1 AND "audit_events"."target_id" = \$2\) OR "audit_events"."actor" = \$3 ORDER BY "audit_events"."id"`).
+			WithArgs(domain.AuditTargetUser, "1", "1").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, occurredAt, "", "create", "user", "1", []byte(`{"email":{"after":"john@doe.com"}}`), "", "127.0.0.1", "test", nil, genesis, hash).
+				AddRow(2, occurredAt, "admin", "restore", "user", "1", nil, "", "10.0.0.1", "admin", nil, hash, "next"))
+		mock.ExpectExec(`SELECT set_config\('audit.erasure', 'on', true\)`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`UPDATE audit_events SET changes = \$1, client_ip = \$2, user_agent = \$3 WHERE id = \$4`).
+			WithArgs(`{"email":{"after":"erased:1"}}`, "", "", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, err := client.Tx(context.Background())
+		assert.NoError(t, err)
+
+		redactions, err := repo.Pseudonymize(ent.NewTxContext(context.Background(), tx), "1")
+		assert.NoError(t, err)
+		assert.NoError(t, tx.Commit())
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Len(t, redactions, 1)
+		assert.NotEqual(t, hash, redactions[1])
+
+		return redactions[1]
+	}
+
+	t.Run("Pseudonymize", func(t *testing.T) {
+		pseudonymize(t)
+	})
+
+	t.Run("No transaction", func(t *testing.T) {
+		client, _ := mockDbClient()
+
+		_, err := repository.NewAuditEventRepository(client).Pseudonymize(context.Background(), "1")
+		assert.ErrorIs(t, err, repository.ErrNoTx)
+	})
+
+	redacted := pseudonymize(t)
+	verify := func(t *testing.T, email string) domain.AuditChainStatus {
+		client, mock := mockDbClient()
+		repo := repository.NewAuditEventRepository(client)
+
+		mock.ExpectQuery(`SELECT .* FROM "audit_events" WHERE "audit_events"."action" = \$1`).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(5, occurredAt, "", "erase", "user", "1", nil, "", "", "", []byte(`{"user_id":"1","audit_redactions":{"1":"`+redacted+`"}}`), "x", "y"))
+		mock.ExpectQuery(`SELECT .* FROM "audit_events" WHERE "audit_events"."id" > \$1`).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(1, occurredAt, "", "create", "user", "1", []byte(`{"email":{"after":"`+email+`"}}`), "", "", "", nil, genesis, hash))
+
+		status, err := repo.Verify(context.Background())
+		assert.NoError(t, err)
+
+		return status
+	}
+
+	t.Run("Verify pseudonymized", func(t *testing.T) {
+		assert.Equal(t, domain.AuditChainStatus{Valid: true, Checked: 1}, verify(t, "erased:1"))
+	})
+
+	t.Run("Verify tampered pseudonymized", func(t *testing.T) {
+		assert.Equal(t, domain.AuditChainStatus{BrokenAt: 1}, verify(t, "someone@else.com"))
+	})
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/consent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+type Consent struct {
+	client *ent.Client
+}
+
+func NewConsentRepository(client *ent.Client) *Consent {
+	return &Consent{
+		client: client,
+	}
+}
+
+func (c *Consent) consentClient(ctx context.Context) *ent.ConsentClient {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.Consent
+	}
+
+	return c.client.Consent
+}
+
+func (c *Consent) Add(ctx context.Context, consents ...domain.Consent) error {
+	if len(consents) == 0 {
+		return nil
+	}
+
+	client := c.consentClient(ctx)
+
+	builders := make([]*ent.ConsentCreate, 0, len(consents))
+	for _, cs := range consents {
+		builders = append(builders, client.Create().
+			SetID(cs.ID).
+			SetUserID(cs.UserID).
+			SetDocumentID(cs.DocumentID).
+			SetKind(cs.Kind).
+			SetVersion(cs.Version).
+			SetAcceptedAt(cs.AcceptedAt).
+			SetClientIP(cs.ClientIP).
+			SetUserAgent(cs.UserAgent))
+	}
+
+	return client.CreateBulk(builders...).Exec(ctx)
+}
+
+// ListByUser returns all the consents of the user, oldest first.
+func (c *Consent) ListByUser(ctx context.Context, userID string) ([]domain.Consent, error) {
+	consents, err := c.consentClient(ctx).Query().
+		Where(consent.UserID(userID)).
+		Order(consent.ByAcceptedAt(), consent.ByID()).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Consent, 0, len(consents))
+	for _, cs := range consents {
+		res = append(res, consentFromEntity(cs))
+	}
+
+	return res, nil
+}
+
+// Erase deletes the consents of the user.
+func (c *Consent) Erase(ctx context.Context, user domain.User) (int, error) {
+	return c.consentClient(ctx).Delete().
+		Where(consent.UserID(user.ID)).
+		Exec(ctx)
+}
+
+func consentFromEntity(c *ent.Consent) domain.Consent {
+	return domain.Consent{
+		ID:         c.ID,
+		UserID:     c.UserID,
+		DocumentID: c.DocumentID,
+		Kind:       c.Kind,
+		Version:    c.Version,
+		AcceptedAt: c.AcceptedAt,
+		ClientIP:   c.ClientIP,
+		UserAgent:  c.UserAgent,
+	}
+}
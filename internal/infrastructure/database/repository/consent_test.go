@@ -0,0 +1,88 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func TestConsent_Add(t *testing.T) {
+	t.Run("Add", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewConsentRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`INSERT INTO "consents" \("accepted_at", "client_ip", "document_id", "id", "kind", "user_agent", "user_id", "version"\)`).
+			WithArgs(now, "10.0.0.1", "doc", "c1", domain.PolicyTermsOfService, "curl", "1", "2").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("c1"))
+
+		err := repo.Add(context.Background(), domain.Consent{
+			ID:         "c1",
+			UserID:     "1",
+			DocumentID: "doc",
+			Kind:       domain.PolicyTermsOfService,
+			Version:    "2",
+			AcceptedAt: now,
+			ClientIP:   "10.0.0.1",
+			UserAgent:  "curl",
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing to add", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewConsentRepository(client)
+
+		err := repo.Add(context.Background())
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestConsent_ListByUser(t *testing.T) {
+	t.Run("ListByUser", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewConsentRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "consents" WHERE "consents"."user_id" = \$1 ORDER BY "consents"."accepted_at", "consents"."id"`).
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "document_id", "kind", "version", "accepted_at", "client_ip", "user_agent"}).
+				AddRow("c1", "1", "doc", "terms_of_service", "2", now, "10.0.0.1", "curl"))
+
+		consents, err := repo.ListByUser(context.Background(), "1")
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Consent{{
+			ID:         "c1",
+			UserID:     "1",
+			DocumentID: "doc",
+			Kind:       domain.PolicyTermsOfService,
+			Version:    "2",
+			AcceptedAt: now,
+			ClientIP:   "10.0.0.1",
+			UserAgent:  "curl",
+		}}, consents)
+	})
+}
+
+func TestConsent_Erase(t *testing.T) {
+	t.Run("Erase", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewConsentRepository(client)
+
+		mock.ExpectExec(`DELETE FROM "consents" WHERE "consents"."user_id" = \$1`).
+			WithArgs("1").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		n, err := repo.Erase(context.Background(), domain.User{ID: "1"})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+	})
+}
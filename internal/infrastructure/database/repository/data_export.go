@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/dataexport"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+)
+
+// dataExportFields are all the columns of data_exports except the archive.
+var dataExportFields = []string{
+	dataexport.FieldID,
+	dataexport.FieldCreatedAt,
+	dataexport.FieldUpdatedAt,
+	dataexport.FieldUserID,
+	dataexport.FieldStatus,
+	dataexport.FieldSize,
+	dataexport.FieldError,
+	dataexport.FieldFinishedAt,
+	dataexport.FieldExpiresAt,
+}
+
+type DataExport struct {
+	client *ent.Client
+}
+
+func NewDataExportRepository(client *ent.Client) *DataExport {
+	return &DataExport{
+		client: client,
+	}
+}
+
+func (d *DataExport) dataExportClient(ctx context.Context) *ent.DataExportClient {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.DataExport
+	}
+
+	return d.client.DataExport
+}
+
+func (d *DataExport) Create(ctx context.Context, export domain.DataExport) (domain.DataExport, error) {
+	created, err := d.client.DataExport.Create().
+		SetID(export.ID).
+		SetUserID(export.UserID).
+		SetStatus(export.Status).
+		SetExpiresAt(export.ExpiresAt).
+		Save(ctx)
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+
+	return dataExportFromEntity(created), nil
+}
+
+// GetLatest returns the most recent export of the user that has neither
+// failed nor expired, nil if there is none.
+func (d *DataExport) GetLatest(ctx context.Context, userID string) (*domain.DataExport, error) {
+	export, err := d.client.DataExport.Query().
+		Where(
+			dataexport.UserID(userID),
+			dataexport.StatusNEQ(domain.DataExportFailed),
+			dataexport.ExpiresAtGT(time.Now()),
+		).
+		Order(ent.Desc(dataexport.FieldCreatedAt)).
+		Select(dataExportFields...).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	domainExport := dataExportFromEntity(export)
+
+	return &domainExport, nil
+}
+
+// Save records the status of export, and its archive if it is ready.
+func (d *DataExport) Save(ctx context.Context, export domain.DataExport, archive []byte) error {
+	upd := d.client.DataExport.Update().
+		Where(dataexport.ID(export.ID)).
+		SetStatus(export.Status).
+		SetSize(export.Size).
+		SetError(export.Error).
+		SetNillableFinishedAt(export.FinishedAt).
+		SetExpiresAt(export.ExpiresAt)
+	if archive != nil {
+		upd = upd.SetArchive(archive)
+	}
+
+	_, err := upd.Save(ctx)
+
+	return err
+}
+
+// Archive returns a ready export with its archive, nil if there is none. It
+// is looked up in any organization, as the signed link is all its owner has.
+func (d *DataExport) Archive(ctx context.Context, id string) (*domain.DataExport, []byte, error) {
+	export, err := d.client.DataExport.Query().
+		Where(dataexport.ID(id), dataexport.StatusEQ(domain.DataExportReady)).
+		Only(tenant.AllTenants(ctx))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	domainExport := dataExportFromEntity(export)
+
+	return &domainExport, export.Archive, nil
+}
+
+// Purge deletes the exports that expired before t.
+func (d *DataExport) Purge(ctx context.Context, t time.Time) (int, error) {
+	return d.client.DataExport.Delete().
+		Where(dataexport.ExpiresAtLT(t)).
+		Exec(ctx)
+}
+
+// FailStale fails the pending exports not updated since updatedBefore with
+// reason, the instance generating them having stopped.
+func (d *DataExport) FailStale(ctx context.Context, updatedBefore time.Time, reason string) (int, error) {
+	return d.client.DataExport.Update().
+		Where(
+			dataexport.StatusEQ(domain.DataExportPending),
+			dataexport.UpdatedAtLT(updatedBefore),
+		).
+		SetStatus(domain.DataExportFailed).
+		SetError(reason).
+		SetFinishedAt(time.Now()).
+		Save(ctx)
+}
+
+// Erase deletes the exports of user, with their archive.
+func (d *DataExport) Erase(ctx context.Context, user domain.User) (int, error) {
+	return d.dataExportClient(ctx).Delete().
+		Where(dataexport.UserID(user.ID)).
+		Exec(ctx)
+}
+
+func dataExportFromEntity(export *ent.DataExport) domain.DataExport {
+	return domain.DataExport{
+		ID:         export.ID,
+		UserID:     export.UserID,
+		Status:     export.Status,
+		Size:       export.Size,
+		Error:      export.Error,
+		CreatedAt:  export.CreatedAt,
+		UpdatedAt:  export.UpdatedAt,
+		FinishedAt: export.FinishedAt,
+		ExpiresAt:  export.ExpiresAt,
+	}
+}
@@ -0,0 +1,123 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func TestDataExport_GetLatest(t *testing.T) {
+	t.Run("GetLatest", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewDataExportRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT "data_exports"."id", .*"data_exports"."expires_at" FROM "data_exports" WHERE .*"data_exports"."user_id" = \$1.*"data_exports"."status" <> \$2.*"data_exports"."expires_at" > \$3.* ORDER BY "data_exports"."created_at" DESC LIMIT 1`).
+			WithArgs("user", domain.DataExportFailed, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "user_id", "status", "size", "error", "finished_at", "expires_at"}).
+				AddRow("export", now, now, "user", "ready", 42, "", now, now.Add(time.Hour)))
+
+		export, err := repo.GetLatest(context.Background(), "user")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.DataExport{
+			ID:         "export",
+			UserID:     "user",
+			Status:     domain.DataExportReady,
+			Size:       42,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			FinishedAt: &now,
+			ExpiresAt:  now.Add(time.Hour),
+		}, export)
+	})
+
+	t.Run("None", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewDataExportRepository(client)
+
+		mock.ExpectQuery(`SELECT .* FROM "data_exports"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		export, err := repo.GetLatest(context.Background(), "user")
+
+		assert.NoError(t, err)
+		assert.Nil(t, export)
+	})
+}
+
+func TestDataExport_Save(t *testing.T) {
+	t.Run("Ready", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewDataExportRepository(client)
+
+		now := time.Now()
+		mock.ExpectExec(`UPDATE "data_exports" SET .*"status" = \$\d+.*"archive" = \$\d+.* WHERE "data_exports"."id" = \$\d+`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Save(context.Background(), domain.DataExport{
+			ID:         "export",
+			Status:     domain.DataExportReady,
+			Size:       3,
+			FinishedAt: &now,
+			ExpiresAt:  now.Add(time.Hour),
+		}, []byte("zip"))
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDataExport_Purge(t *testing.T) {
+	t.Run("Purge", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewDataExportRepository(client)
+
+		now := time.Now()
+		mock.ExpectExec(`DELETE FROM "data_exports" WHERE "data_exports"."expires_at" < \$1`).
+			WithArgs(now).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		n, err := repo.Purge(context.Background(), now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, n)
+	})
+}
+
+func TestDataExport_FailStale(t *testing.T) {
+	t.Run("FailStale", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewDataExportRepository(client)
+
+		mock.ExpectExec(`UPDATE "data_exports" SET .*"status" = \$\d+.*"error" = \$\d+.*"finished_at" = \$\d+ WHERE "data_exports"."status" = \$\d+ AND "data_exports"."updated_at" < \$\d+`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		n, err := repo.FailStale(context.Background(), time.Now().Add(-time.Hour), "Internal Server Error")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDataExport_Erase(t *testing.T) {
+	t.Run("Erase", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewDataExportRepository(client)
+
+		mock.ExpectExec(`DELETE FROM "data_exports" WHERE "data_exports"."user_id" = \$1`).
+			WithArgs("1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		n, err := repo.Erase(context.Background(), domain.User{ID: "1"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+}
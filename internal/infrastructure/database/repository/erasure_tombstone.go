@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/rs/xid"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/erasuretombstone"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// ErasureTombstone remembers the emails of erased users by their hash, salted
+// so that the tombstones cannot be matched against a list of emails without
+// the salt.
+type ErasureTombstone struct {
+	client *ent.Client
+	salt   []byte
+}
+
+func NewErasureTombstoneRepository(client *ent.Client, salt []byte) *ErasureTombstone {
+	return &ErasureTombstone{
+		client: client,
+		salt:   salt,
+	}
+}
+
+func (e *ErasureTombstone) tombstoneClient(ctx context.Context) *ent.ErasureTombstoneClient {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.ErasureTombstone
+	}
+
+	return e.client.ErasureTombstone
+}
+
+// EmailHash is the HMAC-SHA256 of email, case insensitive.
+func (e *ErasureTombstone) EmailHash(email string) string {
+	mac := hmac.New(sha256.New, e.salt)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(email))))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Add records that user was erased from the organization of ctx at erasedAt,
+// returning the hash of their email. A tombstone of the same email in the
+// organization is replaced.
+func (e *ErasureTombstone) Add(ctx context.Context, user domain.User, erasedAt time.Time) (string, error) {
+	hash := e.EmailHash(user.Email)
+
+	err := e.tombstoneClient(ctx).Create().
+		SetID(xid.New().String()).
+		SetEmailHash(hash).
+		SetUserID(user.ID).
+		SetErasedAt(erasedAt).
+		OnConflictColumns(erasuretombstone.FieldOrganizationID, erasuretombstone.FieldEmailHash).
+		UpdateUserID().
+		UpdateErasedAt().
+		Exec(ctx)
+
+	return hash, err
+}
+
+// IsErased reports whether a user with email was erased from the organization
+// of ctx.
+func (e *ErasureTombstone) IsErased(ctx context.Context, email string) (bool, error) {
+	return e.tombstoneClient(ctx).Query().
+		Where(erasuretombstone.EmailHash(e.EmailHash(email))).
+		Exist(ctx)
+}
@@ -0,0 +1,57 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func TestErasureTombstone_EmailHash(t *testing.T) {
+	client, _ := mockDbClient()
+	repo := repository.NewErasureTombstoneRepository(client, []byte("salt"))
+
+	assert.Len(t, repo.EmailHash("john@doe.com"), 64)
+	assert.Equal(t, repo.EmailHash("john@doe.com"), repo.EmailHash(" John@Doe.com"))
+	assert.NotEqual(t, repo.EmailHash("john@doe.com"), repository.NewErasureTombstoneRepository(client, []byte("other")).EmailHash("john@doe.com"))
+}
+
+func TestErasureTombstone_Add(t *testing.T) {
+	t.Run("Add", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.IsolateTenants(client)
+		repo := repository.NewErasureTombstoneRepository(client, []byte("salt"))
+
+		erasedAt := time.Now()
+		mock.ExpectQuery(`INSERT INTO "erasure_tombstones" \("email_hash", "user_id", "erased_at", "organization_id", "id"\) VALUES \(\$1, \$2, \$3, \$4, \$5\) ON CONFLICT \("organization_id", "email_hash"\) DO UPDATE SET "user_id" = "excluded"."user_id", "erased_at" = "excluded"."erased_at" RETURNING "id"`).
+			WithArgs(repo.EmailHash("john@doe.com"), "1", erasedAt, "acme", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1"))
+
+		hash, err := repo.Add(tenant.NewContext(context.Background(), "acme"), domain.User{ID: "1", Email: "john@doe.com"}, erasedAt)
+		assert.NoError(t, err)
+		assert.Equal(t, repo.EmailHash("john@doe.com"), hash)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestErasureTombstone_IsErased(t *testing.T) {
+	t.Run("IsErased", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.IsolateTenants(client)
+		repo := repository.NewErasureTombstoneRepository(client, []byte("salt"))
+
+		mock.ExpectQuery(`SELECT .* FROM "erasure_tombstones" WHERE "erasure_tombstones"."email_hash" = \$1 AND "erasure_tombstones"."organization_id" = \$2 LIMIT 1`).
+			WithArgs(repo.EmailHash("john@doe.com"), "acme").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1"))
+
+		erased, err := repo.IsErased(tenant.NewContext(context.Background(), "acme"), "john@doe.com")
+		assert.NoError(t, err)
+		assert.True(t, erased)
+	})
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/outboxevent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// EventChannel is the Postgres notification channel published events are
+// sent on.
+const EventChannel = "user_events"
+
+// EventStream makes published outbox events available to live streams of
+// every instance: it notifies them on publish and replays the published
+// events still retained in the outbox.
+type EventStream struct {
+	client *ent.Client
+}
+
+func NewEventStreamRepository(client *ent.Client) *EventStream {
+	return &EventStream{
+		client: client,
+	}
+}
+
+// Publish notifies listeners of event. Called from the relay transaction once
+// the event is marked published, the notification is only delivered when the
+// transaction commits.
+func (s *EventStream) Publish(ctx context.Context, event domain.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		_, err = tx.ExecContext(ctx, "SELECT pg_notify($1, $2)", EventChannel, string(payload))
+	} else {
+		_, err = s.client.ExecContext(ctx, "SELECT pg_notify($1, $2)", EventChannel, string(payload))
+	}
+
+	return err
+}
+
+// After returns up to limit published events with a sequence above sequence,
+// in sequence order.
+func (s *EventStream) After(ctx context.Context, sequence int64, limit int) ([]domain.Event, error) {
+	events, err := s.client.OutboxEvent.Query().
+		Where(
+			outboxevent.IDGT(sequence),
+			outboxevent.PublishedAtNotNil(),
+		).
+		Order(outboxevent.ByID()).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Event, 0, len(events))
+	for _, e := range events {
+		res = append(res, outboxEventToDomain(e))
+	}
+
+	return res, nil
+}
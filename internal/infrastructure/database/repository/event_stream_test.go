@@ -0,0 +1,73 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func TestEventStream_Publish(t *testing.T) {
+	t.Run("Publish in transaction", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewEventStreamRepository(client)
+
+		event := domain.NewEvent(domain.UserCreated, "1", domain.UserEventData{ID: "1"})
+		payload, _ := json.Marshal(event)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`SELECT pg_notify\(\$1, \$2\)`).
+			WithArgs(repository.EventChannel, string(payload)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repository.NewTransactor(client).InTx(context.Background(), func(ctx context.Context) error {
+			return repo.Publish(ctx, event)
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Publish error", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewEventStreamRepository(client)
+
+		mock.ExpectExec(`SELECT pg_notify\(\$1, \$2\)`).WillReturnError(assert.AnError)
+
+		err := repo.Publish(context.Background(), domain.NewEvent(domain.UserDeleted, "1", domain.UserEventData{ID: "1"}))
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestEventStream_After(t *testing.T) {
+	t.Run("After", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewEventStreamRepository(client)
+
+		occurredAt := time.Now()
+		publishedAt := occurredAt.Add(time.Second)
+		mock.ExpectQuery(`SELECT .* FROM "outbox_events" WHERE "outbox_events"."id" > \$1 AND "outbox_events"."published_at" IS NOT NULL ORDER BY "outbox_events"."id" LIMIT 10`).
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "type", "aggregate_id", "occurred_at", "data", "published_at", "attempts", "last_error"}).
+				AddRow(6, "e1", "user.updated", "1", occurredAt, []byte(`{"id":"1"}`), publishedAt, 1, ""))
+
+		events, err := repo.After(context.Background(), 5, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Event{{
+			ID:          "e1",
+			Sequence:    6,
+			Type:        domain.UserUpdated,
+			AggregateID: "1",
+			OccurredAt:  occurredAt,
+			Data:        json.RawMessage(`{"id":"1"}`),
+			Attempts:    1,
+		}}, events)
+	})
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/group"
+	entuser "github.com/Beriw98/user-management/ent/user"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+)
+
+type Group struct {
+	client *ent.Client
+}
+
+func NewGroupRepository(client *ent.Client) *Group {
+	return &Group{
+		client: client,
+	}
+}
+
+func (g *Group) entClient(ctx context.Context) *ent.Client {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.Client()
+	}
+
+	return g.client
+}
+
+// Create returns a constraint error if the name is taken or the parent does
+// not exist.
+func (g *Group) Create(ctx context.Context, grp domain.Group) (domain.Group, error) {
+	create := g.entClient(ctx).Group.Create().
+		SetID(grp.ID).
+		SetName(grp.Name).
+		SetDescription(grp.Description)
+	if grp.ParentID != "" {
+		create.SetParentID(grp.ParentID)
+	}
+
+	created, err := create.Save(ctx)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	return groupFromEntity(created), nil
+}
+
+func (g *Group) GetByID(ctx context.Context, id string) (*domain.Group, error) {
+	grp, err := g.entClient(ctx).Group.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	domainGroup := groupFromEntity(grp)
+
+	return &domainGroup, nil
+}
+
+// List returns all the groups by name.
+func (g *Group) List(ctx context.Context) ([]domain.Group, error) {
+	groups, err := g.entClient(ctx).Group.Query().
+		Order(group.ByName()).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupsFromEntities(groups), nil
+}
+
+// Update returns domain.ErrGroupCycle if the new parent of the group is the
+// group itself or one of its subgroups. The ancestors of the parent are
+// locked for the check to hold until the transaction of ctx ends, which is
+// required.
+func (g *Group) Update(ctx context.Context, grp domain.Group) (domain.Group, error) {
+	tx := ent.TxFromContext(ctx)
+	if tx == nil {
+		return domain.Group{}, ErrNoTx
+	}
+
+	for id := grp.ParentID; id != ""; {
+		if id == grp.ID {
+			return domain.Group{}, domain.ErrGroupCycle
+		}
+
+		parent, err := tx.Group.Query().
+			Where(group.ID(id)).
+			ForUpdate().
+			Only(ctx)
+		if err != nil {
+			return domain.Group{}, err
+		}
+
+		id = groupFromEntity(parent).ParentID
+	}
+
+	update := tx.Group.UpdateOneID(grp.ID).
+		SetName(grp.Name).
+		SetDescription(grp.Description)
+	if grp.ParentID != "" {
+		update.SetParentID(grp.ParentID)
+	} else {
+		update.ClearParentID()
+	}
+
+	updated, err := update.Save(ctx)
+	if err != nil {
+		return domain.Group{}, err
+	}
+
+	return groupFromEntity(updated), nil
+}
+
+// Delete removes the group and its memberships, its subgroups become top
+// level groups.
+func (g *Group) Delete(ctx context.Context, id string) error {
+	return g.entClient(ctx).Group.DeleteOneID(id).Exec(ctx)
+}
+
+// AddMembers adds the users to the group, skipping those who already are
+// members. It returns domain.ErrUserNotFound if one of them does not exist.
+func (g *Group) AddMembers(ctx context.Context, groupID string, userIDs ...string) error {
+	client := g.entClient(ctx)
+
+	found, err := client.User.Query().
+		Where(entuser.IDIn(userIDs...)).
+		IDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(found) < len(userIDs) {
+		return fmt.Errorf("%w: %s", domain.ErrUserNotFound, strings.Join(missingIDs(userIDs, found), ", "))
+	}
+
+	members, err := client.Group.Query().
+		Where(group.ID(groupID)).
+		QueryMembers().
+		Where(entuser.IDIn(userIDs...)).
+		IDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	added := missingIDs(userIDs, members)
+	if len(added) == 0 {
+		return nil
+	}
+
+	return client.Group.UpdateOneID(groupID).
+		AddMemberIDs(added...).
+		Exec(ctx)
+}
+
+// RemoveMember reports whether the user was a direct member of the group.
+func (g *Group) RemoveMember(ctx context.Context, groupID, userID string) (bool, error) {
+	client := g.entClient(ctx)
+
+	ok, err := client.Group.Query().
+		Where(group.ID(groupID), group.HasMembersWith(entuser.ID(userID))).
+		Exist(ctx)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	err = client.Group.UpdateOneID(groupID).
+		RemoveMemberIDs(userID).
+		Exec(ctx)
+
+	return err == nil, err
+}
+
+// ListMembers returns up to page.Limit direct members of the group by ID,
+// after page.After.
+func (g *Group) ListMembers(ctx context.Context, groupID string, page query.Page) ([]domain.User, query.PageInfo, error) {
+	var info query.PageInfo
+
+	q := g.entClient(ctx).User.Query().
+		Where(entuser.HasGroupsWith(group.ID(groupID)))
+
+	if len(page.After) > 0 {
+		if len(page.After) != 1 {
+			return nil, info, fmt.Errorf("%w: expected 1 key, got %d", query.ErrInvalidPage, len(page.After))
+		}
+		q = q.Where(entuser.IDGT(page.After[0]))
+	}
+
+	users, err := q.Order(entuser.ByID()).Limit(page.Limit + 1).All(ctx)
+	if err != nil {
+		return nil, info, err
+	}
+
+	info.HasMore = len(users) > page.Limit
+	if info.HasMore {
+		users = users[:page.Limit]
+	}
+
+	if len(users) > 0 {
+		info.First = []string{users[0].ID}
+		info.Last = []string{users[len(users)-1].ID}
+	}
+
+	res := make([]domain.User, 0, len(users))
+	for _, u := range users {
+		res = append(res, userFromEntity(u))
+	}
+
+	return res, info, nil
+}
+
+// ListByUser returns the groups the user is a direct member of, by name,
+// followed by those they inherit from the ancestors of these groups.
+func (g *Group) ListByUser(ctx context.Context, userID string) ([]domain.GroupMembership, error) {
+	client := g.entClient(ctx)
+
+	direct, err := client.Group.Query().
+		Where(group.HasMembersWith(entuser.ID(userID))).
+		Order(group.ByName()).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]domain.Group)
+	for _, grp := range direct {
+		groups[grp.ID] = groupFromEntity(grp)
+	}
+
+	// Load the ancestors a level at a time.
+	for level := direct; len(level) > 0; {
+		var parents []string
+		for _, grp := range level {
+			if grp.ParentID != nil {
+				if _, ok := groups[*grp.ParentID]; !ok {
+					parents = append(parents, *grp.ParentID)
+				}
+			}
+		}
+
+		if len(parents) == 0 {
+			break
+		}
+
+		if level, err = client.Group.Query().Where(group.IDIn(parents...)).All(ctx); err != nil {
+			return nil, err
+		}
+
+		for _, grp := range level {
+			groups[grp.ID] = groupFromEntity(grp)
+		}
+	}
+
+	return domain.GroupMemberships(groupsFromEntities(direct), groups), nil
+}
+
+// missingIDs returns the IDs in want but not in got.
+func missingIDs(want, got []string) []string {
+	found := make(map[string]bool, len(got))
+	for _, id := range got {
+		found[id] = true
+	}
+
+	var res []string
+	for _, id := range want {
+		if !found[id] {
+			res = append(res, id)
+		}
+	}
+
+	return res
+}
+
+func groupFromEntity(g *ent.Group) domain.Group {
+	res := domain.Group{
+		ID:          g.ID,
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+
+	if g.ParentID != nil {
+		res.ParentID = *g.ParentID
+	}
+
+	return res
+}
+
+func groupsFromEntities(groups []*ent.Group) []domain.Group {
+	res := make([]domain.Group, 0, len(groups))
+	for _, g := range groups {
+		res = append(res, groupFromEntity(g))
+	}
+
+	return res
+}
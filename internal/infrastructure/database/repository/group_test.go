@@ -0,0 +1,237 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+var groupColumns = []string{"id", "created_at", "updated_at", "name", "description", "parent_id"}
+
+func TestGroup_Create(t *testing.T) {
+	t.Run("Create", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewGroupRepository(client)
+
+		mock.ExpectExec(`INSERT INTO "groups" \("created_at", "updated_at", "name", "description", "parent_id", "id"\)`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "Backend", "", "eng", "be").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		grp, err := repo.Create(context.Background(), domain.Group{ID: "be", Name: "Backend", ParentID: "eng"})
+		assert.NoError(t, err)
+		assert.Equal(t, "eng", grp.ParentID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGroup_Update(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("Move", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewGroupRepository(client)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "groups" WHERE "groups"."id" = \$1 LIMIT 2 FOR UPDATE`).
+			WithArgs("eng").
+			WillReturnRows(sqlmock.NewRows(groupColumns).AddRow("eng", now, now, "Engineering", "", "company"))
+		mock.ExpectQuery(`SELECT .* FROM "groups" WHERE "groups"."id" = \$1 LIMIT 2 FOR UPDATE`).
+			WithArgs("company").
+			WillReturnRows(sqlmock.NewRows(groupColumns).AddRow("company", now, now, "Company", "", nil))
+		mock.ExpectExec(`UPDATE "groups" SET "updated_at" = \$1, "name" = \$2, "description" = \$3, "parent_id" = \$4 WHERE "id" = \$5`).
+			WithArgs(sqlmock.AnyArg(), "Backend", "", "eng", "be").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT .* FROM "groups" WHERE "id" = \$1`).
+			WithArgs("be").
+			WillReturnRows(sqlmock.NewRows(groupColumns).AddRow("be", now, now, "Backend", "", "eng"))
+		mock.ExpectCommit()
+
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+
+		grp, err := repo.Update(ent.NewTxContext(ctx, tx), domain.Group{ID: "be", Name: "Backend", ParentID: "eng"})
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit())
+		assert.NoError(t, mock.ExpectationsWereMet())
+		assert.Equal(t, "eng", grp.ParentID)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewGroupRepository(client)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "groups" WHERE "groups"."id" = \$1 LIMIT 2 FOR UPDATE`).
+			WithArgs("be").
+			WillReturnRows(sqlmock.NewRows(groupColumns).AddRow("be", now, now, "Backend", "", "eng"))
+		mock.ExpectRollback()
+
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+
+		_, err = repo.Update(ent.NewTxContext(ctx, tx), domain.Group{ID: "eng", Name: "Engineering", ParentID: "be"})
+		assert.ErrorIs(t, err, domain.ErrGroupCycle)
+		require.NoError(t, tx.Rollback())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Itself", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewGroupRepository(client)
+
+		mock.ExpectBegin()
+
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+
+		_, err = repo.Update(ent.NewTxContext(ctx, tx), domain.Group{ID: "eng", Name: "Engineering", ParentID: "eng"})
+		assert.ErrorIs(t, err, domain.ErrGroupCycle)
+	})
+
+	t.Run("No transaction", func(t *testing.T) {
+		client, _ := mockDbClient()
+		repo := repository.NewGroupRepository(client)
+
+		_, err := repo.Update(ctx, domain.Group{ID: "eng", Name: "Engineering"})
+		assert.ErrorIs(t, err, repository.ErrNoTx)
+	})
+}
+
+func TestGroup_AddMembers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("AddMembers", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewGroupRepository(client)
+
+		mock.ExpectQuery(`SELECT "users"."id" FROM "users" WHERE "users"."id" IN \(\$1, \$2\) AND "users"."deleted_at" IS NULL`).
+			WithArgs("1", "2").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1").AddRow("2"))
+		mock.ExpectQuery(`SELECT DISTINCT "users"."id" FROM "users" JOIN .*"group_members".* WHERE "users"."id" IN \(\$\d, \$\d\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "groups" SET "updated_at" = \$1 WHERE "id" = \$2`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "group_members" \("group_id", "user_id"\) VALUES \(\$1, \$2\)`).
+			WithArgs("eng", "2").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT .* FROM "groups" WHERE "id" = \$1`).
+			WithArgs("eng").
+			WillReturnRows(sqlmock.NewRows(groupColumns).AddRow("eng", time.Now(), time.Now(), "Engineering", "", nil))
+		mock.ExpectCommit()
+
+		err := repo.AddMembers(ctx, "eng", "1", "2")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown user", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewGroupRepository(client)
+
+		mock.ExpectQuery(`SELECT "users"."id" FROM "users"`).
+			WithArgs("1", "2").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+
+		err := repo.AddMembers(ctx, "eng", "1", "2")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.ErrorContains(t, err, "2")
+	})
+}
+
+func TestGroup_RemoveMember(t *testing.T) {
+	t.Run("Not a member", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewGroupRepository(client)
+
+		mock.ExpectQuery(`SELECT "groups"."id" FROM "groups" WHERE "groups"."id" = \$1 AND "groups"."id" IN \(SELECT "group_members"."group_id" FROM "group_members" .* WHERE "t1"."id" = \$2\) LIMIT 1`).
+			WithArgs("eng", "1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		removed, err := repo.RemoveMember(context.Background(), "eng", "1")
+		assert.NoError(t, err)
+		assert.False(t, removed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGroup_ListMembers(t *testing.T) {
+	columns := []string{"id", "created_at", "updated_at", "version", "deleted_at", "name", "surname", "email", "password", "email_index"}
+
+	t.Run("ListMembers", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewGroupRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "users" WHERE \("users"."id" IN \(SELECT "group_members"."user_id" FROM "group_members" JOIN "groups" .* WHERE "t1"."id" = \$1\) AND "users"."id" > \$2\) AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT 2`).
+			WithArgs("eng", "1").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("2", now, now, 1, nil, "Jane", "Doe", "jane@doe.com", "secret", nil).
+				AddRow("3", now, now, 1, nil, "John", "Doe", "john@doe.com", "secret", nil))
+
+		users, info, err := repo.ListMembers(context.Background(), "eng", query.Page{After: []string{"1"}, Limit: 1})
+		assert.NoError(t, err)
+		assert.Len(t, users, 1)
+		assert.Equal(t, "Jane", users[0].Name)
+		assert.True(t, info.HasMore)
+		assert.Equal(t, []string{"2"}, info.Last)
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		client, _ := mockDbClient()
+		repo := repository.NewGroupRepository(client)
+
+		_, _, err := repo.ListMembers(context.Background(), "eng", query.Page{After: []string{"1", "2"}, Limit: 1})
+		assert.ErrorIs(t, err, query.ErrInvalidPage)
+	})
+}
+
+func TestGroup_ListByUser(t *testing.T) {
+	t.Run("Direct and inherited", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewGroupRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "groups" WHERE "groups"."id" IN \(SELECT "group_members"."group_id" FROM "group_members" JOIN "users" .*\) ORDER BY "groups"."name"`).
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows(groupColumns).
+				AddRow("be", now, now, "Backend", "", "eng").
+				AddRow("ops", now, now, "Operations", "", nil))
+		mock.ExpectQuery(`SELECT .* FROM "groups" WHERE "groups"."id" IN \(\$1\)`).
+			WithArgs("eng").
+			WillReturnRows(sqlmock.NewRows(groupColumns).AddRow("eng", now, now, "Engineering", "", "company"))
+		mock.ExpectQuery(`SELECT .* FROM "groups" WHERE "groups"."id" IN \(\$1\)`).
+			WithArgs("company").
+			WillReturnRows(sqlmock.NewRows(groupColumns).AddRow("company", now, now, "Company", "", nil))
+
+		memberships, err := repo.ListByUser(context.Background(), "1")
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		type membership struct {
+			group, via string
+			inherited  bool
+		}
+		var got []membership
+		for _, m := range memberships {
+			got = append(got, membership{m.Group.ID, m.Via, m.Inherited})
+		}
+
+		assert.Equal(t, []membership{
+			{"be", "", false},
+			{"ops", "", false},
+			{"eng", "be", true},
+			{"company", "be", true},
+		}, got)
+	})
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/importjob"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+type ImportJob struct {
+	client *ent.Client
+	// cipher encrypts the emails of row errors, nil when they are stored in
+	// cleartext.
+	cipher piiCipher
+}
+
+func NewImportJobRepository(client *ent.Client) *ImportJob {
+	return &ImportJob{
+		client: client,
+	}
+}
+
+// NewEncryptedImportJobRepository is NewImportJobRepository looking the
+// emails of row errors up by their blind index, the client being hooked by
+// EncryptPIICopies with c.
+func NewEncryptedImportJobRepository(client *ent.Client, c piiCipher) *ImportJob {
+	return &ImportJob{
+		client: client,
+		cipher: c,
+	}
+}
+
+func (i *ImportJob) importJobClient(ctx context.Context) *ent.ImportJobClient {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.ImportJob
+	}
+
+	return i.client.ImportJob
+}
+
+func (i *ImportJob) Create(ctx context.Context, job domain.ImportJob) (domain.ImportJob, error) {
+	created, err := i.client.ImportJob.Create().
+		SetID(job.ID).
+		SetFormat(job.Format).
+		SetDryRun(job.DryRun).
+		SetStatus(job.Status).
+		Save(ctx)
+	if err != nil {
+		return domain.ImportJob{}, err
+	}
+
+	return importJobFromEntity(created), nil
+}
+
+func (i *ImportJob) Get(ctx context.Context, id string) (*domain.ImportJob, error) {
+	job, err := i.client.ImportJob.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	domainJob := importJobFromEntity(job)
+
+	return &domainJob, nil
+}
+
+// Save records the progress of job.
+func (i *ImportJob) Save(ctx context.Context, job domain.ImportJob) error {
+	_, err := i.client.ImportJob.Update().
+		Where(importjob.ID(job.ID)).
+		SetStatus(job.Status).
+		SetProcessed(job.Processed).
+		SetCreated(job.Created).
+		SetInvited(job.Invited).
+		SetFailed(job.Failed).
+		SetRowErrors(job.Errors).
+		SetError(job.Error).
+		SetNillableFinishedAt(job.FinishedAt).
+		Save(ctx)
+
+	return err
+}
+
+// FailStale fails the running jobs that made no progress since
+// updatedBefore with reason, the instance running them having stopped.
+func (i *ImportJob) FailStale(ctx context.Context, updatedBefore time.Time, reason string) (int, error) {
+	return i.importJobClient(ctx).Update().
+		Where(
+			importjob.StatusEQ(domain.ImportRunning),
+			importjob.UpdatedAtLT(updatedBefore),
+		).
+		SetStatus(domain.ImportFailed).
+		SetError(reason).
+		SetFinishedAt(time.Now()).
+		Save(ctx)
+}
+
+// Erase pseudonymizes the email of user in the row errors of the jobs.
+func (i *ImportJob) Erase(ctx context.Context, user domain.User) (int, error) {
+	client := i.importJobClient(ctx)
+
+	jobs, err := client.Query().
+		Where(func(s *sql.Selector) {
+			p := sqljson.ValueContains(s.C(importjob.FieldRowErrors), []map[string]string{{"email": user.Email}})
+			// Row errors saved before their emails were encrypted match by
+			// their cleartext email.
+			if i.cipher != nil {
+				p = sql.Or(sqljson.ValueContains(s.C(importjob.FieldRowErrors), []map[string]string{{"email_index": i.cipher.BlindIndex(user.Email)}}), p)
+			}
+			s.Where(p)
+		}).
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pseudonym := domain.UserPseudonym(user.ID)
+	for _, job := range jobs {
+		for j := range job.RowErrors {
+			if job.RowErrors[j].Email == user.Email {
+				job.RowErrors[j].Email = pseudonym
+			}
+		}
+
+		if err = client.Update().Where(importjob.ID(job.ID)).SetRowErrors(job.RowErrors).Exec(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(jobs), nil
+}
+
+func importJobFromEntity(job *ent.ImportJob) domain.ImportJob {
+	return domain.ImportJob{
+		ID:         job.ID,
+		Format:     job.Format,
+		DryRun:     job.DryRun,
+		Status:     job.Status,
+		Processed:  job.Processed,
+		Created:    job.Created,
+		Invited:    job.Invited,
+		Failed:     job.Failed,
+		Errors:     job.RowErrors,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		UpdatedAt:  job.UpdatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
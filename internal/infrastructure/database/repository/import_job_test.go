@@ -0,0 +1,115 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func TestImportJob_Save(t *testing.T) {
+	t.Run("Save", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewImportJobRepository(client)
+
+		finishedAt := time.Now()
+		mock.ExpectExec(`UPDATE "import_jobs" SET .*"status" = \$\d+.*"processed" = \$\d+.*"finished_at" = \$\d+.* WHERE "import_jobs"."id" = \$\d+`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Save(context.Background(), domain.ImportJob{
+			ID:         "job",
+			Status:     domain.ImportSucceeded,
+			Processed:  2,
+			Created:    1,
+			Failed:     1,
+			Errors:     []domain.ImportRowError{{Row: 2, Code: "user_already_exists"}},
+			FinishedAt: &finishedAt,
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestImportJob_FailStale(t *testing.T) {
+	t.Run("FailStale", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewImportJobRepository(client)
+
+		before := time.Now().Add(-time.Hour)
+		mock.ExpectExec(`UPDATE "import_jobs" SET .*"status" = \$\d+.*"error" = \$\d+.*"finished_at" = \$\d+ WHERE "import_jobs"."status" = \$\d+ AND "import_jobs"."updated_at" < \$\d+`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		n, err := repo.FailStale(context.Background(), before, "Internal Server Error")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestImportJob_Get(t *testing.T) {
+	t.Run("Get", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewImportJobRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "import_jobs" WHERE "import_jobs"."id" = \$1`).
+			WithArgs("job").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "format", "dry_run", "status", "processed", "created", "invited", "failed", "row_errors", "error", "finished_at"}).
+				AddRow("job", now, now, "csv", true, "running", 3, 1, 1, 1, []byte(`[{"row":3,"code":"validation_failed"}]`), "", nil))
+
+		job, err := repo.Get(context.Background(), "job")
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.ImportJob{
+			ID:        "job",
+			Format:    domain.ImportCSV,
+			DryRun:    true,
+			Status:    domain.ImportRunning,
+			Processed: 3,
+			Created:   1,
+			Invited:   1,
+			Failed:    1,
+			Errors:    []domain.ImportRowError{{Row: 3, Code: "validation_failed"}},
+			CreatedAt: now,
+			UpdatedAt: now,
+		}, job)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewImportJobRepository(client)
+
+		mock.ExpectQuery(`SELECT .* FROM "import_jobs"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		job, err := repo.Get(context.Background(), "missing")
+		assert.NoError(t, err)
+		assert.Nil(t, job)
+	})
+}
+
+func TestImportJob_Erase(t *testing.T) {
+	t.Run("Erase", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewImportJobRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "import_jobs" WHERE "import_jobs"."row_errors" @> \$1`).
+			WithArgs(`[{"email":"john@doe.com"}]`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "format", "dry_run", "status", "processed", "created", "invited", "failed", "row_errors", "error", "finished_at"}).
+				AddRow("job", now, now, "csv", false, "succeeded", 2, 0, 0, 2, []byte(`[{"row":1,"email":"john@doe.com","code":"user_already_exists"},{"row":2,"email":"jane@doe.com","code":"user_already_exists"}]`), "", now))
+		mock.ExpectExec(`UPDATE "import_jobs" SET .*"row_errors" = \$\d+.* WHERE "import_jobs"."id" = \$\d+`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		n, err := repo.Erase(context.Background(), domain.User{ID: "1", Email: "john@doe.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
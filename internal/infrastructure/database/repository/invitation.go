@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/invitation"
+	"github.com/Beriw98/user-management/ent/predicate"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+)
+
+type Invitation struct {
+	client *ent.Client
+	// cipher encrypts emails, nil when they are stored in cleartext.
+	cipher piiCipher
+}
+
+func NewInvitationRepository(client *ent.Client) *Invitation {
+	return &Invitation{
+		client: client,
+	}
+}
+
+// NewEncryptedInvitationRepository is NewInvitationRepository looking emails
+// up by their blind index, the client being hooked by EncryptPIICopies with c.
+func NewEncryptedInvitationRepository(client *ent.Client, c piiCipher) *Invitation {
+	return &Invitation{
+		client: client,
+		cipher: c,
+	}
+}
+
+func (i *Invitation) invitationClient(ctx context.Context) *ent.InvitationClient {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.Invitation
+	}
+
+	return i.client.Invitation
+}
+
+// Create stores the invitation in the organization of ctx.
+func (i *Invitation) Create(ctx context.Context, inv domain.Invitation) error {
+	orgID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tenant.ErrMissing
+	}
+
+	create := i.invitationClient(ctx).Create().
+		SetID(inv.ID).
+		SetOrganizationID(orgID).
+		SetEmail(inv.Email).
+		SetName(inv.Name).
+		SetSurname(inv.Surname).
+		SetInvitedBy(inv.InvitedBy).
+		SetTokenHash(inv.TokenHash).
+		SetExpiresAt(inv.ExpiresAt)
+
+	if inv.GroupID != "" {
+		create.SetGroupID(inv.GroupID)
+	}
+
+	_, err := create.Save(ctx)
+
+	return err
+}
+
+// GetByID returns the invitation of the organization of ctx by ID.
+func (i *Invitation) GetByID(ctx context.Context, id string) (*domain.Invitation, error) {
+	orgID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, tenant.ErrMissing
+	}
+
+	return i.first(ctx, invitation.ID(id), invitation.OrganizationID(orgID))
+}
+
+// GetByTokenHash returns the invitation of a token, in any organization, as
+// the token is all the invitee has.
+func (i *Invitation) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	return i.first(tenant.AllTenants(ctx), invitation.TokenHash(tokenHash))
+}
+
+// GetPendingByEmail returns the invitation of email that can still be
+// accepted, if any, in the organization of ctx when it is scoped to one.
+func (i *Invitation) GetPendingByEmail(ctx context.Context, email string) (*domain.Invitation, error) {
+	ps := []predicate.Invitation{
+		i.emailIs(email),
+		invitation.AcceptedAtIsNil(),
+		invitation.RevokedAtIsNil(),
+		invitation.ExpiresAtGT(time.Now()),
+	}
+
+	if orgID, ok := tenant.FromContext(ctx); ok {
+		ps = append(ps, invitation.OrganizationID(orgID))
+	}
+
+	return i.first(ctx, ps...)
+}
+
+func (i *Invitation) first(ctx context.Context, ps ...predicate.Invitation) (*domain.Invitation, error) {
+	inv, err := i.invitationClient(ctx).Query().
+		Where(ps...).
+		Order(invitation.ByCreatedAt()).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	domainInv := invitationFromEntity(inv)
+
+	return &domainInv, nil
+}
+
+// List returns up to page.Limit invitations of the organization of ctx in
+// status, or in any when it is empty, newest first, after page.After.
+func (i *Invitation) List(ctx context.Context, status domain.InvitationStatus, page query.Page) ([]domain.Invitation, query.PageInfo, error) {
+	var info query.PageInfo
+
+	orgID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil, info, tenant.ErrMissing
+	}
+
+	q := i.invitationClient(ctx).Query().
+		Where(invitation.OrganizationID(orgID))
+
+	now := time.Now()
+	switch status {
+	case domain.InvitationPending:
+		q = q.Where(invitation.AcceptedAtIsNil(), invitation.RevokedAtIsNil(), invitation.ExpiresAtGT(now))
+	case domain.InvitationAccepted:
+		q = q.Where(invitation.AcceptedAtNotNil())
+	case domain.InvitationRevoked:
+		q = q.Where(invitation.AcceptedAtIsNil(), invitation.RevokedAtNotNil())
+	case domain.InvitationExpired:
+		q = q.Where(invitation.AcceptedAtIsNil(), invitation.RevokedAtIsNil(), invitation.ExpiresAtLTE(now))
+	}
+
+	if len(page.After) > 0 {
+		if len(page.After) != 1 {
+			return nil, info, fmt.Errorf("%w: expected 1 key, got %d", query.ErrInvalidPage, len(page.After))
+		}
+		q = q.Where(invitation.IDLT(page.After[0]))
+	}
+
+	invs, err := q.Order(ent.Desc(invitation.FieldID)).Limit(page.Limit + 1).All(ctx)
+	if err != nil {
+		return nil, info, err
+	}
+
+	info.HasMore = len(invs) > page.Limit
+	if info.HasMore {
+		invs = invs[:page.Limit]
+	}
+
+	if len(invs) > 0 {
+		info.First = []string{invs[0].ID}
+		info.Last = []string{invs[len(invs)-1].ID}
+	}
+
+	res := make([]domain.Invitation, 0, len(invs))
+	for _, inv := range invs {
+		res = append(res, invitationFromEntity(inv))
+	}
+
+	return res, info, nil
+}
+
+// Accept marks the invitation accepted, unless it is no longer pending, in
+// which case it returns false.
+func (i *Invitation) Accept(ctx context.Context, id string) (bool, error) {
+	now := time.Now()
+	n, err := i.invitationClient(ctx).Update().
+		Where(
+			invitation.ID(id),
+			invitation.AcceptedAtIsNil(),
+			invitation.RevokedAtIsNil(),
+			invitation.ExpiresAtGT(now),
+		).
+		SetAcceptedAt(now).
+		Save(ctx)
+
+	return n > 0, err
+}
+
+// Revoke marks the invitation of the organization of ctx revoked, unless it
+// was accepted or revoked already, in which case it returns false.
+func (i *Invitation) Revoke(ctx context.Context, id string) (bool, error) {
+	orgID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return false, tenant.ErrMissing
+	}
+
+	n, err := i.invitationClient(ctx).Update().
+		Where(
+			invitation.ID(id),
+			invitation.OrganizationID(orgID),
+			invitation.AcceptedAtIsNil(),
+			invitation.RevokedAtIsNil(),
+		).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+
+	return n > 0, err
+}
+
+// Renew replaces the token of the invitation of the organization of ctx and
+// extends it to expiresAt, unless it was accepted or revoked, in which case
+// it returns false. The former token no longer works.
+func (i *Invitation) Renew(ctx context.Context, id, tokenHash string, expiresAt time.Time) (bool, error) {
+	orgID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return false, tenant.ErrMissing
+	}
+
+	n, err := i.invitationClient(ctx).Update().
+		Where(
+			invitation.ID(id),
+			invitation.OrganizationID(orgID),
+			invitation.AcceptedAtIsNil(),
+			invitation.RevokedAtIsNil(),
+		).
+		SetTokenHash(tokenHash).
+		SetExpiresAt(expiresAt).
+		Save(ctx)
+
+	return n > 0, err
+}
+
+// ListByEmail returns all the invitations of email, oldest first.
+func (i *Invitation) ListByEmail(ctx context.Context, email string) ([]domain.Invitation, error) {
+	invs, err := i.invitationClient(ctx).Query().
+		Where(i.emailIs(email)).
+		Order(invitation.ByCreatedAt(), invitation.ByID()).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Invitation, 0, len(invs))
+	for _, inv := range invs {
+		res = append(res, invitationFromEntity(inv))
+	}
+
+	return res, nil
+}
+
+// Erase deletes the invitations sent to the email of user.
+func (i *Invitation) Erase(ctx context.Context, user domain.User) (int, error) {
+	return i.invitationClient(ctx).Delete().
+		Where(i.emailIs(user.Email)).
+		Exec(ctx)
+}
+
+// emailIs matches the invitations of email, by its blind index once
+// encrypted. Invitations sent before their emails were encrypted match by
+// their cleartext email.
+func (i *Invitation) emailIs(email string) predicate.Invitation {
+	if i.cipher == nil {
+		return invitation.Email(email)
+	}
+
+	return invitation.Or(
+		invitation.EmailIndex(i.cipher.BlindIndex(email)),
+		invitation.And(invitation.EmailIndexIsNil(), invitation.Email(email)),
+	)
+}
+
+func invitationFromEntity(inv *ent.Invitation) domain.Invitation {
+	var groupID string
+	if inv.GroupID != nil {
+		groupID = *inv.GroupID
+	}
+
+	return domain.Invitation{
+		ID:             inv.ID,
+		OrganizationID: inv.OrganizationID,
+		Email:          inv.Email,
+		Name:           inv.Name,
+		Surname:        inv.Surname,
+		GroupID:        groupID,
+		InvitedBy:      inv.InvitedBy,
+		TokenHash:      inv.TokenHash,
+		ExpiresAt:      inv.ExpiresAt,
+		CreatedAt:      inv.CreatedAt,
+		AcceptedAt:     inv.AcceptedAt,
+		RevokedAt:      inv.RevokedAt,
+	}
+}
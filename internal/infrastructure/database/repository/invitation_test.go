@@ -0,0 +1,204 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func TestInvitation_GetPendingByEmail(t *testing.T) {
+	t.Run("GetPendingByEmail", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewInvitationRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "invitations" WHERE \(\("invitations"."email" = \$1 AND "invitations"."accepted_at" IS NULL\) AND "invitations"."revoked_at" IS NULL\) AND "invitations"."expires_at" > \$2 ORDER BY "invitations"."created_at" LIMIT 1`).
+			WithArgs("john@doe.com", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "surname", "token_hash", "expires_at", "accepted_at", "revoked_at", "created_at"}).
+				AddRow("inv", "john@doe.com", "John", "Doe", "hash", now.Add(time.Hour), nil, nil, now))
+
+		inv, err := repo.GetPendingByEmail(context.Background(), "john@doe.com")
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.Invitation{
+			ID:        "inv",
+			Email:     "john@doe.com",
+			Name:      "John",
+			Surname:   "Doe",
+			TokenHash: "hash",
+			ExpiresAt: now.Add(time.Hour),
+			CreatedAt: now,
+		}, inv)
+	})
+}
+
+func TestInvitation_Create(t *testing.T) {
+	inv := domain.Invitation{
+		ID:        "inv",
+		Email:     "john@doe.com",
+		GroupID:   "g1",
+		InvitedBy: "admin",
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewInvitationRepository(client)
+
+		mock.ExpectExec(`INSERT INTO "invitations" \("email", "name", "surname", "token_hash", "expires_at", "created_at", "organization_id", "group_id", "invited_by", "id"\)`).
+			WithArgs("john@doe.com", "", "", "hash", inv.ExpiresAt, sqlmock.AnyArg(), "acme", "g1", "admin", "inv").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Create(tenant.NewContext(context.Background(), "acme"), inv)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Without tenant", func(t *testing.T) {
+		client, _ := mockDbClient()
+		repo := repository.NewInvitationRepository(client)
+
+		err := repo.Create(context.Background(), inv)
+		assert.ErrorIs(t, err, tenant.ErrMissing)
+	})
+}
+
+func TestInvitation_GetByTokenHash(t *testing.T) {
+	t.Run("GetByTokenHash", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewInvitationRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "invitations" WHERE "invitations"."token_hash" = \$1 ORDER BY "invitations"."created_at" LIMIT 1`).
+			WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email", "token_hash", "expires_at", "created_at", "organization_id", "group_id"}).
+				AddRow("inv", "john@doe.com", "hash", now, now, "acme", "g1"))
+
+		inv, err := repo.GetByTokenHash(context.Background(), "hash")
+		require.NoError(t, err)
+		require.NotNil(t, inv)
+		assert.Equal(t, "acme", inv.OrganizationID)
+		assert.Equal(t, "g1", inv.GroupID)
+	})
+}
+
+func TestInvitation_List(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), "acme")
+
+	t.Run("List", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewInvitationRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "invitations" WHERE \(\("invitations"."organization_id" = \$1 AND "invitations"."accepted_at" IS NULL\) AND "invitations"."revoked_at" IS NOT NULL\) AND "invitations"."id" < \$2 ORDER BY "invitations"."id" DESC LIMIT 2`).
+			WithArgs("acme", "inv3").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email", "expires_at", "revoked_at"}).
+				AddRow("inv2", "john@doe.com", now, now).
+				AddRow("inv1", "jane@doe.com", now, now))
+
+		invs, info, err := repo.List(ctx, domain.InvitationRevoked, query.Page{After: []string{"inv3"}, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, invs, 1)
+		assert.Equal(t, "inv2", invs[0].ID)
+		assert.True(t, info.HasMore)
+		assert.Equal(t, []string{"inv2"}, info.Last)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Invalid page", func(t *testing.T) {
+		client, _ := mockDbClient()
+		repo := repository.NewInvitationRepository(client)
+
+		_, _, err := repo.List(ctx, "", query.Page{After: []string{"a", "b"}, Limit: 1})
+		assert.ErrorIs(t, err, query.ErrInvalidPage)
+	})
+}
+
+func TestInvitation_Accept(t *testing.T) {
+	t.Run("Accept", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewInvitationRepository(client)
+
+		mock.ExpectExec(`UPDATE "invitations" SET "accepted_at" = \$1 WHERE \(\("invitations"."id" = \$2 AND "invitations"."accepted_at" IS NULL\) AND "invitations"."revoked_at" IS NULL\) AND "invitations"."expires_at" > \$3`).
+			WithArgs(sqlmock.AnyArg(), "inv", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ok, err := repo.Accept(context.Background(), "inv")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("No longer pending", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewInvitationRepository(client)
+
+		mock.ExpectExec(`UPDATE "invitations"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		ok, err := repo.Accept(context.Background(), "inv")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestInvitation_Renew(t *testing.T) {
+	t.Run("Renew", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewInvitationRepository(client)
+
+		expiresAt := time.Now().Add(time.Hour)
+		mock.ExpectExec(`UPDATE "invitations" SET "token_hash" = \$1, "expires_at" = \$2 WHERE \(\("invitations"."id" = \$3 AND "invitations"."organization_id" = \$4\) AND "invitations"."accepted_at" IS NULL\) AND "invitations"."revoked_at" IS NULL`).
+			WithArgs("hash", expiresAt, "inv", "acme").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ok, err := repo.Renew(tenant.NewContext(context.Background(), "acme"), "inv", "hash", expiresAt)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
+
+func TestInvitation_ListByEmail(t *testing.T) {
+	t.Run("ListByEmail", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewInvitationRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "invitations" WHERE "invitations"."email" = \$1 ORDER BY "invitations"."created_at", "invitations"."id"`).
+			WithArgs("john@doe.com").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "surname", "token_hash", "expires_at", "accepted_at", "revoked_at", "created_at"}).
+				AddRow("inv1", "john@doe.com", "John", "Doe", "hash1", now, now, nil, now).
+				AddRow("inv2", "john@doe.com", "John", "Doe", "hash2", now.Add(time.Hour), nil, nil, now))
+
+		invs, err := repo.ListByEmail(context.Background(), "john@doe.com")
+		assert.NoError(t, err)
+		assert.Len(t, invs, 2)
+		assert.Equal(t, "inv1", invs[0].ID)
+		assert.Equal(t, &now, invs[0].AcceptedAt)
+		assert.Equal(t, "inv2", invs[1].ID)
+		assert.Nil(t, invs[1].AcceptedAt)
+	})
+}
+
+func TestInvitation_Erase(t *testing.T) {
+	t.Run("Erase", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewInvitationRepository(client)
+
+		mock.ExpectExec(`DELETE FROM "invitations" WHERE "invitations"."email" = \$1`).
+			WithArgs("john@doe.com").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		n, err := repo.Erase(context.Background(), domain.User{ID: "1", Email: "john@doe.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, n)
+	})
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/organization"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+type Organization struct {
+	client *ent.Client
+}
+
+func NewOrganizationRepository(client *ent.Client) *Organization {
+	return &Organization{
+		client: client,
+	}
+}
+
+func (o *Organization) organizationClient(ctx context.Context) *ent.OrganizationClient {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.Organization
+	}
+
+	return o.client.Organization
+}
+
+// Create returns a constraint error if the slug is taken.
+func (o *Organization) Create(ctx context.Context, org domain.Organization) (domain.Organization, error) {
+	created, err := o.organizationClient(ctx).Create().
+		SetID(org.ID).
+		SetName(org.Name).
+		SetSlug(org.Slug).
+		Save(ctx)
+	if err != nil {
+		return domain.Organization{}, err
+	}
+
+	return organizationFromEntity(created), nil
+}
+
+func (o *Organization) GetByID(ctx context.Context, id string) (*domain.Organization, error) {
+	org, err := o.organizationClient(ctx).Get(ctx, id)
+
+	return organizationOrNil(org, err)
+}
+
+func (o *Organization) GetBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	org, err := o.organizationClient(ctx).Query().
+		Where(organization.Slug(slug)).
+		Only(ctx)
+
+	return organizationOrNil(org, err)
+}
+
+// List returns all the organizations by slug.
+func (o *Organization) List(ctx context.Context) ([]domain.Organization, error) {
+	orgs, err := o.organizationClient(ctx).Query().
+		Order(organization.BySlug()).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Organization, 0, len(orgs))
+	for _, org := range orgs {
+		res = append(res, organizationFromEntity(org))
+	}
+
+	return res, nil
+}
+
+func organizationOrNil(org *ent.Organization, err error) (*domain.Organization, error) {
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	res := organizationFromEntity(org)
+
+	return &res, nil
+}
+
+func organizationFromEntity(org *ent.Organization) domain.Organization {
+	return domain.Organization{
+		ID:        org.ID,
+		Name:      org.Name,
+		Slug:      org.Slug,
+		CreatedAt: org.CreatedAt,
+		UpdatedAt: org.UpdatedAt,
+	}
+}
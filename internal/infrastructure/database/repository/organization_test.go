@@ -0,0 +1,45 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func TestOrganization_GetBySlug(t *testing.T) {
+	ctx := context.Background()
+	columns := []string{"id", "created_at", "updated_at", "name", "slug"}
+
+	t.Run("GetBySlug", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewOrganizationRepository(client)
+
+		mock.ExpectQuery(`SELECT .* FROM "organizations" WHERE "organizations"."slug" = \$1 LIMIT 2`).
+			WithArgs("acme").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("acme-id", time.Now(), time.Now(), "Acme", "acme"))
+
+		got, err := repo.GetBySlug(ctx, "acme")
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, "acme-id", got.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewOrganizationRepository(client)
+
+		mock.ExpectQuery(`FROM "organizations"`).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		got, err := repo.GetBySlug(ctx, "acme")
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
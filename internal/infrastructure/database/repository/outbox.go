@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/outboxevent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// outboxRelayLock is the key of the advisory lock electing the instance that
+// relays the outbox, one at a time keeps events of a user in order.
+const outboxRelayLock = 0x6f757462
+
+var ErrNoTx = errors.New("no transaction in context")
+
+type Outbox struct {
+	client *ent.Client
+}
+
+func NewOutboxRepository(client *ent.Client) *Outbox {
+	return &Outbox{
+		client: client,
+	}
+}
+
+func (o *Outbox) outboxClient(ctx context.Context) *ent.OutboxEventClient {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.OutboxEvent
+	}
+
+	return o.client.OutboxEvent
+}
+
+// Add stores events to be published, in the transaction of ctx so they are
+// only published if the change they describe is committed.
+func (o *Outbox) Add(ctx context.Context, events ...domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	client := o.outboxClient(ctx)
+
+	builders := make([]*ent.OutboxEventCreate, 0, len(events))
+	for _, e := range events {
+		builders = append(builders, client.Create().
+			SetEventID(e.ID).
+			SetType(e.Type).
+			SetAggregateID(e.AggregateID).
+			SetOccurredAt(e.OccurredAt).
+			SetData(e.Data))
+	}
+
+	return client.CreateBulk(builders...).Exec(ctx)
+}
+
+// TryLock takes the relay lock for the transaction of ctx, reporting false
+// if another instance holds it.
+func (o *Outbox) TryLock(ctx context.Context) (bool, error) {
+	tx := ent.TxFromContext(ctx)
+	if tx == nil {
+		return false, ErrNoTx
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", outboxRelayLock)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	var locked bool
+	if rows.Next() {
+		if err = rows.Scan(&locked); err != nil {
+			return false, err
+		}
+	}
+
+	return locked, rows.Err()
+}
+
+// Pending returns up to limit unpublished events, oldest first. Events held
+// back behind an earlier failed event of their aggregate are left out, so
+// they don't fill the batch.
+func (o *Outbox) Pending(ctx context.Context, limit int) ([]domain.Event, error) {
+	events, err := o.outboxClient(ctx).Query().
+		Where(
+			outboxevent.PublishedAtIsNil(),
+			outboxevent.DeadAtIsNil(),
+			func(s *sql.Selector) {
+				failed := sql.Table(outboxevent.Table).As("failed")
+				s.Where(sql.NotExists(
+					sql.Select(failed.C(outboxevent.FieldID)).
+						From(failed).
+						Where(sql.And(
+							sql.ColumnsEQ(failed.C(outboxevent.FieldAggregateID), s.C(outboxevent.FieldAggregateID)),
+							sql.ColumnsLT(failed.C(outboxevent.FieldID), s.C(outboxevent.FieldID)),
+							sql.IsNull(failed.C(outboxevent.FieldPublishedAt)),
+							sql.IsNull(failed.C(outboxevent.FieldDeadAt)),
+							sql.GT(failed.C(outboxevent.FieldAttempts), 0),
+						)),
+				))
+			},
+		).
+		Order(outboxevent.ByID()).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Event, 0, len(events))
+	for _, e := range events {
+		res = append(res, outboxEventToDomain(e))
+	}
+
+	return res, nil
+}
+
+func outboxEventToDomain(e *ent.OutboxEvent) domain.Event {
+	return domain.Event{
+		ID:             e.EventID,
+		Sequence:       e.ID,
+		Type:           e.Type,
+		AggregateID:    e.AggregateID,
+		OccurredAt:     e.OccurredAt,
+		Data:           e.Data,
+		Attempts:       e.Attempts,
+		OrganizationID: e.OrganizationID,
+	}
+}
+
+func (o *Outbox) MarkPublished(ctx context.Context, sequences ...int64) error {
+	if len(sequences) == 0 {
+		return nil
+	}
+
+	return o.outboxClient(ctx).Update().
+		Where(outboxevent.IDIn(sequences...)).
+		SetPublishedAt(time.Now()).
+		AddAttempts(1).
+		ClearLastError().
+		Exec(ctx)
+}
+
+func (o *Outbox) MarkFailed(ctx context.Context, sequence int64, cause error) error {
+	_, err := o.outboxClient(ctx).Update().
+		Where(outboxevent.ID(sequence)).
+		AddAttempts(1).
+		SetLastError(cause.Error()).
+		Save(ctx)
+
+	return err
+}
+
+// MarkDead records the last failure of the event at sequence, which is no
+// longer published. Later events of its aggregate are published without it.
+func (o *Outbox) MarkDead(ctx context.Context, sequence int64, cause error) error {
+	_, err := o.outboxClient(ctx).Update().
+		Where(outboxevent.ID(sequence)).
+		AddAttempts(1).
+		SetLastError(cause.Error()).
+		SetDeadAt(time.Now()).
+		Save(ctx)
+
+	return err
+}
+
+// Prune removes events published before publishedBefore.
+func (o *Outbox) Prune(ctx context.Context, publishedBefore time.Time) (int, error) {
+	return o.outboxClient(ctx).Delete().
+		Where(outboxevent.PublishedAtLT(publishedBefore)).
+		Exec(ctx)
+}
+
+// Erase pseudonymizes the data of the events of user, published or not.
+func (o *Outbox) Erase(ctx context.Context, user domain.User) (int, error) {
+	client := o.outboxClient(ctx)
+
+	events, err := client.Query().
+		Where(outboxevent.AggregateID(user.ID)).
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	pseudonym := domain.UserPseudonym(user.ID)
+	for _, e := range events {
+		data, changed, err := domain.PseudonymizeUserEventData(e.Data, pseudonym)
+		if err != nil {
+			return n, err
+		}
+
+		if !changed {
+			continue
+		}
+
+		if err = client.Update().Where(outboxevent.ID(e.ID)).SetData(data).Exec(ctx); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	return n, nil
+}
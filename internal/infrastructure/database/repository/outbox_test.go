@@ -0,0 +1,147 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func TestOutbox_Add(t *testing.T) {
+	t.Run("Add", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewOutboxRepository(client)
+
+		created := domain.NewEvent(domain.UserCreated, "1", domain.UserEventData{ID: "1"})
+		changed := domain.NewEvent(domain.UserEmailChanged, "1", domain.UserEventData{ID: "1"})
+
+		mock.ExpectQuery(`INSERT INTO "outbox_events"`).
+			WithArgs(
+				"1", 0, []byte(created.Data), created.ID, created.OccurredAt, created.Type,
+				"1", 0, []byte(changed.Data), changed.ID, changed.OccurredAt, changed.Type,
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+		assert.NoError(t, repo.Add(context.Background(), created, changed))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Add nothing", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewOutboxRepository(client)
+
+		assert.NoError(t, repo.Add(context.Background()))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOutbox_TryLock(t *testing.T) {
+	t.Run("TryLock", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewOutboxRepository(client)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock\(\$1\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+		mock.ExpectCommit()
+
+		err := repository.NewTransactor(client).InTx(context.Background(), func(ctx context.Context) error {
+			locked, err := repo.TryLock(ctx)
+			assert.True(t, locked)
+			return err
+		})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("TryLock outside a transaction", func(t *testing.T) {
+		client, _ := mockDbClient()
+		repo := repository.NewOutboxRepository(client)
+
+		_, err := repo.TryLock(context.Background())
+		assert.ErrorIs(t, err, repository.ErrNoTx)
+	})
+}
+
+func TestOutbox_Pending(t *testing.T) {
+	t.Run("Pending", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewOutboxRepository(client)
+
+		occurredAt := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "outbox_events" WHERE \("outbox_events"."published_at" IS NULL AND "outbox_events"."dead_at" IS NULL\) ` +
+			`AND NOT EXISTS \(SELECT "failed"."id" FROM "outbox_events" AS "failed" WHERE "failed"."aggregate_id" = "outbox_events"."aggregate_id" ` +
+			`AND "failed"."id" < "outbox_events"."id" AND "failed"."published_at" IS NULL AND "failed"."dead_at" IS NULL AND "failed"."attempts" > \$1\) ` +
+			`ORDER BY "outbox_events"."id" LIMIT 10`).
+			WithArgs(0).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "type", "aggregate_id", "occurred_at", "data", "published_at", "attempts", "last_error"}).
+				AddRow(7, "e1", "user.created", "1", occurredAt, []byte(`{"id":"1"}`), nil, 2, "timeout"))
+
+		events, err := repo.Pending(context.Background(), 10)
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Event{{
+			ID:          "e1",
+			Sequence:    7,
+			Type:        domain.UserCreated,
+			AggregateID: "1",
+			OccurredAt:  occurredAt,
+			Data:        json.RawMessage(`{"id":"1"}`),
+			Attempts:    2,
+		}}, events)
+	})
+}
+
+func TestOutbox_MarkFailed(t *testing.T) {
+	t.Run("MarkFailed", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewOutboxRepository(client)
+
+		mock.ExpectExec(`UPDATE "outbox_events" SET "last_error" = \$1, "attempts" = COALESCE\("outbox_events"."attempts", 0\) \+ \$2 WHERE "outbox_events"."id" = \$3`).
+			WithArgs(assert.AnError.Error(), 1, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.MarkFailed(context.Background(), 7, assert.AnError))
+	})
+}
+
+func TestOutbox_MarkDead(t *testing.T) {
+	t.Run("MarkDead", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewOutboxRepository(client)
+
+		mock.ExpectExec(`UPDATE "outbox_events" SET "last_error" = \$1, "dead_at" = \$2, "attempts" = COALESCE\("outbox_events"."attempts", 0\) \+ \$3 WHERE "outbox_events"."id" = \$4`).
+			WithArgs(assert.AnError.Error(), sqlmock.AnyArg(), 1, 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.MarkDead(context.Background(), 7, assert.AnError))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOutbox_Erase(t *testing.T) {
+	t.Run("Erase", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewOutboxRepository(client)
+
+		mock.ExpectQuery(`SELECT .* FROM "outbox_events" WHERE "outbox_events"."aggregate_id" = \$1`).
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "type", "aggregate_id", "occurred_at", "data", "published_at", "attempts", "last_error"}).
+				AddRow(7, "e1", "user.email_changed", "1", time.Now(), []byte(`{"id":"1","email":"b@test.pl","old_email":"a@test.pl"}`), nil, 0, "").
+				AddRow(8, "e2", "user.deleted", "1", time.Now(), []byte(`{"id":"1"}`), nil, 0, ""))
+		mock.ExpectExec(`UPDATE "outbox_events" SET "data" = \$1 WHERE "outbox_events"."id" = \$2`).
+			WithArgs([]byte(`{"id":"1","email":"erased:1","old_email":"erased:1"}`), 7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		n, err := repo.Erase(context.Background(), domain.User{ID: "1", Email: "b@test.pl"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
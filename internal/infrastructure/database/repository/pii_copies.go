@@ -0,0 +1,383 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/hook"
+	"github.com/Beriw98/user-management/ent/invitation"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// cryptFunc is piiCipher.Encrypt or piiCipher.Decrypt.
+type cryptFunc func(ctx context.Context, field, value string) (string, error)
+
+// EncryptPIICopies hooks client to encrypt with c the copies of the personal
+// data of users kept by audit events, outbox events, webhook deliveries,
+// invitations and import jobs, and to decrypt the ones it reads, as
+// EncryptUserPII does for users. The emails of invitations and import row
+// errors get a blind index to be looked up by, see
+// NewEncryptedInvitationRepository and NewEncryptedImportJobRepository.
+// It is called once per client.
+func EncryptPIICopies(client *ent.Client, c piiCipher) {
+	// Audit events are hashed before they are encrypted and only ever
+	// created through ent, Pseudonymize writes pseudonyms in cleartext.
+	client.AuditEvent.Use(piiHook(
+		func(ctx context.Context, m *ent.AuditEventMutation) error {
+			changes, ok := m.Changes()
+			if targetType, _ := m.TargetType(); !ok || targetType != domain.AuditTargetUser {
+				return nil
+			}
+
+			enc, err := cryptChanges(ctx, changes, c.Encrypt)
+			if err != nil {
+				return err
+			}
+			m.SetChanges(enc)
+
+			return nil
+		},
+		func(ctx context.Context, e *ent.AuditEvent) error { return decryptAuditEvent(ctx, c, e) },
+		ent.OpCreate,
+	))
+	client.AuditEvent.Intercept(piiInterceptor(func(ctx context.Context, e *ent.AuditEvent) error {
+		return decryptAuditEvent(ctx, c, e)
+	}))
+
+	client.OutboxEvent.Use(piiHook(
+		func(ctx context.Context, m *ent.OutboxEventMutation) error {
+			data, ok := m.Data()
+			if !ok {
+				return nil
+			}
+
+			enc, err := cryptUserEventData(ctx, data, "data.", c.Encrypt)
+			if err != nil {
+				return err
+			}
+			m.SetData(enc)
+
+			return nil
+		},
+		func(ctx context.Context, e *ent.OutboxEvent) error { return decryptOutboxEvent(ctx, c, e) },
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	))
+	client.OutboxEvent.Intercept(piiInterceptor(func(ctx context.Context, e *ent.OutboxEvent) error {
+		return decryptOutboxEvent(ctx, c, e)
+	}))
+
+	client.WebhookDelivery.Use(piiHook(
+		func(ctx context.Context, m *ent.WebhookDeliveryMutation) error {
+			payload, ok := m.Payload()
+			if !ok {
+				return nil
+			}
+
+			enc, err := cryptWebhookPayload(ctx, payload, c.Encrypt)
+			if err != nil {
+				return err
+			}
+			m.SetPayload(enc)
+
+			return nil
+		},
+		func(ctx context.Context, d *ent.WebhookDelivery) error { return decryptWebhookDelivery(ctx, c, d) },
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	))
+	client.WebhookDelivery.Intercept(piiInterceptor(func(ctx context.Context, d *ent.WebhookDelivery) error {
+		return decryptWebhookDelivery(ctx, c, d)
+	}))
+
+	client.Invitation.Use(piiHook(
+		func(ctx context.Context, m *ent.InvitationMutation) error {
+			return encryptInvitationMutation(ctx, c, m)
+		},
+		func(ctx context.Context, inv *ent.Invitation) error { return decryptInvitation(ctx, c, inv) },
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	))
+	client.Invitation.Intercept(piiInterceptor(func(ctx context.Context, inv *ent.Invitation) error {
+		return decryptInvitation(ctx, c, inv)
+	}))
+
+	client.ImportJob.Use(piiHook(
+		func(ctx context.Context, m *ent.ImportJobMutation) error {
+			rowErrors, ok := m.RowErrors()
+			if !ok {
+				return nil
+			}
+
+			enc, err := encryptImportRowErrors(ctx, c, rowErrors)
+			if err != nil {
+				return err
+			}
+			m.SetRowErrors(enc)
+
+			return nil
+		},
+		func(ctx context.Context, job *ent.ImportJob) error { return decryptImportJob(ctx, c, job) },
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	))
+	client.ImportJob.Intercept(piiInterceptor(func(ctx context.Context, job *ent.ImportJob) error {
+		return decryptImportJob(ctx, c, job)
+	}))
+}
+
+// piiHook runs encrypt on the mutations of type M before they are written
+// and decrypt on the entity of type T they return, if any.
+func piiHook[M ent.Mutation, T any](encrypt func(context.Context, M) error, decrypt func(context.Context, T) error, op ent.Op) ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				if mm, ok := m.(M); ok {
+					if err := encrypt(ctx, mm); err != nil {
+						return nil, err
+					}
+				}
+
+				v, err := next.Mutate(ctx, m)
+				if err != nil {
+					return nil, err
+				}
+
+				if e, ok := v.(T); ok {
+					if err := decrypt(ctx, e); err != nil {
+						return nil, err
+					}
+				}
+
+				return v, nil
+			})
+		},
+		op,
+	)
+}
+
+// piiInterceptor runs decrypt on the entities of type T queries read.
+func piiInterceptor[T any](decrypt func(context.Context, T) error) ent.Interceptor {
+	return ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, q ent.Query) (ent.Value, error) {
+			v, err := next.Query(ctx, q)
+			if err != nil {
+				return nil, err
+			}
+
+			entities, ok := v.([]T)
+			if !ok {
+				return v, nil
+			}
+
+			for _, e := range entities {
+				if err := decrypt(ctx, e); err != nil {
+					return nil, err
+				}
+			}
+
+			return v, nil
+		})
+	})
+}
+
+func decryptAuditEvent(ctx context.Context, c piiCipher, e *ent.AuditEvent) error {
+	if e.TargetType != domain.AuditTargetUser {
+		return nil
+	}
+
+	changes, err := cryptChanges(ctx, e.Changes, c.Decrypt)
+	if err != nil {
+		return err
+	}
+	e.Changes = changes
+
+	return nil
+}
+
+// cryptChanges returns changes with their string values run through crypt,
+// leaving changes itself untouched as its cleartext is what is hashed.
+func cryptChanges(ctx context.Context, changes map[string]domain.Change, crypt cryptFunc) (map[string]domain.Change, error) {
+	if changes == nil {
+		return nil, nil
+	}
+
+	res := make(map[string]domain.Change, len(changes))
+	for field, change := range changes {
+		for _, v := range []*any{&change.Before, &change.After} {
+			s, ok := (*v).(string)
+			if !ok || s == "" {
+				continue
+			}
+
+			s, err := crypt(ctx, "changes."+field, s)
+			if err != nil {
+				return nil, err
+			}
+			*v = s
+		}
+		res[field] = change
+	}
+
+	return res, nil
+}
+
+func decryptOutboxEvent(ctx context.Context, c piiCipher, e *ent.OutboxEvent) error {
+	data, err := cryptUserEventData(ctx, e.Data, "data.", c.Decrypt)
+	if err != nil {
+		return err
+	}
+	e.Data = data
+
+	return nil
+}
+
+func decryptWebhookDelivery(ctx context.Context, c piiCipher, d *ent.WebhookDelivery) error {
+	payload, err := cryptWebhookPayload(ctx, d.Payload, c.Decrypt)
+	if err != nil {
+		return err
+	}
+	d.Payload = payload
+
+	return nil
+}
+
+// cryptUserEventData runs the personal data of data, a domain.UserEventData,
+// through crypt, its fields being named after prefix. The ID is kept as it
+// is, erasures look events up by it. Data without personal data is returned
+// as it is.
+func cryptUserEventData(ctx context.Context, data json.RawMessage, prefix string, crypt cryptFunc) (json.RawMessage, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	var d domain.UserEventData
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+
+	changed := false
+	for field, v := range map[string]*string{"name": &d.Name, "surname": &d.Surname, "email": &d.Email, "old_email": &d.OldEmail} {
+		if *v == "" {
+			continue
+		}
+
+		s, err := crypt(ctx, prefix+field, *v)
+		if err != nil {
+			return nil, err
+		}
+
+		changed = changed || s != *v
+		*v = s
+	}
+
+	if !changed {
+		return data, nil
+	}
+
+	return json.Marshal(d)
+}
+
+// cryptWebhookPayload runs the event data of payload, a webhookPayload,
+// through crypt.
+func cryptWebhookPayload(ctx context.Context, payload json.RawMessage, crypt cryptFunc) (json.RawMessage, error) {
+	if len(payload) == 0 {
+		return payload, nil
+	}
+
+	var p webhookPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+
+	data, err := cryptUserEventData(ctx, p.Data, "payload.data.", crypt)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(data, p.Data) {
+		return payload, nil
+	}
+	p.Data = data
+
+	return json.Marshal(p)
+}
+
+func encryptInvitationMutation(ctx context.Context, c piiCipher, m *ent.InvitationMutation) error {
+	if email, ok := m.Email(); ok {
+		enc, err := c.Encrypt(ctx, invitation.FieldEmail, email)
+		if err != nil {
+			return err
+		}
+		m.SetEmail(enc)
+		m.SetEmailIndex(c.BlindIndex(email))
+	}
+
+	if name, ok := m.Name(); ok && name != "" {
+		enc, err := c.Encrypt(ctx, invitation.FieldName, name)
+		if err != nil {
+			return err
+		}
+		m.SetName(enc)
+	}
+
+	if surname, ok := m.Surname(); ok && surname != "" {
+		enc, err := c.Encrypt(ctx, invitation.FieldSurname, surname)
+		if err != nil {
+			return err
+		}
+		m.SetSurname(enc)
+	}
+
+	return nil
+}
+
+func decryptInvitation(ctx context.Context, c piiCipher, inv *ent.Invitation) error {
+	var err error
+	if inv.Email, err = c.Decrypt(ctx, invitation.FieldEmail, inv.Email); err != nil {
+		return err
+	}
+
+	if inv.Name, err = c.Decrypt(ctx, invitation.FieldName, inv.Name); err != nil {
+		return err
+	}
+
+	inv.Surname, err = c.Decrypt(ctx, invitation.FieldSurname, inv.Surname)
+
+	return err
+}
+
+// encryptImportRowErrors returns a copy of rowErrors with their emails
+// encrypted and blind indexed, the running import keeps appending to the
+// original.
+func encryptImportRowErrors(ctx context.Context, c piiCipher, rowErrors []domain.ImportRowError) ([]domain.ImportRowError, error) {
+	if rowErrors == nil {
+		return nil, nil
+	}
+
+	res := make([]domain.ImportRowError, len(rowErrors))
+	for i, rowErr := range rowErrors {
+		if rowErr.Email != "" {
+			enc, err := c.Encrypt(ctx, "row_errors.email", rowErr.Email)
+			if err != nil {
+				return nil, err
+			}
+			rowErr.EmailIndex = c.BlindIndex(rowErr.Email)
+			rowErr.Email = enc
+		}
+		res[i] = rowErr
+	}
+
+	return res, nil
+}
+
+func decryptImportJob(ctx context.Context, c piiCipher, job *ent.ImportJob) error {
+	for i := range job.RowErrors {
+		email, err := c.Decrypt(ctx, "row_errors.email", job.RowErrors[i].Email)
+		if err != nil {
+			return err
+		}
+		job.RowErrors[i].Email = email
+		job.RowErrors[i].EmailIndex = ""
+	}
+
+	return nil
+}
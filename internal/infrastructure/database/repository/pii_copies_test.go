@@ -0,0 +1,161 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func TestEncryptPIICopies(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), "acme")
+	c := piiCipher(t)
+
+	t.Run("Audit event", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.EncryptPIICopies(client, c)
+		repo := repository.NewAuditEventRepository(client)
+
+		event := domain.AuditEvent{
+			OccurredAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			Actor:      "admin",
+			Action:     domain.AuditActionUpdate,
+			TargetType: domain.AuditTargetUser,
+			TargetID:   "1",
+			Changes:    map[string]domain.Change{"email": {Before: "a@test.pl", After: "b@test.pl"}},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT .* FROM "audit_events"`).
+			WillReturnRows(sqlmock.NewRows(auditEventColumns))
+
+		var changes, hash string
+		mock.ExpectQuery(`INSERT INTO "audit_events"`).
+			WithArgs(event.OccurredAt, event.Actor, event.Action, event.TargetType, event.TargetID, captureArg{&changes}, "", "", "", strings.Repeat("0", 64), captureArg{&hash}, "acme").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.Append(ctx, event))
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		assert.NotContains(t, changes, "@test.pl")
+		assert.Contains(t, changes, "enc:v1:k1:")
+		assert.Equal(t, appendAuditEvent(t, "", event), hash, "the cleartext is hashed")
+		assert.Equal(t, "a@test.pl", event.Changes["email"].Before, "the event is left untouched")
+
+		mock.ExpectQuery(`SELECT .* FROM "audit_events"`).
+			WillReturnRows(sqlmock.NewRows(auditEventColumns).
+				AddRow(1, event.OccurredAt, event.Actor, event.Action, event.TargetType, event.TargetID, []byte(changes), "", "", "", strings.Repeat("0", 64), hash))
+
+		events, _, err := repo.List(ctx, nil, query.Page{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, event.Changes, events[0].Changes)
+	})
+
+	t.Run("Outbox event", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.EncryptPIICopies(client, c)
+		repo := repository.NewOutboxRepository(client)
+
+		event := domain.NewEvent(domain.UserEmailChanged, "1", domain.UserEventData{ID: "1", Email: "b@test.pl", OldEmail: "a@test.pl"})
+
+		var data string
+		mock.ExpectQuery(`INSERT INTO "outbox_events"`).
+			WithArgs("1", 0, captureArg{&data}, event.ID, event.OccurredAt, event.Type).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		require.NoError(t, repo.Add(ctx, event))
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var stored domain.UserEventData
+		require.NoError(t, json.Unmarshal([]byte(data), &stored))
+		assert.Equal(t, "1", stored.ID)
+		assert.True(t, strings.HasPrefix(stored.Email, "enc:v1:k1:"))
+		assert.True(t, strings.HasPrefix(stored.OldEmail, "enc:v1:k1:"))
+
+		mock.ExpectQuery(`SELECT .* FROM "outbox_events"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "type", "aggregate_id", "occurred_at", "data"}).
+				AddRow(1, event.ID, event.Type, "1", event.OccurredAt, []byte(data)))
+
+		events, err := repo.Pending(ctx, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.JSONEq(t, string(event.Data), string(events[0].Data))
+	})
+
+	t.Run("Invitation", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.EncryptPIICopies(client, c)
+		repo := repository.NewEncryptedInvitationRepository(client, c)
+
+		var email, index, name string
+		mock.ExpectExec(`INSERT INTO "invitations" \("email", "email_index", "name", "surname",`).
+			WithArgs(captureArg{&email}, captureArg{&index}, captureArg{&name}, "", "hash", sqlmock.AnyArg(), sqlmock.AnyArg(), "acme", "", "inv").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Create(ctx, domain.Invitation{ID: "inv", Email: "john@doe.com", Name: "John", TokenHash: "hash", ExpiresAt: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		assert.True(t, strings.HasPrefix(email, "enc:v1:k1:"))
+		assert.True(t, strings.HasPrefix(name, "enc:v1:k1:"))
+		assert.Equal(t, c.BlindIndex("john@doe.com"), index)
+
+		mock.ExpectQuery(`WHERE \(\(\(\("invitations"."email_index" = \$1 OR \("invitations"."email_index" IS NULL AND "invitations"."email" = \$2\)\) AND`).
+			WithArgs(index, "john@doe.com", sqlmock.AnyArg(), "acme").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email", "email_index", "name", "surname", "token_hash", "expires_at", "created_at"}).
+				AddRow("inv", email, index, name, "", "hash", time.Now().Add(time.Hour), time.Now()))
+
+		inv, err := repo.GetPendingByEmail(ctx, "john@doe.com")
+		require.NoError(t, err)
+		require.NotNil(t, inv)
+		assert.Equal(t, "john@doe.com", inv.Email)
+		assert.Equal(t, "John", inv.Name)
+	})
+
+	t.Run("Import job", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.EncryptPIICopies(client, c)
+		repo := repository.NewEncryptedImportJobRepository(client, c)
+
+		john, err := c.Encrypt(ctx, "row_errors.email", "john@doe.com")
+		require.NoError(t, err)
+		rowErrors, err := json.Marshal([]domain.ImportRowError{{Row: 1, Email: john, Code: "user_already_exists", EmailIndex: c.BlindIndex("john@doe.com")}})
+		require.NoError(t, err)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "import_jobs" WHERE "import_jobs"."row_errors" @> \$1 OR "import_jobs"."row_errors" @> \$2`).
+			WithArgs(`[{"email_index":"`+c.BlindIndex("john@doe.com")+`"}]`, `[{"email":"john@doe.com"}]`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "format", "dry_run", "status", "row_errors"}).
+				AddRow("job", now, now, "csv", false, "succeeded", rowErrors))
+
+		var updated string
+		mock.ExpectExec(`UPDATE "import_jobs" SET .*"row_errors" = \$\d+`).
+			WithArgs(sqlmock.AnyArg(), captureArg{&updated}, "job").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		n, err := repo.Erase(ctx, domain.User{ID: "1", Email: "john@doe.com"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var stored []domain.ImportRowError
+		require.NoError(t, json.Unmarshal([]byte(updated), &stored))
+		require.Len(t, stored, 1)
+		assert.True(t, strings.HasPrefix(stored[0].Email, "enc:v1:k1:"))
+		assert.Equal(t, c.BlindIndex(domain.UserPseudonym("1")), stored[0].EmailIndex)
+	})
+}
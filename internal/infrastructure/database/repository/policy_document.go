@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/policydocument"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+type PolicyDocument struct {
+	client *ent.Client
+}
+
+func NewPolicyDocumentRepository(client *ent.Client) *PolicyDocument {
+	return &PolicyDocument{
+		client: client,
+	}
+}
+
+// Publish returns a constraint error if the version of the kind already
+// exists.
+func (p *PolicyDocument) Publish(ctx context.Context, doc domain.PolicyDocument) (domain.PolicyDocument, error) {
+	created, err := p.client.PolicyDocument.Create().
+		SetID(doc.ID).
+		SetKind(doc.Kind).
+		SetVersion(doc.Version).
+		SetURL(doc.URL).
+		SetMandatory(doc.Mandatory).
+		Save(ctx)
+	if err != nil {
+		return domain.PolicyDocument{}, err
+	}
+
+	return policyDocumentFromEntity(created), nil
+}
+
+// List returns all the documents in publication order.
+func (p *PolicyDocument) List(ctx context.Context) ([]domain.PolicyDocument, error) {
+	docs, err := p.client.PolicyDocument.Query().
+		Order(policydocument.ByPublishedAt(), policydocument.ByID()).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.PolicyDocument, 0, len(docs))
+	for _, d := range docs {
+		res = append(res, policyDocumentFromEntity(d))
+	}
+
+	return res, nil
+}
+
+func policyDocumentFromEntity(d *ent.PolicyDocument) domain.PolicyDocument {
+	return domain.PolicyDocument{
+		ID:          d.ID,
+		Kind:        d.Kind,
+		Version:     d.Version,
+		URL:         d.URL,
+		Mandatory:   d.Mandatory,
+		PublishedAt: d.PublishedAt,
+	}
+}
@@ -0,0 +1,56 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func TestPolicyDocument_Publish(t *testing.T) {
+	t.Run("Publish", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewPolicyDocumentRepository(client)
+
+		mock.ExpectExec(`INSERT INTO "policy_documents" \("kind", "version", "url", "mandatory", "published_at", "id"\)`).
+			WithArgs(domain.PolicyTermsOfService, "2", "https://example.com/tos/2", true, sqlmock.AnyArg(), "doc").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		doc, err := repo.Publish(context.Background(), domain.PolicyDocument{
+			ID:        "doc",
+			Kind:      domain.PolicyTermsOfService,
+			Version:   "2",
+			URL:       "https://example.com/tos/2",
+			Mandatory: true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "doc", doc.ID)
+		assert.False(t, doc.PublishedAt.IsZero())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPolicyDocument_List(t *testing.T) {
+	t.Run("List", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewPolicyDocumentRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "policy_documents" ORDER BY "policy_documents"."published_at", "policy_documents"."id"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "version", "url", "mandatory", "published_at"}).
+				AddRow("doc1", "terms_of_service", "1", "https://example.com/tos/1", true, now).
+				AddRow("doc2", "privacy_policy", "1", "https://example.com/privacy/1", false, now))
+
+		docs, err := repo.List(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.PolicyDocument{
+			{ID: "doc1", Kind: domain.PolicyTermsOfService, Version: "1", URL: "https://example.com/tos/1", Mandatory: true, PublishedAt: now},
+			{ID: "doc2", Kind: domain.PolicyPrivacy, Version: "1", URL: "https://example.com/privacy/1", PublishedAt: now},
+		}, docs)
+	})
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/hook"
+	"github.com/Beriw98/user-management/ent/intercept"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+)
+
+// tenantField is the column holding the organization of tenant scoped rows.
+const tenantField = "organization_id"
+
+// IsolateTenants hooks client to scope the users, groups, consents, audit and
+// outbox events, webhook subscriptions and deliveries, import jobs, data
+// exports and erasure tombstones it reads, updates and deletes to the
+// organization of the context, and to create them in it. It fails with
+// tenant.ErrMissing when the context has no organization, unless it comes
+// from tenant.AllTenants, where rows are created in the organization they are
+// given. It is called once per client.
+func IsolateTenants(client *ent.Client) {
+	for _, c := range []interface {
+		Intercept(...ent.Interceptor)
+		Use(...ent.Hook)
+	}{
+		client.User,
+		client.Group,
+		client.Consent,
+		client.AuditEvent,
+		client.OutboxEvent,
+		client.WebhookSubscription,
+		client.WebhookDelivery,
+		client.ImportJob,
+		client.DataExport,
+		client.ErasureTombstone,
+	} {
+		c.Intercept(tenantInterceptor())
+		c.Use(tenantCreateHook(), tenantScopeHook())
+	}
+}
+
+// tenantInterceptor scopes queries to the organization of their context.
+func tenantInterceptor() ent.Interceptor {
+	return intercept.TraverseFunc(func(ctx context.Context, q intercept.Query) error {
+		p, err := tenantPredicate(ctx)
+		if err != nil {
+			return err
+		}
+
+		if p != nil {
+			q.WhereP(p)
+		}
+
+		return nil
+	})
+}
+
+// tenantCreateHook creates rows in the organization of their context.
+func tenantCreateHook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				id, all, ok := tenant.Scope(ctx)
+				if _, set := m.Field(tenantField); !ok || (all && !set) {
+					return nil, tenant.ErrMissing
+				}
+
+				if !all {
+					if err := m.SetField(tenantField, id); err != nil {
+						return nil, err
+					}
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpCreate,
+	)
+}
+
+// tenantScopeHook scopes updates and deletes to the organization of their
+// context.
+func tenantScopeHook() ent.Hook {
+	return hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				mx, ok := m.(interface {
+					WhereP(...func(*sql.Selector))
+				})
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+
+				p, err := tenantPredicate(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				if p != nil {
+					mx.WhereP(p)
+				}
+
+				return next.Mutate(ctx, m)
+			})
+		},
+		ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne,
+	)
+}
+
+// tenantPredicate returns the predicate scoping rows to the organization of
+// ctx, nil when it spans every organization.
+func tenantPredicate(ctx context.Context) (func(*sql.Selector), error) {
+	id, all, ok := tenant.Scope(ctx)
+	switch {
+	case !ok:
+		return nil, tenant.ErrMissing
+	case all:
+		return nil, nil
+	}
+
+	return sql.FieldEQ(tenantField, id), nil
+}
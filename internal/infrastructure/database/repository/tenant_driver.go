@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"sync"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/Beriw98/user-management/internal/app/tenant"
+)
+
+// setTenantQuery sets the organization checked by the row level security
+// policies of the database, for the transaction when $3 is true and for the
+// session otherwise.
+const setTenantQuery = "SELECT set_config('app.tenant_id', $1, $3), set_config('app.all_tenants', $2, $3)"
+
+// TenantDriver runs the statements of ent in the organization of their
+// context, see tenant.Scope, as a defense in depth to IsolateTenants.
+// Transactions are set when they begin, other statements get a connection
+// set for their duration. Raw statements outside transactions, such as
+// those of ExecContext, run in no organization.
+type TenantDriver struct {
+	*sql.Driver
+}
+
+func NewTenantDriver(drv *sql.Driver) *TenantDriver {
+	return &TenantDriver{
+		Driver: drv,
+	}
+}
+
+func (d *TenantDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.BeginTx(ctx, nil)
+}
+
+func (d *TenantDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	tx, err := d.Driver.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setTenant(ctx, tx, true); err != nil {
+		return nil, errors.Join(err, tx.Rollback())
+	}
+
+	return tx, nil
+}
+
+func (d *TenantDriver) Exec(ctx context.Context, query string, args, v any) error {
+	conn, release, err := d.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(conn.Exec(ctx, query, args, v), release())
+}
+
+func (d *TenantDriver) Query(ctx context.Context, query string, args, v any) error {
+	conn, release, err := d.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := conn.Query(ctx, query, args, v); err != nil {
+		return errors.Join(err, release())
+	}
+
+	rows := v.(*sql.Rows)
+	rows.ColumnScanner = &releasingRows{ColumnScanner: rows.ColumnScanner, release: sync.OnceValue(release)}
+
+	return nil
+}
+
+// conn returns a connection set to the organization of ctx until release,
+// which sets it back to none.
+func (d *TenantDriver) conn(ctx context.Context) (sql.Conn, func() error, error) {
+	c, err := d.DB().Conn(ctx)
+	if err != nil {
+		return sql.Conn{}, nil, err
+	}
+
+	conn := sql.Conn{ExecQuerier: c}
+	release := func() error {
+		if err := setTenant(context.Background(), conn, false); err != nil {
+			// The connection must not go back to the pool set.
+			_ = c.Raw(func(any) error { return driver.ErrBadConn })
+			return nil
+		}
+
+		return c.Close()
+	}
+
+	if err := setTenant(ctx, conn, false); err != nil {
+		return sql.Conn{}, nil, errors.Join(err, release())
+	}
+
+	return conn, release, nil
+}
+
+func setTenant(ctx context.Context, ex dialect.ExecQuerier, local bool) error {
+	id, all, _ := tenant.Scope(ctx)
+
+	allTenants := "off"
+	if all {
+		allTenants = "on"
+	}
+
+	return ex.Exec(ctx, setTenantQuery, []any{id, allTenants, local}, nil)
+}
+
+type releasingRows struct {
+	sql.ColumnScanner
+	release func() error
+}
+
+func (r *releasingRows) Close() error {
+	return errors.Join(r.ColumnScanner.Close(), r.release())
+}
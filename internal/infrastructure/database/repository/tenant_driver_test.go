@@ -0,0 +1,97 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func mockTenantClient(t *testing.T) (*ent.Client, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	drv := repository.NewTenantDriver(sql.OpenDB(dialect.Postgres, db))
+
+	return ent.NewClient(ent.Driver(drv)), mock
+}
+
+const setTenantPattern = `SELECT set_config\('app.tenant_id', \$1, \$3\), set_config\('app.all_tenants', \$2, \$3\)`
+
+func TestTenantDriver(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), "acme")
+
+	t.Run("Query", func(t *testing.T) {
+		client, mock := mockTenantClient(t)
+
+		mock.ExpectExec(setTenantPattern).
+			WithArgs("acme", "off", false).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT .* FROM "users"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+		mock.ExpectExec(setTenantPattern).
+			WithArgs("", "off", false).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ids, err := client.User.Query().IDs(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1"}, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Exec", func(t *testing.T) {
+		client, mock := mockTenantClient(t)
+
+		mock.ExpectExec(setTenantPattern).
+			WithArgs("", "on", false).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "users" SET`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(setTenantPattern).
+			WithArgs("", "off", false).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		n, err := client.User.Delete().Exec(tenant.AllTenants(context.Background()))
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Tx", func(t *testing.T) {
+		client, mock := mockTenantClient(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(setTenantPattern).
+			WithArgs("acme", "off", true).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Tx not set", func(t *testing.T) {
+		client, mock := mockTenantClient(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(setTenantPattern).
+			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		_, err := client.Tx(ctx)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
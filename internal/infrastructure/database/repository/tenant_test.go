@@ -0,0 +1,124 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func TestIsolateTenants(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), "acme")
+	user := domain.User{ID: "1", Name: "John", Surname: "Doe", Email: "john@doe.com", Password: "secret"}
+
+	t.Run("Create", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.IsolateTenants(client)
+		userRepo := repository.NewUserRepository(client)
+
+		mock.ExpectExec(`INSERT INTO "users" \(.*"organization_id", "id"\)`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "John", "Doe", "john@doe.com", "secret", "acme", "1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		require.NoError(t, userRepo.Create(ctx, user))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Create without tenant", func(t *testing.T) {
+		client, _ := mockDbClient()
+		repository.IsolateTenants(client)
+		userRepo := repository.NewUserRepository(client)
+
+		assert.ErrorIs(t, userRepo.Create(context.Background(), user), tenant.ErrMissing)
+		assert.ErrorIs(t, userRepo.Create(tenant.AllTenants(context.Background()), user), tenant.ErrMissing)
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.IsolateTenants(client)
+		userRepo := repository.NewUserRepository(client)
+
+		mock.ExpectQuery(`FROM "users" WHERE \("users"."id" = \$1 AND "users"."organization_id" = \$2\) AND "users"."deleted_at" IS NULL`).
+			WithArgs("1", "acme").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		got, err := userRepo.GetByID(ctx, "1")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Query without tenant", func(t *testing.T) {
+		client, _ := mockDbClient()
+		repository.IsolateTenants(client)
+		userRepo := repository.NewUserRepository(client)
+
+		_, err := userRepo.GetByID(context.Background(), "1")
+		assert.ErrorIs(t, err, tenant.ErrMissing)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.IsolateTenants(client)
+		userRepo := repository.NewUserRepository(client)
+
+		mock.ExpectExec(`UPDATE "users" SET .* WHERE .*"users"."organization_id" = \$\d+`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "1", 1, "acme", "acme").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, userRepo.Delete(ctx, "1", 1))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Create group", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.IsolateTenants(client)
+		groupRepo := repository.NewGroupRepository(client)
+
+		mock.ExpectExec(`INSERT INTO "groups" \(.*"organization_id", "id"\)`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "Engineering", "", "acme", "eng").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		_, err := groupRepo.Create(ctx, domain.Group{ID: "eng", Name: "Engineering"})
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Query group", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.IsolateTenants(client)
+		groupRepo := repository.NewGroupRepository(client)
+
+		mock.ExpectQuery(`FROM "groups" WHERE "groups"."id" = \$1 AND "groups"."organization_id" = \$2`).
+			WithArgs("eng", "acme").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		got, err := groupRepo.GetByID(ctx, "eng")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Purge spans every tenant", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.IsolateTenants(client)
+		userRepo := repository.NewUserRepository(client)
+
+		before := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectExec(`DELETE FROM "users" WHERE "users"."deleted_at" < \$1$`).
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		n, err := userRepo.Purge(context.Background(), before)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+	})
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Beriw98/user-management/ent"
+)
+
+// Transactor runs functions in a transaction carried by their context, which
+// the repositories use for all their queries.
+type Transactor struct {
+	client *ent.Client
+}
+
+func NewTransactor(client *ent.Client) *Transactor {
+	return &Transactor{
+		client: client,
+	}
+}
+
+// InTx commits the transaction if fn succeeds and rolls it back otherwise.
+// Nested calls join the transaction of their context.
+func (t *Transactor) InTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if ent.TxFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := t.client.Tx(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if v := recover(); v != nil {
+			_ = tx.Rollback()
+			panic(v)
+		}
+	}()
+
+	if err = fn(ent.NewTxContext(ctx, tx)); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			return fmt.Errorf("%w: rolling back: %w", err, rErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -2,24 +2,46 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/Beriw98/user-management/ent"
+	_ "github.com/Beriw98/user-management/ent/runtime"
 	entuser "github.com/Beriw98/user-management/ent/user"
 	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/entity"
 )
 
 type User struct {
 	Client *ent.UserClient
+	// cipher is nil unless the personal data is encrypted, see
+	// NewEncryptedUserRepository.
+	cipher piiCipher
+	fields map[string]userField
 }
 
 func NewUserRepository(client *ent.Client) *User {
 	return &User{
 		Client: client.User,
+		fields: userFields,
 	}
 }
 
+// client returns the client of the transaction in ctx, if any, see
+// Transactor.
+func (u *User) client(ctx context.Context) *ent.UserClient {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.User
+	}
+
+	return u.Client
+}
+
 func (u *User) Create(ctx context.Context, user domain.User) error {
-	_, err := u.Client.Create().
+	_, err := u.client(ctx).Create().
 		SetID(user.ID).
 		SetName(user.Name).
 		SetSurname(user.Surname).
@@ -31,7 +53,7 @@ func (u *User) Create(ctx context.Context, user domain.User) error {
 }
 
 func (u *User) GetByID(ctx context.Context, id string) (*domain.User, error) {
-	user, err := u.Client.Get(ctx, id)
+	user, err := u.client(ctx).Get(ctx, id)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			return nil, nil
@@ -39,17 +61,18 @@ func (u *User) GetByID(ctx context.Context, id string) (*domain.User, error) {
 		return nil, err
 	}
 
-	return &domain.User{
-		ID:       user.ID,
-		Name:     user.Name,
-		Surname:  user.Surname,
-		Email:    user.Email,
-		Password: user.Password,
-	}, nil
+	domainUser := userFromEntity(user)
+
+	return &domainUser, nil
+}
+
+// GetByIDWithDeleted is GetByID including soft deleted users.
+func (u *User) GetByIDWithDeleted(ctx context.Context, id string) (*domain.User, error) {
+	return u.GetByID(entity.SkipSoftDelete(ctx), id)
 }
 
 func (u *User) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	user, err := u.Client.Query().Where(entuser.Email(email)).Only(ctx)
+	user, err := u.client(ctx).Query().Where(u.fields[entuser.FieldEmail].ops[query.Eq](email)).Only(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			return nil, nil
@@ -57,45 +80,233 @@ func (u *User) GetByEmail(ctx context.Context, email string) (*domain.User, erro
 		return nil, err
 	}
 
-	return &domain.User{
-		ID:       user.ID,
-		Name:     user.Name,
-		Surname:  user.Surname,
-		Email:    user.Email,
-		Password: user.Password,
-	}, nil
+	domainUser := userFromEntity(user)
+
+	return &domainUser, nil
 }
 
-func (u *User) Update(ctx context.Context, user domain.User) error {
-	_, err := u.Client.UpdateOneID(user.ID).
+// Update saves user if it still is at user.Version, otherwise it returns
+// domain.ErrVersionMismatch. It returns user at its new version.
+func (u *User) Update(ctx context.Context, user domain.User) (domain.User, error) {
+	saved, err := u.client(ctx).UpdateOneID(user.ID).
+		Where(entuser.Version(user.Version)).
 		SetName(user.Name).
 		SetSurname(user.Surname).
 		SetEmail(user.Email).
 		SetPassword(user.Password).
 		Save(ctx)
-	return err
+	if err != nil {
+		return user, versionError(err)
+	}
+
+	user.Version = saved.Version
+	user.UpdatedAt = saved.UpdatedAt
+
+	return user, nil
+}
+
+// Delete soft deletes the user if it still is at version, see Restore and
+// Purge.
+func (u *User) Delete(ctx context.Context, id string, version int) error {
+	err := u.client(ctx).DeleteOneID(id).
+		Where(entuser.Version(version)).
+		Exec(ctx)
+	return versionError(err)
+}
+
+// Restore undoes Delete. It returns a not found error if there is no deleted
+// user with the id and a constraint error if the email has been taken since.
+func (u *User) Restore(ctx context.Context, id string) error {
+	return u.client(ctx).UpdateOneID(id).
+		Where(entuser.DeletedAtNotNil()).
+		ClearDeletedAt().
+		Exec(entity.SkipSoftDelete(ctx))
 }
 
-func (u *User) Delete(ctx context.Context, id string) error {
-	return u.Client.DeleteOneID(id).Exec(ctx)
+// Purge removes the users deleted before the given time for good, in every
+// organization.
+func (u *User) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	return u.client(ctx).Delete().
+		Where(entuser.DeletedAtLT(deletedBefore)).
+		Exec(entity.SkipSoftDelete(tenant.AllTenants(ctx)))
 }
 
-func (u *User) GetMany(ctx context.Context, limit, offset int) ([]domain.User, error) {
-	users, err := u.Client.Query().Limit(limit).Offset(offset).Order(entuser.ByID()).All(ctx)
+// Erase removes the user for good, whether it is soft deleted or not.
+func (u *User) Erase(ctx context.Context, user domain.User) (int, error) {
+	return u.client(ctx).Delete().
+		Where(entuser.ID(user.ID)).
+		Exec(entity.SkipSoftDelete(ctx))
+}
+
+func (u *User) GetMany(ctx context.Context, list query.List, limit, offset int) ([]domain.User, error) {
+	ctx = listContext(ctx, list)
+
+	q, sorts, err := u.listQuery(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := q.Limit(limit).Offset(offset).Order(userOrder(sorts, false)...).All(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var domainUsers []domain.User
 	for _, user := range users {
-		domainUsers = append(domainUsers, domain.User{
-			ID:       user.ID,
-			Name:     user.Name,
-			Surname:  user.Surname,
-			Email:    user.Email,
-			Password: user.Password,
-		})
+		domainUsers = append(domainUsers, userFromEntity(user))
 	}
 
 	return domainUsers, nil
 }
+
+// GetPage returns up to page.Limit users matching list in keyset order.
+func (u *User) GetPage(ctx context.Context, list query.List, page query.Page) ([]domain.User, query.PageInfo, error) {
+	var info query.PageInfo
+
+	ctx = listContext(ctx, list)
+
+	q, sorts, err := u.listQuery(ctx, list)
+	if err != nil {
+		return nil, info, err
+	}
+
+	switch {
+	case page.Backward():
+		p, err := userKeyset(sorts, page.Before, true)
+		if err != nil {
+			return nil, info, err
+		}
+		q = q.Where(p)
+	case len(page.After) > 0:
+		p, err := userKeyset(sorts, page.After, false)
+		if err != nil {
+			return nil, info, err
+		}
+		q = q.Where(p)
+	}
+
+	users, err := q.Limit(page.Limit + 1).Order(userOrder(sorts, page.Backward())...).All(ctx)
+	if err != nil {
+		return nil, info, err
+	}
+
+	info.HasMore = len(users) > page.Limit
+	if info.HasMore {
+		users = users[:page.Limit]
+	}
+
+	if page.Backward() {
+		slices.Reverse(users)
+	}
+
+	if len(users) > 0 {
+		info.First = userKeys(sorts, users[0])
+		info.Last = userKeys(sorts, users[len(users)-1])
+	}
+
+	domainUsers := make([]domain.User, 0, len(users))
+	for _, user := range users {
+		domainUsers = append(domainUsers, userFromEntity(user))
+	}
+
+	return domainUsers, info, nil
+}
+
+// Each calls fn with every user matching list in ID order, ignoring
+// list.Sort. Users are read batch at a time with keyset pagination, so they
+// are never all in memory, and without the password.
+func (u *User) Each(ctx context.Context, list query.List, batch int, fn func(domain.User) error) error {
+	ctx = listContext(ctx, list)
+
+	var after string
+	for {
+		q, _, err := u.listQuery(ctx, list)
+		if err != nil {
+			return err
+		}
+
+		if after != "" {
+			q = q.Where(entuser.IDGT(after))
+		}
+
+		users, err := q.Select(userExportFields...).Order(entuser.ByID()).Limit(batch).All(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, user := range users {
+			if err := fn(userFromEntity(user)); err != nil {
+				return err
+			}
+		}
+
+		if len(users) < batch {
+			return nil
+		}
+
+		after = users[len(users)-1].ID
+	}
+}
+
+func (u *User) Count(ctx context.Context, list query.List) (int, error) {
+	ctx = listContext(ctx, list)
+
+	q, _, err := u.listQuery(ctx, list)
+	if err != nil {
+		return 0, err
+	}
+
+	return q.Count(ctx)
+}
+
+func (u *User) listQuery(ctx context.Context, list query.List) (*ent.UserQuery, []query.Sort, error) {
+	sorts, err := userSort(u.fields, list.Sort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q := u.client(ctx).Query()
+	if list.Filter != nil {
+		p, err := userPredicate(u.fields, list.Filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		q = q.Where(p)
+	}
+
+	return q, sorts, nil
+}
+
+// versionError reports a write conditioned on the version that matched no
+// row as domain.ErrVersionMismatch: the user changed or was deleted since it
+// was read.
+func versionError(err error) error {
+	if ent.IsNotFound(err) {
+		return fmt.Errorf("%w: %w", domain.ErrVersionMismatch, err)
+	}
+
+	return err
+}
+
+// listContext makes deleted users visible if the list includes them.
+func listContext(ctx context.Context, list query.List) context.Context {
+	if list.IncludeDeleted {
+		return entity.SkipSoftDelete(ctx)
+	}
+
+	return ctx
+}
+
+func userFromEntity(user *ent.User) domain.User {
+	return domain.User{
+		ID:        user.ID,
+		Name:      user.Name,
+		Surname:   user.Surname,
+		Email:     user.Email,
+		Password:  user.Password,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		Version:   user.Version,
+		DeletedAt: user.DeletedAt,
+	}
+}
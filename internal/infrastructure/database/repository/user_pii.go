@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/hook"
+	"github.com/Beriw98/user-management/ent/predicate"
+	entuser "github.com/Beriw98/user-management/ent/user"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/entity"
+)
+
+// piiCipher encrypts the personal data of users at rest, see
+// encryption.Cipher.
+type piiCipher interface {
+	Encrypt(ctx context.Context, field, plaintext string) (string, error)
+	Decrypt(ctx context.Context, field, value string) (string, error)
+	Stale(value string) bool
+	BlindIndex(value string) string
+}
+
+// NewEncryptedUserRepository is NewUserRepository storing the name, surname
+// and email of users encrypted with c, see EncryptUserPII. Encrypted fields
+// can only be filtered by email equality and not sorted nor searched.
+func NewEncryptedUserRepository(client *ent.Client, c piiCipher) *User {
+	EncryptUserPII(client, c)
+
+	return &User{
+		Client: client.User,
+		cipher: c,
+		fields: encryptedUserFields(c),
+	}
+}
+
+// EncryptUserPII hooks client to encrypt the name, surname and email of the
+// users it writes, along with the blind index of the email, and to decrypt
+// the ones it reads. Values that are not encrypted yet are read as they are,
+// see User.RotatePII. It is called once per client.
+func EncryptUserPII(client *ent.Client, c piiCipher) {
+	client.User.Use(hook.On(
+		func(next ent.Mutator) ent.Mutator {
+			return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+				if err := encryptUserMutation(ctx, c, m); err != nil {
+					return nil, err
+				}
+
+				v, err := next.Mutate(ctx, m)
+				if err != nil {
+					return nil, err
+				}
+
+				if user, ok := v.(*ent.User); ok {
+					if err := decryptUser(ctx, c, user); err != nil {
+						return nil, err
+					}
+				}
+
+				return v, nil
+			})
+		},
+		ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne,
+	))
+
+	client.User.Intercept(ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, q ent.Query) (ent.Value, error) {
+			v, err := next.Query(ctx, q)
+			if err != nil {
+				return nil, err
+			}
+
+			users, ok := v.([]*ent.User)
+			if !ok || ctx.Value(rawPIIKey{}) != nil {
+				return v, nil
+			}
+
+			for _, user := range users {
+				if err := decryptUser(ctx, c, user); err != nil {
+					return nil, err
+				}
+			}
+
+			return v, nil
+		})
+	}))
+}
+
+func encryptUserMutation(ctx context.Context, c piiCipher, m *ent.UserMutation) error {
+	if name, ok := m.Name(); ok {
+		enc, err := c.Encrypt(ctx, entuser.FieldName, name)
+		if err != nil {
+			return err
+		}
+		m.SetName(enc)
+	}
+
+	if surname, ok := m.Surname(); ok {
+		enc, err := c.Encrypt(ctx, entuser.FieldSurname, surname)
+		if err != nil {
+			return err
+		}
+		m.SetSurname(enc)
+	}
+
+	if email, ok := m.Email(); ok {
+		enc, err := c.Encrypt(ctx, entuser.FieldEmail, email)
+		if err != nil {
+			return err
+		}
+		m.SetEmail(enc)
+		m.SetEmailIndex(c.BlindIndex(email))
+	}
+
+	return nil
+}
+
+func decryptUser(ctx context.Context, c piiCipher, user *ent.User) error {
+	var err error
+	if user.Name, err = c.Decrypt(ctx, entuser.FieldName, user.Name); err != nil {
+		return err
+	}
+
+	if user.Surname, err = c.Decrypt(ctx, entuser.FieldSurname, user.Surname); err != nil {
+		return err
+	}
+
+	user.Email, err = c.Decrypt(ctx, entuser.FieldEmail, user.Email)
+
+	return err
+}
+
+// rawPIIKey in a context keeps the users read from being decrypted.
+type rawPIIKey struct{}
+
+// RotatePII encrypts again with the active master key the users after the
+// given ID, up to limit of them in ID order, deleted users and those of every
+// organization included, whose personal data is in cleartext or encrypted
+// with an older key. Their version and update time are kept, it is not a
+// change. It returns the last ID read, empty once there are no more users,
+// and the number of users encrypted again.
+func (u *User) RotatePII(ctx context.Context, after string, limit int) (string, int, error) {
+	tx := ent.TxFromContext(ctx)
+	if tx == nil {
+		return "", 0, ErrNoTx
+	}
+
+	ctx = entity.SkipSoftDelete(tenant.AllTenants(ctx))
+
+	users, err := tx.User.Query().
+		Where(entuser.IDGT(after)).
+		Select(entuser.FieldID, entuser.FieldUpdatedAt, entuser.FieldName, entuser.FieldSurname, entuser.FieldEmail, entuser.FieldEmailIndex).
+		Order(entuser.ByID()).
+		Limit(limit).
+		ForUpdate().
+		All(context.WithValue(ctx, rawPIIKey{}, true))
+	if err != nil {
+		return "", 0, err
+	}
+
+	if len(users) == 0 {
+		return "", 0, nil
+	}
+
+	var n int
+	for _, user := range users {
+		if !u.cipher.Stale(user.Name) && !u.cipher.Stale(user.Surname) && !u.cipher.Stale(user.Email) && user.EmailIndex != nil {
+			continue
+		}
+
+		if err := decryptUser(ctx, u.cipher, user); err != nil {
+			return "", n, err
+		}
+
+		err := tx.User.Update().
+			Where(entuser.ID(user.ID)).
+			SetName(user.Name).
+			SetSurname(user.Surname).
+			SetEmail(user.Email).
+			SetUpdatedAt(user.UpdatedAt).
+			AddVersion(0).
+			Exec(ctx)
+		if err != nil {
+			return "", n, err
+		}
+		n++
+	}
+
+	return users[len(users)-1].ID, n, nil
+}
+
+// encryptedUserFields are the userFields that can still be queried once
+// encrypted: the ID, and the email by equality through its blind index.
+// Users not rotated yet match by their cleartext email.
+func encryptedUserFields(c piiCipher) map[string]userField {
+	emailIs := func(email string) predicate.User {
+		return entuser.Or(
+			entuser.EmailIndex(c.BlindIndex(email)),
+			entuser.And(entuser.EmailIndexIsNil(), entuser.Email(email)),
+		)
+	}
+
+	return map[string]userField{
+		entuser.FieldID:      userFields[entuser.FieldID],
+		entuser.FieldName:    {},
+		entuser.FieldSurname: {},
+		entuser.FieldEmail: {
+			ops: map[query.Operator]func(string) predicate.User{
+				query.Eq: emailIs,
+				query.Ne: func(email string) predicate.User {
+					return entuser.Or(
+						entuser.And(entuser.EmailIndexNotNil(), entuser.EmailIndexNEQ(c.BlindIndex(email))),
+						entuser.And(entuser.EmailIndexIsNil(), entuser.EmailNEQ(email)),
+					)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,172 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+	"github.com/Beriw98/user-management/internal/infrastructure/encryption"
+)
+
+func piiCipher(t *testing.T) *encryption.Cipher {
+	t.Helper()
+
+	ring, err := encryption.ParseKeyring("k1:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	require.NoError(t, err)
+
+	return encryption.NewCipher(ring, []byte("index"))
+}
+
+func TestUser_EncryptedPII(t *testing.T) {
+	ctx := context.Background()
+	c := piiCipher(t)
+	columns := []string{"id", "created_at", "updated_at", "version", "deleted_at", "name", "surname", "email", "password", "email_index"}
+
+	t.Run("Create", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewEncryptedUserRepository(client, c)
+
+		var name, email, index string
+		mock.ExpectExec(`INSERT INTO "users"`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, captureArg{&name}, sqlmock.AnyArg(), captureArg{&email}, "secret", captureArg{&index}, "1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := userRepo.Create(ctx, domain.User{ID: "1", Name: "John", Surname: "Doe", Email: "john@doe.com", Password: "secret"})
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		assert.True(t, strings.HasPrefix(name, "enc:v1:k1:"))
+		assert.True(t, strings.HasPrefix(email, "enc:v1:k1:"))
+		assert.Equal(t, c.BlindIndex("john@doe.com"), index)
+	})
+
+	t.Run("GetByEmail", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewEncryptedUserRepository(client, c)
+
+		name, err := c.Encrypt(ctx, "name", "John")
+		require.NoError(t, err)
+		email, err := c.Encrypt(ctx, "email", "john@doe.com")
+		require.NoError(t, err)
+
+		mock.ExpectQuery(`WHERE \("users"."email_index" = \$1 OR \("users"."email_index" IS NULL AND "users"."email" = \$2\)\) AND "users"."deleted_at" IS NULL`).
+			WithArgs(c.BlindIndex("john@doe.com"), "john@doe.com").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("1", time.Now(), time.Now(), 1, nil, name, "Doe", email, "secret", c.BlindIndex("john@doe.com")))
+
+		got, err := userRepo.GetByEmail(ctx, "john@doe.com")
+		require.NoError(t, err)
+		assert.Equal(t, "John", got.Name)
+		assert.Equal(t, "Doe", got.Surname)
+		assert.Equal(t, "john@doe.com", got.Email)
+	})
+
+	t.Run("Unsupported filter", func(t *testing.T) {
+		client, _ := mockDbClient()
+		userRepo := repository.NewEncryptedUserRepository(client, c)
+
+		_, err := userRepo.GetMany(ctx, query.List{Filter: query.Condition{Field: "name", Operator: query.Sw, Value: "J"}}, 10, 0)
+		assert.ErrorIs(t, err, query.ErrUnknownOperator)
+	})
+
+	t.Run("Unsupported sort", func(t *testing.T) {
+		client, _ := mockDbClient()
+		userRepo := repository.NewEncryptedUserRepository(client, c)
+
+		_, err := userRepo.GetMany(ctx, query.List{Sort: []query.Sort{{Field: "email"}}}, 10, 0)
+		assert.ErrorIs(t, err, query.ErrInvalidSort)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		client, _ := mockDbClient()
+		userRepo := repository.NewEncryptedUserRepository(client, c)
+
+		_, err := userRepo.Search(ctx, "john", 10)
+		assert.ErrorIs(t, err, domain.ErrSearchUnavailable)
+	})
+}
+
+func TestUser_RotatePII(t *testing.T) {
+	ctx := context.Background()
+	c := piiCipher(t)
+	updatedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	columns := []string{"id", "updated_at", "name", "surname", "email", "email_index"}
+
+	t.Run("Rotate", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewEncryptedUserRepository(client, c)
+
+		name, err := c.Encrypt(ctx, "name", "Jane")
+		require.NoError(t, err)
+		surname, err := c.Encrypt(ctx, "surname", "Doe")
+		require.NoError(t, err)
+		email, err := c.Encrypt(ctx, "email", "jane@doe.com")
+		require.NoError(t, err)
+
+		var rotated string
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "users"."id", "users"."updated_at", "users"."name", "users"."surname", "users"."email", "users"."email_index" FROM "users" WHERE "users"."id" > \$1 ORDER BY "users"."id" LIMIT 2 FOR UPDATE`).
+			WithArgs("0").
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow("1", updatedAt, name, surname, email, c.BlindIndex("jane@doe.com")).
+				AddRow("2", updatedAt, "John", "Doe", "john@doe.com", nil))
+		mock.ExpectExec(`UPDATE "users" SET "updated_at" = \$1, "name" = \$2, "surname" = \$3, "email" = \$4, "email_index" = \$5, "version" = COALESCE\("users"."version", 0\) \+ \$6 WHERE "users"."id" = \$7`).
+			WithArgs(updatedAt, sqlmock.AnyArg(), sqlmock.AnyArg(), captureArg{&rotated}, c.BlindIndex("john@doe.com"), 0, "2").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+
+		last, n, err := userRepo.RotatePII(ent.NewTxContext(ctx, tx), "0", 2)
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit())
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		assert.Equal(t, "2", last)
+		assert.Equal(t, 1, n)
+
+		got, err := c.Decrypt(ctx, "email", rotated)
+		require.NoError(t, err)
+		assert.Equal(t, "john@doe.com", got)
+	})
+
+	t.Run("Done", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewEncryptedUserRepository(client, c)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "users" WHERE "users"."id" > \$1`).
+			WithArgs("2").
+			WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectCommit()
+
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+
+		last, n, err := userRepo.RotatePII(ent.NewTxContext(ctx, tx), "2", 2)
+		require.NoError(t, err)
+		require.NoError(t, tx.Commit())
+
+		assert.Empty(t, last)
+		assert.Zero(t, n)
+	})
+
+	t.Run("No transaction", func(t *testing.T) {
+		client, _ := mockDbClient()
+		userRepo := repository.NewEncryptedUserRepository(client, c)
+
+		_, _, err := userRepo.RotatePII(ctx, "", 2)
+		assert.ErrorIs(t, err, repository.ErrNoTx)
+	})
+}
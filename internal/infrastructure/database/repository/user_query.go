@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/predicate"
+	entuser "github.com/Beriw98/user-management/ent/user"
+	"github.com/Beriw98/user-management/internal/app/query"
+)
+
+// userExportFields are all the columns of users except the password.
+var userExportFields = []string{
+	entuser.FieldID,
+	entuser.FieldCreatedAt,
+	entuser.FieldUpdatedAt,
+	entuser.FieldVersion,
+	entuser.FieldDeletedAt,
+	entuser.FieldName,
+	entuser.FieldSurname,
+	entuser.FieldEmail,
+}
+
+type userField struct {
+	ops   map[query.Operator]func(string) predicate.User
+	order func(...sql.OrderTermOption) entuser.OrderOption
+	value func(*ent.User) string
+}
+
+// userFields maps the filterable and sortable fields to the predicates
+// generated by ent, so no user input ever reaches the SQL as an identifier.
+var userFields = map[string]userField{
+	entuser.FieldID: {
+		ops: map[query.Operator]func(string) predicate.User{
+			query.Eq: entuser.IDEQ,
+			query.Ne: entuser.IDNEQ,
+			query.Gt: entuser.IDGT,
+			query.Ge: entuser.IDGTE,
+			query.Lt: entuser.IDLT,
+			query.Le: entuser.IDLTE,
+		},
+		order: entuser.ByID,
+		value: func(u *ent.User) string { return u.ID },
+	},
+	entuser.FieldName: {
+		ops: map[query.Operator]func(string) predicate.User{
+			query.Eq: entuser.NameEQ,
+			query.Ne: entuser.NameNEQ,
+			query.Gt: entuser.NameGT,
+			query.Ge: entuser.NameGTE,
+			query.Lt: entuser.NameLT,
+			query.Le: entuser.NameLTE,
+			query.Sw: entuser.NameHasPrefix,
+			query.Ew: entuser.NameHasSuffix,
+			query.Co: entuser.NameContains,
+		},
+		order: entuser.ByName,
+		value: func(u *ent.User) string { return u.Name },
+	},
+	entuser.FieldSurname: {
+		ops: map[query.Operator]func(string) predicate.User{
+			query.Eq: entuser.SurnameEQ,
+			query.Ne: entuser.SurnameNEQ,
+			query.Gt: entuser.SurnameGT,
+			query.Ge: entuser.SurnameGTE,
+			query.Lt: entuser.SurnameLT,
+			query.Le: entuser.SurnameLTE,
+			query.Sw: entuser.SurnameHasPrefix,
+			query.Ew: entuser.SurnameHasSuffix,
+			query.Co: entuser.SurnameContains,
+		},
+		order: entuser.BySurname,
+		value: func(u *ent.User) string { return u.Surname },
+	},
+	entuser.FieldEmail: {
+		ops: map[query.Operator]func(string) predicate.User{
+			query.Eq: entuser.EmailEQ,
+			query.Ne: entuser.EmailNEQ,
+			query.Gt: entuser.EmailGT,
+			query.Ge: entuser.EmailGTE,
+			query.Lt: entuser.EmailLT,
+			query.Le: entuser.EmailLTE,
+			query.Sw: entuser.EmailHasPrefix,
+			query.Ew: entuser.EmailHasSuffix,
+			query.Co: entuser.EmailContains,
+		},
+		order: entuser.ByEmail,
+		value: func(u *ent.User) string { return u.Email },
+	},
+}
+
+func userPredicate(fields map[string]userField, e query.Expr) (predicate.User, error) {
+	switch e := e.(type) {
+	case query.Condition:
+		f, ok := fields[e.Field]
+		if !ok {
+			return nil, &query.FieldError{Err: query.ErrUnknownField, Field: e.Field}
+		}
+
+		op, ok := f.ops[e.Operator]
+		if !ok {
+			return nil, &query.FieldError{Err: query.ErrUnknownOperator, Field: e.Field, Value: string(e.Operator)}
+		}
+
+		return op(e.Value), nil
+	case query.And:
+		ps, err := userPredicates(fields, e)
+		if err != nil {
+			return nil, err
+		}
+
+		return entuser.And(ps...), nil
+	case query.Or:
+		ps, err := userPredicates(fields, e)
+		if err != nil {
+			return nil, err
+		}
+
+		return entuser.Or(ps...), nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported expression %T", query.ErrInvalidFilter, e)
+	}
+}
+
+func userPredicates(fields map[string]userField, es []query.Expr) ([]predicate.User, error) {
+	ps := make([]predicate.User, 0, len(es))
+	for _, e := range es {
+		p, err := userPredicate(fields, e)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, p)
+	}
+
+	return ps, nil
+}
+
+// userSort appends the ID as the final sort key so the order is total, which
+// keyset pagination relies on.
+func userSort(fields map[string]userField, sorts []query.Sort) ([]query.Sort, error) {
+	res := make([]query.Sort, 0, len(sorts)+1)
+	for _, s := range sorts {
+		f, ok := fields[s.Field]
+		if !ok {
+			return nil, &query.FieldError{Err: query.ErrUnknownField, Field: s.Field}
+		}
+
+		if f.order == nil {
+			return nil, &query.FieldError{Err: query.ErrInvalidSort, Field: s.Field}
+		}
+
+		if s.Field == entuser.FieldID {
+			return append(res, s), nil
+		}
+
+		res = append(res, s)
+	}
+
+	return append(res, query.Sort{Field: entuser.FieldID}), nil
+}
+
+func userOrder(sorts []query.Sort, reverse bool) []entuser.OrderOption {
+	res := make([]entuser.OrderOption, 0, len(sorts))
+	for _, s := range sorts {
+		if s.Desc != reverse {
+			res = append(res, userFields[s.Field].order(sql.OrderDesc()))
+		} else {
+			res = append(res, userFields[s.Field].order())
+		}
+	}
+
+	return res
+}
+
+// userKeyset matches the rows strictly after keys in the order given by sorts,
+// or strictly before them when backward is set:
+//
+//	(k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+func userKeyset(sorts []query.Sort, keys []string, backward bool) (predicate.User, error) {
+	if len(keys) != len(sorts) {
+		return nil, fmt.Errorf("%w: expected %d keys, got %d", query.ErrInvalidPage, len(sorts), len(keys))
+	}
+
+	or := make([]predicate.User, 0, len(sorts))
+	for i, s := range sorts {
+		ops := userFields[s.Field].ops
+
+		and := make([]predicate.User, 0, i+1)
+		for j := 0; j < i; j++ {
+			and = append(and, userFields[sorts[j].Field].ops[query.Eq](keys[j]))
+		}
+
+		if s.Desc != backward {
+			and = append(and, ops[query.Lt](keys[i]))
+		} else {
+			and = append(and, ops[query.Gt](keys[i]))
+		}
+
+		or = append(or, entuser.And(and...))
+	}
+
+	return entuser.Or(or...), nil
+}
+
+// userKeys returns the values of the sort keys of user in the form expected
+// by query.Page.
+func userKeys(sorts []query.Sort, user *ent.User) []string {
+	keys := make([]string, 0, len(sorts))
+	for _, s := range sorts {
+		keys = append(keys, userFields[s.Field].value(user))
+	}
+
+	return keys
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/Beriw98/user-management/ent/predicate"
+	entuser "github.com/Beriw98/user-management/ent/user"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// UserSearcher finds users by a free text query across name, surname and
+// email, best matches first.
+type UserSearcher interface {
+	Search(ctx context.Context, q string, limit int) ([]domain.User, error)
+}
+
+var (
+	_ UserSearcher = (*User)(nil)
+	_ UserSearcher = (*InMemoryUserSearch)(nil)
+)
+
+// Search matches the `search_vector` full-text column and falls back to
+// trigram word similarity, so typos and partial words still match. Both are
+// backed by GIN indexes, see migrations. Encrypted users cannot be searched,
+// the indexes only hold the users without an email blind index.
+func (u *User) Search(ctx context.Context, q string, limit int) ([]domain.User, error) {
+	if u.cipher != nil {
+		return nil, domain.ErrSearchUnavailable
+	}
+
+	users, err := u.client(ctx).Query().
+		Where(entuser.EmailIndexIsNil(), userSearchMatch(q)).
+		Order(userSearchRank(q), entuser.ByID()).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	domainUsers := make([]domain.User, 0, len(users))
+	for _, user := range users {
+		domainUsers = append(domainUsers, userFromEntity(user))
+	}
+
+	return domainUsers, nil
+}
+
+// userSearchDocument must match the expression of the trigram index.
+func userSearchDocument(s *sql.Selector) string {
+	return "(coalesce(" + s.C(entuser.FieldName) + ", '') || ' ' || coalesce(" + s.C(entuser.FieldSurname) + ", '') || ' ' || " + s.C(entuser.FieldEmail) + ")"
+}
+
+func userSearchMatch(q string) predicate.User {
+	return func(s *sql.Selector) {
+		s.Where(sql.P(func(b *sql.Builder) {
+			b.Wrap(func(b *sql.Builder) {
+				b.WriteString(s.C("search_vector")).
+					WriteString(" @@ websearch_to_tsquery('simple', ").Arg(q).WriteString(")").
+					WriteString(" OR ").Arg(q).WriteString(" <% ").WriteString(userSearchDocument(s))
+			})
+		}))
+	}
+}
+
+// userSearchRank orders full-text hits above pure similarity matches.
+func userSearchRank(q string) entuser.OrderOption {
+	return func(s *sql.Selector) {
+		s.OrderExpr(sql.ExprFunc(func(b *sql.Builder) {
+			b.WriteString("ts_rank(").WriteString(s.C("search_vector")).
+				WriteString(", websearch_to_tsquery('simple', ").Arg(q).WriteString(")) + ").
+				WriteString("word_similarity(").Arg(q).WriteString(", ").WriteString(userSearchDocument(s)).
+				WriteString(") DESC")
+		}))
+	}
+}
+
+// InMemoryUserSearch is a UserSearcher over a fixed set of users meant for
+// tests. It ranks users by trigram similarity, close to what pg_trgm does.
+type InMemoryUserSearch struct {
+	mu    sync.RWMutex
+	users []domain.User
+}
+
+// similarityThreshold is lower than pg_trgm.word_similarity_threshold (0.6)
+// as wordSimilarity scores partial words a bit lower than Postgres does.
+const similarityThreshold = 0.5
+
+func NewInMemoryUserSearch(users ...domain.User) *InMemoryUserSearch {
+	return &InMemoryUserSearch{
+		users: users,
+	}
+}
+
+func (s *InMemoryUserSearch) Add(users ...domain.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users = append(s.users, users...)
+}
+
+func (s *InMemoryUserSearch) Search(_ context.Context, q string, limit int) ([]domain.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type hit struct {
+		user  domain.User
+		score float64
+	}
+
+	var hits []hit
+	for _, user := range s.users {
+		score := wordSimilarity(q, strings.Join([]string{user.Name, user.Surname, user.Email}, " "))
+		if score >= similarityThreshold {
+			hits = append(hits, hit{user: user, score: score})
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].user.ID < hits[j].user.ID
+	})
+
+	users := make([]domain.User, 0, min(limit, len(hits)))
+	for _, h := range hits {
+		if len(users) == limit {
+			break
+		}
+		users = append(users, h.user)
+	}
+
+	return users, nil
+}
+
+// wordSimilarity approximates pg_trgm word_similarity: the share of the
+// trigrams of q found in the best matching run of consecutive words of doc,
+// the run being as long as q in words.
+func wordSimilarity(q, doc string) float64 {
+	qt := trigrams(q)
+	if len(qt) == 0 {
+		return 0
+	}
+
+	span := len(strings.FieldsFunc(q, isNotAlphanumeric))
+	words := strings.FieldsFunc(doc, isNotAlphanumeric)
+
+	var best float64
+	for i := range words {
+		wt := trigrams(strings.Join(words[i:min(i+span, len(words))], " "))
+
+		var common int
+		for t := range qt {
+			if _, ok := wt[t]; ok {
+				common++
+			}
+		}
+
+		best = max(best, float64(common)/float64(len(qt)))
+	}
+
+	return best
+}
+
+func trigrams(s string) map[string]struct{} {
+	res := make(map[string]struct{})
+	for _, word := range strings.FieldsFunc(strings.ToLower(s), isNotAlphanumeric) {
+		rs := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(rs); i++ {
+			res[string(rs[i:i+3])] = struct{}{}
+		}
+	}
+
+	return res
+}
+
+func isNotAlphanumeric(r rune) bool {
+	return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r > 127)
+}
@@ -0,0 +1,83 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+func TestUser_Search(t *testing.T) {
+	t.Run("Search", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		rows := sqlmock.NewRows([]string{"id", "name", "surname", "email", "password"}).
+			AddRow("1", "John", "Doe", "john@doe.com", "")
+
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE ("users"."email_index" IS NULL AND ("users"."search_vector" @@ websearch_to_tsquery('simple', $1) OR $2 <% (coalesce("users"."name", '') || ' ' || coalesce("users"."surname", '') || ' ' || "users"."email"))) AND "users"."deleted_at" IS NULL ORDER BY ts_rank("users"."search_vector", websearch_to_tsquery('simple', $3)) + word_similarity($4, `)).
+			WithArgs("jon", "jon", "jon", "jon").
+			WillReturnRows(rows)
+
+		got, err := userRepo.Search(ctx, "jon", 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.User{{ID: "1", Name: "John", Surname: "Doe", Email: "john@doe.com"}}, got)
+	})
+
+	t.Run("Search error", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT").WillReturnError(assert.AnError)
+
+		got, err := userRepo.Search(ctx, "jon", 5)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, got)
+	})
+}
+
+func TestInMemoryUserSearch_Search(t *testing.T) {
+	users := []domain.User{
+		{ID: "1", Name: "John", Surname: "Doe", Email: "john@doe.com"},
+		{ID: "2", Name: "Jane", Surname: "Doe", Email: "jane@doe.com"},
+		{ID: "3", Name: "Johnny", Surname: "Walker", Email: "walker@example.com"},
+		{ID: "4", Name: "Anna", Surname: "Nowak", Email: "anna@example.com"},
+	}
+	s := repository.NewInMemoryUserSearch(users...)
+
+	tests := []struct {
+		name  string
+		q     string
+		limit int
+		want  []string
+	}{
+		{name: "Exact word", q: "nowak", limit: 10, want: []string{"4"}},
+		{name: "Typo", q: "nowk", limit: 10, want: []string{"4"}},
+		{name: "Best match first", q: "john", limit: 10, want: []string{"1", "3"}},
+		{name: "Multiple words", q: "jane doe", limit: 10, want: []string{"2", "1"}},
+		{name: "Limit", q: "doe", limit: 1, want: []string{"1"}},
+		{name: "No match", q: "xyz", limit: 10, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Search(context.Background(), tt.q, tt.limit)
+			assert.NoError(t, err)
+
+			ids := make([]string, 0, len(got))
+			for _, u := range got {
+				ids = append(ids, u.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
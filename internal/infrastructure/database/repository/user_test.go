@@ -3,6 +3,7 @@ package repository_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/Beriw98/user-management/ent"
 	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
 	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
 )
 
@@ -45,7 +47,7 @@ func TestUser_Create(t *testing.T) {
 		}
 
 		mock.ExpectExec("INSERT INTO \"users\"").
-			WithArgs(user.Name, user.Surname, user.Email, user.Password, sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, user.Name, user.Surname, user.Email, user.Password, sqlmock.AnyArg()).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		err := userRepo.Create(ctx, user)
@@ -65,7 +67,7 @@ func TestUser_Create(t *testing.T) {
 		}
 
 		mock.ExpectExec("INSERT INTO \"users\"").
-			WithArgs(user.Name, user.Surname, user.Email, user.Password, sqlmock.AnyArg()).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, user.Name, user.Surname, user.Email, user.Password, sqlmock.AnyArg()).
 			WillReturnError(assert.AnError)
 
 		err := userRepo.Create(ctx, user)
@@ -81,11 +83,12 @@ func TestUser_Delete(t *testing.T) {
 
 		id := "1"
 
-		mock.ExpectExec("DELETE FROM \"users\"").
-			WithArgs(id).
+		mock.ExpectExec("UPDATE \"users\" SET \"updated_at\" = \\$1, \"deleted_at\" = \\$2, .* "+
+			"WHERE \\(\"users\".\"id\" = \\$4 AND \"users\".\"version\" = \\$5\\) AND \"users\".\"deleted_at\" IS NULL").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, id, 1).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
-		err := userRepo.Delete(ctx, id)
+		err := userRepo.Delete(ctx, id, 1)
 		assert.NoError(t, err)
 	})
 
@@ -96,13 +99,27 @@ func TestUser_Delete(t *testing.T) {
 
 		id := "1"
 
-		mock.ExpectExec("DELETE FROM \"users\"").
-			WithArgs(id).
+		mock.ExpectExec("UPDATE \"users\" SET \"updated_at\" = \\$1, \"deleted_at\" = \\$2, .* "+
+			"WHERE \\(\"users\".\"id\" = \\$4 AND \"users\".\"version\" = \\$5\\) AND \"users\".\"deleted_at\" IS NULL").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, id, 1).
 			WillReturnError(assert.AnError)
 
-		err := userRepo.Delete(ctx, id)
+		err := userRepo.Delete(ctx, id, 1)
 		assert.Error(t, err)
 	})
+
+	t.Run("Version mismatch", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		mock.ExpectExec("UPDATE \"users\"").
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "1", 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := userRepo.Delete(ctx, "1", 2)
+		assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+	})
 }
 
 func TestUser_GetByID(t *testing.T) {
@@ -122,7 +139,7 @@ func TestUser_GetByID(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "name", "surname", "email"}).
 			AddRow(user.ID, user.Name, user.Surname, user.Email)
 
-		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\" FROM \"users\"").
+		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"created_at\", \"users\".\"updated_at\", \"users\".\"version\", \"users\".\"deleted_at\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\", \"users\".\"email_index\", \"users\".\"organization_id\" FROM \"users\"").
 			WithArgs(id).
 			WillReturnRows(rows)
 
@@ -140,7 +157,7 @@ func TestUser_GetByID(t *testing.T) {
 
 		rows := sqlmock.NewRows([]string{"id", "name", "surname", "email"})
 
-		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\" FROM \"users\"").
+		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"created_at\", \"users\".\"updated_at\", \"users\".\"version\", \"users\".\"deleted_at\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\", \"users\".\"email_index\", \"users\".\"organization_id\" FROM \"users\"").
 			WithArgs(id).
 			WillReturnRows(rows)
 
@@ -156,7 +173,7 @@ func TestUser_GetByID(t *testing.T) {
 
 		id := "1"
 
-		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\" FROM \"users\"").
+		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"created_at\", \"users\".\"updated_at\", \"users\".\"version\", \"users\".\"deleted_at\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\", \"users\".\"email_index\", \"users\".\"organization_id\" FROM \"users\"").
 			WithArgs(id).
 			WillReturnError(assert.AnError)
 
@@ -185,10 +202,10 @@ func TestUser_GetMany(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "name", "surname", "email"}).
 			AddRow(users[0].ID, users[0].Name, users[0].Surname, users[0].Email)
 
-		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\" FROM \"users\"").
+		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"created_at\", \"users\".\"updated_at\", \"users\".\"version\", \"users\".\"deleted_at\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\", \"users\".\"email_index\", \"users\".\"organization_id\" FROM \"users\"").
 			WillReturnRows(rows)
 
-		got, err := userRepo.GetMany(ctx, 10, 0)
+		got, err := userRepo.GetMany(ctx, query.List{}, 10, 0)
 
 		assert.NoError(t, err)
 		assert.Equal(t, users, got)
@@ -199,16 +216,73 @@ func TestUser_GetMany(t *testing.T) {
 		userRepo := repository.NewUserRepository(client)
 		ctx := context.Background()
 
-		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\" FROM \"users\"").
+		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"created_at\", \"users\".\"updated_at\", \"users\".\"version\", \"users\".\"deleted_at\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\", \"users\".\"email_index\", \"users\".\"organization_id\" FROM \"users\"").
 			WillReturnError(assert.AnError)
 
-		got, err := userRepo.GetMany(ctx, 10, 0)
+		got, err := userRepo.GetMany(ctx, query.List{}, 10, 0)
 
 		assert.Error(t, err)
 		assert.Nil(t, got)
 	})
 }
 
+func TestUser_Each(t *testing.T) {
+	const columns = "SELECT \"users\".\"id\", \"users\".\"created_at\", \"users\".\"updated_at\", \"users\".\"version\", \"users\".\"deleted_at\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\" FROM \"users\""
+
+	t.Run("Each", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		mock.ExpectQuery(columns + ".+ORDER BY \"users\".\"id\" LIMIT 2").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow("1", "a@test.pl").AddRow("2", "b@test.pl"))
+		mock.ExpectQuery(columns + ".+\"users\".\"id\" > \\$1.+ORDER BY \"users\".\"id\" LIMIT 2").
+			WithArgs("2").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow("3", "c@test.pl"))
+
+		var got []string
+		err := userRepo.Each(ctx, query.List{}, 2, func(user domain.User) error {
+			got = append(got, user.ID)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3"}, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Each callback error", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		mock.ExpectQuery(columns).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1").AddRow("2"))
+
+		calls := 0
+		err := userRepo.Each(ctx, query.List{}, 2, func(domain.User) error {
+			calls++
+			return assert.AnError
+		})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Each error", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		mock.ExpectQuery(columns).
+			WillReturnError(assert.AnError)
+
+		err := userRepo.Each(ctx, query.List{}, 2, func(domain.User) error { return nil })
+
+		assert.Error(t, err)
+	})
+}
+
 func TestUser_Update(t *testing.T) {
 	t.Run("Update", func(t *testing.T) {
 		client, mock := mockDbClient()
@@ -221,22 +295,24 @@ func TestUser_Update(t *testing.T) {
 			Surname:  "Test",
 			Email:    "test@test.pl",
 			Password: "testPassword",
+			Version:  1,
 		}
 
 		mock.ExpectBegin()
 		mock.ExpectExec("UPDATE \"users\"").
-			WithArgs(user.Name, user.Surname, user.Email, user.Password, user.ID).
+			WithArgs(sqlmock.AnyArg(), user.Name, user.Surname, user.Email, user.Password, 1, user.ID, user.Version).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
-		mock.ExpectQuery("SELECT \"id\", \"name\", \"surname\", \"email\", \"password\" FROM \"users\"").
+		mock.ExpectQuery("SELECT \"id\", \"created_at\", \"updated_at\", \"version\", \"deleted_at\", \"name\", \"surname\", \"email\", \"password\", \"email_index\", \"organization_id\" FROM \"users\"").
 			WithArgs(user.ID).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "surname", "email", "password"}).
-				AddRow(user.ID, user.Name, user.Surname, user.Email, user.Password))
+			WillReturnRows(sqlmock.NewRows([]string{"id", "version", "name", "surname", "email", "password"}).
+				AddRow(user.ID, user.Version+1, user.Name, user.Surname, user.Email, user.Password))
 
 		mock.ExpectCommit()
 
-		err := userRepo.Update(ctx, user)
+		saved, err := userRepo.Update(ctx, user)
 		assert.NoError(t, err)
+		assert.Equal(t, 2, saved.Version)
 	})
 
 	t.Run("Update error", func(t *testing.T) {
@@ -250,14 +326,36 @@ func TestUser_Update(t *testing.T) {
 			Surname:  "Test",
 			Email:    "test@test.pl",
 			Password: "test",
+			Version:  1,
 		}
 
+		mock.ExpectBegin()
 		mock.ExpectExec("UPDATE \"users\"").
-			WithArgs(user.Name, user.Surname, user.Email, user.ID).
+			WithArgs(sqlmock.AnyArg(), user.Name, user.Surname, user.Email, user.Password, 1, user.ID, user.Version).
 			WillReturnError(assert.AnError)
+		mock.ExpectRollback()
 
-		err := userRepo.Update(ctx, user)
-		assert.Error(t, err)
+		_, err := userRepo.Update(ctx, user)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("Version mismatch", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		user := domain.User{ID: "1", Name: "Test", Surname: "Test", Email: "test@test.pl", Password: "test", Version: 1}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE \"users\" .* WHERE \"id\" = \\$7 AND \\(\"users\".\"version\" = \\$8 AND \"users\".\"deleted_at\" IS NULL\\)").
+			WithArgs(sqlmock.AnyArg(), user.Name, user.Surname, user.Email, user.Password, 1, user.ID, user.Version).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT EXISTS").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		_, err := userRepo.Update(ctx, user)
+		assert.ErrorIs(t, err, domain.ErrVersionMismatch)
 	})
 }
 
@@ -278,7 +376,7 @@ func TestUser_GetByEmail(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "name", "surname", "email"}).
 			AddRow(user.ID, user.Name, user.Surname, user.Email)
 
-		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\" FROM \"users\"").
+		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"created_at\", \"users\".\"updated_at\", \"users\".\"version\", \"users\".\"deleted_at\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\", \"users\".\"email_index\", \"users\".\"organization_id\" FROM \"users\"").
 			WithArgs(email).
 			WillReturnRows(rows)
 
@@ -297,7 +395,7 @@ func TestUser_GetByEmail(t *testing.T) {
 
 		rows := sqlmock.NewRows([]string{"id", "name", "surname", "email"})
 
-		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\" FROM \"users\"").
+		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"created_at\", \"users\".\"updated_at\", \"users\".\"version\", \"users\".\"deleted_at\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\", \"users\".\"email_index\", \"users\".\"organization_id\" FROM \"users\"").
 			WithArgs(email).
 			WillReturnRows(rows)
 
@@ -313,7 +411,7 @@ func TestUser_GetByEmail(t *testing.T) {
 
 		email := "test@test.pl"
 
-		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\" FROM \"users\"").
+		mock.ExpectQuery("SELECT \"users\".\"id\", \"users\".\"created_at\", \"users\".\"updated_at\", \"users\".\"version\", \"users\".\"deleted_at\", \"users\".\"name\", \"users\".\"surname\", \"users\".\"email\", \"users\".\"password\", \"users\".\"email_index\", \"users\".\"organization_id\" FROM \"users\"").
 			WithArgs(email).
 			WillReturnError(assert.AnError)
 
@@ -322,3 +420,234 @@ func TestUser_GetByEmail(t *testing.T) {
 		assert.Nil(t, got)
 	})
 }
+
+func TestUser_GetPage(t *testing.T) {
+	t.Run("GetPage forward", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		rows := sqlmock.NewRows([]string{"id", "name", "surname", "email"}).
+			AddRow("2", "Test", "Test", "test2@test.pl").
+			AddRow("3", "Test", "Test", "test3@test.pl").
+			AddRow("4", "Test", "Test", "test4@test.pl")
+
+		mock.ExpectQuery("SELECT .* FROM \"users\" WHERE \"users\".\"id\" > \\$1 AND \"users\".\"deleted_at\" IS NULL ORDER BY \"users\".\"id\" LIMIT 3").
+			WithArgs("1").
+			WillReturnRows(rows)
+
+		got, info, err := userRepo.GetPage(ctx, query.List{}, query.Page{After: []string{"1"}, Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, query.PageInfo{HasMore: true, First: []string{"2"}, Last: []string{"3"}}, info)
+		assert.Equal(t, []domain.User{
+			{ID: "2", Name: "Test", Surname: "Test", Email: "test2@test.pl"},
+			{ID: "3", Name: "Test", Surname: "Test", Email: "test3@test.pl"},
+		}, got)
+	})
+
+	t.Run("GetPage backward", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		rows := sqlmock.NewRows([]string{"id", "name", "surname", "email"}).
+			AddRow("3", "Test", "Test", "test3@test.pl").
+			AddRow("2", "Test", "Test", "test2@test.pl")
+
+		mock.ExpectQuery("SELECT .* FROM \"users\" WHERE \"users\".\"id\" < \\$1 AND \"users\".\"deleted_at\" IS NULL ORDER BY \"users\".\"id\" DESC LIMIT 3").
+			WithArgs("4").
+			WillReturnRows(rows)
+
+		got, info, err := userRepo.GetPage(ctx, query.List{}, query.Page{Before: []string{"4"}, Limit: 2})
+		assert.NoError(t, err)
+		assert.False(t, info.HasMore)
+		assert.Equal(t, []domain.User{
+			{ID: "2", Name: "Test", Surname: "Test", Email: "test2@test.pl"},
+			{ID: "3", Name: "Test", Surname: "Test", Email: "test3@test.pl"},
+		}, got)
+	})
+
+	t.Run("GetPage error", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT .* FROM \"users\"").
+			WillReturnError(assert.AnError)
+
+		got, _, err := userRepo.GetPage(ctx, query.List{}, query.Page{Limit: 2})
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestUser_Count(t *testing.T) {
+	t.Run("Count", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		mock.ExpectQuery("SELECT COUNT\\(\"users\".\"id\"\\) FROM \"users\"").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+
+		got, err := userRepo.Count(ctx, query.List{})
+		assert.NoError(t, err)
+		assert.Equal(t, 5, got)
+	})
+}
+
+func TestUser_GetPage_FilterAndSort(t *testing.T) {
+	t.Run("GetPage filtered and sorted", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		list := query.List{
+			Filter: query.And{
+				query.Condition{Field: "email", Operator: query.Ew, Value: "@ourco.com"},
+				query.Condition{Field: "name", Operator: query.Ne, Value: "John"},
+			},
+			Sort: []query.Sort{{Field: "surname", Desc: true}},
+		}
+
+		rows := sqlmock.NewRows([]string{"id", "name", "surname", "email"}).
+			AddRow("5", "Jane", "Doe", "jane@ourco.com")
+
+		mock.ExpectQuery("SELECT .* FROM \"users\" WHERE .*\"users\".\"email\" LIKE \\$1 AND \"users\".\"name\" <> \\$2.*"+
+			"\"users\".\"surname\" < \\$3 OR \\(\"users\".\"surname\" = \\$4 AND \"users\".\"id\" > \\$5\\).*"+
+			"ORDER BY \"users\".\"surname\" DESC, \"users\".\"id\" LIMIT 3").
+			WithArgs("%@ourco.com", "John", "Smith", "Smith", "4").
+			WillReturnRows(rows)
+
+		got, info, err := userRepo.GetPage(ctx, list, query.Page{After: []string{"Smith", "4"}, Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, query.PageInfo{First: []string{"Doe", "5"}, Last: []string{"Doe", "5"}}, info)
+		assert.Equal(t, []domain.User{{ID: "5", Name: "Jane", Surname: "Doe", Email: "jane@ourco.com"}}, got)
+	})
+
+	t.Run("GetPage invalid keys", func(t *testing.T) {
+		client, _ := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		list := query.List{Sort: []query.Sort{{Field: "surname"}}}
+
+		_, _, err := userRepo.GetPage(ctx, list, query.Page{After: []string{"4"}, Limit: 2})
+		assert.ErrorIs(t, err, query.ErrInvalidPage)
+	})
+
+	t.Run("GetMany unknown field", func(t *testing.T) {
+		client, _ := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		list := query.List{Filter: query.Condition{Field: "password", Operator: query.Eq, Value: "x"}}
+
+		_, err := userRepo.GetMany(ctx, list, 10, 0)
+		assert.ErrorIs(t, err, query.ErrUnknownField)
+	})
+}
+
+func TestUser_Restore(t *testing.T) {
+	t.Run("Restore", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE \"users\" SET \"deleted_at\" = NULL, .* WHERE \"id\" = \\$3 AND \"users\".\"deleted_at\" IS NOT NULL").
+			WithArgs(sqlmock.AnyArg(), 1, "1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery("SELECT .* FROM \"users\"").
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+		mock.ExpectCommit()
+
+		err := userRepo.Restore(ctx, "1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Restore not deleted", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE \"users\"").
+			WithArgs(sqlmock.AnyArg(), 1, "1").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		err := userRepo.Restore(ctx, "1")
+		assert.True(t, ent.IsNotFound(err))
+	})
+}
+
+func TestUser_Purge(t *testing.T) {
+	t.Run("Purge", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		before := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		mock.ExpectExec("DELETE FROM \"users\" WHERE \"users\".\"deleted_at\" < \\$1$").
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		n, err := userRepo.Purge(ctx, before)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+	})
+
+	t.Run("Purge error", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+		ctx := context.Background()
+
+		mock.ExpectExec("DELETE FROM \"users\"").
+			WillReturnError(assert.AnError)
+
+		_, err := userRepo.Purge(ctx, time.Now())
+		assert.Error(t, err)
+	})
+}
+
+func TestUser_GetMany_IncludeDeleted(t *testing.T) {
+	client, mock := mockDbClient()
+	userRepo := repository.NewUserRepository(client)
+	ctx := context.Background()
+
+	deletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "deleted_at", "name", "surname", "email"}).
+		AddRow("1", deletedAt, "Test", "Test", "test@test.pl")
+
+	mock.ExpectQuery("SELECT .* FROM \"users\" ORDER BY \"users\".\"id\" LIMIT 10$").
+		WillReturnRows(rows)
+
+	got, err := userRepo.GetMany(ctx, query.List{IncludeDeleted: true}, 10, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.User{
+		{ID: "1", Name: "Test", Surname: "Test", Email: "test@test.pl", DeletedAt: &deletedAt},
+	}, got)
+}
+
+func TestUser_Erase(t *testing.T) {
+	t.Run("Erase", func(t *testing.T) {
+		client, mock := mockDbClient()
+		userRepo := repository.NewUserRepository(client)
+
+		mock.ExpectExec(`DELETE FROM "users" WHERE "users"."id" = \$1$`).
+			WithArgs("1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		n, err := userRepo.Erase(context.Background(), domain.User{ID: "1"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+}
@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/ent/webhookdelivery"
+	"github.com/Beriw98/user-management/ent/webhooksubscription"
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+type Webhook struct {
+	client *ent.Client
+}
+
+func NewWebhookRepository(client *ent.Client) *Webhook {
+	return &Webhook{
+		client: client,
+	}
+}
+
+func (w *Webhook) entClient(ctx context.Context) *ent.Client {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.Client()
+	}
+
+	return w.client
+}
+
+func (w *Webhook) CreateSubscription(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	created, err := w.entClient(ctx).WebhookSubscription.Create().
+		SetID(sub.ID).
+		SetURL(sub.URL).
+		SetSecret(sub.Secret).
+		SetEventTypes(sub.EventTypes).
+		SetActive(sub.Active).
+		Save(ctx)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	return subscriptionFromEntity(created), nil
+}
+
+func (w *Webhook) GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	sub, err := w.entClient(ctx).WebhookSubscription.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	domainSub := subscriptionFromEntity(sub)
+
+	return &domainSub, nil
+}
+
+func (w *Webhook) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	subs, err := w.entClient(ctx).WebhookSubscription.Query().
+		Order(webhooksubscription.ByCreatedAt(), webhooksubscription.ByID()).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.WebhookSubscription, 0, len(subs))
+	for _, sub := range subs {
+		res = append(res, subscriptionFromEntity(sub))
+	}
+
+	return res, nil
+}
+
+// UpdateSubscription saves everything but the secret, which cannot change.
+func (w *Webhook) UpdateSubscription(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	updated, err := w.entClient(ctx).WebhookSubscription.UpdateOneID(sub.ID).
+		SetURL(sub.URL).
+		SetEventTypes(sub.EventTypes).
+		SetActive(sub.Active).
+		Save(ctx)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	return subscriptionFromEntity(updated), nil
+}
+
+// DeleteSubscription removes the subscription along with its deliveries.
+func (w *Webhook) DeleteSubscription(ctx context.Context, id string) error {
+	return w.entClient(ctx).WebhookSubscription.DeleteOneID(id).Exec(ctx)
+}
+
+// Publish enqueues a delivery of event to every active subscription of its
+// organization wanting it. Run by the outbox relay, it shares its
+// transaction.
+func (w *Webhook) Publish(ctx context.Context, event domain.Event) error {
+	client := w.entClient(ctx)
+
+	subs, err := client.WebhookSubscription.Query().
+		Where(
+			webhooksubscription.Active(true),
+			webhooksubscription.OrganizationID(event.OrganizationID),
+		).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(webhookPayload{
+		ID:         event.ID,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	var builders []*ent.WebhookDeliveryCreate
+	for _, sub := range subs {
+		if !subscriptionFromEntity(sub).Wants(event.Type) {
+			continue
+		}
+
+		builders = append(builders, client.WebhookDelivery.Create().
+			SetOrganizationID(event.OrganizationID).
+			SetSubscriptionID(sub.ID).
+			SetEventID(event.ID).
+			SetEventType(event.Type).
+			SetPayload(payload))
+	}
+
+	if len(builders) == 0 {
+		return nil
+	}
+
+	// The relay delivers at least once, an event it already went through
+	// must not be enqueued twice.
+	return client.WebhookDelivery.CreateBulk(builders...).
+		OnConflictColumns(webhookdelivery.FieldSubscriptionID, webhookdelivery.FieldEventID).
+		DoNothing().
+		Exec(ctx)
+}
+
+// webhookPayload is the body of deliveries.
+type webhookPayload struct {
+	ID         string           `json:"id"`
+	Type       domain.EventType `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       json.RawMessage  `json:"data"`
+}
+
+// Erase pseudonymizes the payload of the deliveries of events of user,
+// delivered or not.
+func (w *Webhook) Erase(ctx context.Context, user domain.User) (int, error) {
+	client := w.entClient(ctx)
+
+	deliveries, err := client.WebhookDelivery.Query().
+		Where(func(s *sql.Selector) {
+			s.Where(sqljson.ValueEQ(s.C(webhookdelivery.FieldPayload), user.ID, sqljson.DotPath("data.id")))
+		}).
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	pseudonym := domain.UserPseudonym(user.ID)
+	for _, d := range deliveries {
+		var payload webhookPayload
+		if err = json.Unmarshal(d.Payload, &payload); err != nil {
+			return n, err
+		}
+
+		data, changed, err := domain.PseudonymizeUserEventData(payload.Data, pseudonym)
+		if err != nil {
+			return n, err
+		}
+
+		if !changed {
+			continue
+		}
+
+		payload.Data = data
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return n, err
+		}
+
+		if err = client.WebhookDelivery.Update().Where(webhookdelivery.ID(d.ID)).SetPayload(b).Exec(ctx); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	return n, nil
+}
+
+// Claim leases up to limit pending deliveries whose next attempt is due, with
+// their subscription, by moving their next attempt to until. Other instances
+// skip them until then, or while they are being claimed, so it has to run in
+// a transaction.
+func (w *Webhook) Claim(ctx context.Context, limit int, until time.Time) ([]domain.WebhookDelivery, error) {
+	tx := ent.TxFromContext(ctx)
+	if tx == nil {
+		return nil, ErrNoTx
+	}
+
+	deliveries, err := tx.WebhookDelivery.Query().
+		Where(
+			webhookdelivery.StatusEQ(domain.DeliveryPending),
+			webhookdelivery.NextAttemptAtLTE(time.Now()),
+		).
+		Order(webhookdelivery.ByNextAttemptAt(), webhookdelivery.ByID()).
+		Limit(limit).
+		ForUpdate(sql.WithLockAction(sql.SkipLocked)).
+		WithSubscription().
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(deliveries))
+	for _, d := range deliveries {
+		ids = append(ids, d.ID)
+	}
+
+	if err = tx.WebhookDelivery.Update().Where(webhookdelivery.IDIn(ids...)).SetNextAttemptAt(until).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		delivery := deliveryFromEntity(d)
+		delivery.NextAttemptAt = until
+		res = append(res, delivery)
+	}
+
+	return res, nil
+}
+
+// SaveAttempt records the outcome of an attempt to send d.
+func (w *Webhook) SaveAttempt(ctx context.Context, d domain.WebhookDelivery) error {
+	return w.entClient(ctx).WebhookDelivery.UpdateOneID(d.ID).
+		SetStatus(d.Status).
+		SetAttempts(d.Attempts).
+		SetNextAttemptAt(d.NextAttemptAt).
+		SetNillableLastAttemptAt(d.LastAttemptAt).
+		SetLastStatusCode(d.LastStatusCode).
+		SetLastError(d.LastError).
+		Exec(ctx)
+}
+
+// ListDeliveries returns up to limit deliveries of a subscription, newest
+// first, only those in status unless it is empty.
+func (w *Webhook) ListDeliveries(ctx context.Context, subscriptionID string, status domain.DeliveryStatus, limit int) ([]domain.WebhookDelivery, error) {
+	q := w.entClient(ctx).WebhookDelivery.Query().
+		Where(webhookdelivery.SubscriptionID(subscriptionID))
+	if status != "" {
+		q = q.Where(webhookdelivery.StatusEQ(status))
+	}
+
+	deliveries, err := q.
+		Order(webhookdelivery.ByID(sql.OrderDesc())).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		res = append(res, deliveryFromEntity(d))
+	}
+
+	return res, nil
+}
+
+// Redeliver makes a delivery of the subscription pending again, with a fresh
+// set of attempts. It returns a not found error if there is no such delivery.
+func (w *Webhook) Redeliver(ctx context.Context, subscriptionID string, id int64) error {
+	return w.entClient(ctx).WebhookDelivery.UpdateOneID(id).
+		Where(webhookdelivery.SubscriptionID(subscriptionID)).
+		SetStatus(domain.DeliveryPending).
+		SetAttempts(0).
+		SetNextAttemptAt(time.Now()).
+		Exec(ctx)
+}
+
+func subscriptionFromEntity(sub *ent.WebhookSubscription) domain.WebhookSubscription {
+	return domain.WebhookSubscription{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		Secret:     sub.Secret,
+		EventTypes: sub.EventTypes,
+		Active:     sub.Active,
+		CreatedAt:  sub.CreatedAt,
+		UpdatedAt:  sub.UpdatedAt,
+	}
+}
+
+func deliveryFromEntity(d *ent.WebhookDelivery) domain.WebhookDelivery {
+	delivery := domain.WebhookDelivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+	}
+
+	if d.Edges.Subscription != nil {
+		sub := subscriptionFromEntity(d.Edges.Subscription)
+		delivery.Subscription = &sub
+	}
+
+	return delivery
+}
@@ -0,0 +1,160 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+)
+
+var webhookSubscriptionColumns = []string{"id", "created_at", "updated_at", "url", "secret", "event_types", "active"}
+
+func TestWebhook_Publish(t *testing.T) {
+	t.Run("Enqueues for subscriptions wanting the event", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewWebhookRepository(client)
+
+		now := time.Now()
+		mock.ExpectQuery(`SELECT .* FROM "webhook_subscriptions" WHERE "webhook_subscriptions"."active" AND "webhook_subscriptions"."organization_id" = \$1`).
+			WithArgs("acme").
+			WillReturnRows(sqlmock.NewRows(webhookSubscriptionColumns).
+				AddRow("all", now, now, "https://a.test", "secret", nil, true).
+				AddRow("created", now, now, "https://b.test", "secret", []byte(`["user.created"]`), true).
+				AddRow("deleted", now, now, "https://c.test", "secret", []byte(`["user.deleted"]`), true))
+
+		event := domain.NewEvent(domain.UserCreated, "1", domain.UserEventData{ID: "1"})
+		event.OrganizationID = "acme"
+
+		mock.ExpectQuery(`INSERT INTO "webhook_deliveries" .* ON CONFLICT \("subscription_id", "event_id"\) DO NOTHING RETURNING "id"`).
+			WithArgs(
+				0, sqlmock.AnyArg(), event.ID, event.Type, sqlmock.AnyArg(), "acme", sqlmock.AnyArg(), domain.DeliveryPending, "all",
+				0, sqlmock.AnyArg(), event.ID, event.Type, sqlmock.AnyArg(), "acme", sqlmock.AnyArg(), domain.DeliveryPending, "created",
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+		assert.NoError(t, repo.Publish(context.Background(), event))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing to enqueue", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewWebhookRepository(client)
+
+		mock.ExpectQuery(`SELECT .* FROM "webhook_subscriptions"`).
+			WillReturnRows(sqlmock.NewRows(webhookSubscriptionColumns))
+
+		assert.NoError(t, repo.Publish(context.Background(), domain.NewEvent(domain.UserDeleted, "1", nil)))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestWebhook_Claim(t *testing.T) {
+	t.Run("Claim", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewWebhookRepository(client)
+
+		now := time.Now()
+		until := now.Add(time.Minute)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .* FROM "webhook_deliveries" WHERE "webhook_deliveries"."status" = \$1 AND "webhook_deliveries"."next_attempt_at" <= \$2 ORDER BY "webhook_deliveries"."next_attempt_at", "webhook_deliveries"."id" LIMIT 10 FOR UPDATE SKIP LOCKED`).
+			WithArgs(domain.DeliveryPending, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "event_type", "payload", "status", "attempts", "next_attempt_at", "last_attempt_at", "last_status_code", "last_error", "created_at", "subscription_id"}).
+				AddRow(1, "e1", "user.created", []byte(`{}`), "pending", 0, now, nil, nil, nil, now, "s1"))
+		mock.ExpectQuery(`SELECT .* FROM "webhook_subscriptions" WHERE "webhook_subscriptions"."id" IN \(\$1\)`).
+			WithArgs("s1").
+			WillReturnRows(sqlmock.NewRows(webhookSubscriptionColumns).AddRow("s1", now, now, "https://a.test", "secret", nil, true))
+		mock.ExpectExec(`UPDATE "webhook_deliveries" SET "next_attempt_at" = \$1 WHERE "webhook_deliveries"."id" IN \(\$2\)`).
+			WithArgs(until, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		var due []domain.WebhookDelivery
+		err := repository.NewTransactor(client).InTx(context.Background(), func(ctx context.Context) error {
+			var err error
+			due, err = repo.Claim(ctx, 10, until)
+			return err
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, due, 1)
+		assert.Equal(t, "https://a.test", due[0].Subscription.URL)
+		assert.Equal(t, until, due[0].NextAttemptAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Claim outside a transaction", func(t *testing.T) {
+		client, _ := mockDbClient()
+		repo := repository.NewWebhookRepository(client)
+
+		_, err := repo.Claim(context.Background(), 10, time.Now())
+		assert.ErrorIs(t, err, repository.ErrNoTx)
+	})
+}
+
+func TestWebhook_Erase(t *testing.T) {
+	t.Run("Erase", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repo := repository.NewWebhookRepository(client)
+
+		now := time.Now().UTC().Truncate(time.Second)
+		payload := `{"id":"e1","type":"user.created","occurred_at":"` + now.Format(time.RFC3339) + `","data":{"id":"1","name":"John","email":"john@doe.com"}}`
+		mock.ExpectQuery(`SELECT .* FROM "webhook_deliveries" WHERE "webhook_deliveries"."payload"->'data'->>'id' = \$1`).
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "event_type", "payload", "status", "attempts", "next_attempt_at", "last_attempt_at", "last_status_code", "last_error", "created_at", "subscription_id"}).
+				AddRow(1, "e1", "user.created", []byte(payload), "delivered", 1, now, nil, nil, nil, now, "s1"))
+		mock.ExpectExec(`UPDATE "webhook_deliveries" SET "payload" = \$1 WHERE "webhook_deliveries"."id" = \$2`).
+			WithArgs([]byte(`{"id":"e1","type":"user.created","occurred_at":"`+now.Format(time.RFC3339)+`","data":{"id":"1","name":"erased:1","email":"erased:1"}}`), 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		n, err := repo.Erase(context.Background(), domain.User{ID: "1"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestWebhook_Redeliver(t *testing.T) {
+	ctx := tenant.NewContext(context.Background(), "acme")
+
+	t.Run("Redeliver", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.IsolateTenants(client)
+		repo := repository.NewWebhookRepository(client)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "webhook_deliveries" SET .* WHERE "id" = \$4 AND \("webhook_deliveries"."subscription_id" = \$5 AND "webhook_deliveries"."organization_id" = \$6\)`).
+			WithArgs(domain.DeliveryPending, 0, sqlmock.AnyArg(), 5, "s1", "acme").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT .* FROM "webhook_deliveries" WHERE "id" = \$1`).
+			WithArgs(5).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id"}).AddRow(5, "s1"))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.Redeliver(ctx, "s1", 5))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Delivery of another organization", func(t *testing.T) {
+		client, mock := mockDbClient()
+		repository.IsolateTenants(client)
+		repo := repository.NewWebhookRepository(client)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "webhook_deliveries"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT EXISTS \(SELECT \* FROM "webhook_deliveries" WHERE .*"webhook_deliveries"."organization_id" = \$3\)`).
+			WithArgs(5, "s1", "acme").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		assert.True(t, ent.IsNotFound(repo.Redeliver(ctx, "s1", 5)))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,109 @@
+package encryption
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// prefix marks encrypted values, anything else is read as cleartext so rows
+// written before encryption was enabled stay readable until rotated.
+const prefix = "enc:v1:"
+
+var ErrMalformed = errors.New("malformed encrypted value")
+
+// Cipher encrypts values with envelope encryption: every value gets its own
+// AES-256-GCM data key, wrapped by the active master key of the KMS and
+// stored along with it as
+//
+//	enc:v1:<master key ID>:<wrapped data key>:<nonce and ciphertext>
+//
+// Values are bound to the field they are encrypted for, so they cannot be
+// moved to another one.
+type Cipher struct {
+	kms   KMS
+	index []byte
+}
+
+func NewCipher(kms KMS, indexKey []byte) *Cipher {
+	return &Cipher{
+		kms:   kms,
+		index: indexKey,
+	}
+}
+
+func (c *Cipher) Encrypt(ctx context.Context, field, plaintext string) (string, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(key, []byte(plaintext), []byte(field))
+	if err != nil {
+		return "", err
+	}
+
+	keyID, wrapped, err := c.kms.Wrap(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	return prefix + keyID + ":" + base64.RawURLEncoding.EncodeToString(wrapped) + ":" + base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt returns values that are not encrypted as they are.
+func (c *Cipher) Decrypt(ctx context.Context, field, value string) (string, error) {
+	if !strings.HasPrefix(value, prefix) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 {
+		return "", ErrMalformed
+	}
+
+	wrapped, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+
+	key, err := c.kms.Unwrap(ctx, parts[0], wrapped)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(key, ciphertext, []byte(field))
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// Stale reports whether value has to be encrypted again with the active
+// master key: it is either cleartext or wrapped by an older key.
+func (c *Cipher) Stale(value string) bool {
+	keyID, _, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+
+	return !strings.HasPrefix(value, prefix) || !ok || keyID != c.kms.ActiveKeyID()
+}
+
+// BlindIndex is a keyed hash of value, equal for equal values, to look up
+// encrypted values without decrypting them.
+func (c *Cipher) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, c.index)
+	mac.Write([]byte(value))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,169 @@
+package encryption_test
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Beriw98/user-management/internal/infrastructure/encryption"
+)
+
+func key(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+func TestParseKeyring(t *testing.T) {
+	t.Run("First key is active", func(t *testing.T) {
+		k, err := encryption.ParseKeyring("k2:" + key('b') + ", k1:" + key('a'))
+		require.NoError(t, err)
+
+		assert.Equal(t, "k2", k.ActiveKeyID())
+	})
+
+	tests := []struct {
+		name string
+		keys string
+	}{
+		{name: "Missing ID", keys: key('a')},
+		{name: "Not base64", keys: "k1:not base64"},
+		{name: "Short key", keys: "k1:" + base64.StdEncoding.EncodeToString([]byte("short"))},
+		{name: "Given twice", keys: "k1:" + key('a') + ",k1:" + key('b')},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := encryption.ParseKeyring(tt.keys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCipher(t *testing.T) {
+	ctx := context.Background()
+
+	old, err := encryption.ParseKeyring("k1:" + key('a'))
+	require.NoError(t, err)
+
+	ring, err := encryption.ParseKeyring("k2:" + key('b') + ",k1:" + key('a'))
+	require.NoError(t, err)
+
+	c := encryption.NewCipher(ring, []byte("index"))
+
+	t.Run("Round trip", func(t *testing.T) {
+		enc, err := c.Encrypt(ctx, "email", "john@doe.com")
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(enc, "enc:v1:k2:"))
+		assert.NotContains(t, enc, "john")
+		assert.False(t, c.Stale(enc))
+
+		got, err := c.Decrypt(ctx, "email", enc)
+		require.NoError(t, err)
+		assert.Equal(t, "john@doe.com", got)
+	})
+
+	t.Run("Every value has its own data key", func(t *testing.T) {
+		a, err := c.Encrypt(ctx, "email", "john@doe.com")
+		require.NoError(t, err)
+		b, err := c.Encrypt(ctx, "email", "john@doe.com")
+		require.NoError(t, err)
+
+		assert.NotEqual(t, a, b)
+	})
+
+	t.Run("Bound to the field", func(t *testing.T) {
+		enc, err := c.Encrypt(ctx, "name", "John")
+		require.NoError(t, err)
+
+		_, err = c.Decrypt(ctx, "surname", enc)
+		assert.Error(t, err)
+	})
+
+	t.Run("Older master key", func(t *testing.T) {
+		enc, err := encryption.NewCipher(old, nil).Encrypt(ctx, "name", "John")
+		require.NoError(t, err)
+
+		assert.True(t, c.Stale(enc))
+
+		got, err := c.Decrypt(ctx, "name", enc)
+		require.NoError(t, err)
+		assert.Equal(t, "John", got)
+	})
+
+	t.Run("Unknown master key", func(t *testing.T) {
+		enc, err := c.Encrypt(ctx, "name", "John")
+		require.NoError(t, err)
+
+		_, err = encryption.NewCipher(old, nil).Decrypt(ctx, "name", enc)
+		assert.ErrorIs(t, err, encryption.ErrUnknownKey)
+	})
+
+	t.Run("Cleartext", func(t *testing.T) {
+		got, err := c.Decrypt(ctx, "name", "John")
+		require.NoError(t, err)
+
+		assert.Equal(t, "John", got)
+		assert.True(t, c.Stale("John"))
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := c.Decrypt(ctx, "name", "enc:v1:k2:abc")
+		assert.ErrorIs(t, err, encryption.ErrMalformed)
+	})
+
+	t.Run("Blind index", func(t *testing.T) {
+		assert.Equal(t, c.BlindIndex("john@doe.com"), c.BlindIndex("john@doe.com"))
+		assert.NotEqual(t, c.BlindIndex("john@doe.com"), c.BlindIndex("jane@doe.com"))
+		assert.NotEqual(t, c.BlindIndex("john@doe.com"), encryption.NewCipher(ring, []byte("other")).BlindIndex("john@doe.com"))
+	})
+}
+
+func TestLocalKMS(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "keys.json")
+
+	k, err := encryption.NewLocalKMS(path)
+	require.NoError(t, err)
+
+	first := k.ActiveKeyID()
+	assert.NotEmpty(t, first)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	enc, err := encryption.NewCipher(k, nil).Encrypt(ctx, "name", "John")
+	require.NoError(t, err)
+
+	t.Run("Rotate", func(t *testing.T) {
+		id, err := k.Rotate()
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first, id)
+		assert.Equal(t, id, k.ActiveKeyID())
+
+		got, err := encryption.NewCipher(k, nil).Decrypt(ctx, "name", enc)
+		require.NoError(t, err)
+		assert.Equal(t, "John", got)
+	})
+
+	t.Run("Picks up keys added by another process", func(t *testing.T) {
+		other, err := encryption.NewLocalKMS(path)
+		require.NoError(t, err)
+
+		// Make sure the modification time changes on coarse file systems.
+		time.Sleep(10 * time.Millisecond)
+
+		id, err := other.Rotate()
+		require.NoError(t, err)
+
+		assert.Equal(t, id, k.ActiveKeyID())
+	})
+}
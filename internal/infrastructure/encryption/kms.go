@@ -0,0 +1,322 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// KMS wraps data keys with master keys that never leave it.
+type KMS interface {
+	// ActiveKeyID is the ID of the master key Wrap uses.
+	ActiveKeyID() string
+	Wrap(ctx context.Context, key []byte) (keyID string, wrapped []byte, err error)
+	Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+var ErrUnknownKey = errors.New("unknown master key")
+
+const keySize = 32
+
+var (
+	_ KMS = (*Keyring)(nil)
+	_ KMS = (*LocalKMS)(nil)
+)
+
+// Keyring is a KMS over master keys held in memory, the first one being the
+// active key. The others are only kept to unwrap the data keys they wrapped
+// until rotated.
+type Keyring struct {
+	active string
+	keys   map[string][]byte
+}
+
+// ParseKeyring reads master keys given as `id:key` pairs separated by
+// commas, keys being 32 bytes encoded in standard base64.
+func ParseKeyring(s string) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string][]byte)}
+	for _, pair := range strings.Split(s, ",") {
+		id, enc, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid master key %q, expected id:key", pair)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(enc)
+		if err != nil {
+			return nil, fmt.Errorf("master key %s: %w", id, err)
+		}
+
+		if err := k.add(id, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return k, nil
+}
+
+func (k *Keyring) add(id string, key []byte) error {
+	if strings.Contains(id, ":") {
+		return fmt.Errorf("master key ID %q contains a colon", id)
+	}
+
+	if len(key) != keySize {
+		return fmt.Errorf("master key %s is %d bytes, expected %d", id, len(key), keySize)
+	}
+
+	if _, ok := k.keys[id]; ok {
+		return fmt.Errorf("master key %s is given twice", id)
+	}
+
+	if k.active == "" {
+		k.active = id
+	}
+	k.keys[id] = key
+
+	return nil
+}
+
+func (k *Keyring) ActiveKeyID() string {
+	return k.active
+}
+
+func (k *Keyring) Wrap(_ context.Context, key []byte) (string, []byte, error) {
+	wrapped, err := seal(k.keys[k.active], key, []byte(k.active))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return k.active, wrapped, nil
+}
+
+func (k *Keyring) Unwrap(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	master, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+
+	return open(master, wrapped, []byte(keyID))
+}
+
+// LocalKMS stands in for a cloud KMS in development and on single hosts: its
+// master keys are stored in a JSON file, created with a fresh key if missing.
+// The file is read again whenever it changes, so keys added by Rotate in
+// another process, such as cmd/pii-rotate, are picked up.
+type LocalKMS struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	ring    *Keyring
+}
+
+type localKeyFile struct {
+	// Keys are in order, the first one is active.
+	Keys []localKey `json:"keys"`
+}
+
+type localKey struct {
+	ID  string `json:"id"`
+	Key []byte `json:"key"`
+}
+
+func NewLocalKMS(path string) (*LocalKMS, error) {
+	k := &LocalKMS{path: path}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if _, err := k.Rotate(); err != nil {
+			return nil, err
+		}
+		return k, nil
+	}
+
+	if err := k.load(); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// Rotate adds a new master key and makes it the active one, returning its
+// ID.
+func (k *LocalKMS) Rotate() (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	var file localKeyFile
+	if k.ring != nil {
+		if err := k.refresh(); err != nil {
+			return "", err
+		}
+		file = k.file()
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	id := xid.New().String()
+	file.Keys = append([]localKey{{ID: id, Key: key}}, file.Keys...)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	tmp := k.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmp, k.path); err != nil {
+		return "", err
+	}
+
+	return id, k.load()
+}
+
+func (k *LocalKMS) ActiveKeyID() string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	_ = k.refresh()
+
+	return k.ring.ActiveKeyID()
+}
+
+func (k *LocalKMS) Wrap(ctx context.Context, key []byte) (string, []byte, error) {
+	ring, err := k.keyring()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return ring.Wrap(ctx, key)
+}
+
+func (k *LocalKMS) Unwrap(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	ring, err := k.keyring()
+	if err != nil {
+		return nil, err
+	}
+
+	return ring.Unwrap(ctx, keyID, wrapped)
+}
+
+func (k *LocalKMS) keyring() (*Keyring, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if err := k.refresh(); err != nil {
+		return nil, err
+	}
+
+	return k.ring, nil
+}
+
+// refresh loads the file again if it changed since it was last read.
+func (k *LocalKMS) refresh() error {
+	info, err := os.Stat(k.path)
+	if err != nil {
+		return err
+	}
+
+	if info.ModTime().Equal(k.modTime) {
+		return nil
+	}
+
+	return k.load()
+}
+
+func (k *LocalKMS) load() error {
+	info, err := os.Stat(k.path)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(k.path)
+	if err != nil {
+		return err
+	}
+
+	var file localKeyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("reading %s: %w", k.path, err)
+	}
+
+	if len(file.Keys) == 0 {
+		return fmt.Errorf("reading %s: no master keys", k.path)
+	}
+
+	ring := &Keyring{keys: make(map[string][]byte)}
+	for _, key := range file.Keys {
+		if err := ring.add(key.ID, key.Key); err != nil {
+			return fmt.Errorf("reading %s: %w", k.path, err)
+		}
+	}
+
+	k.ring = ring
+	k.modTime = info.ModTime()
+
+	return nil
+}
+
+func (k *LocalKMS) file() localKeyFile {
+	file := localKeyFile{Keys: []localKey{{ID: k.ring.active, Key: k.ring.keys[k.ring.active]}}}
+	for id, key := range k.ring.keys {
+		if id != k.ring.active {
+			file.Keys = append(file.Keys, localKey{ID: id, Key: key})
+		}
+	}
+
+	return file
+}
+
+// seal encrypts plaintext with AES-256-GCM, prepending the random nonce.
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize(), gcm.NonceSize()+len(plaintext)+gcm.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func open(key, ciphertext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+
+	return gcm.Open(nil, nonce, ciphertext, aad)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
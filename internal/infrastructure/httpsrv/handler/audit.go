@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/cursor"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type auditEventRepository interface {
+	List(ctx context.Context, filter query.Expr, page query.Page) ([]domain.AuditEvent, query.PageInfo, error)
+	Verify(ctx context.Context) (domain.AuditChainStatus, error)
+}
+
+type AuditHTTPHandler struct {
+	events   auditEventRepository
+	cursors  *cursor.Codec
+	maxLimit int
+}
+
+// auditListFields are the fields GetMany can filter by, events are always
+// listed newest first.
+var auditListFields = query.Fields{
+	"id":          {query.Eq, query.Gt, query.Ge, query.Lt, query.Le},
+	"occurred_at": {query.Gt, query.Ge, query.Lt, query.Le},
+	"actor":       {query.Eq, query.Ne},
+	"action":      {query.Eq, query.Ne},
+	"target_type": {query.Eq},
+	"target_id":   {query.Eq, query.Ne},
+	"request_id":  {query.Eq},
+}
+
+// auditSort is recorded in cursors so they cannot be replayed elsewhere.
+const auditSort = "-id"
+
+// NewAuditHTTPHandler falls back to a random cursor secret when cursors is
+// nil, see WithPagination.
+func NewAuditHTTPHandler(events auditEventRepository, cursors *cursor.Codec, maxLimit int) *AuditHTTPHandler {
+	if cursors == nil {
+		secret := make([]byte, 32)
+		_, _ = rand.Read(secret)
+		cursors = cursor.NewCodec(secret)
+	}
+
+	if maxLimit < 1 {
+		maxLimit = defaultMaxLimit
+	}
+
+	return &AuditHTTPHandler{
+		events:   events,
+		cursors:  cursors,
+		maxLimit: maxLimit,
+	}
+}
+
+// GetMany lists audit events narrowed by the `filter` query param, newest
+// first, a page at a time.
+func (h *AuditHTTPHandler) GetMany(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "AuditGetMany")
+
+	limit := ec.QueryParam("limit")
+	if limit == "" {
+		limit = defaultLimit
+	}
+
+	li, err := strconv.Atoi(limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLimit).SetInternal(err)
+	}
+
+	if li < 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLimit)
+	}
+
+	filter, err := query.ParseFilter(ec.QueryParam("filter"), auditListFields)
+	if err != nil {
+		return queryError(err)
+	}
+
+	page := query.Page{Limit: min(li, h.maxLimit)}
+	if token := ec.QueryParam("cursor"); token != "" {
+		cur, err := h.cursors.Decode(token)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidCursor).SetInternal(err)
+		}
+
+		if cur.Sort != auditSort || cur.Direction != cursor.Next {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidCursor)
+		}
+
+		page.After = cur.Keys
+	}
+
+	events, info, err := h.events.List(ctx, filter, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, query.ErrInvalidFilter):
+			return queryError(err)
+		case errors.Is(err, query.ErrInvalidPage):
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidCursor).SetInternal(err)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	res := response.AuditEventPageResponse{
+		Data: make([]response.AuditEventResponse, 0, len(events)),
+	}
+
+	for _, event := range events {
+		res.Data = append(res.Data, auditEventResponse(event))
+	}
+
+	if info.HasMore {
+		token, err := h.cursors.Encode(cursor.Cursor{Keys: info.Last, Sort: auditSort, Direction: cursor.Next})
+		if err != nil {
+			l.ErrorContext(ctx, err.Error())
+			return echo.ErrInternalServerError
+		}
+
+		q := ec.Request().URL.Query()
+		q.Set("cursor", token)
+		res.Links.Next = ec.Request().URL.Path + "?" + q.Encode()
+
+		ec.Response().Header().Set(HeaderLink, fmt.Sprintf(`<%s>; rel="next"`, res.Links.Next))
+	}
+
+	return ec.JSON(http.StatusOK, res)
+}
+
+// Verify recomputes the hash chain of the whole audit log.
+func (h *AuditHTTPHandler) Verify(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "AuditVerify")
+
+	status, err := h.events.Verify(ctx)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	res := response.AuditChainResponse{
+		Valid:   status.Valid,
+		Checked: status.Checked,
+	}
+
+	if !status.Valid {
+		res.BrokenAt = &status.BrokenAt
+	}
+
+	return ec.JSON(http.StatusOK, res)
+}
+
+func auditEventResponse(event domain.AuditEvent) response.AuditEventResponse {
+	res := response.AuditEventResponse{
+		ID:         event.ID,
+		OccurredAt: event.OccurredAt,
+		Actor:      event.Actor,
+		Action:     string(event.Action),
+		TargetType: event.TargetType,
+		TargetID:   event.TargetID,
+		RequestID:  event.RequestID,
+		ClientIP:   event.ClientIP,
+		UserAgent:  event.UserAgent,
+		PrevHash:   event.PrevHash,
+		Hash:       event.Hash,
+	}
+
+	if len(event.Changes) > 0 {
+		res.Changes = make(map[string]response.AuditChange, len(event.Changes))
+		for field, change := range event.Changes {
+			res.Changes[field] = response.AuditChange{Before: change.Before, After: change.After}
+		}
+	}
+
+	if event.Certificate != nil {
+		cert := erasureCertificateResponse(*event.Certificate)
+		res.Certificate = &cert
+	}
+
+	return res
+}
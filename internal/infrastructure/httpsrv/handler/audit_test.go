@@ -0,0 +1,167 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/cursor"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type auditRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *auditRepositoryMock) List(ctx context.Context, filter query.Expr, page query.Page) ([]domain.AuditEvent, query.PageInfo, error) {
+	args := r.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(query.PageInfo), args.Error(2)
+	}
+	return args.Get(0).([]domain.AuditEvent), args.Get(1).(query.PageInfo), args.Error(2)
+}
+
+func (r *auditRepositoryMock) Verify(ctx context.Context) (domain.AuditChainStatus, error) {
+	args := r.Called(ctx)
+	return args.Get(0).(domain.AuditChainStatus), args.Error(1)
+}
+
+func TestAuditHTTPHandler_GetMany(t *testing.T) {
+	rm := new(auditRepositoryMock)
+	cursors := cursor.NewCodec([]byte("secret"))
+	h := handler.NewAuditHTTPHandler(rm, cursors, 2)
+	e := echo.New()
+
+	t.Run("GetMany", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/audit-events?"+url.Values{"filter": {`target_id eq "1"`}, "limit": {"5"}}.Encode(), nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+
+		filter := query.Condition{Field: "target_id", Operator: query.Eq, Value: "1"}
+		events := []domain.AuditEvent{
+			{ID: 3, Action: domain.AuditActionUpdate, TargetType: "user", TargetID: "1", Changes: map[string]domain.Change{"name": {Before: "A", After: "B"}}},
+			{ID: 2, Action: domain.AuditActionCreate, TargetType: "user", TargetID: "1"},
+		}
+		rm.On("List", ec.Request().Context(), filter, query.Page{Limit: 2}).
+			Return(events, query.PageInfo{Last: []string{"2"}, HasMore: true}, nil).Once()
+
+		err := h.GetMany(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var body response.AuditEventPageResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Len(t, body.Data, 2)
+		assert.Equal(t, response.AuditChange{Before: "A", After: "B"}, body.Data[0].Changes["name"])
+		assert.NotEmpty(t, body.Links.Next)
+		assert.NotEmpty(t, res.Header().Get(handler.HeaderLink))
+
+		next, _ := url.Parse(body.Links.Next)
+		cur, err := cursors.Decode(next.Query().Get("cursor"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2"}, cur.Keys)
+	})
+
+	t.Run("Next page", func(t *testing.T) {
+		token, _ := cursors.Encode(cursor.Cursor{Keys: []string{"2"}, Sort: "-id", Direction: cursor.Next})
+		req, _ := http.NewRequest(http.MethodGet, "/audit-events?cursor="+token, nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+
+		rm.On("List", ec.Request().Context(), query.Expr(nil), query.Page{Limit: 2, After: []string{"2"}}).
+			Return([]domain.AuditEvent{{ID: 1}}, query.PageInfo{}, nil).Once()
+
+		err := h.GetMany(ec)
+
+		assert.NoError(t, err)
+		assert.NotContains(t, res.Body.String(), `"next"`)
+	})
+
+	tests := []struct {
+		name  string
+		query url.Values
+		code  problem.Code
+	}{
+		{name: "Invalid limit", query: url.Values{"limit": {"0"}}, code: problem.CodeInvalidLimit},
+		{name: "Invalid cursor", query: url.Values{"cursor": {"x"}}, code: problem.CodeInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/audit-events?"+tt.query.Encode(), nil)
+			ec := e.NewContext(req, httptest.NewRecorder())
+
+			err := h.GetMany(ec)
+
+			var he *echo.HTTPError
+			assert.ErrorAs(t, err, &he)
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+			assert.Equal(t, tt.code, he.Message)
+		})
+	}
+
+	t.Run("Unknown field", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/audit-events?"+url.Values{"filter": {`hash eq "x"`}}.Encode(), nil)
+		ec := e.NewContext(req, httptest.NewRecorder())
+
+		err := h.GetMany(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, problem.Message{Code: problem.CodeUnknownField, Params: []string{"hash"}}, he.Message)
+	})
+
+	t.Run("Invalid filter value", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/audit-events?"+url.Values{"filter": {`occurred_at gt "yesterday"`}}.Encode(), nil)
+		ec := e.NewContext(req, httptest.NewRecorder())
+
+		rm.On("List", ec.Request().Context(), mock.Anything, mock.Anything).
+			Return(nil, query.PageInfo{}, query.ErrInvalidFilter).Once()
+
+		err := h.GetMany(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+}
+
+func TestAuditHTTPHandler_Verify(t *testing.T) {
+	rm := new(auditRepositoryMock)
+	h := handler.NewAuditHTTPHandler(rm, nil, 0)
+	e := echo.New()
+
+	t.Run("Valid", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/audit-events/verify", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+
+		rm.On("Verify", ec.Request().Context()).Return(domain.AuditChainStatus{Valid: true, Checked: 3}, nil).Once()
+
+		assert.NoError(t, h.Verify(ec))
+		assert.JSONEq(t, `{"valid":true,"checked":3}`, res.Body.String())
+	})
+
+	t.Run("Broken", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/audit-events/verify", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+
+		rm.On("Verify", ec.Request().Context()).Return(domain.AuditChainStatus{Checked: 1, BrokenAt: 2}, nil).Once()
+
+		assert.NoError(t, h.Verify(ec))
+		assert.JSONEq(t, `{"valid":false,"checked":1,"broken_at":2}`, res.Body.String())
+	})
+}
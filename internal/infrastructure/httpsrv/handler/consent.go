@@ -0,0 +1,318 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/xid"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/request"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type consentUsers interface {
+	GetByID(ctx context.Context, id string) (*domain.User, error)
+}
+
+type policyDocuments interface {
+	Publish(ctx context.Context, doc domain.PolicyDocument) (domain.PolicyDocument, error)
+	List(ctx context.Context) ([]domain.PolicyDocument, error)
+}
+
+type consentRepository interface {
+	Add(ctx context.Context, consents ...domain.Consent) error
+	ListByUser(ctx context.Context, userID string) ([]domain.Consent, error)
+}
+
+type ConsentHTTPHandler struct {
+	users    consentUsers
+	policies policyDocuments
+	consents consentRepository
+}
+
+func NewConsentHTTPHandler(users consentUsers, policies policyDocuments, consents consentRepository) *ConsentHTTPHandler {
+	return &ConsentHTTPHandler{
+		users:    users,
+		policies: policies,
+		consents: consents,
+	}
+}
+
+// WithConsents records the policies accepted when users are created.
+func WithConsents(policies policyDocuments, consents consentRepository) Option {
+	return func(h *UserHTTPHandler) {
+		h.policies = policies
+		h.consents = consents
+	}
+}
+
+// Publish publishes a new version of a policy. Users have to accept it
+// before anything else once published if it is mandatory, see Gate.
+func (h *ConsentHTTPHandler) Publish(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "PolicyPublish")
+
+	var req *request.PolicyPublishRequest
+	if err := ec.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if err := ec.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	doc, err := h.policies.Publish(ctx, domain.PolicyDocument{
+		ID:        xid.New().String(),
+		Kind:      domain.PolicyKind(req.Kind),
+		Version:   req.Version,
+		URL:       req.URL,
+		Mandatory: req.Mandatory,
+	})
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return echo.NewHTTPError(http.StatusConflict, problem.CodePolicyVersionExists).SetInternal(err)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.JSON(http.StatusCreated, policyDocumentResponse(doc))
+}
+
+// GetPolicies returns the current version of every policy.
+func (h *ConsentHTTPHandler) GetPolicies(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "PolicyGetMany")
+
+	docs, err := h.policies.List(ctx)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.JSON(http.StatusOK, &response.PolicyDocumentListResponse{Data: policyDocumentResponses(domain.CurrentPolicies(docs))})
+}
+
+// Accept records that the user accepted the given policies, which have to be
+// current.
+func (h *ConsentHTTPHandler) Accept(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "ConsentAccept")
+	id := ec.Param("id")
+
+	var req *request.ConsentRequest
+	if err := ec.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if err := ec.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	user, err := h.users.GetByID(ctx, id)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if user == nil {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound)
+	}
+
+	consents, err := newConsents(ec, h.policies, id, req.Policies)
+	if err != nil {
+		return err
+	}
+
+	if err := h.consents.Add(ctx, consents...); err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	res := &response.ConsentListResponse{Data: make([]response.ConsentResponse, 0, len(consents))}
+	for _, c := range consents {
+		res.Data = append(res.Data, consentResponse(c))
+	}
+
+	return ec.JSON(http.StatusCreated, res)
+}
+
+// GetMany returns the consents of the user, oldest first, along with the
+// policies they still have to accept.
+func (h *ConsentHTTPHandler) GetMany(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "ConsentGetMany")
+	id := ec.Param("id")
+
+	user, err := h.users.GetByID(ctx, id)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if user == nil {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound)
+	}
+
+	consents, pending, err := h.pending(ctx, id)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	res := &response.ConsentListResponse{
+		Data:    make([]response.ConsentResponse, 0, len(consents)),
+		Pending: policyDocumentResponses(pending),
+	}
+	for _, c := range consents {
+		res.Data = append(res.Data, consentResponse(c))
+	}
+
+	return ec.JSON(http.StatusOK, res)
+}
+
+// Gate rejects with `403 consent_required` the requests of users, as told
+// by HeaderActorID, who have not accepted the current version of every
+// mandatory policy yet. Actors that are not users, such as services, pass.
+func (h *ConsentHTTPHandler) Gate(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(ec echo.Context) error {
+		ctx := ec.Request().Context()
+		l := slog.Default().With("handler", "ConsentGate")
+
+		actor := ec.Request().Header.Get(HeaderActorID)
+		if actor == "" {
+			return next(ec)
+		}
+
+		_, pending, err := h.pending(ctx, actor)
+		if err != nil {
+			l.ErrorContext(ctx, err.Error())
+			return echo.ErrInternalServerError
+		}
+
+		if len(pending) == 0 {
+			return next(ec)
+		}
+
+		user, err := h.users.GetByID(ctx, actor)
+		if err != nil {
+			l.ErrorContext(ctx, err.Error())
+			return echo.ErrInternalServerError
+		}
+
+		if user == nil {
+			return next(ec)
+		}
+
+		names := make([]string, 0, len(pending))
+		for _, d := range pending {
+			names = append(names, string(d.Kind)+" "+d.Version)
+		}
+
+		return echo.NewHTTPError(http.StatusForbidden, problem.Message{
+			Code:   problem.CodeConsentRequired,
+			Params: []string{strings.Join(names, ", ")},
+		})
+	}
+}
+
+func (h *ConsentHTTPHandler) pending(ctx context.Context, userID string) ([]domain.Consent, []domain.PolicyDocument, error) {
+	docs, err := h.policies.List(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	consents, err := h.consents.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return consents, domain.PendingPolicies(docs, consents), nil
+}
+
+// newConsents returns the consents of the user to the given policies, made
+// by the request. It fails with an HTTP error unless they are all current.
+func newConsents(ec echo.Context, policies policyDocuments, userID string, ids []string) ([]domain.Consent, error) {
+	ctx := ec.Request().Context()
+
+	docs, err := policies.List(ctx)
+	if err != nil {
+		slog.Default().With("handler", "Consent").ErrorContext(ctx, err.Error())
+		return nil, echo.ErrInternalServerError
+	}
+
+	byID := make(map[string]domain.PolicyDocument, len(docs))
+	for _, d := range docs {
+		byID[d.ID] = d
+	}
+
+	current := make(map[string]bool)
+	for _, d := range domain.CurrentPolicies(docs) {
+		current[d.ID] = true
+	}
+
+	now := time.Now()
+	consents := make([]domain.Consent, 0, len(ids))
+	for _, id := range ids {
+		doc, ok := byID[id]
+		if !ok {
+			return nil, echo.NewHTTPError(http.StatusNotFound, problem.Message{Code: problem.CodePolicyNotFound, Params: []string{id}})
+		}
+
+		if !current[id] {
+			return nil, echo.NewHTTPError(http.StatusConflict, problem.Message{Code: problem.CodePolicyOutdated, Params: []string{id}})
+		}
+
+		consents = append(consents, domain.Consent{
+			ID:         xid.New().String(),
+			UserID:     userID,
+			DocumentID: doc.ID,
+			Kind:       doc.Kind,
+			Version:    doc.Version,
+			AcceptedAt: now,
+			ClientIP:   ec.RealIP(),
+			UserAgent:  ec.Request().UserAgent(),
+		})
+	}
+
+	return consents, nil
+}
+
+func policyDocumentResponse(d domain.PolicyDocument) response.PolicyDocumentResponse {
+	return response.PolicyDocumentResponse{
+		ID:          d.ID,
+		Kind:        string(d.Kind),
+		Version:     d.Version,
+		URL:         d.URL,
+		Mandatory:   d.Mandatory,
+		PublishedAt: d.PublishedAt,
+	}
+}
+
+func policyDocumentResponses(docs []domain.PolicyDocument) []response.PolicyDocumentResponse {
+	res := make([]response.PolicyDocumentResponse, 0, len(docs))
+	for _, d := range docs {
+		res = append(res, policyDocumentResponse(d))
+	}
+
+	return res
+}
+
+func consentResponse(c domain.Consent) response.ConsentResponse {
+	return response.ConsentResponse{
+		ID:         c.ID,
+		DocumentID: c.DocumentID,
+		Kind:       string(c.Kind),
+		Version:    c.Version,
+		AcceptedAt: c.AcceptedAt,
+		ClientIP:   c.ClientIP,
+		UserAgent:  c.UserAgent,
+	}
+}
@@ -0,0 +1,414 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type policyDocumentsMock struct {
+	mock.Mock
+}
+
+func (p *policyDocumentsMock) Publish(ctx context.Context, doc domain.PolicyDocument) (domain.PolicyDocument, error) {
+	args := p.Called(ctx, doc)
+	return args.Get(0).(domain.PolicyDocument), args.Error(1)
+}
+
+func (p *policyDocumentsMock) List(ctx context.Context) ([]domain.PolicyDocument, error) {
+	args := p.Called(ctx)
+	return args.Get(0).([]domain.PolicyDocument), args.Error(1)
+}
+
+type consentRepositoryMock struct {
+	mock.Mock
+}
+
+func (c *consentRepositoryMock) Add(ctx context.Context, consents ...domain.Consent) error {
+	args := c.Called(ctx, consents)
+	return args.Error(0)
+}
+
+func (c *consentRepositoryMock) ListByUser(ctx context.Context, userID string) ([]domain.Consent, error) {
+	args := c.Called(ctx, userID)
+	return args.Get(0).([]domain.Consent), args.Error(1)
+}
+
+// policies are the terms of service 1 and 2, both mandatory, and an optional
+// privacy policy, in publication order.
+var policies = []domain.PolicyDocument{
+	{ID: "tos1", Kind: domain.PolicyTermsOfService, Version: "1", Mandatory: true},
+	{ID: "privacy1", Kind: domain.PolicyPrivacy, Version: "1"},
+	{ID: "tos2", Kind: domain.PolicyTermsOfService, Version: "2", Mandatory: true},
+}
+
+func TestConsentHTTPHandler_Publish(t *testing.T) {
+	pm := new(policyDocumentsMock)
+	h := handler.NewConsentHTTPHandler(nil, pm, nil)
+	e := echo.New()
+	e.Validator = &requestValidator{
+		Validator: validator.New(),
+	}
+
+	publish := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		req, _ := http.NewRequest(http.MethodPost, "/policies", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+
+		return e.NewContext(req, res), res
+	}
+
+	t.Run("Publish", func(t *testing.T) {
+		ec, res := publish(`{"kind":"terms_of_service","version":"3","url":"https://example.com/tos/3","mandatory":true}`)
+
+		pm.On("Publish", ec.Request().Context(), mock.MatchedBy(func(doc domain.PolicyDocument) bool {
+			return doc.ID != "" && doc.Kind == domain.PolicyTermsOfService && doc.Version == "3" && doc.Mandatory
+		})).Return(domain.PolicyDocument{ID: "tos3", Kind: domain.PolicyTermsOfService, Version: "3", Mandatory: true}, nil).Once()
+
+		err := h.Publish(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, res.Code)
+
+		var body response.PolicyDocumentResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, "tos3", body.ID)
+	})
+
+	t.Run("Version exists", func(t *testing.T) {
+		ec, _ := publish(`{"kind":"terms_of_service","version":"2","url":"https://example.com/tos/2"}`)
+
+		pm.On("Publish", ec.Request().Context(), mock.Anything).Return(domain.PolicyDocument{}, &ent.ConstraintError{}).Once()
+
+		err := h.Publish(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, problem.CodePolicyVersionExists, he.Message)
+	})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "Unknown kind", body: `{"kind":"cookies","version":"1","url":"https://example.com"}`},
+		{name: "Missing version", body: `{"kind":"terms_of_service","url":"https://example.com"}`},
+		{name: "Not an HTTP URL", body: `{"kind":"terms_of_service","version":"1","url":"ftp://example.com"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ec, _ := publish(tt.body)
+
+			err := h.Publish(ec)
+
+			var he *echo.HTTPError
+			assert.ErrorAs(t, err, &he)
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+			assert.Equal(t, problem.CodeValidationFailed, he.Message)
+		})
+	}
+}
+
+func TestConsentHTTPHandler_GetPolicies(t *testing.T) {
+	pm := new(policyDocumentsMock)
+	h := handler.NewConsentHTTPHandler(nil, pm, nil)
+	e := echo.New()
+
+	req, _ := http.NewRequest(http.MethodGet, "/policies", nil)
+	res := httptest.NewRecorder()
+	ec := e.NewContext(req, res)
+
+	pm.On("List", ec.Request().Context()).Return(policies, nil).Once()
+
+	err := h.GetPolicies(ec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	var body response.PolicyDocumentListResponse
+	assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+	assert.Len(t, body.Data, 2)
+	assert.Equal(t, "privacy1", body.Data[0].ID)
+	assert.Equal(t, "tos2", body.Data[1].ID)
+}
+
+func TestConsentHTTPHandler_Accept(t *testing.T) {
+	rm := new(repositoryMock)
+	pm := new(policyDocumentsMock)
+	cm := new(consentRepositoryMock)
+	h := handler.NewConsentHTTPHandler(rm, pm, cm)
+	e := echo.New()
+	e.Validator = &requestValidator{
+		Validator: validator.New(),
+	}
+
+	accept := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		req, _ := http.NewRequest(http.MethodPost, "/users/1/consents", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderXRealIP, "10.0.0.1")
+		req.Header.Set("User-Agent", "curl")
+		res := httptest.NewRecorder()
+
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		return ec, res
+	}
+
+	t.Run("Accept", func(t *testing.T) {
+		ec, res := accept(`{"policies":["tos2"]}`)
+		ctx := ec.Request().Context()
+
+		rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1"}, nil).Once()
+		pm.On("List", ctx).Return(policies, nil).Once()
+		cm.On("Add", ctx, mock.MatchedBy(func(consents []domain.Consent) bool {
+			return len(consents) == 1 && consents[0].UserID == "1" && consents[0].DocumentID == "tos2" &&
+				consents[0].Version == "2" && consents[0].ClientIP == "10.0.0.1" && consents[0].UserAgent == "curl"
+		})).Return(nil).Once()
+
+		err := h.Accept(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, res.Code)
+
+		var body response.ConsentListResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Len(t, body.Data, 1)
+		assert.Equal(t, "terms_of_service", body.Data[0].Kind)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		ec, _ := accept(`{"policies":["tos2"]}`)
+
+		rm.On("GetByID", ec.Request().Context(), "1").Return(nil, nil).Once()
+
+		err := h.Accept(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, problem.CodeUserNotFound, he.Message)
+	})
+
+	tests := []struct {
+		name   string
+		policy string
+		status int
+		code   problem.Code
+	}{
+		{name: "Policy not found", policy: "cookies1", status: http.StatusNotFound, code: problem.CodePolicyNotFound},
+		{name: "Policy outdated", policy: "tos1", status: http.StatusConflict, code: problem.CodePolicyOutdated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ec, _ := accept(`{"policies":["` + tt.policy + `"]}`)
+			ctx := ec.Request().Context()
+
+			rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1"}, nil).Once()
+			pm.On("List", ctx).Return(policies, nil).Once()
+
+			err := h.Accept(ec)
+
+			var he *echo.HTTPError
+			assert.ErrorAs(t, err, &he)
+			assert.Equal(t, tt.status, he.Code)
+			assert.Equal(t, problem.Message{Code: tt.code, Params: []string{tt.policy}}, he.Message)
+		})
+	}
+
+	t.Run("No policies", func(t *testing.T) {
+		ec, _ := accept(`{"policies":[]}`)
+
+		err := h.Accept(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, problem.CodeValidationFailed, he.Message)
+	})
+
+	cm.AssertExpectations(t)
+}
+
+func TestConsentHTTPHandler_GetMany(t *testing.T) {
+	rm := new(repositoryMock)
+	pm := new(policyDocumentsMock)
+	cm := new(consentRepositoryMock)
+	h := handler.NewConsentHTTPHandler(rm, pm, cm)
+	e := echo.New()
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/1/consents", nil)
+	res := httptest.NewRecorder()
+	ec := e.NewContext(req, res)
+	ec.SetParamNames("id")
+	ec.SetParamValues("1")
+	ctx := ec.Request().Context()
+
+	rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1"}, nil).Once()
+	pm.On("List", ctx).Return(policies, nil).Once()
+	cm.On("ListByUser", ctx, "1").Return([]domain.Consent{
+		{ID: "c1", UserID: "1", DocumentID: "tos1", Kind: domain.PolicyTermsOfService, Version: "1", AcceptedAt: time.Now()},
+	}, nil).Once()
+
+	err := h.GetMany(ec)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	var body response.ConsentListResponse
+	assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+	assert.Len(t, body.Data, 1)
+	assert.Len(t, body.Pending, 1)
+	assert.Equal(t, "tos2", body.Pending[0].ID)
+}
+
+func TestConsentHTTPHandler_Gate(t *testing.T) {
+	rm := new(repositoryMock)
+	pm := new(policyDocumentsMock)
+	cm := new(consentRepositoryMock)
+	h := handler.NewConsentHTTPHandler(rm, pm, cm)
+	e := echo.New()
+
+	next := h.Gate(func(ec echo.Context) error {
+		return ec.NoContent(http.StatusNoContent)
+	})
+
+	gate := func(actor string) (echo.Context, *httptest.ResponseRecorder) {
+		req, _ := http.NewRequest(http.MethodGet, "/users/1", nil)
+		if actor != "" {
+			req.Header.Set(handler.HeaderActorID, actor)
+		}
+		res := httptest.NewRecorder()
+
+		return e.NewContext(req, res), res
+	}
+
+	t.Run("No actor", func(t *testing.T) {
+		ec, res := gate("")
+
+		err := next(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("Accepted", func(t *testing.T) {
+		ec, res := gate("1")
+		ctx := ec.Request().Context()
+
+		pm.On("List", ctx).Return(policies, nil).Once()
+		cm.On("ListByUser", ctx, "1").Return([]domain.Consent{{DocumentID: "tos2"}}, nil).Once()
+
+		err := next(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("New mandatory version", func(t *testing.T) {
+		ec, _ := gate("1")
+		ctx := ec.Request().Context()
+
+		pm.On("List", ctx).Return(policies, nil).Once()
+		cm.On("ListByUser", ctx, "1").Return([]domain.Consent{{DocumentID: "tos1"}}, nil).Once()
+		rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1"}, nil).Once()
+
+		err := next(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusForbidden, he.Code)
+		assert.Equal(t, problem.Message{Code: problem.CodeConsentRequired, Params: []string{"terms_of_service 2"}}, he.Message)
+	})
+
+	t.Run("Not a user", func(t *testing.T) {
+		ec, res := gate("billing-service")
+		ctx := ec.Request().Context()
+
+		pm.On("List", ctx).Return(policies, nil).Once()
+		cm.On("ListByUser", ctx, "billing-service").Return([]domain.Consent{}, nil).Once()
+		rm.On("GetByID", ctx, "billing-service").Return(nil, nil).Once()
+
+		err := next(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+}
+
+func TestUserHTTPHandler_CreateWithConsents(t *testing.T) {
+	rm := new(repositoryMock)
+	pm := new(policyDocumentsMock)
+	cm := new(consentRepositoryMock)
+	tx := new(transactorMock)
+	al := new(auditLogMock)
+	h := handler.NewUserHTTPHandler(rm, handler.WithAudit(tx, al), handler.WithConsents(pm, cm))
+	e := echo.New()
+	e.Validator = &requestValidator{
+		Validator: validator.New(),
+	}
+
+	create := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		req, _ := http.NewRequest(http.MethodPost, "/users", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+
+		return e.NewContext(req, res), res
+	}
+
+	t.Run("Accepted with the user", func(t *testing.T) {
+		ec, res := create(`{"name":"Test","surname":"Test","email":"test@test.pl","password":"password","accepted_policies":["tos2","privacy1"]}`)
+		ctx := ec.Request().Context()
+
+		var id string
+		rm.On("GetByEmail", ctx, "test@test.pl").Return(nil, nil).Once()
+		pm.On("List", ctx).Return(policies, nil).Once()
+		rm.On("Create", inTx, mock.Anything).Run(func(args mock.Arguments) {
+			id = args.Get(1).(domain.User).ID
+		}).Return(nil).Once()
+		cm.On("Add", inTx, mock.MatchedBy(func(consents []domain.Consent) bool {
+			return len(consents) == 2 && consents[0].UserID == id && consents[0].DocumentID == "tos2" && consents[1].DocumentID == "privacy1"
+		})).Return(nil).Once()
+		al.On("Append", inTx, mock.Anything).Return(nil).Once()
+
+		err := h.Create(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, res.Code)
+		assert.True(t, tx.committed)
+		cm.AssertExpectations(t)
+	})
+
+	t.Run("Outdated policy", func(t *testing.T) {
+		ec, _ := create(`{"name":"Test","surname":"Test","email":"test@test.pl","password":"password","accepted_policies":["tos1"]}`)
+		ctx := ec.Request().Context()
+
+		rm.On("GetByEmail", ctx, "test@test.pl").Return(nil, nil).Once()
+		pm.On("List", ctx).Return(policies, nil).Once()
+
+		err := h.Create(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusConflict, he.Code)
+	})
+}
@@ -0,0 +1,86 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type Direction string
+
+const (
+	Next Direction = "next"
+	Prev Direction = "prev"
+)
+
+// Cursor points at the row a page starts after (Next) or ends before (Prev)
+// by the values of its sort keys. Sort is the sort the keys belong to.
+type Cursor struct {
+	Keys      []string  `json:"k"`
+	Sort      string    `json:"s,omitempty"`
+	Direction Direction `json:"d"`
+}
+
+// Codec turns cursors into opaque tokens signed with HMAC-SHA256 so clients
+// cannot forge or tamper with them.
+type Codec struct {
+	secret []byte
+}
+
+func NewCodec(secret []byte) *Codec {
+	return &Codec{
+		secret: secret,
+	}
+}
+
+func (c *Codec) Encode(cur Cursor) (string, error) {
+	payload, err := json.Marshal(cur)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(c.sign(payload)), nil
+}
+
+func (c *Codec) Decode(token string) (Cursor, error) {
+	var cur Cursor
+
+	p, s, ok := strings.Cut(token, ".")
+	if !ok {
+		return cur, ErrInvalidCursor
+	}
+
+	enc := base64.RawURLEncoding
+	payload, err := enc.DecodeString(p)
+	if err != nil {
+		return cur, ErrInvalidCursor
+	}
+
+	sig, err := enc.DecodeString(s)
+	if err != nil || !hmac.Equal(sig, c.sign(payload)) {
+		return cur, ErrInvalidCursor
+	}
+
+	if err = json.Unmarshal(payload, &cur); err != nil {
+		return cur, ErrInvalidCursor
+	}
+
+	if len(cur.Keys) == 0 || (cur.Direction != Next && cur.Direction != Prev) {
+		return cur, ErrInvalidCursor
+	}
+
+	return cur, nil
+}
+
+func (c *Codec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
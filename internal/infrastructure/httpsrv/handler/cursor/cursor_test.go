@@ -0,0 +1,39 @@
+package cursor_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/cursor"
+)
+
+func TestCodec(t *testing.T) {
+	codec := cursor.NewCodec([]byte("secret"))
+
+	t.Run("Round trip", func(t *testing.T) {
+		want := cursor.Cursor{Keys: []string{"Doe", "cud9a6h7lsoc73cami4g"}, Sort: "surname", Direction: cursor.Prev}
+
+		token, err := codec.Encode(want)
+		assert.NoError(t, err)
+
+		got, err := codec.Decode(token)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		token, err := cursor.NewCodec([]byte("other")).Encode(cursor.Cursor{Keys: []string{"1"}, Direction: cursor.Next})
+		assert.NoError(t, err)
+
+		_, err = codec.Decode(token)
+		assert.ErrorIs(t, err, cursor.ErrInvalidCursor)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		for _, token := range []string{"", "abc", "abc.def", "!!.!!"} {
+			_, err := codec.Decode(token)
+			assert.ErrorIs(t, err, cursor.ErrInvalidCursor)
+		}
+	})
+}
@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/xid"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+const MIMEApplicationZip = "application/zip"
+
+// dataExportRetry is the polling delay advised while an export is pending,
+// in seconds.
+const dataExportRetry = 5
+
+type dataExportUsers interface {
+	GetByID(ctx context.Context, id string) (*domain.User, error)
+}
+
+type dataExportRepository interface {
+	Create(ctx context.Context, export domain.DataExport) (domain.DataExport, error)
+	GetLatest(ctx context.Context, userID string) (*domain.DataExport, error)
+	Save(ctx context.Context, export domain.DataExport, archive []byte) error
+	Archive(ctx context.Context, id string) (*domain.DataExport, []byte, error)
+}
+
+type dataArchiver interface {
+	Write(ctx context.Context, user domain.User, w io.Writer) error
+}
+
+type DataExportHTTPHandler struct {
+	users     dataExportUsers
+	exports   dataExportRepository
+	archiver  dataArchiver
+	secret    []byte
+	linkTTL   time.Duration
+	retention time.Duration
+	wait      time.Duration
+
+	mu sync.Mutex
+	// running are the exports generated by this instance, closed once done.
+	running map[string]chan struct{}
+	// jobs tracks the exports in progress, see Wait.
+	jobs sync.WaitGroup
+}
+
+// NewDataExportHTTPHandler signs download links valid for linkTTL with
+// secret, a random one if it is empty. Archives are kept for retention, a
+// request waits up to wait for its archive before being told to poll.
+func NewDataExportHTTPHandler(users dataExportUsers, exports dataExportRepository, archiver dataArchiver, secret []byte, linkTTL, retention, wait time.Duration) *DataExportHTTPHandler {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		_, _ = rand.Read(secret)
+	}
+
+	return &DataExportHTTPHandler{
+		users:     users,
+		exports:   exports,
+		archiver:  archiver,
+		secret:    secret,
+		linkTTL:   linkTTL,
+		retention: retention,
+		wait:      wait,
+		running:   make(map[string]chan struct{}),
+	}
+}
+
+// Get returns the data subject access export of a user, starting it if there
+// is none. Small archives are ready within the response, which has a
+// time-limited download link, others respond 202 until they are ready.
+func (h *DataExportHTTPHandler) Get(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "DataExport")
+
+	id := ec.Param("id")
+	user, err := h.users.GetByID(ctx, id)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if user == nil {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound)
+	}
+
+	export, err := h.exports.GetLatest(ctx, id)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	var done <-chan struct{}
+	if export == nil {
+		created, err := h.exports.Create(ctx, domain.DataExport{
+			ID:        xid.New().String(),
+			UserID:    id,
+			Status:    domain.DataExportPending,
+			ExpiresAt: time.Now().Add(h.retention),
+		})
+		if err != nil {
+			l.ErrorContext(ctx, err.Error())
+			return echo.ErrInternalServerError
+		}
+		export = &created
+
+		done = h.start(context.WithoutCancel(ctx), created, *user)
+	} else {
+		h.mu.Lock()
+		done = h.running[export.ID]
+		h.mu.Unlock()
+	}
+
+	if export.Status == domain.DataExportPending && h.await(ctx, done) {
+		if export, err = h.exports.GetLatest(ctx, id); err != nil {
+			l.ErrorContext(ctx, err.Error())
+			return echo.ErrInternalServerError
+		}
+
+		// The export failed, the next request starts a new one.
+		if export == nil {
+			return echo.ErrInternalServerError
+		}
+	}
+
+	res := dataExportResponse(*export)
+	if export.Status != domain.DataExportReady {
+		ec.Response().Header().Set(echo.HeaderLocation, ec.Request().URL.Path)
+		ec.Response().Header().Set("Retry-After", strconv.Itoa(dataExportRetry))
+		return ec.JSON(http.StatusAccepted, res)
+	}
+
+	expires := time.Now().Add(h.linkTTL)
+	if export.ExpiresAt.Before(expires) {
+		expires = export.ExpiresAt
+	}
+	expires = expires.Truncate(time.Second)
+
+	res.DownloadURL = h.downloadURL(export.ID, expires)
+	res.DownloadExpiresAt = &expires
+
+	return ec.JSON(http.StatusOK, res)
+}
+
+// Download serves the archive of a ready export to holders of a valid link.
+func (h *DataExportHTTPHandler) Download(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "DataExportDownload")
+
+	id := ec.Param("id")
+	expires, err := strconv.ParseInt(ec.QueryParam("expires"), 10, 64)
+	if err != nil || !hmac.Equal([]byte(ec.QueryParam("signature")), []byte(h.signature(id, expires))) {
+		return echo.NewHTTPError(http.StatusForbidden, problem.CodeInvalidDownloadLink)
+	}
+
+	if time.Now().Unix() > expires {
+		return echo.NewHTTPError(http.StatusGone, problem.CodeDownloadLinkExpired)
+	}
+
+	export, archive, err := h.exports.Archive(ctx, id)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if export == nil || export.ExpiresAt.Before(time.Now()) {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeDataExportNotFound)
+	}
+
+	res := ec.Response()
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="user-`+export.UserID+`-data.zip"`)
+	res.Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return ec.Blob(http.StatusOK, MIMEApplicationZip, archive)
+}
+
+// start generates the archive of export in the background, the returned
+// channel is closed once it is done.
+func (h *DataExportHTTPHandler) start(ctx context.Context, export domain.DataExport, user domain.User) <-chan struct{} {
+	done := make(chan struct{})
+
+	h.mu.Lock()
+	h.running[export.ID] = done
+	h.mu.Unlock()
+
+	h.jobs.Add(1)
+	go func() {
+		defer func() {
+			h.mu.Lock()
+			delete(h.running, export.ID)
+			h.mu.Unlock()
+			close(done)
+			h.jobs.Done()
+		}()
+
+		l := slog.Default().With("job", "DataExport", "id", export.ID)
+
+		var buf bytes.Buffer
+		err := h.archiver.Write(ctx, user, &buf)
+
+		now := time.Now()
+		export.FinishedAt = &now
+		export.ExpiresAt = now.Add(h.retention)
+
+		var archive []byte
+		if err != nil {
+			l.ErrorContext(ctx, err.Error())
+			export.Status = domain.DataExportFailed
+			export.Error = http.StatusText(http.StatusInternalServerError)
+		} else {
+			export.Status = domain.DataExportReady
+			export.Size = int64(buf.Len())
+			archive = buf.Bytes()
+		}
+
+		if err = h.exports.Save(ctx, export, archive); err != nil {
+			l.ErrorContext(ctx, err.Error())
+		}
+	}()
+
+	return done
+}
+
+// Wait blocks until the exports in progress are done or ctx is, returning
+// the error of ctx then. Exports outliving it are failed by
+// job.DataExportPurge.
+func (h *DataExportHTTPHandler) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		h.jobs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// await waits up to h.wait for done, reporting whether it is closed. Exports
+// generated by other instances have no done channel and are not waited for.
+func (h *DataExportHTTPHandler) await(ctx context.Context, done <-chan struct{}) bool {
+	if done == nil {
+		return false
+	}
+
+	timer := time.NewTimer(h.wait)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	return false
+}
+
+func (h *DataExportHTTPHandler) downloadURL(id string, expires time.Time) string {
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+	q.Set("signature", h.signature(id, expires.Unix()))
+
+	return "/data-exports/" + url.PathEscape(id) + "?" + q.Encode()
+}
+
+func (h *DataExportHTTPHandler) signature(id string, expires int64) string {
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write([]byte(id + "\n" + strconv.FormatInt(expires, 10)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func dataExportResponse(export domain.DataExport) response.DataExportResponse {
+	return response.DataExportResponse{
+		ID:         export.ID,
+		Status:     string(export.Status),
+		Size:       export.Size,
+		CreatedAt:  export.CreatedAt,
+		FinishedAt: export.FinishedAt,
+		ExpiresAt:  export.ExpiresAt,
+	}
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/Beriw98/user-management/internal/app/dataexport"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+)
+
+// The sources below export the data of every subsystem in the shape of its
+// API responses.
+
+// exportAuditPage is how many audit events are read at a time.
+const exportAuditPage = 500
+
+type auditEventLister interface {
+	List(ctx context.Context, filter query.Expr, page query.Page) ([]domain.AuditEvent, query.PageInfo, error)
+}
+
+type invitationLister interface {
+	ListByEmail(ctx context.Context, email string) ([]domain.Invitation, error)
+}
+
+type consentLister interface {
+	ListByUser(ctx context.Context, userID string) ([]domain.Consent, error)
+}
+
+type groupMembershipLister interface {
+	ListByUser(ctx context.Context, userID string) ([]domain.GroupMembership, error)
+}
+
+// ProfileSource exports the user as returned by GET /users/:id.
+var ProfileSource = dataexport.SourceFunc(func(_ context.Context, user domain.User) (any, error) {
+	return userResponse(user), nil
+})
+
+// AuditEventSource exports the audit events of which the user is the
+// target, newest first.
+func AuditEventSource(events auditEventLister) dataexport.Source {
+	return dataexport.SourceFunc(func(ctx context.Context, user domain.User) (any, error) {
+		filter := query.And{
+			query.Condition{Field: "target_type", Operator: query.Eq, Value: domain.AuditTargetUser},
+			query.Condition{Field: "target_id", Operator: query.Eq, Value: user.ID},
+		}
+
+		res := []response.AuditEventResponse{}
+		page := query.Page{Limit: exportAuditPage}
+		for {
+			list, info, err := events.List(ctx, filter, page)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, e := range list {
+				res = append(res, auditEventResponse(e))
+			}
+
+			if !info.HasMore {
+				return res, nil
+			}
+
+			page.After = info.Last
+		}
+	})
+}
+
+// InvitationSource exports the invitations sent to the email of the user.
+func InvitationSource(invitations invitationLister) dataexport.Source {
+	return dataexport.SourceFunc(func(ctx context.Context, user domain.User) (any, error) {
+		invs, err := invitations.ListByEmail(ctx, user.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		res := make([]response.InvitationResponse, 0, len(invs))
+		for _, inv := range invs {
+			res = append(res, invitationResponse(inv))
+		}
+
+		return res, nil
+	})
+}
+
+// ConsentSource exports the policies the user accepted, oldest first.
+func ConsentSource(consents consentLister) dataexport.Source {
+	return dataexport.SourceFunc(func(ctx context.Context, user domain.User) (any, error) {
+		list, err := consents.ListByUser(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		res := make([]response.ConsentResponse, 0, len(list))
+		for _, c := range list {
+			res = append(res, consentResponse(c))
+		}
+
+		return res, nil
+	})
+}
+
+// GroupSource exports the groups the user is a member of as returned by
+// GET /users/:id/groups.
+func GroupSource(groups groupMembershipLister) dataexport.Source {
+	return dataexport.SourceFunc(func(ctx context.Context, user domain.User) (any, error) {
+		memberships, err := groups.ListByUser(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		return groupMembershipResponses(memberships), nil
+	})
+}
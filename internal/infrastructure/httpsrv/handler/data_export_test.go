@@ -0,0 +1,317 @@
+package handler_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+// dataExportsMock reports on saved the exports once they are saved.
+type dataExportsMock struct {
+	mock.Mock
+	saved chan domain.DataExport
+}
+
+func (m *dataExportsMock) Create(ctx context.Context, export domain.DataExport) (domain.DataExport, error) {
+	args := m.Called(ctx, export)
+	return export, args.Error(0)
+}
+
+func (m *dataExportsMock) GetLatest(ctx context.Context, userID string) (*domain.DataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DataExport), args.Error(1)
+}
+
+func (m *dataExportsMock) Save(ctx context.Context, export domain.DataExport, archive []byte) error {
+	args := m.Called(ctx, export, archive)
+	m.saved <- export
+	return args.Error(0)
+}
+
+func (m *dataExportsMock) Archive(ctx context.Context, id string) (*domain.DataExport, []byte, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*domain.DataExport), args.Get(1).([]byte), args.Error(2)
+}
+
+// dataArchiverStub writes data once release is closed, if it is set.
+type dataArchiverStub struct {
+	data    string
+	err     error
+	release chan struct{}
+}
+
+func (s *dataArchiverStub) Write(_ context.Context, _ domain.User, w io.Writer) error {
+	if s.release != nil {
+		<-s.release
+	}
+	if s.err != nil {
+		return s.err
+	}
+	_, err := io.WriteString(w, s.data)
+	return err
+}
+
+func TestDataExportHTTPHandler_Get(t *testing.T) {
+	e := echo.New()
+	secret := []byte("secret")
+	user := &domain.User{ID: "1", Email: "test@test.pl"}
+
+	get := func(h *handler.DataExportHTTPHandler) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodGet, "/users/1/data-export", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		return res, h.Get(ec)
+	}
+
+	body := func(t *testing.T, res *httptest.ResponseRecorder) response.DataExportResponse {
+		var body response.DataExportResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		return body
+	}
+
+	t.Run("Ready", func(t *testing.T) {
+		rm := new(repositoryMock)
+		em := &dataExportsMock{saved: make(chan domain.DataExport, 1)}
+		h := handler.NewDataExportHTTPHandler(rm, em, &dataArchiverStub{}, secret, time.Minute, time.Hour, time.Second)
+
+		export := &domain.DataExport{ID: "e1", UserID: "1", Status: domain.DataExportReady, Size: 3, ExpiresAt: time.Now().Add(time.Hour)}
+		rm.On("GetByID", mock.Anything, "1").Return(user, nil).Once()
+		em.On("GetLatest", mock.Anything, "1").Return(export, nil).Once()
+
+		res, err := get(h)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		got := body(t, res)
+		assert.Equal(t, "ready", got.Status)
+		assert.Contains(t, got.DownloadURL, "/data-exports/e1?expires=")
+		assert.WithinDuration(t, time.Now().Add(time.Minute), *got.DownloadExpiresAt, time.Second)
+		em.AssertExpectations(t)
+	})
+
+	t.Run("Link expires with the archive", func(t *testing.T) {
+		rm := new(repositoryMock)
+		em := &dataExportsMock{saved: make(chan domain.DataExport, 1)}
+		h := handler.NewDataExportHTTPHandler(rm, em, &dataArchiverStub{}, secret, time.Hour, time.Hour, time.Second)
+
+		expires := time.Now().Add(time.Minute).Truncate(time.Second)
+		rm.On("GetByID", mock.Anything, "1").Return(user, nil).Once()
+		em.On("GetLatest", mock.Anything, "1").Return(&domain.DataExport{ID: "e1", Status: domain.DataExportReady, ExpiresAt: expires}, nil).Once()
+
+		res, err := get(h)
+
+		assert.NoError(t, err)
+		assert.True(t, expires.Equal(*body(t, res).DownloadExpiresAt))
+	})
+
+	t.Run("Generated", func(t *testing.T) {
+		rm := new(repositoryMock)
+		em := &dataExportsMock{saved: make(chan domain.DataExport, 1)}
+		h := handler.NewDataExportHTTPHandler(rm, em, &dataArchiverStub{data: "zip"}, secret, time.Minute, time.Hour, time.Second)
+
+		rm.On("GetByID", mock.Anything, "1").Return(user, nil).Once()
+		em.On("GetLatest", mock.Anything, "1").Return(nil, nil).Once()
+		em.On("Create", mock.Anything, mock.MatchedBy(func(ex domain.DataExport) bool {
+			return ex.ID != "" && ex.UserID == "1" && ex.Status == domain.DataExportPending
+		})).Return(nil).Once()
+		em.On("Save", mock.Anything, mock.MatchedBy(func(ex domain.DataExport) bool {
+			return ex.Status == domain.DataExportReady && ex.Size == 3 && ex.FinishedAt != nil
+		}), []byte("zip")).Return(nil).Once()
+		em.On("GetLatest", mock.Anything, "1").Return(&domain.DataExport{ID: "e1", Status: domain.DataExportReady, ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+
+		res, err := get(h)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.NotEmpty(t, body(t, res).DownloadURL)
+		em.AssertExpectations(t)
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		rm := new(repositoryMock)
+		em := &dataExportsMock{saved: make(chan domain.DataExport, 1)}
+		archiver := &dataArchiverStub{data: "zip", release: make(chan struct{})}
+		h := handler.NewDataExportHTTPHandler(rm, em, archiver, secret, time.Minute, time.Hour, 10*time.Millisecond)
+
+		rm.On("GetByID", mock.Anything, "1").Return(user, nil).Once()
+		em.On("GetLatest", mock.Anything, "1").Return(nil, nil).Once()
+		em.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		em.On("Save", mock.Anything, mock.Anything, []byte("zip")).Return(nil).Once()
+
+		res, err := get(h)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, res.Code)
+		assert.Equal(t, "/users/1/data-export", res.Header().Get(echo.HeaderLocation))
+		assert.Equal(t, "5", res.Header().Get("Retry-After"))
+
+		got := body(t, res)
+		assert.Equal(t, "pending", got.Status)
+		assert.Empty(t, got.DownloadURL)
+
+		close(archiver.release)
+		assert.Equal(t, domain.DataExportReady, (<-em.saved).Status)
+		em.AssertExpectations(t)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		rm := new(repositoryMock)
+		em := &dataExportsMock{saved: make(chan domain.DataExport, 1)}
+		h := handler.NewDataExportHTTPHandler(rm, em, &dataArchiverStub{err: assert.AnError}, secret, time.Minute, time.Hour, time.Second)
+
+		rm.On("GetByID", mock.Anything, "1").Return(user, nil).Once()
+		em.On("GetLatest", mock.Anything, "1").Return(nil, nil).Twice()
+		em.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		em.On("Save", mock.Anything, mock.MatchedBy(func(ex domain.DataExport) bool {
+			return ex.Status == domain.DataExportFailed && ex.Error != ""
+		}), []byte(nil)).Return(nil).Once()
+
+		_, err := get(h)
+
+		assert.Equal(t, echo.ErrInternalServerError, err)
+		em.AssertExpectations(t)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		rm := new(repositoryMock)
+		h := handler.NewDataExportHTTPHandler(rm, new(dataExportsMock), &dataArchiverStub{}, secret, time.Minute, time.Hour, time.Second)
+
+		rm.On("GetByID", mock.Anything, "1").Return(nil, nil).Once()
+
+		_, err := get(h)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, problem.CodeUserNotFound, he.Message)
+	})
+}
+
+func TestDataExportHTTPHandler_Download(t *testing.T) {
+	e := echo.New()
+	secret := []byte("secret")
+
+	sign := func(id string, expires int64) string {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(id + "\n" + strconv.FormatInt(expires, 10)))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	download := func(h *handler.DataExportHTTPHandler, expires int64, signature string) (*httptest.ResponseRecorder, error) {
+		target := "/data-exports/e1?expires=" + strconv.FormatInt(expires, 10) + "&signature=" + signature
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("e1")
+
+		return res, h.Download(ec)
+	}
+
+	t.Run("Download", func(t *testing.T) {
+		em := new(dataExportsMock)
+		h := handler.NewDataExportHTTPHandler(new(repositoryMock), em, &dataArchiverStub{}, secret, time.Minute, time.Hour, time.Second)
+
+		expires := time.Now().Add(time.Minute).Unix()
+		em.On("Archive", mock.Anything, "e1").Return(&domain.DataExport{ID: "e1", UserID: "1", ExpiresAt: time.Now().Add(time.Hour)}, []byte("zip"), nil).Once()
+
+		res, err := download(h, expires, sign("e1", expires))
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, handler.MIMEApplicationZip, res.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="user-1-data.zip"`, res.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, "no-store", res.Header().Get(echo.HeaderCacheControl))
+		assert.Equal(t, "zip", res.Body.String())
+	})
+
+	t.Run("Signed by another instance", func(t *testing.T) {
+		em := new(dataExportsMock)
+		h := handler.NewDataExportHTTPHandler(new(repositoryMock), em, &dataArchiverStub{}, secret, time.Minute, time.Hour, time.Second)
+		other := handler.NewDataExportHTTPHandler(new(repositoryMock), em, &dataArchiverStub{}, nil, time.Minute, time.Hour, time.Second)
+
+		expires := time.Now().Add(time.Minute).Unix()
+
+		_, err := download(other, expires, sign("e1", expires))
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusForbidden, he.Code)
+
+		em.On("Archive", mock.Anything, "e1").Return(&domain.DataExport{ID: "e1", UserID: "1", ExpiresAt: time.Now().Add(time.Hour)}, []byte("zip"), nil).Once()
+
+		_, err = download(h, expires, sign("e1", expires))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Archive gone", func(t *testing.T) {
+		em := new(dataExportsMock)
+		h := handler.NewDataExportHTTPHandler(new(repositoryMock), em, &dataArchiverStub{}, secret, time.Minute, time.Hour, time.Second)
+
+		expires := time.Now().Add(time.Minute).Unix()
+		em.On("Archive", mock.Anything, "e1").Return(nil, nil, nil).Once()
+
+		_, err := download(h, expires, sign("e1", expires))
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, problem.CodeDataExportNotFound, he.Message)
+	})
+
+	expired := time.Now().Add(-time.Minute).Unix()
+	valid := time.Now().Add(time.Minute).Unix()
+
+	tests := []struct {
+		name      string
+		expires   int64
+		signature string
+		code      int
+		message   problem.Code
+	}{
+		{name: "Bad signature", expires: valid, signature: sign("e2", valid), code: http.StatusForbidden, message: problem.CodeInvalidDownloadLink},
+		{name: "Tampered expiry", expires: valid + 3600, signature: sign("e1", valid), code: http.StatusForbidden, message: problem.CodeInvalidDownloadLink},
+		{name: "Expired", expires: expired, signature: sign("e1", expired), code: http.StatusGone, message: problem.CodeDownloadLinkExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handler.NewDataExportHTTPHandler(new(repositoryMock), new(dataExportsMock), &dataArchiverStub{}, secret, time.Minute, time.Hour, time.Second)
+
+			_, err := download(h, tt.expires, tt.signature)
+
+			var he *echo.HTTPError
+			assert.ErrorAs(t, err, &he)
+			assert.Equal(t, tt.code, he.Code)
+			assert.Equal(t, tt.message, he.Message)
+		})
+	}
+}
@@ -0,0 +1,414 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/xid"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/cursor"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/request"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type groupRepository interface {
+	Create(ctx context.Context, grp domain.Group) (domain.Group, error)
+	GetByID(ctx context.Context, id string) (*domain.Group, error)
+	List(ctx context.Context) ([]domain.Group, error)
+	Update(ctx context.Context, grp domain.Group) (domain.Group, error)
+	Delete(ctx context.Context, id string) error
+	AddMembers(ctx context.Context, groupID string, userIDs ...string) error
+	RemoveMember(ctx context.Context, groupID, userID string) (bool, error)
+	ListMembers(ctx context.Context, groupID string, page query.Page) ([]domain.User, query.PageInfo, error)
+	ListByUser(ctx context.Context, userID string) ([]domain.GroupMembership, error)
+}
+
+type groupUsers interface {
+	GetByID(ctx context.Context, id string) (*domain.User, error)
+}
+
+type GroupHTTPHandler struct {
+	groups   groupRepository
+	users    groupUsers
+	tx       transactor
+	cursors  *cursor.Codec
+	maxLimit int
+}
+
+// groupMembersSort is recorded in cursors so they cannot be replayed
+// elsewhere.
+const groupMembersSort = "id"
+
+// NewGroupHTTPHandler falls back to a random cursor secret when cursors is
+// nil, see WithPagination.
+func NewGroupHTTPHandler(groups groupRepository, users groupUsers, tx transactor, cursors *cursor.Codec, maxLimit int) *GroupHTTPHandler {
+	if cursors == nil {
+		secret := make([]byte, 32)
+		_, _ = rand.Read(secret)
+		cursors = cursor.NewCodec(secret)
+	}
+
+	if maxLimit < 1 {
+		maxLimit = defaultMaxLimit
+	}
+
+	return &GroupHTTPHandler{
+		groups:   groups,
+		users:    users,
+		tx:       tx,
+		cursors:  cursors,
+		maxLimit: maxLimit,
+	}
+}
+
+func (h *GroupHTTPHandler) Create(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "GroupCreate")
+
+	var req *request.GroupRequest
+	if err := ec.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if err := ec.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	if err := h.checkParent(ctx, req.ParentID); err != nil {
+		return err
+	}
+
+	created, err := h.groups.Create(ctx, domain.Group{
+		ID:          xid.New().String(),
+		Name:        req.Name,
+		Description: req.Description,
+		ParentID:    req.ParentID,
+	})
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return echo.NewHTTPError(http.StatusConflict, problem.CodeGroupExists).SetInternal(err)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.JSON(http.StatusCreated, groupResponse(created))
+}
+
+func (h *GroupHTTPHandler) GetMany(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "GroupGetMany")
+
+	groups, err := h.groups.List(ctx)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	res := response.GroupListResponse{
+		Data: make([]response.GroupResponse, 0, len(groups)),
+	}
+
+	for _, grp := range groups {
+		res.Data = append(res.Data, groupResponse(grp))
+	}
+
+	return ec.JSON(http.StatusOK, res)
+}
+
+func (h *GroupHTTPHandler) GetByID(ec echo.Context) error {
+	ctx := ec.Request().Context()
+
+	grp, err := h.group(ctx, ec.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	return ec.JSON(http.StatusOK, groupResponse(*grp))
+}
+
+// Update replaces the group, moving it under another parent unless that
+// would nest it in itself.
+func (h *GroupHTTPHandler) Update(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "GroupUpdate")
+
+	var req *request.GroupRequest
+	if err := ec.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if err := ec.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	var updated domain.Group
+	err := h.tx.InTx(ctx, func(ctx context.Context) error {
+		grp, err := h.group(ctx, ec.Param("id"))
+		if err != nil {
+			return err
+		}
+
+		if err := h.checkParent(ctx, req.ParentID); err != nil {
+			return err
+		}
+
+		grp.Name = req.Name
+		grp.Description = req.Description
+		grp.ParentID = req.ParentID
+
+		updated, err = h.groups.Update(ctx, *grp)
+
+		return err
+	})
+	if err != nil {
+		var he *echo.HTTPError
+		switch {
+		case errors.As(err, &he):
+			return he
+		case errors.Is(err, domain.ErrGroupCycle):
+			return echo.NewHTTPError(http.StatusConflict, problem.Message{Code: problem.CodeGroupCycle, Params: []string{req.ParentID}})
+		case ent.IsNotFound(err):
+			return echo.NewHTTPError(http.StatusNotFound, problem.Message{Code: problem.CodeParentGroupNotFound, Params: []string{req.ParentID}}).SetInternal(err)
+		case ent.IsConstraintError(err):
+			return echo.NewHTTPError(http.StatusConflict, problem.CodeGroupExists).SetInternal(err)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.JSON(http.StatusOK, groupResponse(updated))
+}
+
+// Delete removes the group and its memberships, its subgroups become top
+// level groups.
+func (h *GroupHTTPHandler) Delete(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "GroupDelete")
+
+	if err := h.groups.Delete(ctx, ec.Param("id")); err != nil {
+		if ent.IsNotFound(err) {
+			return echo.NewHTTPError(http.StatusNotFound, problem.CodeGroupNotFound)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.NoContent(http.StatusNoContent)
+}
+
+// AddMembers adds users to the group, those who already are members are
+// left as is.
+func (h *GroupHTTPHandler) AddMembers(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "GroupAddMembers")
+
+	var req *request.GroupMembersRequest
+	if err := ec.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if err := ec.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	grp, err := h.group(ctx, ec.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	if err := h.groups.AddMembers(ctx, grp.ID, req.Users...); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound).SetInternal(err)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.NoContent(http.StatusNoContent)
+}
+
+func (h *GroupHTTPHandler) RemoveMember(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "GroupRemoveMember")
+
+	grp, err := h.group(ctx, ec.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	removed, err := h.groups.RemoveMember(ctx, grp.ID, ec.Param("user_id"))
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if !removed {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeGroupMemberNotFound)
+	}
+
+	return ec.NoContent(http.StatusNoContent)
+}
+
+// Members lists the direct members of the group by ID, a page at a time.
+func (h *GroupHTTPHandler) Members(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "GroupMembers")
+
+	limit := ec.QueryParam("limit")
+	if limit == "" {
+		limit = defaultLimit
+	}
+
+	li, err := strconv.Atoi(limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLimit).SetInternal(err)
+	}
+
+	if li < 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLimit)
+	}
+
+	page := query.Page{Limit: min(li, h.maxLimit)}
+	if token := ec.QueryParam("cursor"); token != "" {
+		cur, err := h.cursors.Decode(token)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidCursor).SetInternal(err)
+		}
+
+		if cur.Sort != groupMembersSort || cur.Direction != cursor.Next {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidCursor)
+		}
+
+		page.After = cur.Keys
+	}
+
+	grp, err := h.group(ctx, ec.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	users, info, err := h.groups.ListMembers(ctx, grp.ID, page)
+	if err != nil {
+		if errors.Is(err, query.ErrInvalidPage) {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidCursor).SetInternal(err)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	res := response.GroupMemberPageResponse{
+		Data: userResponses(users),
+	}
+
+	if info.HasMore {
+		token, err := h.cursors.Encode(cursor.Cursor{Keys: info.Last, Sort: groupMembersSort, Direction: cursor.Next})
+		if err != nil {
+			l.ErrorContext(ctx, err.Error())
+			return echo.ErrInternalServerError
+		}
+
+		q := ec.Request().URL.Query()
+		q.Set("cursor", token)
+		res.Links.Next = ec.Request().URL.Path + "?" + q.Encode()
+
+		ec.Response().Header().Set(HeaderLink, fmt.Sprintf(`<%s>; rel="next"`, res.Links.Next))
+	}
+
+	return ec.JSON(http.StatusOK, res)
+}
+
+// UserGroups lists the groups the user is a direct member of, followed by
+// the ones they are a member of through them.
+func (h *GroupHTTPHandler) UserGroups(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "UserGroups")
+	id := ec.Param("id")
+
+	user, err := h.users.GetByID(ctx, id)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if user == nil {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound)
+	}
+
+	memberships, err := h.groups.ListByUser(ctx, id)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.JSON(http.StatusOK, response.GroupMembershipListResponse{Data: groupMembershipResponses(memberships)})
+}
+
+// group returns the group or a not found HTTP error.
+func (h *GroupHTTPHandler) group(ctx context.Context, id string) (*domain.Group, error) {
+	grp, err := h.groups.GetByID(ctx, id)
+	if err != nil {
+		slog.Default().With("handler", "Group").ErrorContext(ctx, err.Error())
+		return nil, echo.ErrInternalServerError
+	}
+
+	if grp == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, problem.CodeGroupNotFound)
+	}
+
+	return grp, nil
+}
+
+// checkParent returns a not found HTTP error unless the parent is empty or
+// exists.
+func (h *GroupHTTPHandler) checkParent(ctx context.Context, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+
+	parent, err := h.groups.GetByID(ctx, parentID)
+	if err != nil {
+		slog.Default().With("handler", "Group").ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if parent == nil {
+		return echo.NewHTTPError(http.StatusNotFound, problem.Message{Code: problem.CodeParentGroupNotFound, Params: []string{parentID}})
+	}
+
+	return nil
+}
+
+func groupResponse(g domain.Group) response.GroupResponse {
+	return response.GroupResponse{
+		ID:          g.ID,
+		Name:        g.Name,
+		Description: g.Description,
+		ParentID:    g.ParentID,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
+
+func groupMembershipResponses(memberships []domain.GroupMembership) []response.GroupMembershipResponse {
+	res := make([]response.GroupMembershipResponse, 0, len(memberships))
+	for _, m := range memberships {
+		res = append(res, response.GroupMembershipResponse{
+			Group:     groupResponse(m.Group),
+			Inherited: m.Inherited,
+			Via:       m.Via,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,360 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/cursor"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type groupRepositoryMock struct {
+	mock.Mock
+}
+
+func (g *groupRepositoryMock) Create(ctx context.Context, grp domain.Group) (domain.Group, error) {
+	args := g.Called(ctx, grp)
+	return args.Get(0).(domain.Group), args.Error(1)
+}
+
+func (g *groupRepositoryMock) GetByID(ctx context.Context, id string) (*domain.Group, error) {
+	args := g.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Group), args.Error(1)
+}
+
+func (g *groupRepositoryMock) List(ctx context.Context) ([]domain.Group, error) {
+	args := g.Called(ctx)
+	return args.Get(0).([]domain.Group), args.Error(1)
+}
+
+func (g *groupRepositoryMock) Update(ctx context.Context, grp domain.Group) (domain.Group, error) {
+	args := g.Called(ctx, grp)
+	return args.Get(0).(domain.Group), args.Error(1)
+}
+
+func (g *groupRepositoryMock) Delete(ctx context.Context, id string) error {
+	args := g.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (g *groupRepositoryMock) AddMembers(ctx context.Context, groupID string, userIDs ...string) error {
+	args := g.Called(ctx, groupID, userIDs)
+	return args.Error(0)
+}
+
+func (g *groupRepositoryMock) RemoveMember(ctx context.Context, groupID, userID string) (bool, error) {
+	args := g.Called(ctx, groupID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (g *groupRepositoryMock) ListMembers(ctx context.Context, groupID string, page query.Page) ([]domain.User, query.PageInfo, error) {
+	args := g.Called(ctx, groupID, page)
+	return args.Get(0).([]domain.User), args.Get(1).(query.PageInfo), args.Error(2)
+}
+
+func (g *groupRepositoryMock) ListByUser(ctx context.Context, userID string) ([]domain.GroupMembership, error) {
+	args := g.Called(ctx, userID)
+	return args.Get(0).([]domain.GroupMembership), args.Error(1)
+}
+
+func groupRequest(e *echo.Echo, method, target, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req, _ := http.NewRequest(method, target, bytes.NewReader([]byte(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+
+	return e.NewContext(req, res), res
+}
+
+func TestGroupHTTPHandler_Create(t *testing.T) {
+	gm := new(groupRepositoryMock)
+	h := handler.NewGroupHTTPHandler(gm, nil, new(transactorMock), nil, 10)
+	e := echo.New()
+	e.Validator = &requestValidator{
+		Validator: validator.New(),
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		ec, res := groupRequest(e, http.MethodPost, "/groups", `{"name":"Backend","parent_id":"eng"}`)
+		ctx := ec.Request().Context()
+
+		gm.On("GetByID", ctx, "eng").Return(&domain.Group{ID: "eng"}, nil).Once()
+		gm.On("Create", ctx, mock.MatchedBy(func(grp domain.Group) bool {
+			return grp.ID != "" && grp.Name == "Backend" && grp.ParentID == "eng"
+		})).Return(domain.Group{ID: "be", Name: "Backend", ParentID: "eng"}, nil).Once()
+
+		err := h.Create(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, res.Code)
+
+		var body response.GroupResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, "be", body.ID)
+		assert.Equal(t, "eng", body.ParentID)
+	})
+
+	t.Run("Parent not found", func(t *testing.T) {
+		ec, _ := groupRequest(e, http.MethodPost, "/groups", `{"name":"Backend","parent_id":"eng"}`)
+
+		gm.On("GetByID", ec.Request().Context(), "eng").Return(nil, nil).Once()
+
+		err := h.Create(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, problem.Message{Code: problem.CodeParentGroupNotFound, Params: []string{"eng"}}, he.Message)
+	})
+
+	t.Run("Name taken", func(t *testing.T) {
+		ec, _ := groupRequest(e, http.MethodPost, "/groups", `{"name":"Backend"}`)
+
+		gm.On("Create", ec.Request().Context(), mock.Anything).Return(domain.Group{}, &ent.ConstraintError{}).Once()
+
+		err := h.Create(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, problem.CodeGroupExists, he.Message)
+	})
+
+	t.Run("Missing name", func(t *testing.T) {
+		ec, _ := groupRequest(e, http.MethodPost, "/groups", `{"description":"Backend"}`)
+
+		err := h.Create(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, problem.CodeValidationFailed, he.Message)
+	})
+}
+
+func TestGroupHTTPHandler_Update(t *testing.T) {
+	gm := new(groupRepositoryMock)
+	tx := new(transactorMock)
+	h := handler.NewGroupHTTPHandler(gm, nil, tx, nil, 10)
+	e := echo.New()
+	e.Validator = &requestValidator{
+		Validator: validator.New(),
+	}
+
+	update := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		ec, res := groupRequest(e, http.MethodPut, "/groups/eng", body)
+		ec.SetParamNames("id")
+		ec.SetParamValues("eng")
+
+		return ec, res
+	}
+
+	t.Run("Move", func(t *testing.T) {
+		ec, res := update(`{"name":"Engineering","parent_id":"company"}`)
+
+		gm.On("GetByID", inTx, "eng").Return(&domain.Group{ID: "eng", Name: "Eng"}, nil).Once()
+		gm.On("GetByID", inTx, "company").Return(&domain.Group{ID: "company"}, nil).Once()
+		gm.On("Update", inTx, domain.Group{ID: "eng", Name: "Engineering", ParentID: "company"}).
+			Return(domain.Group{ID: "eng", Name: "Engineering", ParentID: "company"}, nil).Once()
+
+		err := h.Update(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.True(t, tx.committed)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		ec, _ := update(`{"name":"Engineering","parent_id":"be"}`)
+
+		gm.On("GetByID", inTx, "eng").Return(&domain.Group{ID: "eng", Name: "Engineering"}, nil).Once()
+		gm.On("GetByID", inTx, "be").Return(&domain.Group{ID: "be", ParentID: "eng"}, nil).Once()
+		gm.On("Update", inTx, mock.Anything).Return(domain.Group{}, domain.ErrGroupCycle).Once()
+
+		err := h.Update(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, problem.Message{Code: problem.CodeGroupCycle, Params: []string{"be"}}, he.Message)
+		assert.False(t, tx.committed)
+	})
+
+	t.Run("Group not found", func(t *testing.T) {
+		ec, _ := update(`{"name":"Engineering"}`)
+
+		gm.On("GetByID", inTx, "eng").Return(nil, nil).Once()
+
+		err := h.Update(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, problem.CodeGroupNotFound, he.Message)
+	})
+}
+
+func TestGroupHTTPHandler_Members(t *testing.T) {
+	gm := new(groupRepositoryMock)
+	cursors := cursor.NewCodec([]byte("secret"))
+	h := handler.NewGroupHTTPHandler(gm, nil, new(transactorMock), cursors, 2)
+	e := echo.New()
+	e.Validator = &requestValidator{
+		Validator: validator.New(),
+	}
+
+	members := func(method, target, body string) (echo.Context, *httptest.ResponseRecorder) {
+		ec, res := groupRequest(e, method, target, body)
+		ec.SetParamNames("id", "user_id")
+		ec.SetParamValues("eng", "1")
+
+		return ec, res
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		ec, res := members(http.MethodPost, "/groups/eng/members", `{"users":["1","2"]}`)
+		ctx := ec.Request().Context()
+
+		gm.On("GetByID", ctx, "eng").Return(&domain.Group{ID: "eng"}, nil).Once()
+		gm.On("AddMembers", ctx, "eng", []string{"1", "2"}).Return(nil).Once()
+
+		err := h.AddMembers(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("Add unknown user", func(t *testing.T) {
+		ec, _ := members(http.MethodPost, "/groups/eng/members", `{"users":["1","2"]}`)
+		ctx := ec.Request().Context()
+
+		gm.On("GetByID", ctx, "eng").Return(&domain.Group{ID: "eng"}, nil).Once()
+		gm.On("AddMembers", ctx, "eng", []string{"1", "2"}).Return(fmt.Errorf("%w: 2", domain.ErrUserNotFound)).Once()
+
+		err := h.AddMembers(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, problem.CodeUserNotFound, he.Message)
+	})
+
+	t.Run("Remove a user who is not a member", func(t *testing.T) {
+		ec, _ := members(http.MethodDelete, "/groups/eng/members/1", "")
+		ctx := ec.Request().Context()
+
+		gm.On("GetByID", ctx, "eng").Return(&domain.Group{ID: "eng"}, nil).Once()
+		gm.On("RemoveMember", ctx, "eng", "1").Return(false, nil).Once()
+
+		err := h.RemoveMember(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, problem.CodeGroupMemberNotFound, he.Message)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		ec, res := members(http.MethodGet, "/groups/eng/members?limit=5", "")
+		ctx := ec.Request().Context()
+
+		gm.On("GetByID", ctx, "eng").Return(&domain.Group{ID: "eng"}, nil).Once()
+		gm.On("ListMembers", ctx, "eng", query.Page{Limit: 2}).
+			Return([]domain.User{{ID: "1"}, {ID: "2"}}, query.PageInfo{Last: []string{"2"}, HasMore: true}, nil).Once()
+
+		err := h.Members(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var body response.GroupMemberPageResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Len(t, body.Data, 2)
+
+		next, _ := url.Parse(body.Links.Next)
+		cur, err := cursors.Decode(next.Query().Get("cursor"))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2"}, cur.Keys)
+	})
+
+	t.Run("Cursor of another list", func(t *testing.T) {
+		token, _ := cursors.Encode(cursor.Cursor{Keys: []string{"2"}, Sort: "-id", Direction: cursor.Next})
+		ec, _ := members(http.MethodGet, "/groups/eng/members?cursor="+token, "")
+
+		err := h.Members(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, problem.CodeInvalidCursor, he.Message)
+	})
+}
+
+func TestGroupHTTPHandler_UserGroups(t *testing.T) {
+	gm := new(groupRepositoryMock)
+	rm := new(repositoryMock)
+	h := handler.NewGroupHTTPHandler(gm, rm, new(transactorMock), nil, 10)
+	e := echo.New()
+
+	get := func() (echo.Context, *httptest.ResponseRecorder) {
+		ec, res := groupRequest(e, http.MethodGet, "/users/1/groups", "")
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		return ec, res
+	}
+
+	t.Run("UserGroups", func(t *testing.T) {
+		ec, res := get()
+		ctx := ec.Request().Context()
+
+		rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1"}, nil).Once()
+		gm.On("ListByUser", ctx, "1").Return([]domain.GroupMembership{
+			{Group: domain.Group{ID: "be", ParentID: "eng"}},
+			{Group: domain.Group{ID: "eng"}, Inherited: true, Via: "be"},
+		}, nil).Once()
+
+		err := h.UserGroups(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var body response.GroupMembershipListResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, []response.GroupMembershipResponse{
+			{Group: response.GroupResponse{ID: "be", ParentID: "eng"}},
+			{Group: response.GroupResponse{ID: "eng"}, Inherited: true, Via: "be"},
+		}, body.Data)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		ec, _ := get()
+
+		rm.On("GetByID", ec.Request().Context(), "1").Return(nil, nil).Once()
+
+		err := h.UserGroups(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, problem.CodeUserNotFound, he.Message)
+	})
+}
@@ -0,0 +1,472 @@
+package handler
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/xid"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/cursor"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/request"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+// invitationsSort is recorded in cursors so they cannot be replayed
+// elsewhere.
+const invitationsSort = "-id"
+
+type invitationStore interface {
+	invitationRepository
+	GetByID(ctx context.Context, id string) (*domain.Invitation, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error)
+	List(ctx context.Context, status domain.InvitationStatus, page query.Page) ([]domain.Invitation, query.PageInfo, error)
+	Accept(ctx context.Context, id string) (bool, error)
+	Revoke(ctx context.Context, id string) (bool, error)
+	Renew(ctx context.Context, id, tokenHash string, expiresAt time.Time) (bool, error)
+}
+
+type invitationGroups interface {
+	GetByID(ctx context.Context, id string) (*domain.Group, error)
+	AddMembers(ctx context.Context, groupID string, userIDs ...string) error
+}
+
+type userInvitations struct {
+	store     invitationStore
+	sender    invitationSender
+	groups    invitationGroups
+	inviteURL string
+	inviteTTL time.Duration
+}
+
+// WithInvitations lets people be invited to set up their own account. They
+// are sent a link made of inviteURL and a token valid for inviteTTL unless
+// the invitation says otherwise, and join the group of the invitation, if
+// any, once they accept.
+func WithInvitations(store invitationStore, sender invitationSender, groups invitationGroups, inviteURL string, inviteTTL time.Duration) Option {
+	return func(h *UserHTTPHandler) {
+		h.invitations = &userInvitations{
+			store:     store,
+			sender:    sender,
+			groups:    groups,
+			inviteURL: inviteURL,
+			inviteTTL: inviteTTL,
+		}
+	}
+}
+
+// Invite sends an invitation to the email of the request.
+func (h *UserHTTPHandler) Invite(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "Invite")
+
+	if h.invitations == nil {
+		return echo.ErrNotFound
+	}
+
+	var req *request.InvitationRequest
+	if err := ec.Bind(&req); err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if err := ec.Validate(req); err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(h.invitations.inviteTTL)
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(now) {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidExpiry)
+		}
+		expiresAt = *req.ExpiresAt
+	}
+
+	if req.GroupID != "" {
+		grp, err := h.invitations.groups.GetByID(ctx, req.GroupID)
+		if err != nil {
+			l.ErrorContext(ctx, err.Error())
+			return echo.ErrInternalServerError
+		}
+
+		if grp == nil {
+			return echo.NewHTTPError(http.StatusNotFound, problem.CodeGroupNotFound)
+		}
+	}
+
+	if err := h.checkErased(ctx, req.Email); err != nil {
+		var he *echo.HTTPError
+		if errors.As(err, &he) {
+			return he
+		}
+
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	user, err := h.userRepository.GetByEmail(ctx, req.Email)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if user != nil {
+		return echo.NewHTTPError(http.StatusConflict, problem.CodeUserAlreadyExists)
+	}
+
+	pending, err := h.invitations.store.GetPendingByEmail(ctx, req.Email)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if pending != nil {
+		return echo.NewHTTPError(http.StatusConflict, problem.CodeInvitationExists)
+	}
+
+	token, hash := domain.NewInvitationToken()
+	inv := domain.Invitation{
+		ID:        xid.New().String(),
+		Email:     req.Email,
+		Name:      req.Name,
+		Surname:   req.Surname,
+		GroupID:   req.GroupID,
+		InvitedBy: ec.Request().Header.Get(HeaderActorID),
+		TokenHash: hash,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+
+	if err = h.invitations.store.Create(ctx, inv); err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	h.sendInvitation(ctx, inv, token)
+
+	return ec.JSON(http.StatusCreated, invitationResponse(inv))
+}
+
+// GetInvitations lists the invitations, newest first, optionally only those
+// in the `status` query param.
+func (h *UserHTTPHandler) GetInvitations(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "GetInvitations")
+
+	if h.invitations == nil {
+		return echo.ErrNotFound
+	}
+
+	limit := ec.QueryParam("limit")
+	if limit == "" {
+		limit = defaultLimit
+	}
+
+	li, err := strconv.Atoi(limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLimit).SetInternal(err)
+	}
+
+	if li < 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLimit)
+	}
+
+	status := domain.InvitationStatus(ec.QueryParam("status"))
+	switch status {
+	case "", domain.InvitationPending, domain.InvitationAccepted, domain.InvitationRevoked, domain.InvitationExpired:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidInviteStatus)
+	}
+
+	page := query.Page{Limit: min(li, h.maxLimit)}
+	if token := ec.QueryParam("cursor"); token != "" {
+		cur, err := h.cursors.Decode(token)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidCursor).SetInternal(err)
+		}
+
+		if cur.Sort != invitationsSort || cur.Direction != cursor.Next {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidCursor)
+		}
+
+		page.After = cur.Keys
+	}
+
+	invs, info, err := h.invitations.store.List(ctx, status, page)
+	if err != nil {
+		if errors.Is(err, query.ErrInvalidPage) {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidCursor).SetInternal(err)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	res := response.InvitationPageResponse{
+		Data: make([]response.InvitationResponse, 0, len(invs)),
+	}
+	for _, inv := range invs {
+		res.Data = append(res.Data, invitationResponse(inv))
+	}
+
+	if info.HasMore {
+		token, err := h.cursors.Encode(cursor.Cursor{Keys: info.Last, Sort: invitationsSort, Direction: cursor.Next})
+		if err != nil {
+			l.ErrorContext(ctx, err.Error())
+			return echo.ErrInternalServerError
+		}
+
+		q := ec.Request().URL.Query()
+		q.Set("cursor", token)
+		res.Links.Next = ec.Request().URL.Path + "?" + q.Encode()
+
+		ec.Response().Header().Set(HeaderLink, fmt.Sprintf(`<%s>; rel="next"`, res.Links.Next))
+	}
+
+	return ec.JSON(http.StatusOK, res)
+}
+
+// RevokeInvitation revokes a pending or expired invitation, its link no
+// longer works.
+func (h *UserHTTPHandler) RevokeInvitation(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "RevokeInvitation")
+
+	inv, err := h.invitation(ctx, ec.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	if inv.AcceptedAt != nil || inv.RevokedAt != nil {
+		return invitationUnavailable(*inv)
+	}
+
+	revoked, err := h.invitations.store.Revoke(ctx, inv.ID)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if !revoked {
+		// Accepted or revoked since it was read.
+		if inv, err = h.invitation(ctx, inv.ID); err != nil {
+			return err
+		}
+		return invitationUnavailable(*inv)
+	}
+
+	now := time.Now()
+	inv.RevokedAt = &now
+
+	return ec.JSON(http.StatusOK, invitationResponse(*inv))
+}
+
+// ResendInvitation sends a pending or expired invitation again, with a new
+// link valid for the configured time to live. The former link no longer
+// works.
+func (h *UserHTTPHandler) ResendInvitation(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "ResendInvitation")
+
+	inv, err := h.invitation(ctx, ec.Param("id"))
+	if err != nil {
+		return err
+	}
+
+	if inv.AcceptedAt != nil || inv.RevokedAt != nil {
+		return invitationUnavailable(*inv)
+	}
+
+	token, hash := domain.NewInvitationToken()
+	expiresAt := time.Now().Add(h.invitations.inviteTTL)
+
+	renewed, err := h.invitations.store.Renew(ctx, inv.ID, hash, expiresAt)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if !renewed {
+		// Accepted or revoked since it was read.
+		if inv, err = h.invitation(ctx, inv.ID); err != nil {
+			return err
+		}
+		return invitationUnavailable(*inv)
+	}
+
+	inv.TokenHash = hash
+	inv.ExpiresAt = expiresAt
+
+	h.sendInvitation(ctx, *inv, token)
+
+	return ec.JSON(http.StatusOK, invitationResponse(*inv))
+}
+
+// GetInvitation shows the invitation of the token in the URL to the invitee,
+// as long as it can be accepted.
+func (h *UserHTTPHandler) GetInvitation(ec echo.Context) error {
+	inv, err := h.invitationByToken(ec.Request().Context(), ec.Param("token"))
+	if err != nil {
+		return err
+	}
+
+	return ec.JSON(http.StatusOK, invitationResponse(*inv))
+}
+
+// AcceptInvitation creates the account of the invitee, in the organization
+// they were invited to, the same way as Create.
+func (h *UserHTTPHandler) AcceptInvitation(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "AcceptInvitation")
+
+	token := ec.Param("token")
+	inv, err := h.invitationByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	var req *request.InvitationAcceptRequest
+	if err := ec.Bind(&req); err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if err := ec.Validate(req); err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	hash, err := hashPassword(req.Password)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	user := &domain.User{
+		ID:       xid.New().String(),
+		Name:     cmp.Or(req.Name, inv.Name),
+		Surname:  cmp.Or(req.Surname, inv.Surname),
+		Email:    inv.Email,
+		Password: hash,
+	}
+
+	ec.SetRequest(ec.Request().WithContext(tenant.NewContext(ctx, inv.OrganizationID)))
+
+	return h.create(ec, user, req.AcceptedPolicies, func(ctx context.Context) error {
+		accepted, err := h.invitations.store.Accept(ctx, inv.ID)
+		if err != nil {
+			return err
+		}
+
+		if !accepted {
+			// Accepted, revoked or expired since it was read.
+			inv, err := h.invitations.store.GetByTokenHash(ctx, inv.TokenHash)
+			if err != nil {
+				return err
+			}
+
+			if inv == nil {
+				return echo.NewHTTPError(http.StatusNotFound, problem.CodeInvitationNotFound)
+			}
+
+			return invitationUnavailable(*inv)
+		}
+
+		if inv.GroupID != "" {
+			return h.invitations.groups.AddMembers(ctx, inv.GroupID, user.ID)
+		}
+
+		return nil
+	})
+}
+
+// invitation returns the invitation by ID, or an echo.HTTPError if there is
+// none.
+func (h *UserHTTPHandler) invitation(ctx context.Context, id string) (*domain.Invitation, error) {
+	if h.invitations == nil {
+		return nil, echo.ErrNotFound
+	}
+
+	inv, err := h.invitations.store.GetByID(ctx, id)
+	if err != nil {
+		slog.Default().With("handler", "Invitation").ErrorContext(ctx, err.Error())
+		return nil, echo.ErrInternalServerError
+	}
+
+	if inv == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, problem.CodeInvitationNotFound)
+	}
+
+	return inv, nil
+}
+
+// invitationByToken returns the invitation of token if it can be accepted,
+// or an echo.HTTPError.
+func (h *UserHTTPHandler) invitationByToken(ctx context.Context, token string) (*domain.Invitation, error) {
+	if h.invitations == nil {
+		return nil, echo.ErrNotFound
+	}
+
+	inv, err := h.invitations.store.GetByTokenHash(ctx, domain.InvitationTokenHash(token))
+	if err != nil {
+		slog.Default().With("handler", "Invitation").ErrorContext(ctx, err.Error())
+		return nil, echo.ErrInternalServerError
+	}
+
+	if inv == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, problem.CodeInvitationNotFound)
+	}
+
+	if inv.Status(time.Now()) != domain.InvitationPending {
+		return nil, invitationUnavailable(*inv)
+	}
+
+	return inv, nil
+}
+
+// sendInvitation sends inv with its token. The invitation stands even if it
+// could not be sent, it can be sent again.
+func (h *UserHTTPHandler) sendInvitation(ctx context.Context, inv domain.Invitation, token string) {
+	if err := h.invitations.sender.SendInvitation(ctx, inv, h.invitations.inviteURL+token); err != nil {
+		slog.Default().With("handler", "Invite").ErrorContext(ctx, err.Error())
+	}
+}
+
+// invitationUnavailable is the error for an invitation that is no longer
+// pending.
+func invitationUnavailable(inv domain.Invitation) error {
+	switch inv.Status(time.Now()) {
+	case domain.InvitationAccepted:
+		return echo.NewHTTPError(http.StatusConflict, problem.CodeInvitationAccepted)
+	case domain.InvitationRevoked:
+		return echo.NewHTTPError(http.StatusGone, problem.CodeInvitationRevoked)
+	default:
+		return echo.NewHTTPError(http.StatusGone, problem.CodeInvitationExpired)
+	}
+}
+
+func invitationResponse(inv domain.Invitation) response.InvitationResponse {
+	return response.InvitationResponse{
+		ID:         inv.ID,
+		Email:      inv.Email,
+		Name:       inv.Name,
+		Surname:    inv.Surname,
+		GroupID:    inv.GroupID,
+		Status:     string(inv.Status(time.Now())),
+		ExpiresAt:  inv.ExpiresAt,
+		CreatedAt:  inv.CreatedAt,
+		AcceptedAt: inv.AcceptedAt,
+		RevokedAt:  inv.RevokedAt,
+	}
+}
@@ -0,0 +1,433 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	customvalidator "github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/validator"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+func (m *invitationsMock) GetByID(ctx context.Context, id string) (*domain.Invitation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invitation), args.Error(1)
+}
+
+func (m *invitationsMock) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invitation), args.Error(1)
+}
+
+func (m *invitationsMock) List(ctx context.Context, status domain.InvitationStatus, page query.Page) ([]domain.Invitation, query.PageInfo, error) {
+	args := m.Called(ctx, status, page)
+	return args.Get(0).([]domain.Invitation), args.Get(1).(query.PageInfo), args.Error(2)
+}
+
+func (m *invitationsMock) Accept(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *invitationsMock) Revoke(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *invitationsMock) Renew(ctx context.Context, id, tokenHash string, expiresAt time.Time) (bool, error) {
+	args := m.Called(ctx, id, tokenHash, expiresAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestUserHTTPHandler_Invite(t *testing.T) {
+	rm := new(repositoryMock)
+	im := new(invitationsMock)
+	sm := new(invitationSenderMock)
+	gm := new(groupRepositoryMock)
+	h := handler.NewUserHTTPHandler(rm, handler.WithInvitations(im, sm, gm, "https://example.com/invitations/", time.Hour))
+	e := echo.New()
+	e.Validator = &requestValidator{
+		Validator: validator.New(),
+	}
+
+	newContext := func(body string) echo.Context {
+		req := httptest.NewRequest(http.MethodPost, "/invitations", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(handler.HeaderActorID, "admin")
+		return e.NewContext(req, httptest.NewRecorder())
+	}
+
+	t.Run("Invite", func(t *testing.T) {
+		ec := newContext(`{"email":"john@doe.com","name":"John","group_id":"g1"}`)
+		ctx := ec.Request().Context()
+
+		gm.On("GetByID", ctx, "g1").Return(&domain.Group{ID: "g1"}, nil).Once()
+		rm.On("GetByEmail", ctx, "john@doe.com").Return(nil, nil).Once()
+		im.On("GetPendingByEmail", ctx, "john@doe.com").Return(nil, nil).Once()
+
+		var created domain.Invitation
+		im.On("Create", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			created = args.Get(1).(domain.Invitation)
+		}).Return(nil).Once()
+		sm.On("SendInvitation", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			token, ok := strings.CutPrefix(args.String(2), "https://example.com/invitations/")
+			assert.True(t, ok)
+			assert.Equal(t, domain.InvitationTokenHash(token), created.TokenHash)
+		}).Return(nil).Once()
+
+		require.NoError(t, h.Invite(ec))
+		assert.Equal(t, http.StatusCreated, ec.Response().Status)
+
+		assert.Equal(t, "john@doe.com", created.Email)
+		assert.Equal(t, "John", created.Name)
+		assert.Equal(t, "g1", created.GroupID)
+		assert.Equal(t, "admin", created.InvitedBy)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), created.ExpiresAt, time.Minute)
+
+		var res response.InvitationResponse
+		require.NoError(t, json.Unmarshal(ec.Response().Writer.(*httptest.ResponseRecorder).Body.Bytes(), &res))
+		assert.Equal(t, created.ID, res.ID)
+		assert.Equal(t, "pending", res.Status)
+
+		mock.AssertExpectationsForObjects(t, rm, im, sm, gm)
+	})
+
+	t.Run("Expiry in the past", func(t *testing.T) {
+		ec := newContext(`{"email":"john@doe.com","expires_at":"2000-01-01T00:00:00Z"}`)
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, h.Invite(ec), &he)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, problem.CodeInvalidExpiry, he.Message)
+	})
+
+	t.Run("Group not found", func(t *testing.T) {
+		ec := newContext(`{"email":"john@doe.com","group_id":"g2"}`)
+
+		gm.On("GetByID", ec.Request().Context(), "g2").Return(nil, nil).Once()
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, h.Invite(ec), &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, problem.CodeGroupNotFound, he.Message)
+	})
+
+	t.Run("Pending invitation", func(t *testing.T) {
+		ec := newContext(`{"email":"john@doe.com"}`)
+		ctx := ec.Request().Context()
+
+		rm.On("GetByEmail", ctx, "john@doe.com").Return(nil, nil).Once()
+		im.On("GetPendingByEmail", ctx, "john@doe.com").Return(&domain.Invitation{ID: "inv"}, nil).Once()
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, h.Invite(ec), &he)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, problem.CodeInvitationExists, he.Message)
+	})
+
+	t.Run("Existing user", func(t *testing.T) {
+		ec := newContext(`{"email":"john@doe.com"}`)
+
+		rm.On("GetByEmail", ec.Request().Context(), "john@doe.com").Return(&domain.User{ID: "1"}, nil).Once()
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, h.Invite(ec), &he)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, problem.CodeUserAlreadyExists, he.Message)
+	})
+}
+
+func TestUserHTTPHandler_GetInvitations(t *testing.T) {
+	im := new(invitationsMock)
+	h := handler.NewUserHTTPHandler(new(repositoryMock), handler.WithInvitations(im, nil, nil, "", time.Hour))
+	e := echo.New()
+
+	t.Run("GetInvitations", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/invitations?status=pending&limit=1", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+
+		inv := domain.Invitation{ID: "inv2", Email: "john@doe.com", ExpiresAt: time.Now().Add(time.Hour)}
+		im.On("List", req.Context(), domain.InvitationPending, query.Page{Limit: 1}).
+			Return([]domain.Invitation{inv}, query.PageInfo{HasMore: true, First: []string{"inv2"}, Last: []string{"inv2"}}, nil).Once()
+
+		require.NoError(t, h.GetInvitations(ec))
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var body response.InvitationPageResponse
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		require.Len(t, body.Data, 1)
+		assert.Equal(t, "inv2", body.Data[0].ID)
+		assert.Contains(t, body.Links.Next, "cursor=")
+		assert.Contains(t, res.Header().Get(handler.HeaderLink), `rel="next"`)
+
+		im.AssertExpectations(t)
+	})
+
+	t.Run("Invalid status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/invitations?status=sent", nil)
+		ec := e.NewContext(req, httptest.NewRecorder())
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, h.GetInvitations(ec), &he)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, problem.CodeInvalidInviteStatus, he.Message)
+	})
+}
+
+func TestUserHTTPHandler_GetInvitation(t *testing.T) {
+	im := new(invitationsMock)
+	h := handler.NewUserHTTPHandler(new(repositoryMock), handler.WithInvitations(im, nil, nil, "", time.Hour))
+	e := echo.New()
+
+	now := time.Now()
+	tests := []struct {
+		name   string
+		inv    *domain.Invitation
+		status int
+		code   problem.Code
+	}{
+		{name: "Pending", inv: &domain.Invitation{ID: "inv", ExpiresAt: now.Add(time.Hour)}, status: http.StatusOK},
+		{name: "Not found", status: http.StatusNotFound, code: problem.CodeInvitationNotFound},
+		{name: "Expired", inv: &domain.Invitation{ID: "inv", ExpiresAt: now}, status: http.StatusGone, code: problem.CodeInvitationExpired},
+		{name: "Revoked", inv: &domain.Invitation{ID: "inv", ExpiresAt: now.Add(time.Hour), RevokedAt: &now}, status: http.StatusGone, code: problem.CodeInvitationRevoked},
+		{name: "Accepted", inv: &domain.Invitation{ID: "inv", ExpiresAt: now.Add(time.Hour), AcceptedAt: &now}, status: http.StatusConflict, code: problem.CodeInvitationAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/invitations/token", nil)
+			res := httptest.NewRecorder()
+			ec := e.NewContext(req, res)
+			ec.SetParamNames("token")
+			ec.SetParamValues("token")
+
+			if tt.inv == nil {
+				im.On("GetByTokenHash", req.Context(), domain.InvitationTokenHash("token")).Return(nil, nil).Once()
+			} else {
+				im.On("GetByTokenHash", req.Context(), domain.InvitationTokenHash("token")).Return(tt.inv, nil).Once()
+			}
+
+			err := h.GetInvitation(ec)
+			if tt.code == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.status, res.Code)
+				return
+			}
+
+			var he *echo.HTTPError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, tt.status, he.Code)
+			assert.Equal(t, tt.code, he.Message)
+		})
+	}
+}
+
+func TestUserHTTPHandler_AcceptInvitation(t *testing.T) {
+	rm := new(repositoryMock)
+	im := new(invitationsMock)
+	gm := new(groupRepositoryMock)
+	al := new(auditLogMock)
+	tx := new(transactorMock)
+	h := handler.NewUserHTTPHandler(rm,
+		handler.WithAudit(tx, al),
+		handler.WithInvitations(im, nil, gm, "", time.Hour),
+	)
+	e := echo.New()
+	v := &requestValidator{
+		Validator: validator.New(),
+	}
+
+	_ = v.Validator.RegisterValidation("password", customvalidator.PasswordValidate)
+	e.Validator = v
+
+	inOrg := mock.MatchedBy(func(ctx context.Context) bool {
+		id, ok := tenant.FromContext(ctx)
+		return ok && id == "acme"
+	})
+	inOrgTx := mock.MatchedBy(func(ctx context.Context) bool {
+		id, _ := tenant.FromContext(ctx)
+		return id == "acme" && ctx.Value(txKey{}) == true
+	})
+
+	newContext := func(body string) echo.Context {
+		req := httptest.NewRequest(http.MethodPost, "/invitations/token/accept", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		ec := e.NewContext(req, httptest.NewRecorder())
+		ec.SetParamNames("token")
+		ec.SetParamValues("token")
+		return ec
+	}
+
+	pending := func() *domain.Invitation {
+		return &domain.Invitation{
+			ID:             "inv",
+			OrganizationID: "acme",
+			Email:          "john@doe.com",
+			Name:           "John",
+			Surname:        "Doe",
+			GroupID:        "g1",
+			TokenHash:      domain.InvitationTokenHash("token"),
+			ExpiresAt:      time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("AcceptInvitation", func(t *testing.T) {
+		ec := newContext(`{"surname":"Smith","password":"1Password."}`)
+
+		im.On("GetByTokenHash", mock.Anything, domain.InvitationTokenHash("token")).Return(pending(), nil).Once()
+		rm.On("GetByEmail", inOrg, "john@doe.com").Return(nil, nil).Once()
+
+		var created domain.User
+		rm.On("Create", inOrgTx, mock.Anything).Run(func(args mock.Arguments) {
+			created = args.Get(1).(domain.User)
+		}).Return(nil).Once()
+		im.On("Accept", inOrgTx, "inv").Return(true, nil).Once()
+		gm.On("AddMembers", inOrgTx, "g1", mock.Anything).Return(nil).Once()
+		al.On("Append", inOrgTx, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, h.AcceptInvitation(ec))
+		assert.Equal(t, http.StatusCreated, ec.Response().Status)
+		assert.True(t, tx.committed)
+
+		assert.Equal(t, "John", created.Name)
+		assert.Equal(t, "Smith", created.Surname)
+		assert.Equal(t, "john@doe.com", created.Email)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(created.Password), []byte("1Password.")))
+
+		mock.AssertExpectationsForObjects(t, rm, im, gm, al)
+	})
+
+	t.Run("Weak password", func(t *testing.T) {
+		ec := newContext(`{"password":"password"}`)
+
+		im.On("GetByTokenHash", mock.Anything, domain.InvitationTokenHash("token")).Return(pending(), nil).Once()
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, h.AcceptInvitation(ec), &he)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, problem.CodeValidationFailed, he.Message)
+	})
+
+	t.Run("Revoked meanwhile", func(t *testing.T) {
+		ec := newContext(`{"password":"1Password."}`)
+
+		revoked := pending()
+		now := time.Now()
+		revoked.RevokedAt = &now
+
+		im.On("GetByTokenHash", mock.Anything, domain.InvitationTokenHash("token")).Return(pending(), nil).Once()
+		rm.On("GetByEmail", inOrg, "john@doe.com").Return(nil, nil).Once()
+		rm.On("Create", inOrgTx, mock.Anything).Return(nil).Once()
+		im.On("Accept", inOrgTx, "inv").Return(false, nil).Once()
+		im.On("GetByTokenHash", inOrgTx, domain.InvitationTokenHash("token")).Return(revoked, nil).Once()
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, h.AcceptInvitation(ec), &he)
+		assert.Equal(t, http.StatusGone, he.Code)
+		assert.Equal(t, problem.CodeInvitationRevoked, he.Message)
+		assert.False(t, tx.committed)
+
+		mock.AssertExpectationsForObjects(t, rm, im)
+	})
+}
+
+func TestUserHTTPHandler_RevokeInvitation(t *testing.T) {
+	im := new(invitationsMock)
+	h := handler.NewUserHTTPHandler(new(repositoryMock), handler.WithInvitations(im, nil, nil, "", time.Hour))
+	e := echo.New()
+
+	newContext := func() echo.Context {
+		req := httptest.NewRequest(http.MethodPost, "/invitations/inv/revoke", nil)
+		ec := e.NewContext(req, httptest.NewRecorder())
+		ec.SetParamNames("id")
+		ec.SetParamValues("inv")
+		return ec
+	}
+
+	t.Run("RevokeInvitation", func(t *testing.T) {
+		ec := newContext()
+		ctx := ec.Request().Context()
+
+		im.On("GetByID", ctx, "inv").Return(&domain.Invitation{ID: "inv", ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+		im.On("Revoke", ctx, "inv").Return(true, nil).Once()
+
+		require.NoError(t, h.RevokeInvitation(ec))
+
+		var res response.InvitationResponse
+		require.NoError(t, json.Unmarshal(ec.Response().Writer.(*httptest.ResponseRecorder).Body.Bytes(), &res))
+		assert.Equal(t, "revoked", res.Status)
+
+		im.AssertExpectations(t)
+	})
+
+	t.Run("Accepted", func(t *testing.T) {
+		ec := newContext()
+		now := time.Now()
+
+		im.On("GetByID", ec.Request().Context(), "inv").Return(&domain.Invitation{ID: "inv", ExpiresAt: now.Add(time.Hour), AcceptedAt: &now}, nil).Once()
+
+		var he *echo.HTTPError
+		require.ErrorAs(t, h.RevokeInvitation(ec), &he)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, problem.CodeInvitationAccepted, he.Message)
+	})
+}
+
+func TestUserHTTPHandler_ResendInvitation(t *testing.T) {
+	im := new(invitationsMock)
+	sm := new(invitationSenderMock)
+	h := handler.NewUserHTTPHandler(new(repositoryMock), handler.WithInvitations(im, sm, nil, "https://example.com/invitations/", time.Hour))
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/invitations/inv/resend", nil)
+	ec := e.NewContext(req, httptest.NewRecorder())
+	ec.SetParamNames("id")
+	ec.SetParamValues("inv")
+	ctx := req.Context()
+
+	expired := &domain.Invitation{ID: "inv", Email: "john@doe.com", TokenHash: "old", ExpiresAt: time.Now().Add(-time.Hour)}
+	im.On("GetByID", ctx, "inv").Return(expired, nil).Once()
+
+	var hash string
+	im.On("Renew", ctx, "inv", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		hash = args.String(2)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), args.Get(3).(time.Time), time.Minute)
+	}).Return(true, nil).Once()
+	sm.On("SendInvitation", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		token := strings.TrimPrefix(args.String(2), "https://example.com/invitations/")
+		assert.Equal(t, hash, domain.InvitationTokenHash(token))
+	}).Return(nil).Once()
+
+	require.NoError(t, h.ResendInvitation(ec))
+	assert.NotEqual(t, "old", hash)
+
+	var res response.InvitationResponse
+	require.NoError(t, json.Unmarshal(ec.Response().Writer.(*httptest.ResponseRecorder).Body.Bytes(), &res))
+	assert.Equal(t, "pending", res.Status)
+
+	mock.AssertExpectationsForObjects(t, im, sm)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/xid"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/request"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type organizationRepository interface {
+	Create(ctx context.Context, org domain.Organization) (domain.Organization, error)
+	List(ctx context.Context) ([]domain.Organization, error)
+}
+
+type OrganizationHTTPHandler struct {
+	organizations organizationRepository
+}
+
+func NewOrganizationHTTPHandler(organizations organizationRepository) *OrganizationHTTPHandler {
+	return &OrganizationHTTPHandler{
+		organizations: organizations,
+	}
+}
+
+func (h *OrganizationHTTPHandler) Create(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "OrganizationCreate")
+
+	var req *request.OrganizationRequest
+	if err := ec.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if err := ec.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	created, err := h.organizations.Create(ctx, domain.Organization{
+		ID:   xid.New().String(),
+		Name: req.Name,
+		Slug: req.Slug,
+	})
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return echo.NewHTTPError(http.StatusConflict, problem.CodeOrganizationExists).SetInternal(err)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.JSON(http.StatusCreated, organizationResponse(created))
+}
+
+func (h *OrganizationHTTPHandler) GetMany(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "OrganizationGetMany")
+
+	orgs, err := h.organizations.List(ctx)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	res := response.OrganizationListResponse{
+		Data: make([]response.OrganizationResponse, 0, len(orgs)),
+	}
+
+	for _, org := range orgs {
+		res.Data = append(res.Data, organizationResponse(org))
+	}
+
+	return ec.JSON(http.StatusOK, res)
+}
+
+func organizationResponse(org domain.Organization) response.OrganizationResponse {
+	return response.OrganizationResponse{
+		ID:        org.ID,
+		Name:      org.Name,
+		Slug:      org.Slug,
+		CreatedAt: org.CreatedAt,
+		UpdatedAt: org.UpdatedAt,
+	}
+}
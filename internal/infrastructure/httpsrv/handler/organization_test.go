@@ -0,0 +1,82 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type organizationRepositoryMock struct {
+	mock.Mock
+}
+
+func (o *organizationRepositoryMock) Create(ctx context.Context, org domain.Organization) (domain.Organization, error) {
+	args := o.Called(ctx, org)
+	return args.Get(0).(domain.Organization), args.Error(1)
+}
+
+func (o *organizationRepositoryMock) List(ctx context.Context) ([]domain.Organization, error) {
+	args := o.Called(ctx)
+	return args.Get(0).([]domain.Organization), args.Error(1)
+}
+
+func TestOrganizationHTTPHandler_Create(t *testing.T) {
+	om := new(organizationRepositoryMock)
+	h := handler.NewOrganizationHTTPHandler(om)
+	e := echo.New()
+	e.Validator = &requestValidator{
+		Validator: validator.New(),
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		ec, res := groupRequest(e, http.MethodPost, "/organizations", `{"name":"Acme","slug":"acme"}`)
+
+		om.On("Create", ec.Request().Context(), mock.MatchedBy(func(org domain.Organization) bool {
+			return org.ID != "" && org.Name == "Acme" && org.Slug == "acme"
+		})).Return(domain.Organization{ID: "o1", Name: "Acme", Slug: "acme"}, nil).Once()
+
+		err := h.Create(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, res.Code)
+
+		var body response.OrganizationResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, "o1", body.ID)
+	})
+
+	t.Run("Invalid slug", func(t *testing.T) {
+		ec, _ := groupRequest(e, http.MethodPost, "/organizations", `{"name":"Acme","slug":"acme.inc"}`)
+
+		err := h.Create(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, problem.CodeValidationFailed, he.Message)
+	})
+
+	t.Run("Slug taken", func(t *testing.T) {
+		ec, _ := groupRequest(e, http.MethodPost, "/organizations", `{"name":"Acme","slug":"acme"}`)
+
+		om.On("Create", ec.Request().Context(), mock.Anything).Return(domain.Organization{}, &ent.ConstraintError{}).Once()
+
+		err := h.Create(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, problem.CodeOrganizationExists, he.Message)
+	})
+}
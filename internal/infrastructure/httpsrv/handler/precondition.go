@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// userETag is a strong entity tag of the current version of user.
+func userETag(user *domain.User) string {
+	return `"` + strconv.Itoa(user.Version) + `"`
+}
+
+// setUserValidators sets the ETag and Last-Modified headers of user.
+func setUserValidators(ec echo.Context, user *domain.User) {
+	ec.Response().Header().Set(HeaderETag, userETag(user))
+	ec.Response().Header().Set(echo.HeaderLastModified, user.UpdatedAt.UTC().Format(http.TimeFormat))
+}
+
+// ifMatch evaluates the If-Match header of the request against the current
+// version of user, see RFC 9110 section 13.1.1. Requests without it match,
+// weak tags never do.
+func ifMatch(ec echo.Context, user *domain.User) bool {
+	header := ec.Request().Header.Get(HeaderIfMatch)
+	if header == "" {
+		return true
+	}
+
+	etag := userETag(user)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,13 @@
+package request
+
+type PolicyPublishRequest struct {
+	Kind      string `json:"kind" validate:"required,oneof=terms_of_service privacy_policy"`
+	Version   string `json:"version" validate:"required,max=50"`
+	URL       string `json:"url" validate:"required,http_url"`
+	Mandatory bool   `json:"mandatory"`
+}
+
+// ConsentRequest lists the IDs of the policies accepted.
+type ConsentRequest struct {
+	Policies []string `json:"policies" validate:"required,min=1,unique,dive,required"`
+}
@@ -0,0 +1,13 @@
+package request
+
+type GroupRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"max=500"`
+	// ParentID nests the group in another one, it is a top level group when
+	// empty.
+	ParentID string `json:"parent_id"`
+}
+
+type GroupMembersRequest struct {
+	Users []string `json:"users" validate:"required,min=1,max=100,unique,dive,required"`
+}
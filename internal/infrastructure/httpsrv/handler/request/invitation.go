@@ -0,0 +1,24 @@
+package request
+
+import "time"
+
+type InvitationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	// Name and Surname prefill the account of the invitee.
+	Name    string `json:"name"`
+	Surname string `json:"surname"`
+	// GroupID is the group the invitee joins once they accept.
+	GroupID string `json:"group_id"`
+	// ExpiresAt defaults to the configured time to live of invitations.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// InvitationAcceptRequest creates the account of the invitee, whose name
+// and surname default to those of the invitation.
+type InvitationAcceptRequest struct {
+	Name     string `json:"name"`
+	Surname  string `json:"surname"`
+	Password string `json:"password" validate:"required,password"`
+	// AcceptedPolicies are the IDs of the policies the user accepted.
+	AcceptedPolicies []string `json:"accepted_policies" validate:"unique,dive,required"`
+}
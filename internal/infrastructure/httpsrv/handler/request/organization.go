@@ -0,0 +1,7 @@
+package request
+
+type OrganizationRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	// Slug is the subdomain of the organization.
+	Slug string `json:"slug" validate:"required,max=63,lowercase,hostname_rfc1123,excludes=."`
+}
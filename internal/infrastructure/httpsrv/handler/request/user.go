@@ -1,10 +1,14 @@
 package request
 
+import "encoding/json"
+
 type UserCreateRequest struct {
 	Name     string `json:"name"`
 	Surname  string `json:"surname"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
+	// AcceptedPolicies are the IDs of the policies the user accepted.
+	AcceptedPolicies []string `json:"accepted_policies" validate:"unique,dive,required"`
 }
 
 type UserUpdateRequest struct {
@@ -16,3 +20,35 @@ type UserUpdateRequest struct {
 type UserUpdatePasswordRequest struct {
 	Password string `json:"password" validate:"required,password"`
 }
+
+// UserImportRow is a UserCreateRequest whose password is optional, people
+// imported without one are invited instead.
+type UserImportRow struct {
+	Name     string `json:"name"`
+	Surname  string `json:"surname"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password"`
+}
+
+const (
+	BatchCreate = "create"
+	BatchUpdate = "update"
+	BatchDelete = "delete"
+)
+
+// UserBatchRequest runs its operations in order. Atomic batches are applied
+// all together or not at all.
+type UserBatchRequest struct {
+	Atomic     bool                 `json:"atomic"`
+	Operations []UserBatchOperation `json:"operations" validate:"required,min=1,dive"`
+}
+
+// UserBatchOperation has the body of the request of its single counterpart,
+// the id and optional version (in place of If-Match) of the user to update
+// or delete.
+type UserBatchOperation struct {
+	Op      string          `json:"op" validate:"required,oneof=create update delete"`
+	ID      string          `json:"id" validate:"required_unless=Op create"`
+	Version *int            `json:"version"`
+	Body    json.RawMessage `json:"body" validate:"required_unless=Op delete"`
+}
@@ -0,0 +1,15 @@
+package request
+
+type WebhookCreateRequest struct {
+	URL    string `json:"url" validate:"required,http_url"`
+	Secret string `json:"secret" validate:"omitempty,min=16"`
+	// EventTypes subscribes to all events when empty.
+	EventTypes []string `json:"event_types" validate:"dive,oneof=user.created user.updated user.email_changed user.password_changed user.deleted user.restored user.erased"`
+	Active     *bool    `json:"active"`
+}
+
+type WebhookUpdateRequest struct {
+	URL        string   `json:"url" validate:"required,http_url"`
+	EventTypes []string `json:"event_types" validate:"dive,oneof=user.created user.updated user.email_changed user.password_changed user.deleted user.restored user.erased"`
+	Active     bool     `json:"active"`
+}
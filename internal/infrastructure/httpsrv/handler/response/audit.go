@@ -0,0 +1,36 @@
+package response
+
+import "time"
+
+type AuditEventResponse struct {
+	ID         int64                  `json:"id"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Actor      string                 `json:"actor"`
+	Action     string                 `json:"action"`
+	TargetType string                 `json:"target_type"`
+	TargetID   string                 `json:"target_id"`
+	Changes    map[string]AuditChange `json:"changes,omitempty"`
+	RequestID  string                 `json:"request_id"`
+	ClientIP   string                 `json:"client_ip"`
+	UserAgent  string                 `json:"user_agent"`
+	// Certificate is only set on erasures.
+	Certificate *ErasureCertificateResponse `json:"certificate,omitempty"`
+	PrevHash    string                      `json:"prev_hash"`
+	Hash        string                      `json:"hash"`
+}
+
+type AuditChange struct {
+	Before any `json:"before,omitempty"`
+	After  any `json:"after,omitempty"`
+}
+
+type AuditEventPageResponse struct {
+	Data  []AuditEventResponse `json:"data"`
+	Links PageLinks            `json:"links"`
+}
+
+type AuditChainResponse struct {
+	Valid    bool   `json:"valid"`
+	Checked  int    `json:"checked"`
+	BrokenAt *int64 `json:"broken_at,omitempty"`
+}
@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+type PolicyDocumentResponse struct {
+	ID          string    `json:"id"`
+	Kind        string    `json:"kind"`
+	Version     string    `json:"version"`
+	URL         string    `json:"url"`
+	Mandatory   bool      `json:"mandatory"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+type PolicyDocumentListResponse struct {
+	Data []PolicyDocumentResponse `json:"data"`
+}
+
+type ConsentResponse struct {
+	ID         string    `json:"id"`
+	DocumentID string    `json:"document_id"`
+	Kind       string    `json:"kind"`
+	Version    string    `json:"version"`
+	AcceptedAt time.Time `json:"accepted_at"`
+	ClientIP   string    `json:"client_ip,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+}
+
+type ConsentListResponse struct {
+	Data []ConsentResponse `json:"data"`
+	// Pending are the policies still to accept, only listed with GET.
+	Pending []PolicyDocumentResponse `json:"pending,omitempty"`
+}
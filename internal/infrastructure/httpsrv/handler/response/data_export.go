@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type DataExportResponse struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	Size       int64      `json:"size,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	// DownloadURL is set once the archive is ready, it is valid until
+	// DownloadExpiresAt.
+	DownloadURL       string     `json:"download_url,omitempty"`
+	DownloadExpiresAt *time.Time `json:"download_expires_at,omitempty"`
+}
@@ -0,0 +1,18 @@
+package response
+
+import "time"
+
+type ErasureCertificateResponse struct {
+	UserID          string                   `json:"user_id"`
+	EmailHash       string                   `json:"email_hash"`
+	ErasedAt        time.Time                `json:"erased_at"`
+	Records         []ErasureRecordsResponse `json:"records"`
+	AuditRedactions map[int64]string         `json:"audit_redactions,omitempty"`
+	Signature       string                   `json:"signature"`
+}
+
+type ErasureRecordsResponse struct {
+	Holder  string `json:"holder"`
+	Method  string `json:"method"`
+	Records int    `json:"records"`
+}
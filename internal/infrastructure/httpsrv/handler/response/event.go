@@ -0,0 +1,14 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type EventResponse struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+}
@@ -0,0 +1,33 @@
+package response
+
+import "time"
+
+type GroupResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	ParentID    string    `json:"parent_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type GroupListResponse struct {
+	Data []GroupResponse `json:"data"`
+}
+
+type GroupMemberPageResponse struct {
+	Data  []UserResponse `json:"data"`
+	Links PageLinks      `json:"links"`
+}
+
+// GroupMembershipResponse is a group the user is a member of, directly or
+// through the subgroup Via.
+type GroupMembershipResponse struct {
+	Group     GroupResponse `json:"group"`
+	Inherited bool          `json:"inherited"`
+	Via       string        `json:"via,omitempty"`
+}
+
+type GroupMembershipListResponse struct {
+	Data []GroupMembershipResponse `json:"data"`
+}
@@ -0,0 +1,33 @@
+package response
+
+import "time"
+
+type ImportJobResponse struct {
+	ID         string                   `json:"id"`
+	Status     string                   `json:"status"`
+	Format     string                   `json:"format"`
+	DryRun     bool                     `json:"dry_run"`
+	Processed  int                      `json:"processed"`
+	Created    int                      `json:"created"`
+	Invited    int                      `json:"invited"`
+	Failed     int                      `json:"failed"`
+	Errors     []ImportRowErrorResponse `json:"errors"`
+	Error      string                   `json:"error,omitempty"`
+	CreatedAt  time.Time                `json:"created_at"`
+	UpdatedAt  time.Time                `json:"updated_at"`
+	FinishedAt *time.Time               `json:"finished_at,omitempty"`
+}
+
+type ImportRowErrorResponse struct {
+	Row    int                  `json:"row"`
+	Email  string               `json:"email,omitempty"`
+	Code   string               `json:"code"`
+	Detail string               `json:"detail,omitempty"`
+	Errors []ImportFieldProblem `json:"errors,omitempty"`
+}
+
+type ImportFieldProblem struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,21 @@
+package response
+
+import "time"
+
+type InvitationResponse struct {
+	ID         string     `json:"id"`
+	Email      string     `json:"email"`
+	Name       string     `json:"name,omitempty"`
+	Surname    string     `json:"surname,omitempty"`
+	GroupID    string     `json:"group_id,omitempty"`
+	Status     string     `json:"status"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type InvitationPageResponse struct {
+	Data  []InvitationResponse `json:"data"`
+	Links PageLinks            `json:"links"`
+}
@@ -0,0 +1,15 @@
+package response
+
+import "time"
+
+type OrganizationResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OrganizationListResponse struct {
+	Data []OrganizationResponse `json:"data"`
+}
@@ -1,12 +1,49 @@
 package response
 
+import (
+	"time"
+
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
 type UserIDResponse struct {
 	ID string `json:"id"`
 }
 
 type UserResponse struct {
-	ID      string `json:"id"`
-	Name    string `json:"name"`
-	Surname string `json:"surname"`
-	Email   string `json:"email"`
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Surname   string     `json:"surname"`
+	Email     string     `json:"email"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Version   int        `json:"version"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type UserPageResponse struct {
+	Data  []UserResponse `json:"data"`
+	Links PageLinks      `json:"links"`
+	Total *int           `json:"total,omitempty"`
+}
+
+type PageLinks struct {
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+type UserSearchResponse struct {
+	Data []UserResponse `json:"data"`
+}
+
+type UserBatchResponse struct {
+	Results []UserBatchResult `json:"results"`
+}
+
+// UserBatchResult is the outcome of the operation at the same index of the
+// batch, with the status and body its single counterpart would respond with.
+type UserBatchResult struct {
+	Status int              `json:"status"`
+	ID     string           `json:"id,omitempty"`
+	Error  *problem.Problem `json:"error,omitempty"`
 }
@@ -0,0 +1,39 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookResponse struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	// Secret is only returned when the subscription is created.
+	Secret string `json:"secret,omitempty"`
+}
+
+type WebhookListResponse struct {
+	Data []WebhookResponse `json:"data"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Data []WebhookDeliveryResponse `json:"data"`
+}
@@ -2,44 +2,108 @@ package handler
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/xid"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/Beriw98/user-management/ent"
 	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/cursor"
 	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/request"
 	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
-	customvalidator "github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/validator"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
 )
 
 type userRepository interface {
 	Create(ctx context.Context, user domain.User) error
 	GetByID(ctx context.Context, id string) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
-	Update(ctx context.Context, user domain.User) error
-	Delete(ctx context.Context, id string) error
-	GetMany(ctx context.Context, limit, offset int) ([]domain.User, error)
+	Update(ctx context.Context, user domain.User) (domain.User, error)
+	Delete(ctx context.Context, id string, version int) error
+	Restore(ctx context.Context, id string) error
+	GetMany(ctx context.Context, list query.List, limit, offset int) ([]domain.User, error)
+	GetPage(ctx context.Context, list query.List, page query.Page) ([]domain.User, query.PageInfo, error)
+	Count(ctx context.Context, list query.List) (int, error)
 }
 
 type UserHTTPHandler struct {
 	userRepository userRepository
+	cursors        *cursor.Codec
+	maxLimit       int
+	tx             transactor
+	audit          auditLog
+	outbox         eventOutbox
+	imports        *userImport
+	invitations    *userInvitations
+	tombstones     erasedEmails
+	policies       policyDocuments
+	consents       consentRepository
+	batchMax       int
 }
 
+type Option func(h *UserHTTPHandler)
+
+// HeaderLink is the RFC 8288 web linking header.
+const HeaderLink = "Link"
+
+var (
+	stringOperators = []query.Operator{query.Eq, query.Ne, query.Gt, query.Ge, query.Lt, query.Le, query.Sw, query.Ew, query.Co}
+
+	// userListFields are the fields GetMany can filter and sort by.
+	userListFields = query.Fields{
+		"id":      {query.Eq, query.Ne, query.Gt, query.Ge, query.Lt, query.Le},
+		"name":    stringOperators,
+		"surname": stringOperators,
+		"email":   stringOperators,
+	}
+)
+
 const (
-	defaultLimit = "10"
-	defaultPage  = "0"
+	defaultLimit    = "10"
+	defaultPage     = "0"
+	defaultMaxLimit = 100
+
+	passwordCost = 14
 )
 
-func NewUserHTTPHandler(repository userRepository) *UserHTTPHandler {
-	return &UserHTTPHandler{
+// WithPagination sets the codec signing list cursors and the upper bound of
+// the `limit` query param. Without it cursors are signed with a random key,
+// which only works as long as a single instance serves all requests.
+func WithPagination(cursors *cursor.Codec, maxLimit int) Option {
+	return func(h *UserHTTPHandler) {
+		h.cursors = cursors
+		if maxLimit > 0 {
+			h.maxLimit = maxLimit
+		}
+	}
+}
+
+func NewUserHTTPHandler(repository userRepository, opts ...Option) *UserHTTPHandler {
+	h := &UserHTTPHandler{
 		userRepository: repository,
+		maxLimit:       defaultMaxLimit,
 	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if h.cursors == nil {
+		secret := make([]byte, 32)
+		_, _ = rand.Read(secret)
+		h.cursors = cursor.NewCodec(secret)
+	}
+
+	return h
 }
 
 func (h *UserHTTPHandler) Create(ec echo.Context) error {
@@ -50,39 +114,104 @@ func (h *UserHTTPHandler) Create(ec echo.Context) error {
 	var req *request.UserCreateRequest
 	if err := ec.Bind(&req); err != nil {
 		l.ErrorContext(ctx, err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
 	}
 
 	if err := ec.Validate(req); err != nil {
 		l.ErrorContext(ctx, err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	user := &domain.User{
+		ID:       xid.New().String(),
+		Name:     req.Name,
+		Surname:  req.Surname,
+		Email:    req.Email,
+		Password: req.Password,
 	}
 
-	user, err := h.userRepository.GetByEmail(ctx, req.Email)
+	return h.create(ec, user, req.AcceptedPolicies, nil)
+}
+
+// create creates user with their consents to policies and responds with
+// their ID. fn, if any, runs in the same transaction and may reject the
+// creation with an echo.HTTPError. Everyone signing up goes through it, see
+// Create and AcceptInvitation.
+func (h *UserHTTPHandler) create(ec echo.Context, user *domain.User, policies []string, fn func(ctx context.Context) error) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "Create")
+
+	existing, err := h.userRepository.GetByEmail(ctx, user.Email)
 	if err != nil {
 		l.ErrorContext(ctx, err.Error())
 		return echo.ErrInternalServerError
 	}
 
-	if user != nil {
-		return echo.NewHTTPError(http.StatusConflict, "user already exists")
+	if existing != nil {
+		return echo.NewHTTPError(http.StatusConflict, problem.CodeUserAlreadyExists)
 	}
 
-	id := xid.New().String()
-	err = h.userRepository.Create(ctx, domain.User{
-		ID:       id,
-		Name:     req.Name,
-		Surname:  req.Surname,
-		Email:    req.Email,
-		Password: req.Password,
+	var consents []domain.Consent
+	if h.consents != nil && len(policies) > 0 {
+		if consents, err = newConsents(ec, h.policies, user.ID, policies); err != nil {
+			return err
+		}
+	}
+
+	event := domain.AuditEvent{Action: domain.AuditActionCreate, TargetID: user.ID, Changes: domain.UserChanges(nil, user)}
+	err = h.mutate(ec, event, domain.UserEvents(nil, user), func(ctx context.Context) error {
+		if err := h.checkErased(ctx, user.Email); err != nil {
+			return err
+		}
+
+		if err := h.userRepository.Create(ctx, *user); err != nil {
+			return err
+		}
+
+		if len(consents) > 0 {
+			if err := h.consents.Add(ctx, consents...); err != nil {
+				return err
+			}
+		}
+
+		if fn != nil {
+			return fn(ctx)
+		}
+
+		return nil
 	})
 
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he
+	}
+
 	if err != nil {
 		l.ErrorContext(ctx, err.Error())
 		return echo.ErrInternalServerError
 	}
 
-	return ec.JSON(http.StatusCreated, &response.UserIDResponse{ID: id})
+	return ec.JSON(http.StatusCreated, &response.UserIDResponse{ID: user.ID})
+}
+
+// checkErased rejects email with an echo.HTTPError if it belongs to an erased
+// user, who must not be created again by sign up, batches, imports nor
+// invitations.
+func (h *UserHTTPHandler) checkErased(ctx context.Context, email string) error {
+	if h.tombstones == nil {
+		return nil
+	}
+
+	erased, err := h.tombstones.IsErased(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	if erased {
+		return echo.NewHTTPError(http.StatusConflict, problem.CodeUserErased)
+	}
+
+	return nil
 }
 
 func (h *UserHTTPHandler) GetByID(ec echo.Context) error {
@@ -97,15 +226,12 @@ func (h *UserHTTPHandler) GetByID(ec echo.Context) error {
 	}
 
 	if user == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "user not found")
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound)
 	}
 
-	return ec.JSON(http.StatusOK, &response.UserResponse{
-		ID:      user.ID,
-		Name:    user.Name,
-		Surname: user.Surname,
-		Email:   user.Email,
-	})
+	setUserValidators(ec, user)
+
+	return ec.JSON(http.StatusOK, userResponse(*user))
 }
 
 func (h *UserHTTPHandler) Update(ec echo.Context) error {
@@ -116,11 +242,11 @@ func (h *UserHTTPHandler) Update(ec echo.Context) error {
 	var req *request.UserUpdateRequest
 	if err := ec.Bind(&req); err != nil {
 		l.ErrorContext(ctx, err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
 	}
 
 	if err := ec.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
 	}
 
 	user, err := h.userRepository.GetByID(ctx, id)
@@ -130,17 +256,37 @@ func (h *UserHTTPHandler) Update(ec echo.Context) error {
 	}
 
 	if user == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "user not found")
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound)
+	}
+
+	if !ifMatch(ec, user) {
+		return echo.NewHTTPError(http.StatusPreconditionFailed, problem.CodeVersionMismatch)
 	}
 
+	before := *user
 	user.Name = req.Name
 	user.Surname = req.Surname
 	user.Email = req.Email
 
-	if err = h.userRepository.Update(ctx, *user); err != nil {
+	event := domain.AuditEvent{Action: domain.AuditActionUpdate, TargetID: id, Changes: domain.UserChanges(&before, user)}
+	err = h.mutate(ec, event, domain.UserEvents(&before, user), func(ctx context.Context) error {
+		saved, err := h.userRepository.Update(ctx, *user)
+		*user = saved
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrVersionMismatch):
+			return echo.NewHTTPError(http.StatusPreconditionFailed, problem.CodeVersionMismatch)
+		case ent.IsConstraintError(err):
+			return echo.NewHTTPError(http.StatusConflict, problem.CodeUserAlreadyExists)
+		}
+		l.ErrorContext(ctx, err.Error())
 		return echo.ErrInternalServerError
 	}
 
+	setUserValidators(ec, user)
+
 	return ec.JSON(http.StatusOK, &response.UserIDResponse{ID: id})
 }
 
@@ -153,19 +299,11 @@ func (h *UserHTTPHandler) UpdatePassword(ec echo.Context) error {
 	var req *request.UserUpdatePasswordRequest
 	if err := ec.Bind(&req); err != nil {
 		l.ErrorContext(ctx, err.Error())
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
 	}
 
 	if err := ec.Validate(req); err != nil {
-		var vErr validator.ValidationErrors
-		if errors.As(err, &vErr) {
-			for _, e := range vErr {
-				if e.Tag() == customvalidator.PasswordValidator {
-					return echo.NewHTTPError(http.StatusBadRequest, customvalidator.ErrPasswordValidation)
-				}
-			}
-		}
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
 	}
 
 	user, err := h.userRepository.GetByID(ctx, id)
@@ -175,22 +313,38 @@ func (h *UserHTTPHandler) UpdatePassword(ec echo.Context) error {
 	}
 
 	if user == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "user not found")
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound)
 	}
 
-	bytes, err := bcrypt.GenerateFromPassword([]byte(req.Password), 14)
+	if !ifMatch(ec, user) {
+		return echo.NewHTTPError(http.StatusPreconditionFailed, problem.CodeVersionMismatch)
+	}
+
+	hash, err := hashPassword(req.Password)
 	if err != nil {
 		l.ErrorContext(ctx, err.Error())
 		return echo.ErrInternalServerError
 	}
 
-	user.Password = string(bytes)
+	before := *user
+	user.Password = hash
 
-	if err = h.userRepository.Update(ctx, *user); err != nil {
+	event := domain.AuditEvent{Action: domain.AuditActionPasswordChange, TargetID: id}
+	err = h.mutate(ec, event, domain.UserEvents(&before, user), func(ctx context.Context) error {
+		saved, err := h.userRepository.Update(ctx, *user)
+		*user = saved
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return echo.NewHTTPError(http.StatusPreconditionFailed, problem.CodeVersionMismatch)
+		}
 		l.ErrorContext(ctx, err.Error())
 		return echo.ErrInternalServerError
 	}
 
+	setUserValidators(ec, user)
+
 	return ec.JSON(http.StatusOK, &response.UserIDResponse{ID: id})
 }
 
@@ -206,10 +360,21 @@ func (h *UserHTTPHandler) Delete(ec echo.Context) error {
 	}
 
 	if user == nil {
-		return echo.NewHTTPError(http.StatusNotFound, "user not found")
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound)
 	}
 
-	if err = h.userRepository.Delete(ctx, id); err != nil {
+	if !ifMatch(ec, user) {
+		return echo.NewHTTPError(http.StatusPreconditionFailed, problem.CodeVersionMismatch)
+	}
+
+	event := domain.AuditEvent{Action: domain.AuditActionDelete, TargetID: id, Changes: domain.UserChanges(user, nil)}
+	err = h.mutate(ec, event, domain.UserEvents(user, nil), func(ctx context.Context) error {
+		return h.userRepository.Delete(ctx, id, user.Version)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return echo.NewHTTPError(http.StatusPreconditionFailed, problem.CodeVersionMismatch)
+		}
 		l.ErrorContext(ctx, err.Error())
 		return echo.ErrInternalServerError
 	}
@@ -217,6 +382,36 @@ func (h *UserHTTPHandler) Delete(ec echo.Context) error {
 	return ec.NoContent(http.StatusNoContent)
 }
 
+// Restore undoes the deletion of a user, see the purge job for how long
+// deleted users are kept.
+func (h *UserHTTPHandler) Restore(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "Restore")
+
+	id := ec.Param("id")
+	event := domain.AuditEvent{Action: domain.AuditActionRestore, TargetID: id}
+	events := []domain.Event{domain.NewEvent(domain.UserRestored, id, domain.UserEventData{ID: id})}
+	err := h.mutate(ec, event, events, func(ctx context.Context) error {
+		return h.userRepository.Restore(ctx, id)
+	})
+	if err != nil {
+		switch {
+		case ent.IsNotFound(err):
+			return echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound)
+		case ent.IsConstraintError(err):
+			return echo.NewHTTPError(http.StatusConflict, problem.CodeUserAlreadyExists)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.JSON(http.StatusOK, &response.UserIDResponse{ID: id})
+}
+
+// GetMany lists users narrowed by the `filter` and ordered by the `sort`
+// query params. Requests with a `cursor` query param (empty for the first
+// page) get keyset pagination with a page envelope and `Link` header, others
+// keep the legacy offset mode returning a bare array.
 func (h *UserHTTPHandler) GetMany(ec echo.Context) error {
 	ctx := ec.Request().Context()
 	l := slog.Default().With("handler", "GetMany")
@@ -232,29 +427,226 @@ func (h *UserHTTPHandler) GetMany(ec echo.Context) error {
 
 	li, err := strconv.Atoi(limit)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "limit must be an integer")
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLimit).SetInternal(err)
+	}
+
+	if li < 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLimit)
+	}
+
+	li = min(li, h.maxLimit)
+
+	list, err := parseList(ec)
+	if err != nil {
+		return err
+	}
+
+	if ec.QueryParams().Has("cursor") {
+		return h.getPage(ec, list, li)
 	}
 
 	p, err := strconv.Atoi(page)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "offset must be an integer")
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidPage).SetInternal(err)
 	}
 
-	users, err := h.userRepository.GetMany(ctx, li, p)
+	users, err := h.userRepository.GetMany(ctx, list, li, p)
 	if err != nil {
+		if isQueryError(err) {
+			return queryError(err)
+		}
 		l.ErrorContext(ctx, err.Error())
 		return echo.ErrInternalServerError
 	}
 
-	responseUsers := make([]response.UserResponse, 0)
+	return ec.JSON(http.StatusOK, userResponses(users))
+}
+
+func (h *UserHTTPHandler) getPage(ec echo.Context, list query.List, limit int) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "GetMany")
+
+	sort := query.FormatSort(list.Sort)
+
+	pq := query.Page{Limit: limit}
+	if token := ec.QueryParam("cursor"); token != "" {
+		cur, err := h.cursors.Decode(token)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidCursor).SetInternal(err)
+		}
+
+		if cur.Sort != sort {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidCursor)
+		}
+
+		if cur.Direction == cursor.Prev {
+			pq.Before = cur.Keys
+		} else {
+			pq.After = cur.Keys
+		}
+	}
+
+	var withTotal bool
+	if total := ec.QueryParam("total"); total != "" {
+		var err error
+		if withTotal, err = strconv.ParseBool(total); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidTotal).SetInternal(err)
+		}
+	}
+
+	users, info, err := h.userRepository.GetPage(ctx, list, pq)
+	if err != nil {
+		if errors.Is(err, query.ErrInvalidPage) {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidCursor).SetInternal(err)
+		}
+		if isQueryError(err) {
+			return queryError(err)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	res := response.UserPageResponse{
+		Data: userResponses(users),
+	}
+
+	if withTotal {
+		total, err := h.userRepository.Count(ctx, list)
+		if err != nil {
+			l.ErrorContext(ctx, err.Error())
+			return echo.ErrInternalServerError
+		}
+		res.Total = &total
+	}
+
+	if len(users) > 0 {
+		hasNext, hasPrev := info.HasMore, len(pq.After) > 0
+		if pq.Backward() {
+			hasNext, hasPrev = true, info.HasMore
+		}
+
+		if hasNext {
+			if res.Links.Next, err = h.pageLink(ec, cursor.Cursor{Keys: info.Last, Sort: sort, Direction: cursor.Next}); err != nil {
+				l.ErrorContext(ctx, err.Error())
+				return echo.ErrInternalServerError
+			}
+		}
+
+		if hasPrev {
+			if res.Links.Prev, err = h.pageLink(ec, cursor.Cursor{Keys: info.First, Sort: sort, Direction: cursor.Prev}); err != nil {
+				l.ErrorContext(ctx, err.Error())
+				return echo.ErrInternalServerError
+			}
+		}
+	}
+
+	var links []string
+	if res.Links.Next != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, res.Links.Next))
+	}
+
+	if res.Links.Prev != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, res.Links.Prev))
+	}
+
+	if len(links) > 0 {
+		ec.Response().Header().Set(HeaderLink, strings.Join(links, ", "))
+	}
+
+	return ec.JSON(http.StatusOK, res)
+}
+
+// parseList reads the `filter`, `sort` and `include_deleted` query params.
+func parseList(ec echo.Context) (query.List, error) {
+	var (
+		list query.List
+		err  error
+	)
+
+	if includeDeleted := ec.QueryParam("include_deleted"); includeDeleted != "" {
+		if list.IncludeDeleted, err = strconv.ParseBool(includeDeleted); err != nil {
+			return list, echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidIncludeDeleted).SetInternal(err)
+		}
+	}
+
+	if list.Filter, err = query.ParseFilter(ec.QueryParam("filter"), userListFields); err != nil {
+		return list, queryError(err)
+	}
+
+	if list.Sort, err = query.ParseSort(ec.QueryParam("sort"), userListFields); err != nil {
+		return list, queryError(err)
+	}
+
+	return list, nil
+}
+
+// isQueryError reports a filter or sort the repository cannot run although
+// it parsed, such as one on encrypted fields.
+func isQueryError(err error) bool {
+	return errors.Is(err, query.ErrUnknownField) ||
+		errors.Is(err, query.ErrUnknownOperator) ||
+		errors.Is(err, query.ErrInvalidSort) ||
+		errors.Is(err, query.ErrInvalidFilter)
+}
+
+func queryError(err error) *echo.HTTPError {
+	var msg problem.Message
+
+	var fErr *query.FieldError
+	switch {
+	case errors.Is(err, query.ErrUnknownField) && errors.As(err, &fErr):
+		msg = problem.Message{Code: problem.CodeUnknownField, Params: []string{fErr.Field}}
+	case errors.Is(err, query.ErrUnknownOperator) && errors.As(err, &fErr):
+		msg = problem.Message{Code: problem.CodeUnknownOperator, Params: []string{fErr.Value, fErr.Field}}
+	case errors.Is(err, query.ErrInvalidSort) && errors.As(err, &fErr):
+		msg = problem.Message{Code: problem.CodeInvalidSort, Params: []string{fErr.Field}}
+	default:
+		msg = problem.Message{Code: problem.CodeInvalidFilter, Params: []string{strings.TrimPrefix(err.Error(), query.ErrInvalidFilter.Error()+": ")}}
+	}
+
+	return echo.NewHTTPError(http.StatusBadRequest, msg).SetInternal(err)
+}
+
+// pageLink returns the request URL with the cursor param replaced.
+func (h *UserHTTPHandler) pageLink(ec echo.Context, cur cursor.Cursor) (string, error) {
+	token, err := h.cursors.Encode(cur)
+	if err != nil {
+		return "", err
+	}
+
+	q := ec.Request().URL.Query()
+	q.Set("cursor", token)
+
+	return ec.Request().URL.Path + "?" + q.Encode(), nil
+}
+
+func userResponse(user domain.User) response.UserResponse {
+	return response.UserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Surname:   user.Surname,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		Version:   user.Version,
+		DeletedAt: user.DeletedAt,
+	}
+}
+
+func userResponses(users []domain.User) []response.UserResponse {
+	responseUsers := make([]response.UserResponse, 0, len(users))
 	for _, user := range users {
-		responseUsers = append(responseUsers, response.UserResponse{
-			ID:      user.ID,
-			Name:    user.Name,
-			Surname: user.Surname,
-			Email:   user.Email,
-		})
+		responseUsers = append(responseUsers, userResponse(user))
+	}
+
+	return responseUsers
+}
+
+func hashPassword(password string) (string, error) {
+	b, err := bcrypt.GenerateFromPassword([]byte(password), passwordCost)
+	if err != nil {
+		return "", err
 	}
 
-	return ec.JSON(http.StatusOK, responseUsers)
+	return string(b), nil
 }
@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/xid"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/request"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/i18n"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+// errBatchFailed rolls back an atomic batch once an operation failed.
+var errBatchFailed = errors.New("batch operation failed")
+
+// WithBatch enables batches of up to maxSize operations, tx runs the atomic
+// ones.
+func WithBatch(tx transactor, maxSize int) Option {
+	return func(h *UserHTTPHandler) {
+		h.tx = tx
+		h.batchMax = maxSize
+	}
+}
+
+// Batch runs the create, update and delete operations of the body in order.
+// Operations are independent and the response is a 207 with the result of
+// each, unless the batch is atomic: it is then run in a single transaction
+// responding 200 if all operations succeed, or with the status of the first
+// failed one if it is rolled back.
+func (h *UserHTTPHandler) Batch(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "Batch")
+
+	if h.batchMax < 1 {
+		return echo.ErrNotFound
+	}
+
+	var req *request.UserBatchRequest
+	if err := ec.Bind(&req); err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if req != nil && len(req.Operations) > h.batchMax {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.Message{Code: problem.CodeBatchTooLarge, Params: []string{strconv.Itoa(h.batchMax)}})
+	}
+
+	if err := ec.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	trans, _ := i18n.Negotiated(ec)
+	result := func(status int, id string, err error) response.UserBatchResult {
+		if err == nil {
+			return response.UserBatchResult{Status: status, ID: id}
+		}
+
+		he := batchError(err)
+		if he.Code >= http.StatusInternalServerError {
+			l.ErrorContext(ctx, err.Error())
+		}
+
+		return response.UserBatchResult{Status: he.Code, Error: problem.FromError(he, trans)}
+	}
+
+	results := make([]response.UserBatchResult, len(req.Operations))
+
+	if !req.Atomic {
+		for i, op := range req.Operations {
+			prepared, err := h.prepareBatchOperation(ec, op)
+			if err != nil {
+				results[i] = result(0, "", err)
+				continue
+			}
+
+			results[i] = result(h.batchOperation(ctx, ec, prepared))
+		}
+
+		return ec.JSON(http.StatusMultiStatus, &response.UserBatchResponse{Results: results})
+	}
+
+	// Operations are decoded, validated and their passwords hashed before
+	// the transaction, which holds the audit lock, so it only waits on the
+	// database.
+	failed := -1
+	prepared := make([]batchOperation, len(req.Operations))
+	for i, op := range req.Operations {
+		var err error
+		if prepared[i], err = h.prepareBatchOperation(ec, op); err != nil {
+			results[i] = result(0, "", err)
+			failed = i
+			break
+		}
+	}
+
+	var err error
+	if failed < 0 {
+		err = h.tx.InTx(ctx, func(ctx context.Context) error {
+			for i, op := range prepared {
+				if results[i] = result(h.batchOperation(ctx, ec, op)); results[i].Error != nil {
+					failed = i
+					return errBatchFailed
+				}
+			}
+
+			return nil
+		})
+	}
+
+	if failed < 0 {
+		if err != nil {
+			l.ErrorContext(ctx, err.Error())
+			return echo.ErrInternalServerError
+		}
+
+		return ec.JSON(http.StatusOK, &response.UserBatchResponse{Results: results})
+	}
+
+	// errBatchFailed is wrapped if the rollback failed.
+	if err != nil && err != errBatchFailed {
+		l.ErrorContext(ctx, err.Error())
+	}
+
+	// The other operations are rolled back or not run.
+	for i := range results {
+		if i != failed {
+			results[i] = response.UserBatchResult{Status: http.StatusFailedDependency}
+		}
+	}
+
+	return ec.JSON(results[failed].Status, &response.UserBatchResponse{Results: results})
+}
+
+// batchOperation is an operation of a batch ready to be run, with its body
+// decoded and validated and the password of a created user hashed.
+type batchOperation struct {
+	request.UserBatchOperation
+	create request.UserCreateRequest
+	update request.UserUpdateRequest
+}
+
+// prepareBatchOperation does the work of op that doesn't need the database,
+// it returns an echo.HTTPError if op is rejected.
+func (h *UserHTTPHandler) prepareBatchOperation(ec echo.Context, op request.UserBatchOperation) (batchOperation, error) {
+	prepared := batchOperation{UserBatchOperation: op}
+
+	switch op.Op {
+	case request.BatchCreate:
+		if err := h.batchBody(ec, op, &prepared.create); err != nil {
+			return prepared, err
+		}
+
+		hash, err := hashPassword(prepared.create.Password)
+		if err != nil {
+			return prepared, err
+		}
+
+		prepared.create.Password = hash
+	case request.BatchUpdate:
+		if err := h.batchBody(ec, op, &prepared.update); err != nil {
+			return prepared, err
+		}
+	}
+
+	return prepared, nil
+}
+
+// batchOperation runs op like its single counterpart, returning the status
+// and the id it would respond with. Rejected operations return an
+// echo.HTTPError.
+func (h *UserHTTPHandler) batchOperation(ctx context.Context, ec echo.Context, op batchOperation) (int, string, error) {
+	switch op.Op {
+	case request.BatchCreate:
+		req := op.create
+
+		if err := h.checkErased(ctx, req.Email); err != nil {
+			return 0, "", err
+		}
+
+		user, err := h.userRepository.GetByEmail(ctx, req.Email)
+		if err != nil {
+			return 0, "", err
+		}
+
+		if user != nil {
+			return 0, "", echo.NewHTTPError(http.StatusConflict, problem.CodeUserAlreadyExists)
+		}
+
+		user = &domain.User{
+			ID:       xid.New().String(),
+			Name:     req.Name,
+			Surname:  req.Surname,
+			Email:    req.Email,
+			Password: req.Password,
+		}
+
+		event := domain.AuditEvent{Action: domain.AuditActionCreate, TargetID: user.ID, Changes: domain.UserChanges(nil, user)}
+		err = h.inTx(ctx, requestAuditEvent(ec, event), domain.UserEvents(nil, user), func(ctx context.Context) error {
+			return h.userRepository.Create(ctx, *user)
+		})
+
+		return http.StatusCreated, user.ID, err
+	case request.BatchUpdate:
+		req := op.update
+
+		user, err := h.batchUser(ctx, op.UserBatchOperation)
+		if err != nil {
+			return 0, "", err
+		}
+
+		before := *user
+		user.Name = req.Name
+		user.Surname = req.Surname
+		user.Email = req.Email
+
+		event := domain.AuditEvent{Action: domain.AuditActionUpdate, TargetID: user.ID, Changes: domain.UserChanges(&before, user)}
+		err = h.inTx(ctx, requestAuditEvent(ec, event), domain.UserEvents(&before, user), func(ctx context.Context) error {
+			_, err := h.userRepository.Update(ctx, *user)
+			return err
+		})
+
+		return http.StatusOK, user.ID, err
+	default:
+		user, err := h.batchUser(ctx, op.UserBatchOperation)
+		if err != nil {
+			return 0, "", err
+		}
+
+		event := domain.AuditEvent{Action: domain.AuditActionDelete, TargetID: user.ID, Changes: domain.UserChanges(user, nil)}
+		err = h.inTx(ctx, requestAuditEvent(ec, event), domain.UserEvents(user, nil), func(ctx context.Context) error {
+			return h.userRepository.Delete(ctx, user.ID, user.Version)
+		})
+
+		return http.StatusNoContent, "", err
+	}
+}
+
+// batchBody decodes and validates the body of op into req.
+func (h *UserHTTPHandler) batchBody(ec echo.Context, op request.UserBatchOperation, req any) error {
+	if err := json.Unmarshal(op.Body, req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if err := ec.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	return nil
+}
+
+// batchUser returns the user op updates or deletes, if it is at the version
+// op is based on.
+func (h *UserHTTPHandler) batchUser(ctx context.Context, op request.UserBatchOperation) (*domain.User, error) {
+	user, err := h.userRepository.GetByID(ctx, op.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound)
+	}
+
+	if op.Version != nil && *op.Version != user.Version {
+		return nil, echo.NewHTTPError(http.StatusPreconditionFailed, problem.CodeVersionMismatch)
+	}
+
+	return user, nil
+}
+
+// batchError maps the errors of batchOperation to the response its single
+// counterpart would make.
+func batchError(err error) *echo.HTTPError {
+	var he *echo.HTTPError
+	switch {
+	case errors.As(err, &he):
+		return he
+	case errors.Is(err, domain.ErrVersionMismatch):
+		return echo.NewHTTPError(http.StatusPreconditionFailed, problem.CodeVersionMismatch).SetInternal(err)
+	case ent.IsConstraintError(err):
+		return echo.NewHTTPError(http.StatusConflict, problem.CodeUserAlreadyExists).SetInternal(err)
+	default:
+		return echo.ErrInternalServerError
+	}
+}
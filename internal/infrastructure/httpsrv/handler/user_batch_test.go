@@ -0,0 +1,226 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+func TestUserHTTPHandler_Batch(t *testing.T) {
+	e := echo.New()
+	v := &requestValidator{
+		Validator: validator.New(),
+	}
+	v.Validator.RegisterTagNameFunc(problem.JSONTagName)
+	e.Validator = v
+
+	user := func() *domain.User {
+		return &domain.User{ID: "1", Name: "Test", Surname: "Test", Email: "test@test.pl", Version: 2}
+	}
+
+	batch := func(h *handler.UserHTTPHandler, body string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodPost, "/users:batch", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+
+		return res, h.Batch(e.NewContext(req, res))
+	}
+
+	statuses := func(t *testing.T, res *httptest.ResponseRecorder) []int {
+		var body response.UserBatchResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+
+		var got []int
+		for _, r := range body.Results {
+			got = append(got, r.Status)
+		}
+
+		return got
+	}
+
+	t.Run("Independent", func(t *testing.T) {
+		rm := new(repositoryMock)
+		tx := new(transactorMock)
+		h := handler.NewUserHTTPHandler(rm, handler.WithBatch(tx, 10))
+
+		rm.On("GetByEmail", mock.Anything, "new@test.pl").Return(nil, nil).Once()
+		rm.On("Create", mock.Anything, mock.MatchedBy(func(u domain.User) bool {
+			return u.Email == "new@test.pl" && u.Password != "1Password." && u.ID != ""
+		})).Return(nil).Once()
+		rm.On("GetByID", mock.Anything, "2").Return(nil, nil).Once()
+		rm.On("GetByID", mock.Anything, "1").Return(user(), nil).Once()
+
+		res, err := batch(h, `{"operations":[
+			{"op":"create","body":{"name":"New","surname":"User","email":"new@test.pl","password":"1Password."}},
+			{"op":"update","id":"2","body":{"email":"x@test.pl"}},
+			{"op":"delete","id":"1","version":1},
+			{"op":"update","id":"1","body":{"email":"invalid"}}
+		]}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusMultiStatus, res.Code)
+		assert.Equal(t, []int{http.StatusCreated, http.StatusNotFound, http.StatusPreconditionFailed, http.StatusBadRequest}, statuses(t, res))
+
+		var body response.UserBatchResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.NotEmpty(t, body.Results[0].ID)
+		assert.Equal(t, problem.CodeUserNotFound, body.Results[1].Error.Code)
+		assert.Equal(t, problem.CodeVersionMismatch, body.Results[2].Error.Code)
+		assert.Equal(t, problem.CodeValidationFailed, body.Results[3].Error.Code)
+		assert.Equal(t, "email", body.Results[3].Error.Errors[0].Field)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Erased", func(t *testing.T) {
+		rm := new(repositoryMock)
+		tx := new(transactorMock)
+		tombstones := new(erasedEmailsMock)
+		h := handler.NewUserHTTPHandler(rm, handler.WithBatch(tx, 10), handler.WithErasureTombstones(tombstones))
+
+		tombstones.On("IsErased", mock.Anything, "erased@test.pl").Return(true, nil).Once()
+
+		res, err := batch(h, `{"operations":[
+			{"op":"create","body":{"name":"New","surname":"User","email":"erased@test.pl","password":"1Password."}}
+		]}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusMultiStatus, res.Code)
+		assert.Equal(t, []int{http.StatusConflict}, statuses(t, res))
+
+		var body response.UserBatchResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, problem.CodeUserErased, body.Results[0].Error.Code)
+		rm.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		tombstones.AssertExpectations(t)
+	})
+
+	t.Run("Atomic", func(t *testing.T) {
+		rm := new(repositoryMock)
+		tx := new(transactorMock)
+		h := handler.NewUserHTTPHandler(rm, handler.WithBatch(tx, 10))
+
+		rm.On("GetByID", inTx, "1").Return(user(), nil).Twice()
+		rm.On("Update", inTx, mock.MatchedBy(func(u domain.User) bool { return u.Email == "new@test.pl" })).Return(nil).Once()
+		rm.On("Delete", inTx, "1", 2).Return(nil).Once()
+
+		res, err := batch(h, `{"atomic":true,"operations":[
+			{"op":"update","id":"1","version":2,"body":{"email":"new@test.pl"}},
+			{"op":"delete","id":"1"}
+		]}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, []int{http.StatusOK, http.StatusNoContent}, statuses(t, res))
+		assert.True(t, tx.committed)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Atomic rolled back", func(t *testing.T) {
+		rm := new(repositoryMock)
+		tx := new(transactorMock)
+		h := handler.NewUserHTTPHandler(rm, handler.WithBatch(tx, 10))
+
+		rm.On("GetByID", inTx, "1").Return(user(), nil).Once()
+		rm.On("Delete", inTx, "1", 2).Return(nil).Once()
+		rm.On("GetByID", inTx, "2").Return(nil, nil).Once()
+
+		res, err := batch(h, `{"atomic":true,"operations":[
+			{"op":"delete","id":"1"},
+			{"op":"delete","id":"2"},
+			{"op":"delete","id":"3"}
+		]}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, []int{http.StatusFailedDependency, http.StatusNotFound, http.StatusFailedDependency}, statuses(t, res))
+		assert.False(t, tx.committed)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Atomic invalid", func(t *testing.T) {
+		rm := new(repositoryMock)
+		tx := new(transactorMock)
+		h := handler.NewUserHTTPHandler(rm, handler.WithBatch(tx, 10))
+
+		// Rejected before the transaction, the repository isn't called.
+		res, err := batch(h, `{"atomic":true,"operations":[
+			{"op":"create","body":{"name":"New","surname":"User","email":"new@test.pl","password":"1Password."}},
+			{"op":"update","id":"1","body":{"email":"invalid"}}
+		]}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, []int{http.StatusFailedDependency, http.StatusBadRequest}, statuses(t, res))
+		assert.False(t, tx.committed)
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Atomic error", func(t *testing.T) {
+		rm := new(repositoryMock)
+		tx := new(transactorMock)
+		h := handler.NewUserHTTPHandler(rm, handler.WithBatch(tx, 10))
+
+		rm.On("GetByID", inTx, "1").Return(user(), nil).Once()
+		rm.On("Delete", inTx, "1", 2).Return(assert.AnError).Once()
+
+		res, err := batch(h, `{"atomic":true,"operations":[{"op":"delete","id":"1"}]}`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		assert.NotContains(t, res.Body.String(), assert.AnError.Error())
+	})
+
+	t.Run("Too large", func(t *testing.T) {
+		h := handler.NewUserHTTPHandler(new(repositoryMock), handler.WithBatch(new(transactorMock), 2))
+
+		_, err := batch(h, `{"operations":[{"op":"delete","id":"1"},{"op":"delete","id":"2"},{"op":"delete","id":"3"}]}`)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, problem.Message{Code: problem.CodeBatchTooLarge, Params: []string{"2"}}, he.Message)
+	})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "No operations", body: `{"operations":[]}`},
+		{name: "Unknown operation", body: `{"operations":[{"op":"merge","id":"1"}]}`},
+		{name: "Missing id", body: `{"operations":[{"op":"delete"}]}`},
+		{name: "Missing body", body: `{"operations":[{"op":"create"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := handler.NewUserHTTPHandler(new(repositoryMock), handler.WithBatch(new(transactorMock), 10))
+
+			_, err := batch(h, tt.body)
+
+			var he *echo.HTTPError
+			assert.ErrorAs(t, err, &he)
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+			assert.Equal(t, problem.CodeValidationFailed, he.Message)
+		})
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		h := handler.NewUserHTTPHandler(new(repositoryMock))
+
+		_, err := batch(h, `{"operations":[{"op":"delete","id":"1"}]}`)
+
+		assert.Equal(t, echo.ErrNotFound, err)
+	})
+}
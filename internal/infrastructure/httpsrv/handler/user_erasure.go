@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type erasureUsers interface {
+	GetByIDWithDeleted(ctx context.Context, id string) (*domain.User, error)
+}
+
+type erasureAuditLog interface {
+	Append(ctx context.Context, event domain.AuditEvent) error
+	Pseudonymize(ctx context.Context, userID string) (map[int64]string, error)
+}
+
+type dataHolders interface {
+	Erase(ctx context.Context, user domain.User) ([]domain.ErasureRecords, error)
+}
+
+type erasureTombstones interface {
+	Add(ctx context.Context, user domain.User, erasedAt time.Time) (string, error)
+}
+
+type ErasureHTTPHandler struct {
+	users      erasureUsers
+	tx         transactor
+	audit      erasureAuditLog
+	outbox     eventOutbox
+	holders    dataHolders
+	tombstones erasureTombstones
+	secret     []byte
+}
+
+// NewErasureHTTPHandler signs erasure certificates with secret, a random one
+// if it is empty.
+func NewErasureHTTPHandler(users erasureUsers, tx transactor, audit erasureAuditLog, outbox eventOutbox, holders dataHolders, tombstones erasureTombstones, secret []byte) *ErasureHTTPHandler {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		_, _ = rand.Read(secret)
+	}
+
+	return &ErasureHTTPHandler{
+		users:      users,
+		tx:         tx,
+		audit:      audit,
+		outbox:     outbox,
+		holders:    holders,
+		tombstones: tombstones,
+		secret:     secret,
+	}
+}
+
+// Erase fulfils the right to erasure of a user, deleted or not. In a single
+// transaction every data holder deletes or pseudonymizes what it holds about
+// the user, including the audit log, a tombstone keeps the hash of their
+// email and the signed erasure certificate is appended to the audit log.
+func (h *ErasureHTTPHandler) Erase(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "Erase")
+
+	id := ec.Param("id")
+	user, err := h.users.GetByIDWithDeleted(ctx, id)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if user == nil {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound)
+	}
+
+	// The certificate is hashed in the audit log, which keeps microseconds.
+	cert := domain.ErasureCertificate{UserID: id, ErasedAt: time.Now().UTC().Truncate(time.Microsecond)}
+	err = h.tx.InTx(ctx, func(ctx context.Context) error {
+		records, err := h.holders.Erase(ctx, *user)
+		if err != nil {
+			return err
+		}
+
+		redactions, err := h.audit.Pseudonymize(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		cert.Records = append(records, domain.ErasureRecords{
+			Holder:  "audit_events",
+			Method:  domain.ErasurePseudonymized,
+			Records: len(redactions),
+		})
+		cert.AuditRedactions = redactions
+
+		if cert.EmailHash, err = h.tombstones.Add(ctx, *user, cert.ErasedAt); err != nil {
+			return err
+		}
+
+		cert.Signature = cert.Sign(h.secret)
+
+		event := requestAuditEvent(ec, domain.AuditEvent{Action: domain.AuditActionErase, TargetID: id, Certificate: &cert})
+		// Users erasing themselves leave no trace of their client either.
+		if event.Actor == id {
+			event.ClientIP = ""
+			event.UserAgent = ""
+		}
+
+		if err = h.audit.Append(ctx, event); err != nil {
+			return err
+		}
+
+		return h.outbox.Add(ctx, domain.NewEvent(domain.UserErased, id, domain.UserEventData{ID: id}))
+	})
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.JSON(http.StatusOK, erasureCertificateResponse(cert))
+}
+
+func erasureCertificateResponse(cert domain.ErasureCertificate) response.ErasureCertificateResponse {
+	res := response.ErasureCertificateResponse{
+		UserID:          cert.UserID,
+		EmailHash:       cert.EmailHash,
+		ErasedAt:        cert.ErasedAt,
+		Records:         make([]response.ErasureRecordsResponse, 0, len(cert.Records)),
+		AuditRedactions: cert.AuditRedactions,
+		Signature:       cert.Signature,
+	}
+
+	for _, r := range cert.Records {
+		res.Records = append(res.Records, response.ErasureRecordsResponse{Holder: r.Holder, Method: string(r.Method), Records: r.Records})
+	}
+
+	return res
+}
@@ -0,0 +1,190 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type erasureUsersMock struct {
+	mock.Mock
+}
+
+func (m *erasureUsersMock) GetByIDWithDeleted(ctx context.Context, id string) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+type erasureAuditMock struct {
+	auditLogMock
+}
+
+func (m *erasureAuditMock) Pseudonymize(ctx context.Context, userID string) (map[int64]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]string), args.Error(1)
+}
+
+type dataHoldersMock struct {
+	mock.Mock
+}
+
+func (m *dataHoldersMock) Erase(ctx context.Context, user domain.User) ([]domain.ErasureRecords, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ErasureRecords), args.Error(1)
+}
+
+type tombstonesMock struct {
+	mock.Mock
+}
+
+func (m *tombstonesMock) Add(ctx context.Context, user domain.User, erasedAt time.Time) (string, error) {
+	args := m.Called(ctx, user, erasedAt)
+	return args.String(0), args.Error(1)
+}
+
+func TestErasureHTTPHandler_Erase(t *testing.T) {
+	e := echo.New()
+	secret := []byte("secret")
+	user := &domain.User{ID: "1", Email: "john@doe.com"}
+
+	erase := func(h *handler.ErasureHTTPHandler, actor string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodPost, "/users/1/erasure", nil)
+		req.Header.Set(handler.HeaderActorID, actor)
+		req.Header.Set("User-Agent", "test")
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		return res, h.Erase(ec)
+	}
+
+	t.Run("Erase", func(t *testing.T) {
+		um := new(erasureUsersMock)
+		tx := new(transactorMock)
+		am := new(erasureAuditMock)
+		om := new(outboxMock)
+		hm := new(dataHoldersMock)
+		tm := new(tombstonesMock)
+		h := handler.NewErasureHTTPHandler(um, tx, am, om, hm, tm, secret)
+
+		um.On("GetByIDWithDeleted", mock.Anything, "1").Return(user, nil).Once()
+		hm.On("Erase", inTx, *user).Return([]domain.ErasureRecords{{Holder: "users", Method: domain.ErasureDeleted, Records: 1}}, nil).Once()
+		am.On("Pseudonymize", inTx, "1").Return(map[int64]string{4: "hash"}, nil).Once()
+		tm.On("Add", inTx, *user, mock.AnythingOfType("time.Time")).Return("email-hash", nil).Once()
+		am.On("Append", inTx, mock.MatchedBy(func(event domain.AuditEvent) bool {
+			return event.Action == domain.AuditActionErase && event.TargetID == "1" && event.Changes == nil &&
+				event.ClientIP == "" && event.UserAgent == "" &&
+				event.Certificate != nil && event.Certificate.Verify(secret)
+		})).Return(nil).Once()
+		om.On("Add", inTx, eventTypes(domain.UserErased)).Return(nil).Once()
+
+		res, err := erase(h, "1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.True(t, tx.committed)
+
+		var body response.ErasureCertificateResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, "1", body.UserID)
+		assert.Equal(t, "email-hash", body.EmailHash)
+		assert.Equal(t, []response.ErasureRecordsResponse{
+			{Holder: "users", Method: "deleted", Records: 1},
+			{Holder: "audit_events", Method: "pseudonymized", Records: 1},
+		}, body.Records)
+		assert.Equal(t, map[int64]string{4: "hash"}, body.AuditRedactions)
+		assert.NotContains(t, res.Body.String(), "john@doe.com")
+
+		cert := domain.ErasureCertificate{
+			UserID:          body.UserID,
+			EmailHash:       body.EmailHash,
+			ErasedAt:        body.ErasedAt,
+			Records:         []domain.ErasureRecords{{Holder: "users", Method: domain.ErasureDeleted, Records: 1}, {Holder: "audit_events", Method: domain.ErasurePseudonymized, Records: 1}},
+			AuditRedactions: body.AuditRedactions,
+			Signature:       body.Signature,
+		}
+		assert.True(t, cert.Verify(secret))
+		assert.False(t, cert.Verify([]byte("other")))
+
+		hm.AssertExpectations(t)
+		am.AssertExpectations(t)
+		tm.AssertExpectations(t)
+		om.AssertExpectations(t)
+	})
+
+	t.Run("Erased by someone else", func(t *testing.T) {
+		um := new(erasureUsersMock)
+		am := new(erasureAuditMock)
+		om := new(outboxMock)
+		hm := new(dataHoldersMock)
+		tm := new(tombstonesMock)
+		h := handler.NewErasureHTTPHandler(um, new(transactorMock), am, om, hm, tm, secret)
+
+		um.On("GetByIDWithDeleted", mock.Anything, "1").Return(user, nil).Once()
+		hm.On("Erase", inTx, *user).Return([]domain.ErasureRecords{}, nil).Once()
+		am.On("Pseudonymize", inTx, "1").Return(map[int64]string{}, nil).Once()
+		tm.On("Add", inTx, *user, mock.Anything).Return("email-hash", nil).Once()
+		am.On("Append", inTx, mock.MatchedBy(func(event domain.AuditEvent) bool {
+			return event.Actor == "admin" && event.UserAgent == "test"
+		})).Return(nil).Once()
+		om.On("Add", inTx, mock.Anything).Return(nil).Once()
+
+		_, err := erase(h, "admin")
+
+		assert.NoError(t, err)
+		am.AssertExpectations(t)
+	})
+
+	t.Run("Holder error", func(t *testing.T) {
+		um := new(erasureUsersMock)
+		tx := new(transactorMock)
+		am := new(erasureAuditMock)
+		hm := new(dataHoldersMock)
+		h := handler.NewErasureHTTPHandler(um, tx, am, new(outboxMock), hm, new(tombstonesMock), secret)
+
+		um.On("GetByIDWithDeleted", mock.Anything, "1").Return(user, nil).Once()
+		hm.On("Erase", inTx, *user).Return(nil, assert.AnError).Once()
+
+		_, err := erase(h, "")
+
+		assert.Equal(t, echo.ErrInternalServerError, err)
+		assert.False(t, tx.committed)
+		am.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		um := new(erasureUsersMock)
+		h := handler.NewErasureHTTPHandler(um, new(transactorMock), new(erasureAuditMock), new(outboxMock), new(dataHoldersMock), new(tombstonesMock), secret)
+
+		um.On("GetByIDWithDeleted", mock.Anything, "1").Return(nil, nil).Once()
+
+		_, err := erase(h, "")
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, problem.CodeUserNotFound, he.Message)
+	})
+}
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+const (
+	HeaderLastEventID = "Last-Event-ID"
+
+	MIMETextEventStream = "text/event-stream"
+)
+
+const (
+	defaultHeartbeat = 15 * time.Second
+	// eventBacklogPage is how many events are replayed per query on resume.
+	eventBacklogPage = 500
+	// eventBuffer is how many live events a stream may lag behind before it
+	// is closed, the client then resumes from its last event.
+	eventBuffer = 256
+	// eventRetry is the reconnection delay advised to clients, in ms.
+	eventRetry = 2000
+)
+
+type eventLog interface {
+	After(ctx context.Context, sequence int64, limit int) ([]domain.Event, error)
+}
+
+type eventHub interface {
+	Subscribe(organizationID string, buffer int) (<-chan domain.Event, func())
+}
+
+type UserEventsHTTPHandler struct {
+	events    eventLog
+	hub       eventHub
+	heartbeat time.Duration
+}
+
+func NewUserEventsHTTPHandler(events eventLog, hub eventHub, heartbeat time.Duration) *UserEventsHTTPHandler {
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+
+	return &UserEventsHTTPHandler{
+		events:    events,
+		hub:       hub,
+		heartbeat: heartbeat,
+	}
+}
+
+// Stream sends the user events of the organization of the request as
+// Server-Sent Events, optionally only those of the comma separated `types`.
+// Events are identified by their sequence, a client resuming with the
+// `Last-Event-ID` header (or `last_event_id` query param) first gets the
+// events it missed that are still retained.
+func (h *UserEventsHTTPHandler) Stream(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "UserEvents")
+
+	types, err := parseEventTypes(ec.QueryParam("types"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidEventType).SetInternal(err)
+	}
+
+	lastID := ec.Request().Header.Get(HeaderLastEventID)
+	if lastID == "" {
+		lastID = ec.QueryParam("last_event_id")
+	}
+
+	var last int64
+	if lastID != "" {
+		if last, err = strconv.ParseInt(lastID, 10, 64); err != nil || last < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLastEventID).SetInternal(err)
+		}
+	}
+
+	// Subscribing before replaying the backlog ensures no event published in
+	// between is missed, the overlap is skipped below.
+	organizationID, _ := tenant.FromContext(ctx)
+	live, unsubscribe := h.hub.Subscribe(organizationID, eventBuffer)
+	defer unsubscribe()
+
+	res := ec.Response()
+	res.Header().Set(echo.HeaderContentType, MIMETextEventStream)
+	res.Header().Set(echo.HeaderCacheControl, "no-store")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if _, err = fmt.Fprintf(res, "retry: %d\n\n", eventRetry); err != nil {
+		return nil
+	}
+	res.Flush()
+
+	replayed := make(map[int64]struct{})
+	if lastID != "" {
+		for {
+			events, err := h.events.After(ctx, last, eventBacklogPage)
+			if err != nil {
+				l.ErrorContext(ctx, err.Error())
+				return nil
+			}
+
+			for _, e := range events {
+				replayed[e.Sequence] = struct{}{}
+				last = e.Sequence
+
+				if err = writeEvent(res, e, types); err != nil {
+					return nil
+				}
+			}
+			res.Flush()
+
+			if len(events) < eventBacklogPage {
+				break
+			}
+		}
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err = fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+		case e, ok := <-live:
+			if !ok {
+				return nil
+			}
+
+			if _, ok = replayed[e.Sequence]; ok {
+				delete(replayed, e.Sequence)
+				continue
+			}
+
+			if err = writeEvent(res, e, types); err != nil {
+				return nil
+			}
+		}
+		res.Flush()
+	}
+}
+
+func parseEventTypes(s string) ([]domain.EventType, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var types []domain.EventType
+	for _, t := range strings.Split(s, ",") {
+		t := domain.EventType(strings.TrimSpace(t))
+		if !slices.Contains(domain.UserEventTypes, t) {
+			return nil, fmt.Errorf("unknown event type %q", t)
+		}
+		types = append(types, t)
+	}
+
+	return types, nil
+}
+
+// writeEvent writes e unless types is set and does not contain its type.
+func writeEvent(res *echo.Response, e domain.Event, types []domain.EventType) error {
+	if types != nil && !slices.Contains(types, e.Type) {
+		return nil
+	}
+
+	data, err := json.Marshal(response.EventResponse{
+		ID:          e.ID,
+		Type:        string(e.Type),
+		AggregateID: e.AggregateID,
+		OccurredAt:  e.OccurredAt,
+		Data:        e.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", e.Sequence, e.Type, data)
+
+	return err
+}
@@ -0,0 +1,192 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+	"github.com/Beriw98/user-management/internal/infrastructure/stream"
+)
+
+type eventLogMock struct {
+	mock.Mock
+}
+
+func (m *eventLogMock) After(ctx context.Context, sequence int64, limit int) ([]domain.Event, error) {
+	args := m.Called(ctx, sequence, limit)
+	return args.Get(0).([]domain.Event), args.Error(1)
+}
+
+type sseMessage struct {
+	id, event, data string
+	comment         bool
+}
+
+// readMessage reads the next message of an event stream.
+func readMessage(t *testing.T, r *bufio.Reader) sseMessage {
+	t.Helper()
+
+	var msg sseMessage
+	for {
+		line, err := r.ReadString('\n')
+		if !assert.NoError(t, err) {
+			return msg
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return msg
+		case strings.HasPrefix(line, ":"):
+			msg.comment = true
+		case strings.HasPrefix(line, "id: "):
+			msg.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			msg.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			msg.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func userEvent(seq int64, t domain.EventType) domain.Event {
+	e := domain.NewEvent(t, "1", domain.UserEventData{ID: "1"})
+	e.Sequence = seq
+
+	return e
+}
+
+func TestUserEventsHTTPHandler_Stream(t *testing.T) {
+	open := func(t *testing.T, events *eventLogMock, hub *stream.Hub, url string, lastEventID string, heartbeat time.Duration) *bufio.Reader {
+		t.Helper()
+
+		e := echo.New()
+		e.GET("/users/events", handler.NewUserEventsHTTPHandler(events, hub, heartbeat).Stream)
+		srv := httptest.NewServer(e)
+		t.Cleanup(srv.Close)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+url, nil)
+		if lastEventID != "" {
+			req.Header.Set(handler.HeaderLastEventID, lastEventID)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		t.Cleanup(func() { _ = res.Body.Close() })
+
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, handler.MIMETextEventStream, res.Header.Get(echo.HeaderContentType))
+
+		r := bufio.NewReader(res.Body)
+		assert.Equal(t, sseMessage{}, readMessage(t, r), "retry")
+
+		return r
+	}
+
+	t.Run("Live events", func(t *testing.T) {
+		hub := stream.NewHub()
+		r := open(t, new(eventLogMock), hub, "/users/events", "", time.Hour)
+
+		created := userEvent(3, domain.UserCreated)
+		hub.Broadcast(created)
+
+		msg := readMessage(t, r)
+		assert.Equal(t, "3", msg.id)
+		assert.Equal(t, string(domain.UserCreated), msg.event)
+
+		var body response.EventResponse
+		assert.NoError(t, json.Unmarshal([]byte(msg.data), &body))
+		assert.Equal(t, created.ID, body.ID)
+		assert.Equal(t, "1", body.AggregateID)
+		assert.JSONEq(t, `{"id":"1"}`, string(body.Data))
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		events := new(eventLogMock)
+		events.On("After", mock.Anything, int64(5), mock.Anything).
+			Return([]domain.Event{userEvent(6, domain.UserUpdated), userEvent(7, domain.UserDeleted)}, nil)
+
+		hub := stream.NewHub()
+		r := open(t, events, hub, "/users/events", "5", time.Hour)
+
+		assert.Equal(t, "6", readMessage(t, r).id)
+		assert.Equal(t, "7", readMessage(t, r).id)
+
+		// Replayed events notified meanwhile are not sent twice.
+		hub.Broadcast(userEvent(7, domain.UserDeleted))
+		hub.Broadcast(userEvent(8, domain.UserRestored))
+
+		assert.Equal(t, "8", readMessage(t, r).id)
+	})
+
+	t.Run("Filter by type", func(t *testing.T) {
+		hub := stream.NewHub()
+		r := open(t, new(eventLogMock), hub, "/users/events?types=user.created,user.deleted", "", time.Hour)
+
+		hub.Broadcast(userEvent(1, domain.UserCreated))
+		hub.Broadcast(userEvent(2, domain.UserUpdated))
+		hub.Broadcast(userEvent(3, domain.UserDeleted))
+
+		assert.Equal(t, "1", readMessage(t, r).id)
+		assert.Equal(t, "3", readMessage(t, r).id)
+	})
+
+	t.Run("Heartbeat", func(t *testing.T) {
+		r := open(t, new(eventLogMock), stream.NewHub(), "/users/events", "", 10*time.Millisecond)
+
+		assert.True(t, readMessage(t, r).comment)
+	})
+
+	t.Run("Dropped subscriber", func(t *testing.T) {
+		hub := stream.NewHub()
+		r := open(t, new(eventLogMock), hub, "/users/events", "", time.Hour)
+
+		hub.Reset()
+
+		_, err := r.ReadString('\n')
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid params", func(t *testing.T) {
+		h := handler.NewUserEventsHTTPHandler(new(eventLogMock), stream.NewHub(), 0)
+		e := echo.New()
+
+		for _, tc := range []struct {
+			url, lastEventID string
+			code             problem.Code
+		}{
+			{url: "/users/events?types=user.created,user.unknown", code: problem.CodeInvalidEventType},
+			{url: "/users/events", lastEventID: "abc", code: problem.CodeInvalidLastEventID},
+			{url: "/users/events?last_event_id=-1", code: problem.CodeInvalidLastEventID},
+		} {
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			if tc.lastEventID != "" {
+				req.Header.Set(handler.HeaderLastEventID, tc.lastEventID)
+			}
+
+			err := h.Stream(e.NewContext(req, httptest.NewRecorder()))
+
+			var httpErr *echo.HTTPError
+			assert.True(t, errors.As(err, &httpErr), tc.url)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+			assert.Equal(t, tc.code, httpErr.Message)
+		}
+	})
+}
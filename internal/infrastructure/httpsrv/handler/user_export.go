@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+const MIMEApplicationParquet = "application/vnd.apache.parquet"
+
+const (
+	// exportBatch is how many users are read from the database at a time.
+	exportBatch = 1000
+	// exportRowGroup bounds how many rows the parquet writer buffers.
+	exportRowGroup = 10000
+)
+
+// ExportFormat is the file format of a user export.
+type ExportFormat string
+
+const (
+	ExportCSV     ExportFormat = "csv"
+	ExportNDJSON  ExportFormat = "ndjson"
+	ExportParquet ExportFormat = "parquet"
+)
+
+var exportContentTypes = map[ExportFormat]string{
+	ExportCSV:     MIMETextCSV,
+	ExportNDJSON:  MIMEApplicationNDJSON,
+	ExportParquet: MIMEApplicationParquet,
+}
+
+type userExporter interface {
+	Each(ctx context.Context, list query.List, batch int, fn func(domain.User) error) error
+}
+
+type UserExportHTTPHandler struct {
+	users userExporter
+}
+
+func NewUserExportHTTPHandler(users userExporter) *UserExportHTTPHandler {
+	return &UserExportHTTPHandler{
+		users: users,
+	}
+}
+
+// Export streams all users narrowed by the same query params as GetMany, in
+// ID order, as a `format` file (csv by default). The body is gzip compressed
+// if the client accepts it.
+func (h *UserExportHTTPHandler) Export(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "Export")
+
+	format := ExportFormat(ec.QueryParam("format"))
+	if format == "" {
+		format = ExportCSV
+	}
+
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidExportFormat)
+	}
+
+	list, err := parseList(ec)
+	if err != nil {
+		return err
+	}
+
+	res := ec.Response()
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="users.`+string(format)+`"`)
+	res.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+
+	var (
+		out io.Writer = res
+		gz  *gzip.Writer
+	)
+	if acceptsGzip(ec.Request().Header.Get(echo.HeaderAcceptEncoding)) {
+		res.Header().Set(echo.HeaderContentEncoding, "gzip")
+
+		gz = gzip.NewWriter(res)
+		out = gz
+	}
+
+	w := newUserWriter(format, out)
+
+	err = h.users.Each(ctx, list, exportBatch, func(user domain.User) error {
+		if !res.Committed {
+			res.WriteHeader(http.StatusOK)
+		}
+
+		return w.Write(user)
+	})
+	if err == nil {
+		err = w.Close()
+	}
+
+	if err == nil && gz != nil {
+		err = gz.Close()
+	}
+
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		if !res.Committed {
+			res.Header().Del(echo.HeaderContentEncoding)
+			res.Header().Del(echo.HeaderContentDisposition)
+			if isQueryError(err) {
+				return queryError(err)
+			}
+			return echo.ErrInternalServerError
+		}
+
+		// The status is sent, only breaking the connection tells the client
+		// that the export is incomplete.
+		panic(http.ErrAbortHandler)
+	}
+
+	return nil
+}
+
+// acceptsGzip reports whether an Accept-Encoding header value allows gzip.
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if coding = strings.ToLower(strings.TrimSpace(coding)); coding != "gzip" && coding != "x-gzip" && coding != "*" {
+			continue
+		}
+
+		q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !ok {
+			return true
+		}
+
+		if v, err := strconv.ParseFloat(q, 64); err == nil && v > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// userWriter encodes users of an export, Close writes what is buffered.
+type userWriter interface {
+	Write(user domain.User) error
+	Close() error
+}
+
+func newUserWriter(format ExportFormat, w io.Writer) userWriter {
+	switch format {
+	case ExportNDJSON:
+		return &ndjsonUserWriter{enc: json.NewEncoder(w)}
+	case ExportParquet:
+		return &parquetUserWriter{w: parquet.NewGenericWriter[userExportRow](w, parquet.MaxRowsPerRowGroup(exportRowGroup))}
+	default:
+		return &csvUserWriter{w: csv.NewWriter(w)}
+	}
+}
+
+var userCSVHeader = []string{"id", "name", "surname", "email", "created_at", "updated_at", "version", "deleted_at"}
+
+type csvUserWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (c *csvUserWriter) Write(user domain.User) error {
+	if !c.header {
+		if err := c.w.Write(userCSVHeader); err != nil {
+			return err
+		}
+		c.header = true
+	}
+
+	var deletedAt string
+	if user.DeletedAt != nil {
+		deletedAt = user.DeletedAt.Format(time.RFC3339Nano)
+	}
+
+	return c.w.Write([]string{
+		user.ID,
+		csvCell(user.Name),
+		csvCell(user.Surname),
+		csvCell(user.Email),
+		user.CreatedAt.Format(time.RFC3339Nano),
+		user.UpdatedAt.Format(time.RFC3339Nano),
+		strconv.Itoa(user.Version),
+		deletedAt,
+	})
+}
+
+// csvCell escapes value with a leading ' if it starts like a formula, which
+// spreadsheets would run when the export is opened.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+// Close writes the header even if there were no users.
+func (c *csvUserWriter) Close() error {
+	if !c.header {
+		if err := c.w.Write(userCSVHeader); err != nil {
+			return err
+		}
+	}
+
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+type ndjsonUserWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonUserWriter) Write(user domain.User) error {
+	return n.enc.Encode(userResponse(user))
+}
+
+func (n *ndjsonUserWriter) Close() error {
+	return nil
+}
+
+type userExportRow struct {
+	ID        string    `parquet:"id"`
+	Name      string    `parquet:"name"`
+	Surname   string    `parquet:"surname"`
+	Email     string    `parquet:"email"`
+	CreatedAt time.Time `parquet:"created_at,timestamp(microsecond)"`
+	UpdatedAt time.Time `parquet:"updated_at,timestamp(microsecond)"`
+	Version   int64     `parquet:"version"`
+	// DeletedAt is null when zero, the timestamp tag does not take pointers.
+	DeletedAt int64 `parquet:"deleted_at,optional,timestamp(microsecond)"`
+}
+
+type parquetUserWriter struct {
+	w *parquet.GenericWriter[userExportRow]
+}
+
+func (p *parquetUserWriter) Write(user domain.User) error {
+	row := userExportRow{
+		ID:        user.ID,
+		Name:      user.Name,
+		Surname:   user.Surname,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		Version:   int64(user.Version),
+	}
+	if user.DeletedAt != nil {
+		row.DeletedAt = user.DeletedAt.UnixMicro()
+	}
+
+	_, err := p.w.Write([]userExportRow{row})
+
+	return err
+}
+
+func (p *parquetUserWriter) Close() error {
+	return p.w.Close()
+}
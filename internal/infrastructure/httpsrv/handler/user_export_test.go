@@ -0,0 +1,192 @@
+package handler_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type userExporterStub struct {
+	users []domain.User
+	err   error
+	list  query.List
+}
+
+func (s *userExporterStub) Each(_ context.Context, list query.List, _ int, fn func(domain.User) error) error {
+	s.list = list
+	if s.err != nil {
+		return s.err
+	}
+
+	for _, user := range s.users {
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestUserExportHTTPHandler_Export(t *testing.T) {
+	created := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	deleted := created.Add(time.Hour)
+	users := []domain.User{
+		{ID: "1", Name: "John", Surname: "Doe", Email: "john@doe.com", CreatedAt: created, UpdatedAt: created, Version: 1},
+		{ID: "2", Name: "Jane", Surname: "Doe, Jr.", Email: "jane@doe.com", CreatedAt: created, UpdatedAt: deleted, Version: 2, DeletedAt: &deleted},
+	}
+	e := echo.New()
+
+	export := func(target string, header http.Header, s *userExporterStub) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		res := httptest.NewRecorder()
+
+		return res, handler.NewUserExportHTTPHandler(s).Export(e.NewContext(req, res))
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		s := &userExporterStub{users: users}
+		res, err := export("/users:export?filter=surname+sw+%22Doe%22&include_deleted=true", nil, s)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, handler.MIMETextCSV, res.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="users.csv"`, res.Header().Get(echo.HeaderContentDisposition))
+		assert.Empty(t, res.Header().Get(echo.HeaderContentEncoding))
+		assert.True(t, s.list.IncludeDeleted)
+		assert.Equal(t, query.Condition{Field: "surname", Operator: query.Sw, Value: "Doe"}, s.list.Filter)
+
+		records, err := csv.NewReader(res.Body).ReadAll()
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"id", "name", "surname", "email", "created_at", "updated_at", "version", "deleted_at"},
+			{"1", "John", "Doe", "john@doe.com", "2026-10-19T12:00:00Z", "2026-10-19T12:00:00Z", "1", ""},
+			{"2", "Jane", "Doe, Jr.", "jane@doe.com", "2026-10-19T12:00:00Z", "2026-10-19T13:00:00Z", "2", "2026-10-19T13:00:00Z"},
+		}, records)
+	})
+
+	t.Run("CSV formulas", func(t *testing.T) {
+		formulas := []domain.User{
+			{ID: "1", Name: "=HYPERLINK(\"https://evil.example\")", Surname: "+1", Email: "@doe.com", CreatedAt: created, UpdatedAt: created, Version: 1},
+			{ID: "2", Name: "-2", Surname: "\t3", Email: "jane@doe.com", CreatedAt: created, UpdatedAt: created, Version: 1},
+		}
+		res, err := export("/users:export", nil, &userExporterStub{users: formulas})
+
+		assert.NoError(t, err)
+		records, err := csv.NewReader(res.Body).ReadAll()
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"1", `'=HYPERLINK("https://evil.example")`, "'+1", "'@doe.com"},
+			{"2", "'-2", "'\t3", "jane@doe.com"},
+		}, [][]string{records[1][:4], records[2][:4]})
+	})
+
+	t.Run("CSV without users", func(t *testing.T) {
+		res, err := export("/users:export", nil, &userExporterStub{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "id,name,surname,email,created_at,updated_at,version,deleted_at\n", res.Body.String())
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		res, err := export("/users:export?format=ndjson", nil, &userExporterStub{users: users})
+
+		assert.NoError(t, err)
+		assert.Equal(t, handler.MIMEApplicationNDJSON, res.Header().Get(echo.HeaderContentType))
+
+		lines := strings.Split(strings.TrimSuffix(res.Body.String(), "\n"), "\n")
+		assert.Len(t, lines, 2)
+		assert.JSONEq(t, `{"id":"1","name":"John","surname":"Doe","email":"john@doe.com","created_at":"2026-10-19T12:00:00Z","updated_at":"2026-10-19T12:00:00Z","version":1}`, lines[0])
+		assert.NotContains(t, res.Body.String(), "password")
+	})
+
+	t.Run("Parquet", func(t *testing.T) {
+		res, err := export("/users:export?format=parquet", nil, &userExporterStub{users: users})
+
+		assert.NoError(t, err)
+		assert.Equal(t, handler.MIMEApplicationParquet, res.Header().Get(echo.HeaderContentType))
+
+		type row struct {
+			ID        string `parquet:"id"`
+			Email     string `parquet:"email"`
+			Version   int64  `parquet:"version"`
+			DeletedAt *int64 `parquet:"deleted_at,optional"`
+		}
+
+		rows, err := parquet.Read[row](bytes.NewReader(res.Body.Bytes()), int64(res.Body.Len()))
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, "jane@doe.com", rows[1].Email)
+		assert.Equal(t, int64(2), rows[1].Version)
+		assert.Nil(t, rows[0].DeletedAt)
+		if assert.NotNil(t, rows[1].DeletedAt) {
+			assert.Equal(t, deleted.UnixMicro(), *rows[1].DeletedAt)
+		}
+	})
+
+	t.Run("Gzip", func(t *testing.T) {
+		res, err := export("/users:export?format=ndjson", http.Header{"Accept-Encoding": {"br, gzip;q=0.5"}}, &userExporterStub{users: users})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "gzip", res.Header().Get(echo.HeaderContentEncoding))
+		assert.Equal(t, echo.HeaderAcceptEncoding, res.Header().Get(echo.HeaderVary))
+
+		gz, err := gzip.NewReader(res.Body)
+		assert.NoError(t, err)
+		body, err := io.ReadAll(gz)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, strings.Count(string(body), "\n"))
+	})
+
+	t.Run("Gzip refused", func(t *testing.T) {
+		res, err := export("/users:export", http.Header{"Accept-Encoding": {"gzip;q=0"}}, &userExporterStub{users: users})
+
+		assert.NoError(t, err)
+		assert.Empty(t, res.Header().Get(echo.HeaderContentEncoding))
+		assert.True(t, strings.HasPrefix(res.Body.String(), "id,name"))
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		_, err := export("/users:export?format=xlsx", nil, &userExporterStub{})
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, problem.CodeInvalidExportFormat, he.Message)
+	})
+
+	t.Run("Invalid filter", func(t *testing.T) {
+		_, err := export("/users:export?filter=password+eq+x", nil, &userExporterStub{})
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		res, err := export("/users:export", http.Header{"Accept-Encoding": {"gzip"}}, &userExporterStub{err: assert.AnError})
+
+		assert.Equal(t, echo.ErrInternalServerError, err)
+		assert.Empty(t, res.Header().Get(echo.HeaderContentEncoding))
+		assert.Empty(t, res.Body.String())
+	})
+}
@@ -0,0 +1,472 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/xid"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/request"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/i18n"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+const (
+	MIMETextCSV             = "text/csv"
+	MIMEApplicationNDJSON   = "application/x-ndjson"
+	mimeApplicationNDJSONv2 = "application/ndjson"
+)
+
+const (
+	// importProgressEvery is how many rows are imported between saves of
+	// the progress of the job.
+	importProgressEvery = 100
+	// maxImportErrors caps the row errors kept in the job.
+	maxImportErrors = 1000
+)
+
+type importJobRepository interface {
+	Create(ctx context.Context, job domain.ImportJob) (domain.ImportJob, error)
+	Get(ctx context.Context, id string) (*domain.ImportJob, error)
+	Save(ctx context.Context, job domain.ImportJob) error
+}
+
+type invitationRepository interface {
+	Create(ctx context.Context, inv domain.Invitation) error
+	GetPendingByEmail(ctx context.Context, email string) (*domain.Invitation, error)
+}
+
+type invitationSender interface {
+	SendInvitation(ctx context.Context, inv domain.Invitation, link string) error
+}
+
+type erasedEmails interface {
+	IsErased(ctx context.Context, email string) (bool, error)
+}
+
+type userImport struct {
+	jobs        importJobRepository
+	invitations invitationRepository
+	sender      invitationSender
+	inviteURL   string
+	inviteTTL   time.Duration
+	maxSize     int64
+	// running tracks the imports in progress, see Wait.
+	running sync.WaitGroup
+}
+
+// WithImport enables bulk imports of up to maxSize bytes. People imported
+// without a password are sent a link made of inviteURL and a token valid for
+// inviteTTL.
+func WithImport(jobs importJobRepository, invitations invitationRepository, sender invitationSender, inviteURL string, inviteTTL time.Duration, maxSize int64) Option {
+	return func(h *UserHTTPHandler) {
+		h.imports = &userImport{
+			jobs:        jobs,
+			invitations: invitations,
+			sender:      sender,
+			inviteURL:   inviteURL,
+			inviteTTL:   inviteTTL,
+			maxSize:     maxSize,
+		}
+	}
+}
+
+// WithErasureTombstones rejects the import of people who were erased, so
+// that an old file does not bring them back.
+func WithErasureTombstones(tombstones erasedEmails) Option {
+	return func(h *UserHTTPHandler) {
+		h.tombstones = tombstones
+	}
+}
+
+// Import creates users from a CSV or NDJSON body, see UserImportRow. The body
+// is spooled to a temporary file and imported in the background, the
+// response points to the job reporting the progress. With `dry_run` rows
+// are only validated.
+func (h *UserHTTPHandler) Import(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "Import")
+
+	if h.imports == nil {
+		return echo.ErrNotFound
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(ec.Request().Header.Get(echo.HeaderContentType))
+
+	var format domain.ImportFormat
+	switch mediaType {
+	case MIMETextCSV:
+		format = domain.ImportCSV
+	case MIMEApplicationNDJSON, mimeApplicationNDJSONv2:
+		format = domain.ImportNDJSON
+	default:
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType)
+	}
+
+	var dryRun bool
+	if v := ec.QueryParam("dry_run"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidDryRun).SetInternal(err)
+		}
+	}
+
+	f, err := os.CreateTemp("", "user-import-*")
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	// The file is removed by run, or here if the import does not start.
+	started := false
+	defer func() {
+		if !started {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	body := http.MaxBytesReader(ec.Response(), ec.Request().Body, h.imports.maxSize)
+	if _, err = io.Copy(f, body); err != nil {
+		var mErr *http.MaxBytesError
+		if errors.As(err, &mErr) {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, problem.CodeRequestTooLarge)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	rows, err := newImportRows(f, format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.Message{
+			Code:   problem.CodeInvalidImportFile,
+			Params: []string{err.Error()},
+		}).SetInternal(err)
+	}
+
+	job, err := h.imports.jobs.Create(ctx, domain.ImportJob{
+		ID:     xid.New().String(),
+		Format: format,
+		DryRun: dryRun,
+		Status: domain.ImportRunning,
+	})
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	trans, _ := i18n.Negotiated(ec)
+	run := &importRun{
+		h:        h,
+		job:      job,
+		rows:     rows,
+		validate: ec.Echo().Validator,
+		trans:    trans,
+		audit:    requestAuditEvent(ec, domain.AuditEvent{Action: domain.AuditActionCreate}),
+		seen:     make(map[string]struct{}),
+	}
+
+	started = true
+	h.imports.running.Add(1)
+	go func() {
+		defer func() {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+			h.imports.running.Done()
+		}()
+
+		run.run(context.WithoutCancel(ctx))
+	}()
+
+	ec.Response().Header().Set(echo.HeaderLocation, "/users/imports/"+job.ID)
+
+	return ec.JSON(http.StatusAccepted, importJobResponse(job))
+}
+
+// Wait blocks until the imports in progress are done or ctx is, returning
+// the error of ctx then. Imports outliving it are failed by
+// job.StaleImports.
+func (h *UserHTTPHandler) Wait(ctx context.Context) error {
+	if h.imports == nil {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		h.imports.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (h *UserHTTPHandler) GetImport(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "GetImport")
+
+	if h.imports == nil {
+		return echo.ErrNotFound
+	}
+
+	job, err := h.imports.jobs.Get(ctx, ec.Param("id"))
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if job == nil {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeImportNotFound)
+	}
+
+	return ec.JSON(http.StatusOK, importJobResponse(*job))
+}
+
+type importRun struct {
+	h        *UserHTTPHandler
+	job      domain.ImportJob
+	rows     importRows
+	validate echo.Validator
+	// trans translates row errors, they are only reported by code and rule
+	// without it.
+	trans ut.Translator
+	audit domain.AuditEvent
+	// seen are the emails of the rows so far, to report duplicates within
+	// the import.
+	seen map[string]struct{}
+}
+
+type importOutcome int
+
+const (
+	importCreated importOutcome = iota
+	importInvited
+)
+
+func (r *importRun) run(ctx context.Context) {
+	l := slog.Default().With("job", "UserImport", "id", r.job.ID)
+
+	for {
+		row, err := r.rows.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		r.job.Processed++
+
+		var rowErr *importRowError
+		switch {
+		case errors.As(err, &rowErr):
+			r.fail(row, echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err))
+		case err != nil:
+			r.abort(ctx, err)
+			return
+		default:
+			outcome, err := r.importRow(ctx, row)
+
+			var he *echo.HTTPError
+			switch {
+			case errors.As(err, &he):
+				r.fail(row, he)
+			case err != nil:
+				r.abort(ctx, err)
+				return
+			case outcome == importCreated:
+				r.job.Created++
+			default:
+				r.job.Invited++
+			}
+		}
+
+		if r.job.Processed%importProgressEvery == 0 {
+			if err = r.h.imports.jobs.Save(ctx, r.job); err != nil {
+				l.ErrorContext(ctx, err.Error())
+			}
+		}
+	}
+
+	now := time.Now()
+	r.job.Status = domain.ImportSucceeded
+	r.job.FinishedAt = &now
+	if err := r.h.imports.jobs.Save(ctx, r.job); err != nil {
+		l.ErrorContext(ctx, err.Error())
+	}
+}
+
+// importRow creates the user of row, or invites them without a password. It
+// returns an echo.HTTPError if the row is rejected.
+func (r *importRun) importRow(ctx context.Context, row request.UserImportRow) (importOutcome, error) {
+	if err := r.validate.Validate(&row); err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	if _, ok := r.seen[row.Email]; ok {
+		return 0, echo.NewHTTPError(http.StatusConflict, problem.CodeUserAlreadyExists)
+	}
+	r.seen[row.Email] = struct{}{}
+
+	if err := r.h.checkErased(ctx, row.Email); err != nil {
+		return 0, err
+	}
+
+	user, err := r.h.userRepository.GetByEmail(ctx, row.Email)
+	if err != nil {
+		return 0, err
+	}
+
+	if user != nil {
+		return 0, echo.NewHTTPError(http.StatusConflict, problem.CodeUserAlreadyExists)
+	}
+
+	if row.Password == "" {
+		return importInvited, r.invite(ctx, row)
+	}
+
+	if r.job.DryRun {
+		return importCreated, nil
+	}
+
+	hash, err := hashPassword(row.Password)
+	if err != nil {
+		return 0, err
+	}
+
+	user = &domain.User{
+		ID:       xid.New().String(),
+		Name:     row.Name,
+		Surname:  row.Surname,
+		Email:    row.Email,
+		Password: hash,
+	}
+
+	event := r.audit
+	event.TargetID = user.ID
+	event.Changes = domain.UserChanges(nil, user)
+
+	err = r.h.inTx(ctx, event, domain.UserEvents(nil, user), func(ctx context.Context) error {
+		return r.h.userRepository.Create(ctx, *user)
+	})
+	if ent.IsConstraintError(err) {
+		return 0, echo.NewHTTPError(http.StatusConflict, problem.CodeUserAlreadyExists)
+	}
+
+	return importCreated, err
+}
+
+func (r *importRun) invite(ctx context.Context, row request.UserImportRow) error {
+	pending, err := r.h.imports.invitations.GetPendingByEmail(ctx, row.Email)
+	if err != nil {
+		return err
+	}
+
+	if pending != nil {
+		return echo.NewHTTPError(http.StatusConflict, problem.CodeInvitationExists)
+	}
+
+	if r.job.DryRun {
+		return nil
+	}
+
+	token, hash := domain.NewInvitationToken()
+	inv := domain.Invitation{
+		ID:        xid.New().String(),
+		Email:     row.Email,
+		Name:      row.Name,
+		Surname:   row.Surname,
+		InvitedBy: r.audit.Actor,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(r.h.imports.inviteTTL),
+	}
+
+	if err = r.h.imports.invitations.Create(ctx, inv); err != nil {
+		return err
+	}
+
+	// The invitation stands even if it could not be sent, it can be sent
+	// again.
+	if err = r.h.imports.sender.SendInvitation(ctx, inv, r.h.imports.inviteURL+token); err != nil {
+		slog.Default().With("job", "UserImport", "id", r.job.ID).ErrorContext(ctx, err.Error())
+	}
+
+	return nil
+}
+
+// fail records that row was rejected with he.
+func (r *importRun) fail(row request.UserImportRow, he *echo.HTTPError) {
+	r.job.Failed++
+	if len(r.job.Errors) >= maxImportErrors {
+		return
+	}
+
+	p := problem.FromError(he, r.trans)
+	rowErr := domain.ImportRowError{Row: r.job.Processed, Email: row.Email, Code: string(p.Code), Detail: p.Detail}
+	for _, fe := range p.Errors {
+		rowErr.Errors = append(rowErr.Errors, domain.ImportFieldError{Field: fe.Field, Rule: fe.Rule, Message: fe.Message})
+	}
+
+	r.job.Errors = append(r.job.Errors, rowErr)
+}
+
+// abort fails the whole import on an unexpected error.
+func (r *importRun) abort(ctx context.Context, err error) {
+	l := slog.Default().With("job", "UserImport", "id", r.job.ID)
+	l.ErrorContext(ctx, err.Error())
+
+	now := time.Now()
+	r.job.Status = domain.ImportFailed
+	r.job.Error = http.StatusText(http.StatusInternalServerError)
+	r.job.FinishedAt = &now
+	if err = r.h.imports.jobs.Save(ctx, r.job); err != nil {
+		l.ErrorContext(ctx, err.Error())
+	}
+}
+
+func importJobResponse(job domain.ImportJob) response.ImportJobResponse {
+	res := response.ImportJobResponse{
+		ID:         job.ID,
+		Status:     string(job.Status),
+		Format:     string(job.Format),
+		DryRun:     job.DryRun,
+		Processed:  job.Processed,
+		Created:    job.Created,
+		Invited:    job.Invited,
+		Failed:     job.Failed,
+		Errors:     make([]response.ImportRowErrorResponse, 0, len(job.Errors)),
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		UpdatedAt:  job.UpdatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+
+	for _, e := range job.Errors {
+		rowErr := response.ImportRowErrorResponse{Row: e.Row, Email: e.Email, Code: e.Code, Detail: e.Detail}
+		for _, fe := range e.Errors {
+			rowErr.Errors = append(rowErr.Errors, response.ImportFieldProblem{Field: fe.Field, Rule: fe.Rule, Message: fe.Message})
+		}
+		res.Errors = append(res.Errors, rowErr)
+	}
+
+	return res
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/request"
+)
+
+var errInvalidImportFile = errors.New("invalid import file")
+
+// importRows reads the rows of an import one at a time. Next returns an
+// importRowError for a row that cannot be parsed, after which it can go on,
+// and io.EOF after the last row.
+type importRows interface {
+	Next() (request.UserImportRow, error)
+}
+
+type importRowError struct {
+	err error
+}
+
+func (e *importRowError) Error() string {
+	return e.err.Error()
+}
+
+func (e *importRowError) Unwrap() error {
+	return e.err
+}
+
+func newImportRows(r io.Reader, format domain.ImportFormat) (importRows, error) {
+	if format == domain.ImportCSV {
+		return newCSVRows(r)
+	}
+
+	return &ndjsonRows{r: bufio.NewReader(r)}, nil
+}
+
+// csvColumns maps the CSV header to the fields of a row.
+var csvColumns = map[string]func(row *request.UserImportRow, v string){
+	"name":     func(row *request.UserImportRow, v string) { row.Name = v },
+	"surname":  func(row *request.UserImportRow, v string) { row.Surname = v },
+	"email":    func(row *request.UserImportRow, v string) { row.Email = v },
+	"password": func(row *request.UserImportRow, v string) { row.Password = v },
+}
+
+type csvRows struct {
+	r       *csv.Reader
+	columns []func(row *request.UserImportRow, v string)
+}
+
+// newCSVRows reads the header, which must name the email column and may name
+// the other ones in any order.
+func newCSVRows(r io.Reader) (*csvRows, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: missing header", errInvalidImportFile)
+		}
+		return nil, fmt.Errorf("%w: %w", errInvalidImportFile, err)
+	}
+
+	seen := make(map[string]bool, len(header))
+	columns := make([]func(row *request.UserImportRow, v string), 0, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		set, ok := csvColumns[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", errInvalidImportFile, name)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("%w: duplicate column %q", errInvalidImportFile, name)
+		}
+		seen[name] = true
+
+		columns = append(columns, set)
+	}
+
+	if !seen["email"] {
+		return nil, fmt.Errorf("%w: missing column \"email\"", errInvalidImportFile)
+	}
+
+	return &csvRows{r: cr, columns: columns}, nil
+}
+
+func (c *csvRows) Next() (request.UserImportRow, error) {
+	var row request.UserImportRow
+
+	record, err := c.r.Read()
+	if err != nil {
+		var pErr *csv.ParseError
+		if errors.As(err, &pErr) {
+			return row, &importRowError{err: pErr.Err}
+		}
+		return row, err
+	}
+
+	for i, v := range record {
+		c.columns[i](&row, strings.TrimSpace(v))
+	}
+
+	return row, nil
+}
+
+// ndjsonRows reads a JSON object per line, skipping blank lines.
+type ndjsonRows struct {
+	r *bufio.Reader
+}
+
+func (n *ndjsonRows) Next() (request.UserImportRow, error) {
+	var row request.UserImportRow
+
+	for {
+		line, err := n.r.ReadBytes('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || len(line) == 0) {
+			return row, err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		if err = json.Unmarshal(line, &row); err != nil {
+			return row, &importRowError{err: err}
+		}
+
+		return row, nil
+	}
+}
@@ -0,0 +1,354 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/i18n"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/middleware"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+// importJobsMock reports on done the job once it is finished.
+type importJobsMock struct {
+	mock.Mock
+	done chan domain.ImportJob
+}
+
+func (m *importJobsMock) Create(ctx context.Context, job domain.ImportJob) (domain.ImportJob, error) {
+	args := m.Called(ctx, job)
+	if args.Get(0) == nil {
+		return job, args.Error(1)
+	}
+	return args.Get(0).(domain.ImportJob), args.Error(1)
+}
+
+func (m *importJobsMock) Get(ctx context.Context, id string) (*domain.ImportJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ImportJob), args.Error(1)
+}
+
+func (m *importJobsMock) Save(ctx context.Context, job domain.ImportJob) error {
+	args := m.Called(ctx, job)
+	if job.FinishedAt != nil {
+		m.done <- job
+	}
+	return args.Error(0)
+}
+
+type invitationsMock struct {
+	mock.Mock
+}
+
+func (m *invitationsMock) Create(ctx context.Context, inv domain.Invitation) error {
+	args := m.Called(ctx, inv)
+	return args.Error(0)
+}
+
+func (m *invitationsMock) GetPendingByEmail(ctx context.Context, email string) (*domain.Invitation, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invitation), args.Error(1)
+}
+
+type invitationSenderMock struct {
+	mock.Mock
+}
+
+func (m *invitationSenderMock) SendInvitation(ctx context.Context, inv domain.Invitation, link string) error {
+	args := m.Called(ctx, inv, link)
+	return args.Error(0)
+}
+
+type erasedEmailsMock struct {
+	mock.Mock
+}
+
+func (m *erasedEmailsMock) IsErased(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestUserHTTPHandler_Import(t *testing.T) {
+	v := validator.New()
+	v.RegisterTagNameFunc(problem.JSONTagName)
+	tr, err := i18n.New(v, "")
+	assert.NoError(t, err)
+
+	e := echo.New()
+	e.Validator = &requestValidator{Validator: v}
+
+	setup := func(maxSize int64) (*repositoryMock, *importJobsMock, *invitationsMock, *invitationSenderMock, *handler.UserHTTPHandler) {
+		rm := new(repositoryMock)
+		jobs := &importJobsMock{done: make(chan domain.ImportJob, 1)}
+		invitations := new(invitationsMock)
+		sender := new(invitationSenderMock)
+
+		jobs.On("Create", mock.Anything, mock.Anything).Return(nil, nil)
+		jobs.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		h := handler.NewUserHTTPHandler(rm, handler.WithImport(jobs, invitations, sender, "https://app.example.com/invitations/", time.Hour, maxSize))
+
+		return rm, jobs, invitations, sender, h
+	}
+
+	start := func(h *handler.UserHTTPHandler, contentType, url, body string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		req.Header.Set(i18n.HeaderAcceptLanguage, "en")
+		res := httptest.NewRecorder()
+
+		ec := e.NewContext(req, res)
+		err := middleware.NewLocaleMiddleware(tr)(h.Import)(ec)
+
+		return res, err
+	}
+
+	wait := func(t *testing.T, jobs *importJobsMock) domain.ImportJob {
+		t.Helper()
+
+		select {
+		case job := <-jobs.done:
+			return job
+		case <-time.After(10 * time.Second):
+			t.Fatal("import did not finish")
+			return domain.ImportJob{}
+		}
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		rm, jobs, invitations, sender, h := setup(1 << 20)
+
+		body := "email,name,surname,password\n" +
+			"jane@doe.com,Jane,Doe,secret\n" +
+			"john@doe.com,John,Doe,\n" +
+			"invalid,Bad,Email,secret\n" +
+			"jane@doe.com,Jane,Again,secret\n" +
+			"taken@doe.com,Taken,Doe,secret\n" +
+			"too,many,fields,here,now\n"
+
+		rm.On("GetByEmail", mock.Anything, "jane@doe.com").Return(nil, nil).Once()
+		rm.On("GetByEmail", mock.Anything, "john@doe.com").Return(nil, nil).Once()
+		rm.On("GetByEmail", mock.Anything, "taken@doe.com").Return(&domain.User{ID: "taken"}, nil).Once()
+		rm.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			user := args.Get(1).(domain.User)
+			assert.Equal(t, "jane@doe.com", user.Email)
+			assert.NotEmpty(t, user.ID)
+			assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("secret")))
+		}).Return(nil).Once()
+
+		var inv domain.Invitation
+		invitations.On("GetPendingByEmail", mock.Anything, "john@doe.com").Return(nil, nil).Once()
+		invitations.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			inv = args.Get(1).(domain.Invitation)
+		}).Return(nil).Once()
+
+		var link string
+		sender.On("SendInvitation", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			link = args.String(2)
+		}).Return(nil).Once()
+
+		res, err := start(h, "text/csv; charset=utf-8", "/users:import", body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, res.Code)
+
+		var created response.ImportJobResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &created))
+		assert.Equal(t, "running", created.Status)
+		assert.Equal(t, "/users/imports/"+created.ID, res.Header().Get(echo.HeaderLocation))
+
+		job := wait(t, jobs)
+		assert.Equal(t, domain.ImportSucceeded, job.Status)
+		assert.Equal(t, 6, job.Processed)
+		assert.Equal(t, 1, job.Created)
+		assert.Equal(t, 1, job.Invited)
+		assert.Equal(t, 4, job.Failed)
+		assert.Equal(t, []domain.ImportRowError{
+			{Row: 3, Email: "invalid", Code: "validation_failed", Detail: "request validation failed", Errors: []domain.ImportFieldError{
+				{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+			}},
+			{Row: 4, Email: "jane@doe.com", Code: "user_already_exists", Detail: "user already exists"},
+			{Row: 5, Email: "taken@doe.com", Code: "user_already_exists", Detail: "user already exists"},
+			{Row: 6, Code: "invalid_body", Detail: "request body is malformed"},
+		}, job.Errors)
+
+		assert.Equal(t, "john@doe.com", inv.Email)
+		assert.Equal(t, "John", inv.Name)
+		token, ok := strings.CutPrefix(link, "https://app.example.com/invitations/")
+		assert.True(t, ok)
+		assert.Equal(t, domain.InvitationTokenHash(token), inv.TokenHash)
+
+		rm.AssertExpectations(t)
+		invitations.AssertExpectations(t)
+	})
+
+	t.Run("NDJSON dry run", func(t *testing.T) {
+		rm, jobs, invitations, _, h := setup(1 << 20)
+
+		body := `{"email":"jane@doe.com","password":"secret"}` + "\n\n" +
+			`{"email":` + "\n" +
+			`{"email":"john@doe.com"}` + "\n" +
+			`{"email":"invited@doe.com"}`
+
+		rm.On("GetByEmail", mock.Anything, mock.Anything).Return(nil, nil)
+		invitations.On("GetPendingByEmail", mock.Anything, "john@doe.com").Return(nil, nil).Once()
+		invitations.On("GetPendingByEmail", mock.Anything, "invited@doe.com").Return(&domain.Invitation{ID: "inv"}, nil).Once()
+
+		res, err := start(h, handler.MIMEApplicationNDJSON, "/users:import?dry_run=true", body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, res.Code)
+
+		job := wait(t, jobs)
+		assert.Equal(t, 4, job.Processed)
+		assert.Equal(t, 1, job.Created)
+		assert.Equal(t, 1, job.Invited)
+		assert.Equal(t, 2, job.Failed)
+		assert.Equal(t, "invalid_body", job.Errors[0].Code)
+		assert.Equal(t, 2, job.Errors[0].Row)
+		assert.Equal(t, "invitation_already_exists", job.Errors[1].Code)
+
+		rm.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		invitations.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		assert.NoError(t, h.Wait(ctx))
+	})
+
+	t.Run("Erased", func(t *testing.T) {
+		rm, jobs, invitations, sender, _ := setup(1 << 20)
+		tombstones := new(erasedEmailsMock)
+		h := handler.NewUserHTTPHandler(rm,
+			handler.WithImport(jobs, invitations, sender, "https://app.example.com/invitations/", time.Hour, 1<<20),
+			handler.WithErasureTombstones(tombstones),
+		)
+
+		tombstones.On("IsErased", mock.Anything, "erased@doe.com").Return(true, nil).Once()
+		tombstones.On("IsErased", mock.Anything, "jane@doe.com").Return(false, nil).Once()
+		rm.On("GetByEmail", mock.Anything, "jane@doe.com").Return(nil, nil).Once()
+		rm.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := start(h, "text/csv", "/users:import", "email,password\nerased@doe.com,secret\njane@doe.com,secret\n")
+		assert.NoError(t, err)
+
+		job := wait(t, jobs)
+		assert.Equal(t, 1, job.Created)
+		assert.Equal(t, []domain.ImportRowError{
+			{Row: 1, Email: "erased@doe.com", Code: "user_erased", Detail: "a user with this email was erased"},
+		}, job.Errors)
+		rm.AssertNotCalled(t, "GetByEmail", mock.Anything, "erased@doe.com")
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		rm, jobs, _, _, h := setup(1 << 20)
+
+		rm.On("GetByEmail", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+		_, err := start(h, "text/csv", "/users:import", "email\njane@doe.com\njohn@doe.com\n")
+		assert.NoError(t, err)
+
+		job := wait(t, jobs)
+		assert.Equal(t, domain.ImportFailed, job.Status)
+		assert.Equal(t, 1, job.Processed)
+		assert.NotEmpty(t, job.Error)
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		for _, tc := range []struct {
+			name, contentType, url, body string
+			status                       int
+			code                         problem.Code
+		}{
+			{name: "Unsupported media type", contentType: echo.MIMEApplicationJSON, url: "/users:import", body: "{}", status: http.StatusUnsupportedMediaType, code: problem.CodeUnsupportedMediaType},
+			{name: "Invalid dry run", contentType: "text/csv", url: "/users:import?dry_run=maybe", body: "email\n", status: http.StatusBadRequest, code: problem.CodeInvalidDryRun},
+			{name: "Missing header", contentType: "text/csv", url: "/users:import", body: "", status: http.StatusBadRequest, code: problem.CodeInvalidImportFile},
+			{name: "Unknown column", contentType: "text/csv", url: "/users:import", body: "email,role\n", status: http.StatusBadRequest, code: problem.CodeInvalidImportFile},
+			{name: "Missing email column", contentType: "text/csv", url: "/users:import", body: "name\n", status: http.StatusBadRequest, code: problem.CodeInvalidImportFile},
+			{name: "Too large", contentType: "text/csv", url: "/users:import", body: "email\n" + strings.Repeat("a@b.co\n", 20), status: http.StatusRequestEntityTooLarge, code: problem.CodeRequestTooLarge},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				_, jobs, _, _, h := setup(64)
+
+				_, err := start(h, tc.contentType, tc.url, tc.body)
+
+				var he *echo.HTTPError
+				assert.True(t, errors.As(err, &he))
+				assert.Equal(t, tc.status, he.Code)
+				p := problem.FromError(err, tr.Fallback())
+				assert.Equal(t, tc.code, p.Code)
+				jobs.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
+
+func TestUserHTTPHandler_GetImport(t *testing.T) {
+	jobs := new(importJobsMock)
+	h := handler.NewUserHTTPHandler(new(repositoryMock), handler.WithImport(jobs, new(invitationsMock), new(invitationSenderMock), "", time.Hour, 1<<20))
+	e := echo.New()
+
+	t.Run("GetImport", func(t *testing.T) {
+		finished := time.Now()
+		jobs.On("Get", mock.Anything, "job").Return(&domain.ImportJob{
+			ID:         "job",
+			Format:     domain.ImportCSV,
+			Status:     domain.ImportSucceeded,
+			Processed:  2,
+			Created:    1,
+			Failed:     1,
+			Errors:     []domain.ImportRowError{{Row: 2, Email: "taken@doe.com", Code: "user_already_exists"}},
+			FinishedAt: &finished,
+		}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/users/imports/job", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("job")
+
+		assert.NoError(t, h.GetImport(ec))
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var body response.ImportJobResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, "succeeded", body.Status)
+		assert.Equal(t, "csv", body.Format)
+		assert.Equal(t, []response.ImportRowErrorResponse{{Row: 2, Email: "taken@doe.com", Code: "user_already_exists"}}, body.Errors)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		jobs.On("Get", mock.Anything, "missing").Return(nil, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/users/imports/missing", nil)
+		ec := e.NewContext(req, httptest.NewRecorder())
+		ec.SetParamNames("id")
+		ec.SetParamValues("missing")
+
+		err := h.GetImport(ec)
+
+		var he *echo.HTTPError
+		assert.True(t, errors.As(err, &he))
+		assert.Equal(t, http.StatusNotFound, he.Code)
+		assert.Equal(t, problem.CodeImportNotFound, he.Message)
+	})
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// HeaderActorID identifies who makes the request. There is no authentication
+// yet, so it is trusted as set by the gateway in front of the service.
+const HeaderActorID = "X-Actor-ID"
+
+type transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type auditLog interface {
+	Append(ctx context.Context, event domain.AuditEvent) error
+}
+
+type eventOutbox interface {
+	Add(ctx context.Context, events ...domain.Event) error
+}
+
+// WithAudit records every mutation of a user in audit, in the same
+// transaction as the mutation itself.
+func WithAudit(tx transactor, audit auditLog) Option {
+	return func(h *UserHTTPHandler) {
+		h.tx = tx
+		h.audit = audit
+	}
+}
+
+// WithOutbox adds the domain events of every mutation of a user to outbox, in
+// the same transaction as the mutation itself.
+func WithOutbox(tx transactor, outbox eventOutbox) Option {
+	return func(h *UserHTTPHandler) {
+		h.tx = tx
+		h.outbox = outbox
+	}
+}
+
+// mutate runs fn, appending event to the audit log and events to the outbox
+// when they are configured. fn must use the context it is given so they all
+// share the transaction.
+func (h *UserHTTPHandler) mutate(ec echo.Context, event domain.AuditEvent, events []domain.Event, fn func(ctx context.Context) error) error {
+	return h.inTx(ec.Request().Context(), requestAuditEvent(ec, event), events, fn)
+}
+
+// requestAuditEvent fills event with who made the request.
+func requestAuditEvent(ec echo.Context, event domain.AuditEvent) domain.AuditEvent {
+	req := ec.Request()
+
+	event.TargetType = domain.AuditTargetUser
+	event.Actor = req.Header.Get(HeaderActorID)
+	event.ClientIP = ec.RealIP()
+	event.UserAgent = req.UserAgent()
+	event.RequestID = ec.Response().Header().Get(echo.HeaderXRequestID)
+	if event.RequestID == "" {
+		event.RequestID = req.Header.Get(echo.HeaderXRequestID)
+	}
+
+	return event
+}
+
+// inTx is mutate for an event already filled by requestAuditEvent, for
+// mutations outliving their request.
+func (h *UserHTTPHandler) inTx(ctx context.Context, event domain.AuditEvent, events []domain.Event, fn func(ctx context.Context) error) error {
+	if h.audit == nil && h.outbox == nil {
+		return fn(ctx)
+	}
+
+	return h.tx.InTx(ctx, func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+
+		if h.audit != nil {
+			if err := h.audit.Append(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		if h.outbox != nil {
+			return h.outbox.Add(ctx, events...)
+		}
+
+		return nil
+	})
+}
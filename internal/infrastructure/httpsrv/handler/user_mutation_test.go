@@ -0,0 +1,268 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	customvalidator "github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/validator"
+)
+
+type txKey struct{}
+
+// transactorMock runs functions in a fake transaction, marked in their
+// context, and records whether it was committed.
+type transactorMock struct {
+	committed bool
+}
+
+func (t *transactorMock) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.committed = false
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		return err
+	}
+	t.committed = true
+	return nil
+}
+
+type auditLogMock struct {
+	mock.Mock
+}
+
+func (a *auditLogMock) Append(ctx context.Context, event domain.AuditEvent) error {
+	args := a.Called(ctx, event)
+	return args.Error(0)
+}
+
+type outboxMock struct {
+	mock.Mock
+}
+
+func (o *outboxMock) Add(ctx context.Context, events ...domain.Event) error {
+	args := o.Called(ctx, events)
+	return args.Error(0)
+}
+
+// eventTypes matches events by their types, the rest is random.
+func eventTypes(types ...domain.EventType) any {
+	return mock.MatchedBy(func(events []domain.Event) bool {
+		if len(events) != len(types) {
+			return false
+		}
+		for i, e := range events {
+			if e.Type != types[i] || e.AggregateID != "1" {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+var inTx = mock.MatchedBy(func(ctx context.Context) bool {
+	return ctx.Value(txKey{}) == true
+})
+
+func TestUserHTTPHandler_Audit(t *testing.T) {
+	rm := new(repositoryMock)
+	al := new(auditLogMock)
+	tx := new(transactorMock)
+	h := handler.NewUserHTTPHandler(rm, handler.WithAudit(tx, al))
+	e := echo.New()
+	v := &requestValidator{
+		Validator: validator.New(),
+	}
+
+	_ = v.Validator.RegisterValidation("password", customvalidator.PasswordValidate)
+	e.Validator = v
+
+	user := func() *domain.User {
+		return &domain.User{ID: "1", Name: "Test", Surname: "Test", Email: "test@test.pl", Version: 1}
+	}
+
+	t.Run("Update", func(t *testing.T) {
+		body := `{"name":"Test","surname":"Test","email":"new@test.pl"}`
+		req, _ := http.NewRequest(http.MethodPut, "/users/1", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(handler.HeaderActorID, "admin")
+		req.Header.Set(echo.HeaderXRequestID, "req-1")
+		req.Header.Set(echo.HeaderXRealIP, "10.0.0.1")
+		req.Header.Set("User-Agent", "test")
+		res := httptest.NewRecorder()
+
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		rm.On("GetByID", ec.Request().Context(), "1").Return(user(), nil).Once()
+		rm.On("Update", inTx, mock.Anything).Return(nil).Once()
+		al.On("Append", inTx, domain.AuditEvent{
+			Actor:      "admin",
+			Action:     domain.AuditActionUpdate,
+			TargetType: domain.AuditTargetUser,
+			TargetID:   "1",
+			Changes:    map[string]domain.Change{"email": {Before: "test@test.pl", After: "new@test.pl"}},
+			RequestID:  "req-1",
+			ClientIP:   "10.0.0.1",
+			UserAgent:  "test",
+		}).Return(nil).Once()
+
+		err := h.Update(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.True(t, tx.committed)
+	})
+
+	t.Run("Password change without diff", func(t *testing.T) {
+		body := `{"password":"1Password."}`
+		req, _ := http.NewRequest(http.MethodPatch, "/users/1/password", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		rm.On("GetByID", ec.Request().Context(), "1").Return(user(), nil).Once()
+		rm.On("Update", inTx, mock.Anything).Return(nil).Once()
+		al.On("Append", inTx, mock.MatchedBy(func(event domain.AuditEvent) bool {
+			return event.Action == domain.AuditActionPasswordChange && event.Changes == nil
+		})).Return(nil).Once()
+
+		err := h.UpdatePassword(ec)
+
+		assert.NoError(t, err)
+		assert.True(t, tx.committed)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, "/users/1", nil)
+		res := httptest.NewRecorder()
+
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		rm.On("GetByID", ec.Request().Context(), "1").Return(user(), nil).Once()
+		rm.On("Delete", inTx, "1", 1).Return(nil).Once()
+		al.On("Append", inTx, mock.MatchedBy(func(event domain.AuditEvent) bool {
+			return event.Action == domain.AuditActionDelete && event.Changes["email"] == domain.Change{Before: "test@test.pl"}
+		})).Return(nil).Once()
+
+		err := h.Delete(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("Append error rolls back", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/users/1/restore", nil)
+		res := httptest.NewRecorder()
+
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		rm.On("Restore", inTx, "1").Return(nil).Once()
+		al.On("Append", inTx, mock.Anything).Return(assert.AnError).Once()
+
+		err := h.Restore(ec)
+
+		assert.ErrorIs(t, err, echo.ErrInternalServerError)
+		assert.False(t, tx.committed)
+	})
+
+	t.Run("Failed mutation is not recorded", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, "/users/1", nil)
+		res := httptest.NewRecorder()
+
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		rm.On("GetByID", ec.Request().Context(), "1").Return(user(), nil).Once()
+		rm.On("Delete", inTx, "1", 1).Return(domain.ErrVersionMismatch).Once()
+
+		err := h.Delete(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+		assert.Equal(t, http.StatusPreconditionFailed, he.Code)
+	})
+
+	rm.AssertExpectations(t)
+	al.AssertExpectations(t)
+}
+
+func TestUserHTTPHandler_Outbox(t *testing.T) {
+	rm := new(repositoryMock)
+	om := new(outboxMock)
+	tx := new(transactorMock)
+	h := handler.NewUserHTTPHandler(rm, handler.WithOutbox(tx, om))
+	e := echo.New()
+	e.Validator = &requestValidator{
+		Validator: validator.New(),
+	}
+
+	user := func() *domain.User {
+		return &domain.User{ID: "1", Name: "Test", Surname: "Test", Email: "test@test.pl", Version: 1}
+	}
+
+	update := func(body string) echo.Context {
+		req, _ := http.NewRequest(http.MethodPut, "/users/1", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		ec := e.NewContext(req, httptest.NewRecorder())
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		return ec
+	}
+
+	t.Run("Email change", func(t *testing.T) {
+		ec := update(`{"name":"Test","surname":"Test","email":"new@test.pl"}`)
+
+		rm.On("GetByID", ec.Request().Context(), "1").Return(user(), nil).Once()
+		rm.On("Update", inTx, mock.Anything).Return(nil).Once()
+		om.On("Add", inTx, eventTypes(domain.UserUpdated, domain.UserEmailChanged)).Return(nil).Once()
+
+		assert.NoError(t, h.Update(ec))
+		assert.True(t, tx.committed)
+	})
+
+	t.Run("No change", func(t *testing.T) {
+		ec := update(`{"name":"Test","surname":"Test","email":"test@test.pl"}`)
+
+		rm.On("GetByID", ec.Request().Context(), "1").Return(user(), nil).Once()
+		rm.On("Update", inTx, mock.Anything).Return(nil).Once()
+		om.On("Add", inTx, eventTypes()).Return(nil).Once()
+
+		assert.NoError(t, h.Update(ec))
+	})
+
+	t.Run("Outbox error rolls back", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, "/users/1", nil)
+		ec := e.NewContext(req, httptest.NewRecorder())
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		rm.On("GetByID", ec.Request().Context(), "1").Return(user(), nil).Once()
+		rm.On("Delete", inTx, "1", 1).Return(nil).Once()
+		om.On("Add", inTx, eventTypes(domain.UserDeleted)).Return(assert.AnError).Once()
+
+		assert.ErrorIs(t, h.Delete(ec), echo.ErrInternalServerError)
+		assert.False(t, tx.committed)
+	})
+
+	rm.AssertExpectations(t)
+	om.AssertExpectations(t)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/labstack/echo/v4"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/request"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+const (
+	MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+	MIMEApplicationJSONPatchJSON  = "application/json-patch+json"
+
+	// HeaderAcceptPatch lists the patch formats accepted, see RFC 5789.
+	HeaderAcceptPatch = "Accept-Patch"
+)
+
+// Patch applies a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) to
+// the fields of the user that PUT replaces, validating the result the same
+// way. A failed `test` operation leaves the user unchanged and yields 409.
+func (h *UserHTTPHandler) Patch(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "Patch")
+
+	id := ec.Param("id")
+
+	mediaType, _, _ := mime.ParseMediaType(ec.Request().Header.Get(echo.HeaderContentType))
+	if mediaType != MIMEApplicationMergePatchJSON && mediaType != MIMEApplicationJSONPatchJSON {
+		ec.Response().Header().Set(HeaderAcceptPatch, MIMEApplicationMergePatchJSON+", "+MIMEApplicationJSONPatchJSON)
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType)
+	}
+
+	body, err := io.ReadAll(ec.Request().Body)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	user, err := h.userRepository.GetByID(ctx, id)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if user == nil {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound)
+	}
+
+	if !ifMatch(ec, user) {
+		return echo.NewHTTPError(http.StatusPreconditionFailed, problem.CodeVersionMismatch)
+	}
+
+	doc, err := json.Marshal(request.UserUpdateRequest{
+		Name:    user.Name,
+		Surname: user.Surname,
+		Email:   user.Email,
+	})
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if mediaType == MIMEApplicationMergePatchJSON {
+		doc, err = applyMergePatch(doc, body)
+	} else {
+		doc, err = applyJSONPatch(doc, body)
+	}
+	if err != nil {
+		return err
+	}
+
+	var req request.UserUpdateRequest
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&req); err != nil {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, problem.CodePatchNotApplicable).SetInternal(err)
+	}
+
+	if err = ec.Validate(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	before := *user
+	user.Name = req.Name
+	user.Surname = req.Surname
+	user.Email = req.Email
+
+	event := domain.AuditEvent{Action: domain.AuditActionUpdate, TargetID: id, Changes: domain.UserChanges(&before, user)}
+	err = h.mutate(ec, event, domain.UserEvents(&before, user), func(ctx context.Context) error {
+		saved, err := h.userRepository.Update(ctx, *user)
+		*user = saved
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrVersionMismatch):
+			return echo.NewHTTPError(http.StatusPreconditionFailed, problem.CodeVersionMismatch)
+		case ent.IsConstraintError(err):
+			return echo.NewHTTPError(http.StatusConflict, problem.CodeUserAlreadyExists)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	setUserValidators(ec, user)
+
+	return ec.JSON(http.StatusOK, &response.UserIDResponse{ID: id})
+}
+
+func applyMergePatch(doc, patch []byte) ([]byte, error) {
+	if !json.Valid(patch) {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidPatch)
+	}
+
+	res, err := jsonpatch.MergePatch(doc, patch)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, problem.CodePatchNotApplicable).SetInternal(err)
+	}
+
+	return res, nil
+}
+
+func applyJSONPatch(doc, body []byte) ([]byte, error) {
+	patch, err := jsonpatch.DecodePatch(body)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidPatch).SetInternal(err)
+	}
+
+	res, err := patch.Apply(doc)
+	if err != nil {
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, echo.NewHTTPError(http.StatusConflict, problem.CodePatchTestFailed).SetInternal(err)
+		}
+		return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, problem.CodePatchNotApplicable).SetInternal(err)
+	}
+
+	return res, nil
+}
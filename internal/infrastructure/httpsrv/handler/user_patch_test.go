@@ -0,0 +1,192 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+func TestUserHTTPHandler_Patch(t *testing.T) {
+	e := echo.New()
+	e.Validator = &requestValidator{
+		Validator: validator.New(),
+	}
+
+	user := domain.User{ID: "1", Name: "John", Surname: "Doe", Email: "john@doe.com", Password: "hash", Version: 2}
+
+	newContext := func(contentType, body string) echo.Context {
+		req, _ := http.NewRequest(http.MethodPatch, "/users/1", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		ec := e.NewContext(req, httptest.NewRecorder())
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		return ec
+	}
+
+	updated := []struct {
+		name        string
+		contentType string
+		body        string
+		want        domain.User
+	}{
+		{
+			name:        "Merge patch",
+			contentType: handler.MIMEApplicationMergePatchJSON,
+			body:        `{"surname":"Smith"}`,
+			want:        domain.User{ID: "1", Name: "John", Surname: "Smith", Email: "john@doe.com", Password: "hash", Version: 2},
+		},
+		{
+			name:        "Merge patch removing a field",
+			contentType: handler.MIMEApplicationMergePatchJSON + "; charset=utf-8",
+			body:        `{"name":null}`,
+			want:        domain.User{ID: "1", Surname: "Doe", Email: "john@doe.com", Password: "hash", Version: 2},
+		},
+		{
+			name:        "JSON patch",
+			contentType: handler.MIMEApplicationJSONPatchJSON,
+			body:        `[{"op":"test","path":"/surname","value":"Doe"},{"op":"replace","path":"/email","value":"john@smith.com"}]`,
+			want:        domain.User{ID: "1", Name: "John", Surname: "Doe", Email: "john@smith.com", Password: "hash", Version: 2},
+		},
+	}
+
+	for _, tt := range updated {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := new(repositoryMock)
+			h := handler.NewUserHTTPHandler(rm)
+			ec := newContext(tt.contentType, tt.body)
+			ctx := ec.Request().Context()
+
+			u := user
+			rm.On("GetByID", ctx, "1").Return(&u, nil).Once()
+			rm.On("Update", ctx, tt.want).Return(nil).Once()
+
+			err := h.Patch(ec)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, ec.Response().Status)
+
+			rm.AssertExpectations(t)
+		})
+	}
+
+	rejected := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		code        problem.Code
+	}{
+		{name: "Failed test", contentType: handler.MIMEApplicationJSONPatchJSON, body: `[{"op":"test","path":"/surname","value":"Smith"},{"op":"remove","path":"/name"}]`, status: http.StatusConflict, code: problem.CodePatchTestFailed},
+		{name: "Missing path", contentType: handler.MIMEApplicationJSONPatchJSON, body: `[{"op":"replace","path":"/address/city","value":"Paris"}]`, status: http.StatusUnprocessableEntity, code: problem.CodePatchNotApplicable},
+		{name: "Unknown field", contentType: handler.MIMEApplicationJSONPatchJSON, body: `[{"op":"add","path":"/password","value":"secret"}]`, status: http.StatusUnprocessableEntity, code: problem.CodePatchNotApplicable},
+		{name: "Malformed JSON patch", contentType: handler.MIMEApplicationJSONPatchJSON, body: `{"op":"add"}`, status: http.StatusBadRequest, code: problem.CodeInvalidPatch},
+		{name: "Malformed merge patch", contentType: handler.MIMEApplicationMergePatchJSON, body: `{"name":`, status: http.StatusBadRequest, code: problem.CodeInvalidPatch},
+		{name: "Invalid result", contentType: handler.MIMEApplicationMergePatchJSON, body: `{"email":"not an email"}`, status: http.StatusBadRequest, code: problem.CodeValidationFailed},
+	}
+
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := new(repositoryMock)
+			h := handler.NewUserHTTPHandler(rm)
+			ec := newContext(tt.contentType, tt.body)
+			ctx := ec.Request().Context()
+
+			u := user
+			rm.On("GetByID", ctx, "1").Return(&u, nil).Once()
+
+			err := h.Patch(ec)
+
+			var he *echo.HTTPError
+			assert.ErrorAs(t, err, &he)
+
+			assert.Equal(t, tt.status, he.Code)
+			assert.Equal(t, tt.code, he.Message)
+
+			rm.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Unsupported media type", func(t *testing.T) {
+		rm := new(repositoryMock)
+		h := handler.NewUserHTTPHandler(rm)
+		ec := newContext(echo.MIMEApplicationJSON, `{"surname":"Smith"}`)
+
+		err := h.Patch(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, he.Code)
+		assert.Equal(t, handler.MIMEApplicationMergePatchJSON+", "+handler.MIMEApplicationJSONPatchJSON,
+			ec.Response().Header().Get(handler.HeaderAcceptPatch))
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		rm := new(repositoryMock)
+		h := handler.NewUserHTTPHandler(rm)
+		ec := newContext(handler.MIMEApplicationMergePatchJSON, `{"surname":"Smith"}`)
+		ctx := ec.Request().Context()
+
+		rm.On("GetByID", ctx, "1").Return(nil, nil).Once()
+
+		err := h.Patch(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusNotFound, he.Code)
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("If-Match mismatch", func(t *testing.T) {
+		rm := new(repositoryMock)
+		h := handler.NewUserHTTPHandler(rm)
+		ec := newContext(handler.MIMEApplicationMergePatchJSON, `{"surname":"Smith"}`)
+		ec.Request().Header.Set(handler.HeaderIfMatch, `"1"`)
+		ctx := ec.Request().Context()
+
+		u := user
+		rm.On("GetByID", ctx, "1").Return(&u, nil).Once()
+
+		err := h.Patch(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusPreconditionFailed, he.Code)
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Update error", func(t *testing.T) {
+		rm := new(repositoryMock)
+		h := handler.NewUserHTTPHandler(rm)
+		ec := newContext(handler.MIMEApplicationMergePatchJSON, `{"surname":"Smith"}`)
+		ctx := ec.Request().Context()
+
+		u := user
+		rm.On("GetByID", ctx, "1").Return(&u, nil).Once()
+		rm.On("Update", ctx, mock.Anything).Return(assert.AnError).Once()
+
+		err := h.Patch(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+
+		rm.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type userSearcher interface {
+	Search(ctx context.Context, q string, limit int) ([]domain.User, error)
+}
+
+type UserSearchHTTPHandler struct {
+	searcher userSearcher
+	maxLimit int
+}
+
+const maxSearchQueryLength = 256
+
+func NewUserSearchHTTPHandler(searcher userSearcher, maxLimit int) *UserSearchHTTPHandler {
+	if maxLimit < 1 {
+		maxLimit = defaultMaxLimit
+	}
+
+	return &UserSearchHTTPHandler{
+		searcher: searcher,
+		maxLimit: maxLimit,
+	}
+}
+
+// Search returns users matching the `q` query param, best matches first.
+func (h *UserSearchHTTPHandler) Search(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "Search")
+
+	q := strings.TrimSpace(ec.QueryParam("q"))
+	if q == "" || utf8.RuneCountInString(q) > maxSearchQueryLength {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidSearchQuery)
+	}
+
+	limit := ec.QueryParam("limit")
+	if limit == "" {
+		limit = defaultLimit
+	}
+
+	li, err := strconv.Atoi(limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLimit).SetInternal(err)
+	}
+
+	if li < 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLimit)
+	}
+
+	users, err := h.searcher.Search(ctx, q, min(li, h.maxLimit))
+	if errors.Is(err, domain.ErrSearchUnavailable) {
+		return echo.NewHTTPError(http.StatusNotImplemented, problem.CodeSearchUnavailable).SetInternal(err)
+	}
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.JSON(http.StatusOK, &response.UserSearchResponse{Data: userResponses(users)})
+}
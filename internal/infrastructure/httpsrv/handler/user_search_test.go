@@ -0,0 +1,120 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/database/repository"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type unavailableSearch struct{}
+
+func (unavailableSearch) Search(context.Context, string, int) ([]domain.User, error) {
+	return nil, domain.ErrSearchUnavailable
+}
+
+func TestUserSearchHTTPHandler_Search(t *testing.T) {
+	s := repository.NewInMemoryUserSearch(
+		domain.User{ID: "1", Name: "John", Surname: "Doe", Email: "john@doe.com", Password: "secret"},
+		domain.User{ID: "2", Name: "Jane", Surname: "Doe", Email: "jane@doe.com"},
+		domain.User{ID: "3", Name: "Anna", Surname: "Nowak", Email: "anna@example.com"},
+	)
+	h := handler.NewUserSearchHTTPHandler(s, 1)
+	e := echo.New()
+
+	t.Run("Search", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/users/search?q=nowk", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+
+		err := h.Search(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var body response.UserSearchResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Equal(t, []response.UserResponse{
+			{ID: "3", Name: "Anna", Surname: "Nowak", Email: "anna@example.com"},
+		}, body.Data)
+	})
+
+	t.Run("Limit capped", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/users/search?q=doe&limit=50", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+
+		err := h.Search(ec)
+
+		assert.NoError(t, err)
+
+		var body response.UserSearchResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Len(t, body.Data, 1)
+		assert.Equal(t, "1", body.Data[0].ID)
+	})
+
+	t.Run("No match", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/users/search?q=xyz", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+
+		err := h.Search(ec)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"data":[]}`, res.Body.String())
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/users/search?q=doe", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+
+		err := handler.NewUserSearchHTTPHandler(unavailableSearch{}, 1).Search(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusNotImplemented, he.Code)
+		assert.Equal(t, problem.CodeSearchUnavailable, he.Message)
+	})
+
+	tests := []struct {
+		name  string
+		query url.Values
+		code  problem.Code
+	}{
+		{name: "Missing query", query: url.Values{}, code: problem.CodeInvalidSearchQuery},
+		{name: "Blank query", query: url.Values{"q": {"   "}}, code: problem.CodeInvalidSearchQuery},
+		{name: "Query too long", query: url.Values{"q": {strings.Repeat("a", 257)}}, code: problem.CodeInvalidSearchQuery},
+		{name: "Invalid limit", query: url.Values{"q": {"doe"}, "limit": {"0"}}, code: problem.CodeInvalidLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/users/search?"+tt.query.Encode(), nil)
+			res := httptest.NewRecorder()
+			ec := e.NewContext(req, res)
+
+			err := h.Search(ec)
+
+			var he *echo.HTTPError
+			assert.ErrorAs(t, err, &he)
+
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+			assert.Equal(t, tt.code, he.Message)
+		})
+	}
+}
@@ -3,18 +3,27 @@ package handler_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/Beriw98/user-management/ent"
 	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/query"
 	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/cursor"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
 	customvalidator "github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/validator"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
 )
 
 type requestValidator struct {
@@ -50,24 +59,47 @@ func (r *repositoryMock) GetByEmail(ctx context.Context, email string) (*domain.
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
-func (r *repositoryMock) Update(ctx context.Context, user domain.User) error {
+// Update saves user at its next version, unless it is told to fail.
+func (r *repositoryMock) Update(ctx context.Context, user domain.User) (domain.User, error) {
 	args := r.Called(ctx, user)
+	if err := args.Error(0); err != nil {
+		return user, err
+	}
+	user.Version++
+	return user, nil
+}
+
+func (r *repositoryMock) Delete(ctx context.Context, id string, version int) error {
+	args := r.Called(ctx, id, version)
 	return args.Error(0)
 }
 
-func (r *repositoryMock) Delete(ctx context.Context, id string) error {
+func (r *repositoryMock) Restore(ctx context.Context, id string) error {
 	args := r.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (r *repositoryMock) GetMany(ctx context.Context, limit, offset int) ([]domain.User, error) {
-	args := r.Called(ctx, limit, offset)
+func (r *repositoryMock) GetMany(ctx context.Context, list query.List, limit, offset int) ([]domain.User, error) {
+	args := r.Called(ctx, list, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.User), args.Error(1)
 }
 
+func (r *repositoryMock) GetPage(ctx context.Context, list query.List, page query.Page) ([]domain.User, query.PageInfo, error) {
+	args := r.Called(ctx, list, page)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(query.PageInfo), args.Error(2)
+	}
+	return args.Get(0).([]domain.User), args.Get(1).(query.PageInfo), args.Error(2)
+}
+
+func (r *repositoryMock) Count(ctx context.Context, list query.List) (int, error) {
+	args := r.Called(ctx, list)
+	return args.Int(0), args.Error(1)
+}
+
 func TestNewUserHTTPHandler(t *testing.T) {
 	t.Run("NewUserHTTPHandler", func(t *testing.T) {
 		h := handler.NewUserHTTPHandler(nil)
@@ -115,6 +147,28 @@ func TestUserHTTPHandler_Create(t *testing.T) {
 		rm.AssertExpectations(t)
 	})
 
+	t.Run("Erased", func(t *testing.T) {
+		rm := new(repositoryMock)
+		tombstones := new(erasedEmailsMock)
+		h := handler.NewUserHTTPHandler(rm, handler.WithErasureTombstones(tombstones))
+
+		body := `{"name":"Test","surname":"Test","email":"erased@test.pl","password":"password"}`
+		req, _ := http.NewRequest(http.MethodPost, "/users", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+
+		ec := e.NewContext(req, res)
+
+		rm.On("GetByEmail", mock.Anything, "erased@test.pl").Return(nil, nil).Once()
+		tombstones.On("IsErased", mock.Anything, "erased@test.pl").Return(true, nil).Once()
+
+		err := h.Create(ec)
+
+		assert.Equal(t, echo.NewHTTPError(http.StatusConflict, problem.CodeUserErased), err)
+		rm.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		tombstones.AssertExpectations(t)
+	})
+
 	t.Run("Bind error", func(t *testing.T) {
 		body := `{"name":"Test","surname":"Test","email":"`
 		req, _ := http.NewRequest(http.MethodPost, "/users", bytes.NewReader([]byte(body)))
@@ -249,7 +303,7 @@ func TestUserHTTPHandler_Delete(t *testing.T) {
 		ctx := ec.Request().Context()
 
 		rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1"}, nil).Once()
-		rm.On("Delete", ctx, "1").Return(nil).Once()
+		rm.On("Delete", ctx, "1", 0).Return(nil).Once()
 
 		err := h.Delete(ec)
 
@@ -268,7 +322,7 @@ func TestUserHTTPHandler_Delete(t *testing.T) {
 		ctx := ec.Request().Context()
 
 		rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1"}, nil).Once()
-		rm.On("Delete", ctx, "1").Return(assert.AnError).Once()
+		rm.On("Delete", ctx, "1", 0).Return(assert.AnError).Once()
 
 		err := h.Delete(ec)
 
@@ -319,6 +373,125 @@ func TestUserHTTPHandler_Delete(t *testing.T) {
 
 		rm.AssertExpectations(t)
 	})
+
+	t.Run("If-Match", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, "/users/1", nil)
+		req.Header.Set(handler.HeaderIfMatch, `"1", "2"`)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+		ctx := ec.Request().Context()
+
+		rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1", Version: 2}, nil).Once()
+		rm.On("Delete", ctx, "1", 2).Return(nil).Once()
+
+		err := h.Delete(ec)
+
+		assert.NoError(t, err)
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("If-Match mismatch", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, "/users/1", nil)
+		req.Header.Set(handler.HeaderIfMatch, `"1"`)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+		ctx := ec.Request().Context()
+
+		rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1", Version: 2}, nil).Once()
+
+		err := h.Delete(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusPreconditionFailed, he.Code)
+		assert.Equal(t, problem.CodeVersionMismatch, he.Message)
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Concurrent change", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodDelete, "/users/1", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+		ctx := ec.Request().Context()
+
+		rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1", Version: 2}, nil).Once()
+		rm.On("Delete", ctx, "1", 2).Return(domain.ErrVersionMismatch).Once()
+
+		err := h.Delete(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusPreconditionFailed, he.Code)
+
+		rm.AssertExpectations(t)
+	})
+}
+
+func TestUserHTTPHandler_Restore(t *testing.T) {
+	rm := new(repositoryMock)
+	h := handler.NewUserHTTPHandler(rm)
+	e := echo.New()
+
+	t.Run("Restore", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/users/1/restore", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+		ctx := ec.Request().Context()
+
+		rm.On("Restore", ctx, "1").Return(nil).Once()
+
+		err := h.Restore(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"id":"1"}`, res.Body.String())
+
+		rm.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "User not deleted", err: &ent.NotFoundError{}, status: http.StatusNotFound},
+		{name: "Email taken", err: &ent.ConstraintError{}, status: http.StatusConflict},
+		{name: "Restore error", err: assert.AnError, status: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, "/users/1/restore", nil)
+			res := httptest.NewRecorder()
+			ec := e.NewContext(req, res)
+			ec.SetParamNames("id")
+			ec.SetParamValues("1")
+			ctx := ec.Request().Context()
+
+			rm.On("Restore", ctx, "1").Return(tt.err).Once()
+
+			err := h.Restore(ec)
+
+			var he *echo.HTTPError
+			assert.ErrorAs(t, err, &he)
+
+			assert.Equal(t, tt.status, he.Code)
+
+			rm.AssertExpectations(t)
+		})
+	}
 }
 
 func TestUserHTTPHandler_GetByID(t *testing.T) {
@@ -338,10 +511,13 @@ func TestUserHTTPHandler_GetByID(t *testing.T) {
 		ctx := ec.Request().Context()
 
 		user := domain.User{
-			ID:      "1",
-			Name:    "Test",
-			Surname: "Test",
-			Email:   "test@test.pl",
+			ID:        "1",
+			Name:      "Test",
+			Surname:   "Test",
+			Email:     "test@test.pl",
+			CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2026, 2, 1, 12, 30, 0, 0, time.UTC),
+			Version:   3,
 		}
 
 		rm.On("GetByID", ctx, "1").Return(&user, nil).Once()
@@ -349,6 +525,10 @@ func TestUserHTTPHandler_GetByID(t *testing.T) {
 		err := h.GetByID(ec)
 
 		assert.NoError(t, err)
+		assert.Equal(t, `"3"`, res.Header().Get(handler.HeaderETag))
+		assert.Equal(t, "Sun, 01 Feb 2026 12:30:00 GMT", res.Header().Get(echo.HeaderLastModified))
+		assert.JSONEq(t, `{"id":"1","name":"Test","surname":"Test","email":"test@test.pl",`+
+			`"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-02-01T12:30:00Z","version":3}`, res.Body.String())
 
 		rm.AssertExpectations(t)
 	})
@@ -418,7 +598,7 @@ func TestUserHTTPHandler_GetMany(t *testing.T) {
 			},
 		}
 
-		rm.On("GetMany", ctx, 10, 0).Return(users, nil).Once()
+		rm.On("GetMany", ctx, query.List{}, 10, 0).Return(users, nil).Once()
 
 		err := h.GetMany(ec)
 
@@ -433,7 +613,7 @@ func TestUserHTTPHandler_GetMany(t *testing.T) {
 		ec := e.NewContext(req, res)
 		ctx := ec.Request().Context()
 
-		rm.On("GetMany", ctx, 10, 0).Return(nil, assert.AnError).Once()
+		rm.On("GetMany", ctx, query.List{}, 10, 0).Return(nil, assert.AnError).Once()
 
 		err := h.GetMany(ec)
 
@@ -461,7 +641,7 @@ func TestUserHTTPHandler_GetMany(t *testing.T) {
 			},
 		}
 
-		rm.On("GetMany", ctx, 5, 1).Return(users, nil).Once()
+		rm.On("GetMany", ctx, query.List{}, 5, 1).Return(users, nil).Once()
 
 		err := h.GetMany(ec)
 
@@ -501,6 +681,258 @@ func TestUserHTTPHandler_GetMany(t *testing.T) {
 	})
 }
 
+func TestUserHTTPHandler_GetMany_Cursor(t *testing.T) {
+	rm := new(repositoryMock)
+	codec := cursor.NewCodec([]byte("secret"))
+	h := handler.NewUserHTTPHandler(rm, handler.WithPagination(codec, 50))
+	e := echo.New()
+
+	users := []domain.User{
+		{ID: "1", Name: "Test", Surname: "Test", Email: "test1@test.pl"},
+		{ID: "2", Name: "Test", Surname: "Test", Email: "test2@test.pl"},
+	}
+
+	t.Run("First page", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/users?cursor=&limit=2&total=true", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ctx := ec.Request().Context()
+
+		info := query.PageInfo{HasMore: true, First: []string{"1"}, Last: []string{"2"}}
+		rm.On("GetPage", ctx, query.List{}, query.Page{Limit: 2}).Return(users, info, nil).Once()
+		rm.On("Count", ctx, query.List{}).Return(5, nil).Once()
+
+		err := h.GetMany(ec)
+		assert.NoError(t, err)
+
+		var got response.UserPageResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &got))
+
+		assert.Len(t, got.Data, 2)
+		assert.Equal(t, 5, *got.Total)
+		assert.Empty(t, got.Links.Prev)
+		assert.Equal(t, `<`+got.Links.Next+`>; rel="next"`, res.Header().Get(handler.HeaderLink))
+
+		next, _ := url.Parse(got.Links.Next)
+		assert.Equal(t, "/users", next.Path)
+		assert.Equal(t, "2", next.Query().Get("limit"))
+
+		cur, err := codec.Decode(next.Query().Get("cursor"))
+		assert.NoError(t, err)
+		assert.Equal(t, cursor.Cursor{Keys: []string{"2"}, Direction: cursor.Next}, cur)
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Previous page", func(t *testing.T) {
+		token, _ := codec.Encode(cursor.Cursor{Keys: []string{"Test", "3"}, Sort: "-surname", Direction: cursor.Prev})
+		req, _ := http.NewRequest(http.MethodGet, "/users?cursor="+token+"&limit=2&sort=-surname", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ctx := ec.Request().Context()
+
+		list := query.List{Sort: []query.Sort{{Field: "surname", Desc: true}}}
+		info := query.PageInfo{First: []string{"Test", "1"}, Last: []string{"Test", "2"}}
+		rm.On("GetPage", ctx, list, query.Page{Before: []string{"Test", "3"}, Limit: 2}).Return(users, info, nil).Once()
+
+		err := h.GetMany(ec)
+		assert.NoError(t, err)
+
+		var got response.UserPageResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &got))
+
+		assert.Nil(t, got.Total)
+		assert.Empty(t, got.Links.Prev)
+		assert.NotEmpty(t, got.Links.Next)
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Limit capped", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/users?cursor=&limit=1000", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ctx := ec.Request().Context()
+
+		rm.On("GetPage", ctx, query.List{}, query.Page{Limit: 50}).Return([]domain.User{}, query.PageInfo{}, nil).Once()
+
+		err := h.GetMany(ec)
+		assert.NoError(t, err)
+		assert.Empty(t, res.Header().Get(handler.HeaderLink))
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/users?cursor=invalid", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+
+		err := h.GetMany(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Cursor of other sort", func(t *testing.T) {
+		token, _ := codec.Encode(cursor.Cursor{Keys: []string{"Test", "3"}, Sort: "-surname", Direction: cursor.Next})
+		req, _ := http.NewRequest(http.MethodGet, "/users?cursor="+token+"&sort=name", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+
+		err := h.GetMany(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("GetPage error", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/users?cursor=", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ctx := ec.Request().Context()
+
+		rm.On("GetPage", ctx, query.List{}, query.Page{Limit: 10}).Return(nil, query.PageInfo{}, assert.AnError).Once()
+
+		err := h.GetMany(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusInternalServerError, he.Code)
+
+		rm.AssertExpectations(t)
+	})
+}
+
+func TestUserHTTPHandler_GetMany_Filter(t *testing.T) {
+	rm := new(repositoryMock)
+	h := handler.NewUserHTTPHandler(rm)
+	e := echo.New()
+
+	t.Run("Filter and sort", func(t *testing.T) {
+		q := url.Values{
+			"filter": {`email ew "@ourco.com" and (surname eq "Doe" or name sw "J")`},
+			"sort":   {"surname,-name"},
+		}
+		req, _ := http.NewRequest(http.MethodGet, "/users?"+q.Encode(), nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ctx := ec.Request().Context()
+
+		list := query.List{
+			Filter: query.And{
+				query.Condition{Field: "email", Operator: query.Ew, Value: "@ourco.com"},
+				query.Or{
+					query.Condition{Field: "surname", Operator: query.Eq, Value: "Doe"},
+					query.Condition{Field: "name", Operator: query.Sw, Value: "J"},
+				},
+			},
+			Sort: []query.Sort{{Field: "surname"}, {Field: "name", Desc: true}},
+		}
+
+		rm.On("GetMany", ctx, list, 10, 0).Return([]domain.User{}, nil).Once()
+
+		err := h.GetMany(ec)
+
+		assert.NoError(t, err)
+
+		rm.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name  string
+		query url.Values
+		code  problem.Code
+	}{
+		{name: "Unknown filter field", query: url.Values{"filter": {`password eq "x"`}}, code: problem.CodeUnknownField},
+		{name: "Unknown operator", query: url.Values{"filter": {`id sw "x"`}}, code: problem.CodeUnknownOperator},
+		{name: "Invalid filter", query: url.Values{"filter": {`email eq`}}, code: problem.CodeInvalidFilter},
+		{name: "Unknown sort field", query: url.Values{"sort": {"password"}}, code: problem.CodeUnknownField},
+		{name: "Duplicate sort field", query: url.Values{"sort": {"name,-name"}}, code: problem.CodeInvalidSort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/users?"+tt.query.Encode(), nil)
+			res := httptest.NewRecorder()
+			ec := e.NewContext(req, res)
+
+			err := h.GetMany(ec)
+
+			var he *echo.HTTPError
+			assert.ErrorAs(t, err, &he)
+
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+			assert.Equal(t, tt.code, he.Message.(problem.Message).Code)
+
+			rm.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Filter the repository cannot run", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/users?filter="+url.QueryEscape(`name sw "J"`), nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ctx := ec.Request().Context()
+
+		list := query.List{Filter: query.Condition{Field: "name", Operator: query.Sw, Value: "J"}}
+		rm.On("GetMany", ctx, list, 10, 0).
+			Return([]domain.User(nil), &query.FieldError{Err: query.ErrUnknownOperator, Field: "name", Value: "sw"}).Once()
+
+		err := h.GetMany(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, problem.Message{Code: problem.CodeUnknownOperator, Params: []string{"sw", "name"}}, he.Message)
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Include deleted", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/users?include_deleted=true", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ctx := ec.Request().Context()
+
+		deletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		rm.On("GetMany", ctx, query.List{IncludeDeleted: true}, 10, 0).
+			Return([]domain.User{{ID: "1", DeletedAt: &deletedAt}}, nil).Once()
+
+		err := h.GetMany(ec)
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"id":"1","name":"","surname":"","email":"","created_at":"0001-01-01T00:00:00Z",`+
+			`"updated_at":"0001-01-01T00:00:00Z","version":0,"deleted_at":"2026-01-01T00:00:00Z"}]`, res.Body.String())
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Invalid include_deleted", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/users?include_deleted=maybe", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+
+		err := h.GetMany(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusBadRequest, he.Code)
+		assert.Equal(t, problem.CodeInvalidIncludeDeleted, he.Message)
+	})
+}
+
 func TestUserHTTPHandler_Update(t *testing.T) {
 	rm := new(repositoryMock)
 	h := handler.NewUserHTTPHandler(rm)
@@ -540,6 +972,8 @@ func TestUserHTTPHandler_Update(t *testing.T) {
 		err := h.Update(ec)
 
 		assert.NoError(t, err)
+		assert.Equal(t, `"1"`, res.Header().Get(handler.HeaderETag))
+		assert.NotEmpty(t, res.Header().Get(echo.HeaderLastModified))
 
 		rm.AssertExpectations(t)
 	})
@@ -661,6 +1095,81 @@ func TestUserHTTPHandler_Update(t *testing.T) {
 
 		rm.AssertExpectations(t)
 	})
+
+	t.Run("If-Match mismatch", func(t *testing.T) {
+		body := `{"name":"Test","surname":"Test","email":"test@test.pl"}`
+
+		req, _ := http.NewRequest(http.MethodPut, "/users/1", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(handler.HeaderIfMatch, `W/"2"`)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+		ctx := ec.Request().Context()
+
+		rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1", Version: 2}, nil).Once()
+
+		err := h.Update(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusPreconditionFailed, he.Code)
+		assert.Equal(t, problem.CodeVersionMismatch, he.Message)
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Concurrent change", func(t *testing.T) {
+		body := `{"name":"Test","surname":"Test","email":"test@test.pl"}`
+
+		req, _ := http.NewRequest(http.MethodPut, "/users/1", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(handler.HeaderIfMatch, `"2"`)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+		ctx := ec.Request().Context()
+
+		rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1", Version: 2}, nil).Once()
+		rm.On("Update", ctx, mock.Anything).Return(fmt.Errorf("wrapped: %w", domain.ErrVersionMismatch)).Once()
+
+		err := h.Update(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusPreconditionFailed, he.Code)
+
+		rm.AssertExpectations(t)
+	})
+
+	t.Run("Email taken", func(t *testing.T) {
+		body := `{"name":"Test","surname":"Test","email":"taken@test.pl"}`
+
+		req, _ := http.NewRequest(http.MethodPut, "/users/1", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+		ctx := ec.Request().Context()
+
+		rm.On("GetByID", ctx, "1").Return(&domain.User{ID: "1", Version: 1}, nil).Once()
+		rm.On("Update", ctx, mock.Anything).Return(&ent.ConstraintError{}).Once()
+
+		err := h.Update(ec)
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, err, &he)
+
+		assert.Equal(t, http.StatusConflict, he.Code)
+		assert.Equal(t, problem.CodeUserAlreadyExists, he.Message)
+
+		rm.AssertExpectations(t)
+	})
 }
 
 func TestUserHTTPHandler_UpdatePassword(t *testing.T) {
@@ -0,0 +1,295 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/xid"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/request"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type webhookRepository interface {
+	CreateSubscription(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, subscriptionID string, status domain.DeliveryStatus, limit int) ([]domain.WebhookDelivery, error)
+	Redeliver(ctx context.Context, subscriptionID string, id int64) error
+}
+
+type WebhookHTTPHandler struct {
+	webhooks webhookRepository
+	maxLimit int
+}
+
+func NewWebhookHTTPHandler(webhooks webhookRepository, maxLimit int) *WebhookHTTPHandler {
+	if maxLimit < 1 {
+		maxLimit = defaultMaxLimit
+	}
+
+	return &WebhookHTTPHandler{
+		webhooks: webhooks,
+		maxLimit: maxLimit,
+	}
+}
+
+// Create subscribes a URL to events. The secret signing the deliveries is
+// generated unless given, and is only ever returned here.
+func (h *WebhookHTTPHandler) Create(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "WebhookCreate")
+
+	var req *request.WebhookCreateRequest
+	if err := ec.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if err := ec.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	sub := domain.WebhookSubscription{
+		ID:         xid.New().String(),
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: eventTypes(req.EventTypes),
+		Active:     req.Active == nil || *req.Active,
+	}
+
+	if sub.Secret == "" {
+		secret := make([]byte, 32)
+		_, _ = rand.Read(secret)
+		sub.Secret = hex.EncodeToString(secret)
+	}
+
+	created, err := h.webhooks.CreateSubscription(ctx, sub)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	res := webhookResponse(created)
+	res.Secret = sub.Secret
+
+	return ec.JSON(http.StatusCreated, res)
+}
+
+func (h *WebhookHTTPHandler) GetMany(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "WebhookGetMany")
+
+	subs, err := h.webhooks.ListSubscriptions(ctx)
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	res := response.WebhookListResponse{
+		Data: make([]response.WebhookResponse, 0, len(subs)),
+	}
+
+	for _, sub := range subs {
+		res.Data = append(res.Data, webhookResponse(sub))
+	}
+
+	return ec.JSON(http.StatusOK, res)
+}
+
+func (h *WebhookHTTPHandler) GetByID(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "WebhookGetByID")
+
+	sub, err := h.webhooks.GetSubscription(ctx, ec.Param("id"))
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if sub == nil {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeWebhookNotFound)
+	}
+
+	return ec.JSON(http.StatusOK, webhookResponse(*sub))
+}
+
+func (h *WebhookHTTPHandler) Update(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "WebhookUpdate")
+
+	var req *request.WebhookUpdateRequest
+	if err := ec.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidBody).SetInternal(err)
+	}
+
+	if err := ec.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(err)
+	}
+
+	sub, err := h.webhooks.GetSubscription(ctx, ec.Param("id"))
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if sub == nil {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeWebhookNotFound)
+	}
+
+	sub.URL = req.URL
+	sub.EventTypes = eventTypes(req.EventTypes)
+	sub.Active = req.Active
+
+	updated, err := h.webhooks.UpdateSubscription(ctx, *sub)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return echo.NewHTTPError(http.StatusNotFound, problem.CodeWebhookNotFound)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.JSON(http.StatusOK, webhookResponse(updated))
+}
+
+// Delete removes the subscription and its delivery log.
+func (h *WebhookHTTPHandler) Delete(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "WebhookDelete")
+
+	if err := h.webhooks.DeleteSubscription(ctx, ec.Param("id")); err != nil {
+		if ent.IsNotFound(err) {
+			return echo.NewHTTPError(http.StatusNotFound, problem.CodeWebhookNotFound)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.NoContent(http.StatusNoContent)
+}
+
+// Deliveries lists the latest deliveries of a subscription, optionally only
+// those in the `status` query param.
+func (h *WebhookHTTPHandler) Deliveries(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "WebhookDeliveries")
+
+	limit := ec.QueryParam("limit")
+	if limit == "" {
+		limit = defaultLimit
+	}
+
+	li, err := strconv.Atoi(limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLimit).SetInternal(err)
+	}
+
+	if li < 1 {
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidLimit)
+	}
+
+	status := domain.DeliveryStatus(ec.QueryParam("status"))
+	switch status {
+	case "", domain.DeliveryPending, domain.DeliverySucceeded, domain.DeliveryDead:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, problem.CodeInvalidDeliveryStatus)
+	}
+
+	sub, err := h.webhooks.GetSubscription(ctx, ec.Param("id"))
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	if sub == nil {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeWebhookNotFound)
+	}
+
+	deliveries, err := h.webhooks.ListDeliveries(ctx, sub.ID, status, min(li, h.maxLimit))
+	if err != nil {
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	res := response.WebhookDeliveryListResponse{
+		Data: make([]response.WebhookDeliveryResponse, 0, len(deliveries)),
+	}
+
+	for _, d := range deliveries {
+		res.Data = append(res.Data, webhookDeliveryResponse(d))
+	}
+
+	return ec.JSON(http.StatusOK, res)
+}
+
+// Redeliver sends a delivery again as soon as possible, dead ones included.
+func (h *WebhookHTTPHandler) Redeliver(ec echo.Context) error {
+	ctx := ec.Request().Context()
+	l := slog.Default().With("handler", "WebhookRedeliver")
+
+	id, err := strconv.ParseInt(ec.Param("delivery_id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, problem.CodeDeliveryNotFound)
+	}
+
+	if err = h.webhooks.Redeliver(ctx, ec.Param("id"), id); err != nil {
+		if ent.IsNotFound(err) {
+			return echo.NewHTTPError(http.StatusNotFound, problem.CodeDeliveryNotFound)
+		}
+		l.ErrorContext(ctx, err.Error())
+		return echo.ErrInternalServerError
+	}
+
+	return ec.NoContent(http.StatusAccepted)
+}
+
+func eventTypes(types []string) []domain.EventType {
+	res := make([]domain.EventType, 0, len(types))
+	for _, t := range types {
+		res = append(res, domain.EventType(t))
+	}
+
+	return res
+}
+
+func webhookResponse(sub domain.WebhookSubscription) response.WebhookResponse {
+	types := make([]string, 0, len(sub.EventTypes))
+	for _, t := range sub.EventTypes {
+		types = append(types, string(t))
+	}
+
+	return response.WebhookResponse{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: types,
+		Active:     sub.Active,
+		CreatedAt:  sub.CreatedAt,
+		UpdatedAt:  sub.UpdatedAt,
+	}
+}
+
+func webhookDeliveryResponse(d domain.WebhookDelivery) response.WebhookDeliveryResponse {
+	return response.WebhookDeliveryResponse{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		EventType:      string(d.EventType),
+		Payload:        d.Payload,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastAttemptAt:  d.LastAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+	}
+}
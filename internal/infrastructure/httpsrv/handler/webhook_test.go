@@ -0,0 +1,234 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Beriw98/user-management/ent"
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/response"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type webhookRepositoryMock struct {
+	mock.Mock
+}
+
+func (w *webhookRepositoryMock) CreateSubscription(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	args := w.Called(ctx, sub)
+	return args.Get(0).(domain.WebhookSubscription), args.Error(1)
+}
+
+func (w *webhookRepositoryMock) GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	args := w.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookSubscription), args.Error(1)
+}
+
+func (w *webhookRepositoryMock) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	args := w.Called(ctx)
+	return args.Get(0).([]domain.WebhookSubscription), args.Error(1)
+}
+
+func (w *webhookRepositoryMock) UpdateSubscription(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	args := w.Called(ctx, sub)
+	return args.Get(0).(domain.WebhookSubscription), args.Error(1)
+}
+
+func (w *webhookRepositoryMock) DeleteSubscription(ctx context.Context, id string) error {
+	args := w.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (w *webhookRepositoryMock) ListDeliveries(ctx context.Context, subscriptionID string, status domain.DeliveryStatus, limit int) ([]domain.WebhookDelivery, error) {
+	args := w.Called(ctx, subscriptionID, status, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (w *webhookRepositoryMock) Redeliver(ctx context.Context, subscriptionID string, id int64) error {
+	args := w.Called(ctx, subscriptionID, id)
+	return args.Error(0)
+}
+
+func TestWebhookHTTPHandler_Create(t *testing.T) {
+	wm := new(webhookRepositoryMock)
+	h := handler.NewWebhookHTTPHandler(wm, 10)
+	e := echo.New()
+	e.Validator = &requestValidator{
+		Validator: validator.New(),
+	}
+
+	create := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res := httptest.NewRecorder()
+
+		return e.NewContext(req, res), res
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		ec, res := create(`{"url":"https://partner.test/hook","event_types":["user.created"]}`)
+
+		wm.On("CreateSubscription", ec.Request().Context(), mock.MatchedBy(func(sub domain.WebhookSubscription) bool {
+			return sub.URL == "https://partner.test/hook" && len(sub.Secret) == 64 && sub.Active &&
+				len(sub.EventTypes) == 1 && sub.EventTypes[0] == domain.UserCreated
+		})).Return(domain.WebhookSubscription{ID: "1", URL: "https://partner.test/hook", EventTypes: []domain.EventType{domain.UserCreated}, Active: true}, nil).Once()
+
+		err := h.Create(ec)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, res.Code)
+
+		var body response.WebhookResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Len(t, body.Secret, 64)
+		assert.Equal(t, []string{"user.created"}, body.EventTypes)
+	})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "Missing URL", body: `{}`},
+		{name: "Not an HTTP URL", body: `{"url":"ftp://partner.test"}`},
+		{name: "Unknown event type", body: `{"url":"https://partner.test","event_types":["user.renamed"]}`},
+		{name: "Short secret", body: `{"url":"https://partner.test","secret":"short"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ec, _ := create(tt.body)
+
+			err := h.Create(ec)
+
+			var he *echo.HTTPError
+			assert.ErrorAs(t, err, &he)
+			assert.Equal(t, http.StatusBadRequest, he.Code)
+			assert.Equal(t, problem.CodeValidationFailed, he.Message)
+		})
+	}
+}
+
+func TestWebhookHTTPHandler_GetByID(t *testing.T) {
+	wm := new(webhookRepositoryMock)
+	h := handler.NewWebhookHTTPHandler(wm, 10)
+	e := echo.New()
+
+	t.Run("Secret is not returned", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/webhooks/1", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		wm.On("GetSubscription", ec.Request().Context(), "1").
+			Return(&domain.WebhookSubscription{ID: "1", URL: "https://partner.test", Secret: "secret", Active: true}, nil).Once()
+
+		assert.NoError(t, h.GetByID(ec))
+		assert.NotContains(t, res.Body.String(), "secret")
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/webhooks/2", nil)
+		ec := e.NewContext(req, httptest.NewRecorder())
+		ec.SetParamNames("id")
+		ec.SetParamValues("2")
+
+		wm.On("GetSubscription", ec.Request().Context(), "2").Return(nil, nil).Once()
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, h.GetByID(ec), &he)
+		assert.Equal(t, problem.CodeWebhookNotFound, he.Message)
+	})
+}
+
+func TestWebhookHTTPHandler_Deliveries(t *testing.T) {
+	wm := new(webhookRepositoryMock)
+	h := handler.NewWebhookHTTPHandler(wm, 10)
+	e := echo.New()
+
+	t.Run("Deliveries", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/webhooks/1/deliveries?status=dead&limit=50", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		wm.On("GetSubscription", ec.Request().Context(), "1").Return(&domain.WebhookSubscription{ID: "1"}, nil).Once()
+		wm.On("ListDeliveries", ec.Request().Context(), "1", domain.DeliveryDead, 10).
+			Return([]domain.WebhookDelivery{{ID: 5, Status: domain.DeliveryDead, Attempts: 10, LastStatusCode: 500, Payload: []byte(`{}`)}}, nil).Once()
+
+		assert.NoError(t, h.Deliveries(ec))
+
+		var body response.WebhookDeliveryListResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &body))
+		assert.Len(t, body.Data, 1)
+		assert.Equal(t, "dead", body.Data[0].Status)
+	})
+
+	t.Run("Invalid status", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/webhooks/1/deliveries?status=failed", nil)
+		ec := e.NewContext(req, httptest.NewRecorder())
+		ec.SetParamNames("id")
+		ec.SetParamValues("1")
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, h.Deliveries(ec), &he)
+		assert.Equal(t, problem.CodeInvalidDeliveryStatus, he.Message)
+	})
+}
+
+func TestWebhookHTTPHandler_Redeliver(t *testing.T) {
+	wm := new(webhookRepositoryMock)
+	h := handler.NewWebhookHTTPHandler(wm, 10)
+	e := echo.New()
+
+	redeliver := func(id, deliveryID string) (echo.Context, *httptest.ResponseRecorder) {
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks/"+id+"/deliveries/"+deliveryID+"/redeliver", nil)
+		res := httptest.NewRecorder()
+		ec := e.NewContext(req, res)
+		ec.SetParamNames("id", "delivery_id")
+		ec.SetParamValues(id, deliveryID)
+
+		return ec, res
+	}
+
+	t.Run("Redeliver", func(t *testing.T) {
+		ec, res := redeliver("1", "5")
+
+		wm.On("Redeliver", ec.Request().Context(), "1", int64(5)).Return(nil).Once()
+
+		assert.NoError(t, h.Redeliver(ec))
+		assert.Equal(t, http.StatusAccepted, res.Code)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		ec, _ := redeliver("1", "6")
+
+		wm.On("Redeliver", ec.Request().Context(), "1", int64(6)).Return(&ent.NotFoundError{}).Once()
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, h.Redeliver(ec), &he)
+		assert.Equal(t, problem.CodeDeliveryNotFound, he.Message)
+	})
+
+	t.Run("Invalid delivery ID", func(t *testing.T) {
+		ec, _ := redeliver("1", "x")
+
+		var he *echo.HTTPError
+		assert.ErrorAs(t, h.Redeliver(ec), &he)
+		assert.Equal(t, http.StatusNotFound, he.Code)
+	})
+}
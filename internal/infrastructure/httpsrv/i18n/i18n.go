@@ -0,0 +1,202 @@
+package i18n
+
+import (
+	"embed"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/fr"
+	"github.com/go-playground/locales/pl"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	estranslations "github.com/go-playground/validator/v10/translations/es"
+	frtranslations "github.com/go-playground/validator/v10/translations/fr"
+	pltranslations "github.com/go-playground/validator/v10/translations/pl"
+	"github.com/labstack/echo/v4"
+
+	customvalidator "github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/validator"
+)
+
+const (
+	HeaderAcceptLanguage  = "Accept-Language"
+	HeaderContentLanguage = "Content-Language"
+
+	contextKey = "translator"
+)
+
+//go:embed catalogs/*.json
+var catalogs embed.FS
+
+var validatorTranslations = map[string]func(*validator.Validate, ut.Translator) error{
+	"en": entranslations.RegisterDefaultTranslations,
+	"pl": pltranslations.RegisterDefaultTranslations,
+	"es": estranslations.RegisterDefaultTranslations,
+	"fr": frtranslations.RegisterDefaultTranslations,
+}
+
+// Translator negotiates the request locale and holds translations of
+// validator rules and problem details for every supported locale.
+type Translator struct {
+	uni *ut.UniversalTranslator
+}
+
+// New registers translations of all validator tags on v. Catalogs embedded in
+// the binary are loaded first, JSON catalogs from catalogDir (if set) can add
+// locales' keys or override them with `"override": true`.
+func New(v *validator.Validate, catalogDir string) (*Translator, error) {
+	uni := ut.New(en.New(), en.New(), pl.New(), es.New(), fr.New())
+
+	for locale, register := range validatorTranslations {
+		trans, _ := uni.GetTranslator(locale)
+		if err := register(v, trans); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := catalogs.ReadDir("catalogs")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		f, err := catalogs.Open(path.Join("catalogs", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		err = uni.ImportByReader(ut.FormatJSON, f)
+		_ = f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if catalogDir != "" {
+		if err = uni.Import(ut.FormatJSON, catalogDir); err != nil {
+			return nil, err
+		}
+	}
+
+	for locale := range validatorTranslations {
+		trans, _ := uni.GetTranslator(locale)
+		err = v.RegisterTranslation(customvalidator.PasswordValidator, trans, registerNoop, translateTag)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Translator{uni: uni}, nil
+}
+
+// Negotiate picks the best supported translator for an Accept-Language
+// header value, falling back to English.
+func (t *Translator) Negotiate(acceptLanguage string) ut.Translator {
+	trans, _ := t.uni.FindTranslator(parseAcceptLanguage(acceptLanguage)...)
+
+	return trans
+}
+
+// Fallback returns the default translator.
+func (t *Translator) Fallback() ut.Translator {
+	return t.uni.GetFallback()
+}
+
+// FromContext returns the translator negotiated for the request, or the
+// fallback one when the locale middleware did not run.
+func (t *Translator) FromContext(c echo.Context) ut.Translator {
+	if trans, ok := c.Get(contextKey).(ut.Translator); ok {
+		return trans
+	}
+
+	return t.Fallback()
+}
+
+// Negotiated returns the translator negotiated for the request, if the locale
+// middleware ran, for when the Translator is out of reach.
+func Negotiated(c echo.Context) (ut.Translator, bool) {
+	trans, ok := c.Get(contextKey).(ut.Translator)
+
+	return trans, ok
+}
+
+// SetContext stores the negotiated translator in the request context.
+func SetContext(c echo.Context, trans ut.Translator) {
+	c.Set(contextKey, trans)
+}
+
+// Message translates key, returning an empty string if it is unknown or there
+// is no translator.
+func Message(trans ut.Translator, key string, params ...string) string {
+	if trans == nil {
+		return ""
+	}
+
+	msg, err := trans.T(key, params...)
+	if err != nil {
+		return ""
+	}
+
+	return msg
+}
+
+func registerNoop(ut.Translator) error {
+	return nil
+}
+
+func translateTag(trans ut.Translator, fe validator.FieldError) string {
+	return Message(trans, fe.Tag(), fe.Field())
+}
+
+type languageRange struct {
+	tag string
+	q   float64
+}
+
+// parseAcceptLanguage returns locales ordered by preference. Every region
+// specific tag is followed by its base language, e.g. `pl-PL` yields `pl_PL`
+// and `pl`.
+func parseAcceptLanguage(header string) []string {
+	var ranges []languageRange
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		if q <= 0 {
+			continue
+		}
+
+		ranges = append(ranges, languageRange{tag: tag, q: q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+
+	res := make([]string, 0, len(ranges)*2)
+	for _, r := range ranges {
+		tag := strings.ReplaceAll(r.tag, "-", "_")
+		res = append(res, tag)
+
+		if base, _, ok := strings.Cut(tag, "_"); ok {
+			res = append(res, base)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,63 @@
+package i18n_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/i18n"
+)
+
+func TestTranslator_Negotiate(t *testing.T) {
+	tr, err := i18n.New(validator.New(), "")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "Empty", header: "", want: "en"},
+		{name: "Exact", header: "pl", want: "pl"},
+		{name: "Region", header: "fr-CA", want: "fr"},
+		{name: "Quality", header: "es;q=0.5,pl;q=0.8", want: "pl"},
+		{name: "Unsupported", header: "de-DE,de;q=0.9", want: "en"},
+		{name: "Excluded", header: "pl;q=0,fr", want: "fr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tr.Negotiate(tt.header).Locale())
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Catalog directory", func(t *testing.T) {
+		dir := t.TempDir()
+		catalog := `[{"locale": "en", "key": "problem.user_not_found", "trans": "no such user", "override": true}]`
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "en.json"), []byte(catalog), 0o600))
+
+		tr, err := i18n.New(validator.New(), dir)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "no such user", i18n.Message(tr.Fallback(), "problem.user_not_found"))
+	})
+
+	t.Run("Conflicting catalog", func(t *testing.T) {
+		dir := t.TempDir()
+		catalog := `[{"locale": "en", "key": "problem.user_not_found", "trans": "no such user"}]`
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "en.json"), []byte(catalog), 0o600))
+
+		_, err := i18n.New(validator.New(), dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("Missing directory", func(t *testing.T) {
+		_, err := i18n.New(validator.New(), filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+	})
+}
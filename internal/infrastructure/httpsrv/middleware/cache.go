@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderETag        = "ETag"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// NewCacheMiddleware makes GET and HEAD requests conditional, see RFC 9110
+// section 13. Successful responses get a strong ETag computed from the body
+// unless the handler set one, and `304 Not Modified` is returned when
+// If-None-Match, or else If-Modified-Since, says the client has the current
+// representation. cacheControl, if set, is sent as the Cache-Control header.
+//
+// The response is buffered, so the middleware must not be used for
+// streaming routes.
+func NewCacheMiddleware(cacheControl string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				return next(c)
+			}
+
+			res := c.Response()
+			res.Header().Add(echo.HeaderVary, echo.HeaderAuthorization)
+
+			original := res.Writer
+			w := &bufferedWriter{ResponseWriter: original, status: http.StatusOK}
+			res.Writer = w
+
+			err := next(c)
+			res.Writer = original
+			if err != nil {
+				if res.Committed {
+					// Nothing has been written to the client yet.
+					res.Committed = false
+				}
+				return err
+			}
+
+			if w.status != http.StatusOK {
+				original.WriteHeader(w.status)
+				_, err = original.Write(w.buf.Bytes())
+				return err
+			}
+
+			if cacheControl != "" {
+				res.Header().Set(echo.HeaderCacheControl, cacheControl)
+			}
+
+			etag := res.Header().Get(HeaderETag)
+			if etag == "" {
+				sum := sha256.Sum256(w.buf.Bytes())
+				etag = `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+				res.Header().Set(HeaderETag, etag)
+			}
+
+			if notModified(req, etag, res.Header().Get(echo.HeaderLastModified)) {
+				res.Header().Del(echo.HeaderContentType)
+				res.Header().Del(echo.HeaderContentLength)
+				res.Status = http.StatusNotModified
+				original.WriteHeader(http.StatusNotModified)
+				return nil
+			}
+
+			original.WriteHeader(http.StatusOK)
+			_, err = original.Write(w.buf.Bytes())
+			return err
+		}
+	}
+}
+
+// notModified evaluates If-None-Match, using the weak comparison, and falls
+// back to If-Modified-Since when it is absent.
+func notModified(req *http.Request, etag, lastModified string) bool {
+	if header := req.Header.Get(HeaderIfNoneMatch); header != "" {
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	since, err := http.ParseTime(req.Header.Get(echo.HeaderIfModifiedSince))
+	if err != nil {
+		return false
+	}
+
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+
+	return !modified.After(since.Truncate(time.Second))
+}
+
+// bufferedWriter holds back the response until the middleware knows whether
+// to send it.
+type bufferedWriter struct {
+	http.ResponseWriter
+	buf    bytes.Buffer
+	status int
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
@@ -0,0 +1,108 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/middleware"
+)
+
+func serve(t *testing.T, handler echo.HandlerFunc, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	e := echo.New()
+	e.GET("/users/:id", handler, middleware.NewCacheMiddleware("private, no-cache"))
+
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	res := httptest.NewRecorder()
+
+	e.ServeHTTP(res, req)
+
+	return res
+}
+
+func TestNewCacheMiddleware(t *testing.T) {
+	user := func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"id": "1"})
+	}
+
+	versioned := func(c echo.Context) error {
+		c.Response().Header().Set(middleware.HeaderETag, `"3"`)
+		c.Response().Header().Set(echo.HeaderLastModified, "Sun, 01 Feb 2026 12:30:00 GMT")
+		return user(c)
+	}
+
+	t.Run("Content hash ETag", func(t *testing.T) {
+		res := serve(t, user, nil)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Regexp(t, `^"[\w-]{22}"$`, res.Header().Get(middleware.HeaderETag))
+		assert.Equal(t, "private, no-cache", res.Header().Get(echo.HeaderCacheControl))
+		assert.Equal(t, echo.HeaderAuthorization, res.Header().Get(echo.HeaderVary))
+		assert.JSONEq(t, `{"id":"1"}`, res.Body.String())
+
+		again := serve(t, user, map[string]string{middleware.HeaderIfNoneMatch: res.Header().Get(middleware.HeaderETag)})
+
+		assert.Equal(t, http.StatusNotModified, again.Code)
+		assert.Empty(t, again.Body.String())
+		assert.Empty(t, again.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, res.Header().Get(middleware.HeaderETag), again.Header().Get(middleware.HeaderETag))
+	})
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+	}{
+		{name: "If-None-Match", headers: map[string]string{middleware.HeaderIfNoneMatch: `"2", "3"`}, status: http.StatusNotModified},
+		{name: "If-None-Match weak", headers: map[string]string{middleware.HeaderIfNoneMatch: `W/"3"`}, status: http.StatusNotModified},
+		{name: "If-None-Match any", headers: map[string]string{middleware.HeaderIfNoneMatch: `*`}, status: http.StatusNotModified},
+		{name: "If-None-Match stale", headers: map[string]string{middleware.HeaderIfNoneMatch: `"2"`}, status: http.StatusOK},
+		{name: "If-Modified-Since", headers: map[string]string{echo.HeaderIfModifiedSince: "Sun, 01 Feb 2026 12:30:00 GMT"}, status: http.StatusNotModified},
+		{name: "If-Modified-Since stale", headers: map[string]string{echo.HeaderIfModifiedSince: "Sun, 01 Feb 2026 12:29:59 GMT"}, status: http.StatusOK},
+		{
+			name: "If-None-Match takes precedence",
+			headers: map[string]string{
+				middleware.HeaderIfNoneMatch: `"2"`,
+				echo.HeaderIfModifiedSince:   "Sun, 01 Feb 2026 12:30:00 GMT",
+			},
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := serve(t, versioned, tt.headers)
+
+			assert.Equal(t, tt.status, res.Code)
+			assert.Equal(t, `"3"`, res.Header().Get(middleware.HeaderETag))
+		})
+	}
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		res := serve(t, func(c echo.Context) error {
+			return echo.ErrNotFound
+		}, map[string]string{middleware.HeaderIfNoneMatch: `*`})
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Empty(t, res.Header().Get(middleware.HeaderETag))
+		assert.Empty(t, res.Header().Get(echo.HeaderCacheControl))
+	})
+
+	t.Run("Non 200 responses are passed through", func(t *testing.T) {
+		res := serve(t, func(c echo.Context) error {
+			return c.JSON(http.StatusAccepted, map[string]string{"id": "1"})
+		}, nil)
+
+		assert.Equal(t, http.StatusAccepted, res.Code)
+		assert.Empty(t, res.Header().Get(middleware.HeaderETag))
+		assert.JSONEq(t, `{"id":"1"}`, res.Body.String())
+	})
+}
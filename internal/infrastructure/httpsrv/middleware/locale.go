@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/i18n"
+)
+
+func NewLocaleMiddleware(tr *i18n.Translator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			trans := tr.Negotiate(c.Request().Header.Get(i18n.HeaderAcceptLanguage))
+			i18n.SetContext(c, trans)
+
+			c.Response().Header().Set(i18n.HeaderContentLanguage, trans.Locale())
+			c.Response().Header().Add(echo.HeaderVary, i18n.HeaderAcceptLanguage)
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+// HeaderOrganizationID names the organization a request is made for.
+const HeaderOrganizationID = "X-Organization-ID"
+
+var errInvalidToken = errors.New("invalid token")
+
+type organizations interface {
+	GetByID(ctx context.Context, id string) (*domain.Organization, error)
+	GetBySlug(ctx context.Context, slug string) (*domain.Organization, error)
+}
+
+// NewTenantMiddleware scopes the context of requests to their organization,
+// see tenant.NewContext. The organization is named by the tokenClaim of a
+// bearer token signed with HS256 and tokenSecret, by HeaderOrganizationID,
+// or by its slug as the subdomain of baseDomain the request is made to. These
+// have to agree, requests naming none are made for fallback, or rejected if
+// it is empty. Bearer tokens are ignored without tokenSecret.
+func NewTenantMiddleware(orgs organizations, baseDomain string, tokenSecret []byte, tokenClaim, fallback string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := req.Context()
+			l := slog.Default().With("handler", "TenantMiddleware")
+
+			c.Response().Header().Add(echo.HeaderVary, HeaderOrganizationID)
+
+			var named []string
+			if token, ok := strings.CutPrefix(req.Header.Get(echo.HeaderAuthorization), "Bearer "); ok && len(tokenSecret) > 0 {
+				id, err := tokenTenant(token, tokenSecret, tokenClaim, time.Now())
+				if err != nil {
+					return echo.NewHTTPError(http.StatusUnauthorized, problem.CodeInvalidTenantToken).SetInternal(err)
+				}
+				named = append(named, id)
+			}
+
+			if id := req.Header.Get(HeaderOrganizationID); id != "" {
+				named = append(named, id)
+			}
+
+			var bySlug *domain.Organization
+			if slug := subdomain(req.Host, baseDomain); slug != "" {
+				org, err := orgs.GetBySlug(ctx, slug)
+				if err != nil {
+					l.ErrorContext(ctx, err.Error())
+					return echo.ErrInternalServerError
+				}
+
+				if org == nil {
+					return echo.NewHTTPError(http.StatusNotFound, problem.CodeOrganizationNotFound)
+				}
+				bySlug = org
+				named = append(named, org.ID)
+			}
+
+			for _, id := range named {
+				if id != named[0] {
+					return echo.NewHTTPError(http.StatusForbidden, problem.CodeTenantMismatch)
+				}
+			}
+
+			if len(named) == 0 {
+				if fallback == "" {
+					return echo.NewHTTPError(http.StatusBadRequest, problem.CodeTenantRequired)
+				}
+				named = append(named, fallback)
+			}
+
+			if bySlug == nil {
+				org, err := orgs.GetByID(ctx, named[0])
+				if err != nil {
+					l.ErrorContext(ctx, err.Error())
+					return echo.ErrInternalServerError
+				}
+
+				if org == nil {
+					return echo.NewHTTPError(http.StatusNotFound, problem.CodeOrganizationNotFound)
+				}
+			}
+
+			c.SetRequest(req.WithContext(tenant.NewContext(ctx, named[0])))
+
+			return next(c)
+		}
+	}
+}
+
+// subdomain returns the label of host right below baseDomain, if any.
+func subdomain(host, baseDomain string) string {
+	if baseDomain == "" {
+		return ""
+	}
+
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	label, ok := strings.CutSuffix(strings.ToLower(host), "."+strings.ToLower(baseDomain))
+	if !ok || strings.Contains(label, ".") {
+		return ""
+	}
+
+	return label
+}
+
+// tokenTenant returns the claim of a JWT signed with HS256 and secret, that
+// has not expired at now.
+func tokenTenant(token string, secret []byte, claim string, now time.Time) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errInvalidToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil || header.Alg != "HS256" {
+		return "", errInvalidToken
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", errInvalidToken
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return "", errInvalidToken
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return "", errInvalidToken
+	}
+
+	if exp, ok := claims["exp"].(float64); ok && !now.Before(time.Unix(int64(exp), 0)) {
+		return "", errors.New("token expired")
+	}
+
+	id, _ := claims[claim].(string)
+	if id == "" {
+		return "", errors.New("token without " + claim + " claim")
+	}
+
+	return id, nil
+}
+
+func decodeSegment(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,99 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/tenant"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/middleware"
+)
+
+type organizationsStub map[string]domain.Organization
+
+func (s organizationsStub) GetByID(_ context.Context, id string) (*domain.Organization, error) {
+	if org, ok := s[id]; ok {
+		return &org, nil
+	}
+	return nil, nil
+}
+
+func (s organizationsStub) GetBySlug(_ context.Context, slug string) (*domain.Organization, error) {
+	for _, org := range s {
+		if org.Slug == slug {
+			return &org, nil
+		}
+	}
+	return nil, nil
+}
+
+func token(secret, payload string) string {
+	enc := base64.RawURLEncoding
+	signed := enc.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + enc.EncodeToString([]byte(payload))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+
+	return signed + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+func TestNewTenantMiddleware(t *testing.T) {
+	orgs := organizationsStub{
+		"default": {ID: "default", Slug: "default"},
+		"o1":      {ID: "o1", Slug: "acme"},
+		"o2":      {ID: "o2", Slug: "globex"},
+	}
+
+	tests := []struct {
+		name     string
+		host     string
+		headers  map[string]string
+		fallback string
+		status   int
+		tenant   string
+	}{
+		{name: "Header", headers: map[string]string{middleware.HeaderOrganizationID: "o1"}, status: http.StatusOK, tenant: "o1"},
+		{name: "Subdomain", host: "acme.example.com:8080", status: http.StatusOK, tenant: "o1"},
+		{name: "Token", headers: map[string]string{echo.HeaderAuthorization: "Bearer " + token("secret", `{"org_id":"o2","exp":4102444800}`)}, status: http.StatusOK, tenant: "o2"},
+		{name: "Fallback", fallback: "default", status: http.StatusOK, tenant: "default"},
+		{name: "Required", status: http.StatusBadRequest},
+		{name: "Unknown subdomain", host: "initech.example.com", status: http.StatusNotFound},
+		{name: "Unknown organization", headers: map[string]string{middleware.HeaderOrganizationID: "o3"}, status: http.StatusNotFound},
+		{name: "Mismatch", host: "acme.example.com", headers: map[string]string{middleware.HeaderOrganizationID: "o2"}, status: http.StatusForbidden},
+		{name: "Token mismatch", headers: map[string]string{middleware.HeaderOrganizationID: "o1", echo.HeaderAuthorization: "Bearer " + token("secret", `{"org_id":"o2"}`)}, status: http.StatusForbidden},
+		{name: "Bad signature", headers: map[string]string{echo.HeaderAuthorization: "Bearer " + token("other", `{"org_id":"o2"}`)}, status: http.StatusUnauthorized},
+		{name: "Expired token", headers: map[string]string{echo.HeaderAuthorization: "Bearer " + token("secret", `{"org_id":"o2","exp":1}`)}, status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			e := echo.New()
+			e.GET("/users", func(c echo.Context) error {
+				got, _ = tenant.FromContext(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			}, middleware.NewTenantMiddleware(orgs, "example.com", []byte("secret"), "org_id", tt.fallback))
+
+			req := httptest.NewRequest(http.MethodGet, "/users", nil)
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			res := httptest.NewRecorder()
+
+			e.ServeHTTP(res, req)
+
+			assert.Equal(t, tt.status, res.Code)
+			assert.Equal(t, tt.tenant, got)
+		})
+	}
+}
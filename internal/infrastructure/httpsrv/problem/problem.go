@@ -0,0 +1,264 @@
+package problem
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/i18n"
+)
+
+// MIMEApplicationProblemJSON is the media type defined by RFC 9457.
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// TypeBaseURI prefixes every problem code to build its stable `type` URI.
+const TypeBaseURI = "https://github.com/Beriw98/user-management/problems/"
+
+// Code is a stable, machine-readable error code. Handlers pass it as the
+// message of an echo.HTTPError and the error handler turns it into a problem.
+type Code string
+
+const (
+	CodeBadRequest           Code = "bad_request"
+	CodeUnauthorized         Code = "unauthorized"
+	CodeForbidden            Code = "forbidden"
+	CodeNotFound             Code = "not_found"
+	CodeMethodNotAllowed     Code = "method_not_allowed"
+	CodeConflict             Code = "conflict"
+	CodePreconditionFailed   Code = "precondition_failed"
+	CodeRequestTooLarge      Code = "request_too_large"
+	CodeUnsupportedMediaType Code = "unsupported_media_type"
+	CodeTooManyRequests      Code = "too_many_requests"
+	CodeInternal             Code = "internal_error"
+	CodeServiceUnavailable   Code = "service_unavailable"
+
+	CodeInvalidBody           Code = "invalid_body"
+	CodeValidationFailed      Code = "validation_failed"
+	CodeInvalidLimit          Code = "invalid_limit"
+	CodeInvalidPage           Code = "invalid_page"
+	CodeInvalidCursor         Code = "invalid_cursor"
+	CodeInvalidTotal          Code = "invalid_total"
+	CodeInvalidFilter         Code = "invalid_filter"
+	CodeInvalidSort           Code = "invalid_sort"
+	CodeUnknownField          Code = "unknown_field"
+	CodeUnknownOperator       Code = "unknown_operator"
+	CodeInvalidSearchQuery    Code = "invalid_search_query"
+	CodeSearchUnavailable     Code = "search_unavailable"
+	CodeInvalidIncludeDeleted Code = "invalid_include_deleted"
+	CodeUserNotFound          Code = "user_not_found"
+	CodeUserAlreadyExists     Code = "user_already_exists"
+	CodeVersionMismatch       Code = "version_mismatch"
+	CodeInvalidPatch          Code = "invalid_patch"
+	CodePatchNotApplicable    Code = "patch_not_applicable"
+	CodePatchTestFailed       Code = "patch_test_failed"
+	CodeWebhookNotFound       Code = "webhook_not_found"
+	CodeDeliveryNotFound      Code = "delivery_not_found"
+	CodeInvalidDeliveryStatus Code = "invalid_delivery_status"
+	CodeInvalidEventType      Code = "invalid_event_type"
+	CodeInvalidLastEventID    Code = "invalid_last_event_id"
+	CodeInvalidDryRun         Code = "invalid_dry_run"
+	CodeInvalidImportFile     Code = "invalid_import_file"
+	CodeImportNotFound        Code = "import_not_found"
+	CodeInvitationExists      Code = "invitation_already_exists"
+	CodeInvalidExportFormat   Code = "invalid_export_format"
+	CodeBatchTooLarge         Code = "batch_too_large"
+	CodeDataExportNotFound    Code = "data_export_not_found"
+	CodeInvalidDownloadLink   Code = "invalid_download_link"
+	CodeDownloadLinkExpired   Code = "download_link_expired"
+	CodeUserErased            Code = "user_erased"
+	CodePolicyNotFound        Code = "policy_not_found"
+	CodePolicyOutdated        Code = "policy_outdated"
+	CodePolicyVersionExists   Code = "policy_version_exists"
+	CodeConsentRequired       Code = "consent_required"
+	CodeGroupNotFound         Code = "group_not_found"
+	CodeGroupExists           Code = "group_already_exists"
+	CodeParentGroupNotFound   Code = "parent_group_not_found"
+	CodeGroupCycle            Code = "group_cycle"
+	CodeGroupMemberNotFound   Code = "group_member_not_found"
+	CodeTenantRequired        Code = "tenant_required"
+	CodeTenantMismatch        Code = "tenant_mismatch"
+	CodeInvalidTenantToken    Code = "invalid_tenant_token"
+	CodeOrganizationNotFound  Code = "organization_not_found"
+	CodeOrganizationExists    Code = "organization_already_exists"
+	CodeInvitationNotFound    Code = "invitation_not_found"
+	CodeInvitationExpired     Code = "invitation_expired"
+	CodeInvitationRevoked     Code = "invitation_revoked"
+	CodeInvitationAccepted    Code = "invitation_already_accepted"
+	CodeInvalidExpiry         Code = "invalid_expiry"
+	CodeInvalidInviteStatus   Code = "invalid_invitation_status"
+)
+
+var statusCodes = map[int]Code{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusConflict:              CodeConflict,
+	http.StatusPreconditionFailed:    CodePreconditionFailed,
+	http.StatusRequestEntityTooLarge: CodeRequestTooLarge,
+	http.StatusUnsupportedMediaType:  CodeUnsupportedMediaType,
+	http.StatusTooManyRequests:       CodeTooManyRequests,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusServiceUnavailable:    CodeServiceUnavailable,
+}
+
+// Message is a Code whose translation takes parameters, e.g. the name of an
+// unknown field.
+type Message struct {
+	Code   Code
+	Params []string
+}
+
+// Problem is the RFC 9457 problem details object extended with a code and
+// field level validation errors.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     Code         `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// FromError converts any error returned by a handler into a Problem with
+// messages translated by trans. Errors other than echo.HTTPError are reported
+// as internal errors without leaking their message.
+func FromError(err error, trans ut.Translator) *Problem {
+	var he *echo.HTTPError
+	if !errors.As(err, &he) {
+		he = echo.ErrInternalServerError
+	}
+
+	p := &Problem{
+		Status: he.Code,
+		Title:  http.StatusText(he.Code),
+		Code:   statusCode(he.Code),
+	}
+
+	switch m := he.Message.(type) {
+	case Code:
+		p.Code = m
+		p.Detail = i18n.Message(trans, "problem."+string(m))
+	case Message:
+		p.Code = m.Code
+		p.Detail = i18n.Message(trans, "problem."+string(m.Code), m.Params...)
+	case string:
+		if m != p.Title {
+			p.Detail = m
+		}
+	case error:
+		p.Detail = m.Error()
+	}
+
+	if p.Status >= http.StatusInternalServerError {
+		p.Detail = ""
+	}
+
+	var vErr validator.ValidationErrors
+	if errors.As(he.Internal, &vErr) {
+		p.Errors = fieldErrors(vErr, trans)
+	}
+
+	p.Type = TypeBaseURI + string(p.Code)
+
+	return p
+}
+
+// NewErrorHandler returns an echo.HTTPErrorHandler rendering every error as
+// application/problem+json in the locale negotiated for the request.
+func NewErrorHandler(tr *i18n.Translator) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		p := FromError(err, tr.FromContext(c))
+		p.Instance = c.Request().URL.Path
+
+		if p.Status >= http.StatusInternalServerError {
+			slog.ErrorContext(c.Request().Context(), err.Error(), "code", p.Code)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+
+		var wErr error
+		if c.Request().Method == http.MethodHead {
+			wErr = c.NoContent(p.Status)
+		} else {
+			wErr = c.JSON(p.Status, p)
+		}
+
+		if wErr != nil {
+			slog.ErrorContext(c.Request().Context(), wErr.Error())
+		}
+	}
+}
+
+func fieldErrors(vErr validator.ValidationErrors, trans ut.Translator) []FieldError {
+	res := make([]FieldError, 0, len(vErr))
+	for _, fe := range vErr {
+		msg := fe.Translate(trans)
+		if msg == fe.Error() {
+			msg = i18n.Message(trans, "rule", fe.Field(), fe.Tag())
+		}
+
+		res = append(res, FieldError{
+			Field:   FieldName(fe),
+			Rule:    fe.Tag(),
+			Message: msg,
+		})
+	}
+
+	return res
+}
+
+// FieldName returns the path of the failing field without the top level
+// struct name, e.g. `email` or `users[0].email`.
+func FieldName(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.IndexByte(ns, '.'); i >= 0 {
+		return ns[i+1:]
+	}
+
+	return fe.Field()
+}
+
+// JSONTagName makes the validator report fields by their JSON names.
+func JSONTagName(fld reflect.StructField) string {
+	name, _, _ := strings.Cut(fld.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+
+	if name == "" {
+		return fld.Name
+	}
+
+	return name
+}
+
+func statusCode(status int) Code {
+	if c, ok := statusCodes[status]; ok {
+		return c
+	}
+
+	if text := http.StatusText(status); text != "" {
+		return Code(strings.ReplaceAll(strings.ToLower(text), " ", "_"))
+	}
+
+	return CodeInternal
+}
@@ -0,0 +1,128 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	customvalidator "github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/validator"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/i18n"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/middleware"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
+)
+
+type testRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,password"`
+}
+
+func newValidator(t *testing.T) (*validator.Validate, *i18n.Translator) {
+	t.Helper()
+
+	v := validator.New()
+	_ = v.RegisterValidation(customvalidator.PasswordValidator, customvalidator.PasswordValidate)
+	v.RegisterTagNameFunc(problem.JSONTagName)
+
+	tr, err := i18n.New(v, "")
+	assert.NoError(t, err)
+
+	return v, tr
+}
+
+func render(t *testing.T, tr *i18n.Translator, acceptLanguage string, err error) (*httptest.ResponseRecorder, problem.Problem) {
+	t.Helper()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/users", nil)
+	req.Header.Set(i18n.HeaderAcceptLanguage, acceptLanguage)
+	res := httptest.NewRecorder()
+
+	ec := e.NewContext(req, res)
+	_ = middleware.NewLocaleMiddleware(tr)(func(c echo.Context) error {
+		problem.NewErrorHandler(tr)(err, c)
+		return nil
+	})(ec)
+
+	var p problem.Problem
+	assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &p))
+
+	return res, p
+}
+
+func TestNewErrorHandler(t *testing.T) {
+	v, tr := newValidator(t)
+
+	t.Run("Validation errors", func(t *testing.T) {
+		vErr := v.Struct(testRequest{Email: "invalid", Password: "password"})
+		err := echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(vErr)
+
+		res, p := render(t, tr, "", err)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, problem.MIMEApplicationProblemJSON, res.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, problem.TypeBaseURI+"validation_failed", p.Type)
+		assert.Equal(t, problem.CodeValidationFailed, p.Code)
+		assert.Equal(t, "/users", p.Instance)
+		assert.Equal(t, []problem.FieldError{
+			{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+			{Field: "password", Rule: "password", Message: customvalidator.ErrPasswordValidation},
+		}, p.Errors)
+	})
+
+	t.Run("Localized validation errors", func(t *testing.T) {
+		vErr := v.Struct(testRequest{Password: "1Password."})
+		err := echo.NewHTTPError(http.StatusBadRequest, problem.CodeValidationFailed).SetInternal(vErr)
+
+		res, p := render(t, tr, "de-DE,pl-PL;q=0.9,en;q=0.8", err)
+
+		assert.Equal(t, "pl", res.Header().Get(i18n.HeaderContentLanguage))
+		assert.Equal(t, "walidacja żądania nie powiodła się", p.Detail)
+		assert.Equal(t, []problem.FieldError{
+			{Field: "email", Rule: "required", Message: "email jest wymaganym polem"},
+		}, p.Errors)
+	})
+
+	t.Run("Code message", func(t *testing.T) {
+		res, p := render(t, tr, "", echo.NewHTTPError(http.StatusNotFound, problem.CodeUserNotFound))
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.Equal(t, problem.CodeUserNotFound, p.Code)
+		assert.Equal(t, "Not Found", p.Title)
+		assert.Equal(t, "user not found", p.Detail)
+	})
+
+	t.Run("Localized code message", func(t *testing.T) {
+		_, p := render(t, tr, "fr", echo.NewHTTPError(http.StatusConflict, problem.CodeUserAlreadyExists))
+
+		assert.Equal(t, "l'utilisateur existe déjà", p.Detail)
+	})
+
+	t.Run("Generic code message", func(t *testing.T) {
+		res, p := render(t, tr, "es", echo.NewHTTPError(http.StatusUnsupportedMediaType, problem.CodeUnsupportedMediaType))
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, res.Code)
+		assert.Equal(t, problem.CodeUnsupportedMediaType, p.Code)
+		assert.Equal(t, "el tipo de medio del cuerpo de la solicitud no es compatible", p.Detail)
+	})
+
+	t.Run("Echo error", func(t *testing.T) {
+		res, p := render(t, tr, "", echo.ErrMethodNotAllowed)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, res.Code)
+		assert.Equal(t, problem.CodeMethodNotAllowed, p.Code)
+		assert.Empty(t, p.Detail)
+	})
+
+	t.Run("Internal error", func(t *testing.T) {
+		res, p := render(t, tr, "", assert.AnError)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+		assert.Equal(t, problem.CodeInternal, p.Code)
+		assert.Empty(t, p.Detail)
+	})
+}
@@ -1,12 +1,19 @@
 package httpsrv
 
 import (
+	"fmt"
+	"net"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
 
 	"github.com/Beriw98/user-management/internal/container"
 	customvalidator "github.com/Beriw98/user-management/internal/infrastructure/httpsrv/handler/validator"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/i18n"
 	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/middleware"
+	"github.com/Beriw98/user-management/internal/infrastructure/httpsrv/problem"
 )
 
 type requestValidator struct {
@@ -17,7 +24,30 @@ func (v *requestValidator) Validate(i interface{}) error {
 	return v.Validator.Struct(i)
 }
 
-func NewRouter(ctr *container.Container) *echo.Echo {
+// ipExtractor only takes the client IP from X-Forwarded-For when the request
+// comes through one of the trusted proxies, so clients can't forge it.
+func ipExtractor(trustedProxies string) (echo.IPExtractor, error) {
+	if strings.TrimSpace(trustedProxies) == "" {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range strings.Split(trustedProxies, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+func NewRouter(ctr *container.Container) (*echo.Echo, error) {
 	e := echo.New()
 
 	v := &requestValidator{
@@ -25,19 +55,122 @@ func NewRouter(ctr *container.Container) *echo.Echo {
 	}
 
 	_ = v.Validator.RegisterValidation(customvalidator.PasswordValidator, customvalidator.PasswordValidate)
+	v.Validator.RegisterTagNameFunc(problem.JSONTagName)
+
+	tr, err := i18n.New(v.Validator, ctr.Config.LocalesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	e.IPExtractor, err = ipExtractor(ctr.Config.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
 
 	e.Validator = v
+	e.HTTPErrorHandler = problem.NewErrorHandler(tr)
 	e.HideBanner = true
 
-	g := e.Group("/users", middleware.NewLoggerMiddleware())
+	e.Use(echomiddleware.RequestID())
+	e.Use(middleware.NewLocaleMiddleware(tr))
+
+	cache := func(path string) echo.MiddlewareFunc {
+		return middleware.NewCacheMiddleware(ctr.Config.CacheControl[path])
+	}
+
+	// Users, groups, audit events and webhooks are scoped to the organization
+	// of the request.
+	tenant := middleware.NewTenantMiddleware(
+		ctr.Organizations,
+		ctr.Config.TenantDomain,
+		[]byte(ctr.Config.TenantTokenSecret),
+		ctr.Config.TenantTokenClaim,
+		ctr.Config.TenantDefault,
+	)
+
+	// Users have to accept the current mandatory policies before anything
+	// else, but not to exercise their rights over their data.
+	gate := ctr.Consent.Gate
+
+	g := e.Group("/users", middleware.NewLoggerMiddleware(), tenant)
 	{
 		g.POST("", ctr.UserHandler.Create)
-		g.GET("", ctr.UserHandler.GetMany)
-		g.GET("/:id", ctr.UserHandler.GetByID)
-		g.PUT("/:id", ctr.UserHandler.Update)
-		g.PATCH("/:id/password", ctr.UserHandler.UpdatePassword)
-		g.DELETE("/:id", ctr.UserHandler.Delete)
+		g.POST(`\:import`, ctr.UserHandler.Import, gate)
+		g.POST(`\:batch`, ctr.UserHandler.Batch, gate)
+		g.GET("/imports/:id", ctr.UserHandler.GetImport, gate)
+		g.GET(`\:export`, ctr.ExportHandler.Export, gate)
+		g.GET("", ctr.UserHandler.GetMany, gate, cache("/users"))
+		g.GET("/search", ctr.SearchHandler.Search, gate, cache("/users/search"))
+		g.GET("/events", ctr.EventsHandler.Stream, gate)
+		g.GET("/:id", ctr.UserHandler.GetByID, gate, cache("/users/:id"))
+		g.PUT("/:id", ctr.UserHandler.Update, gate)
+		g.PATCH("/:id", ctr.UserHandler.Patch, gate)
+		g.PATCH("/:id/password", ctr.UserHandler.UpdatePassword, gate)
+		g.DELETE("/:id", ctr.UserHandler.Delete, gate)
+		g.POST("/:id/restore", ctr.UserHandler.Restore, gate)
+		g.GET("/:id/groups", ctr.GroupHandler.UserGroups, gate)
+		g.GET("/:id/consents", ctr.Consent.GetMany)
+		g.POST("/:id/consents", ctr.Consent.Accept)
+		g.GET("/:id/data-export", ctr.DataExport.Get)
+		g.POST("/:id/erasure", ctr.Erasure.Erase)
+	}
+
+	i := e.Group("/invitations", middleware.NewLoggerMiddleware())
+	{
+		i.POST("", ctr.UserHandler.Invite, tenant, gate)
+		i.GET("", ctr.UserHandler.GetInvitations, tenant, gate)
+		i.POST("/:id/revoke", ctr.UserHandler.RevokeInvitation, tenant, gate)
+		i.POST("/:id/resend", ctr.UserHandler.ResendInvitation, tenant, gate)
+	}
+
+	// Not logged, the URL carries the token of the invitation, which also
+	// names its organization.
+	e.GET("/invitations/:token", ctr.UserHandler.GetInvitation)
+	e.POST("/invitations/:token/accept", ctr.UserHandler.AcceptInvitation)
+
+	p := e.Group("/policies", middleware.NewLoggerMiddleware())
+	{
+		p.POST("", ctr.Consent.Publish)
+		p.GET("", ctr.Consent.GetPolicies)
+	}
+
+	gr := e.Group("/groups", middleware.NewLoggerMiddleware(), tenant, gate)
+	{
+		gr.POST("", ctr.GroupHandler.Create)
+		gr.GET("", ctr.GroupHandler.GetMany)
+		gr.GET("/:id", ctr.GroupHandler.GetByID)
+		gr.PUT("/:id", ctr.GroupHandler.Update)
+		gr.DELETE("/:id", ctr.GroupHandler.Delete)
+		gr.GET("/:id/members", ctr.GroupHandler.Members)
+		gr.POST("/:id/members", ctr.GroupHandler.AddMembers)
+		gr.DELETE("/:id/members/:user_id", ctr.GroupHandler.RemoveMember)
+	}
+
+	o := e.Group("/organizations", middleware.NewLoggerMiddleware())
+	{
+		o.POST("", ctr.Organization.Create)
+		o.GET("", ctr.Organization.GetMany)
+	}
+
+	// Not logged, the URL carries the signature of the download link.
+	e.GET("/data-exports/:id", ctr.DataExport.Download)
+
+	a := e.Group("/audit-events", middleware.NewLoggerMiddleware(), tenant)
+	{
+		a.GET("", ctr.AuditHandler.GetMany)
+		a.GET("/verify", ctr.AuditHandler.Verify)
+	}
+
+	w := e.Group("/webhooks", middleware.NewLoggerMiddleware(), tenant)
+	{
+		w.POST("", ctr.WebhookHandler.Create)
+		w.GET("", ctr.WebhookHandler.GetMany)
+		w.GET("/:id", ctr.WebhookHandler.GetByID)
+		w.PUT("/:id", ctr.WebhookHandler.Update)
+		w.DELETE("/:id", ctr.WebhookHandler.Delete)
+		w.GET("/:id/deliveries", ctr.WebhookHandler.Deliveries)
+		w.POST("/:id/deliveries/:delivery_id/redeliver", ctr.WebhookHandler.Redeliver)
 	}
 
-	return e
+	return e, nil
 }
@@ -0,0 +1,26 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// LogMailer logs emails instead of sending them, it is the default until a
+// mail server is configured.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) SendInvitation(ctx context.Context, inv domain.Invitation, link string) error {
+	slog.Default().With("mailer", "log").InfoContext(ctx, "invitation",
+		"id", inv.ID,
+		"email", inv.Email,
+		"link", link,
+	)
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+var ErrUnknownBroker = errors.New("unknown broker")
+
+// Broker sends CloudEvents to a message broker, encoded as required by the
+// protocol binding of the broker.
+type Broker interface {
+	Send(ctx context.Context, event CloudEvent) error
+	Close() error
+}
+
+// NewBroker connects to the broker of kind at url (comma separated for
+// several Kafka brokers), sending to topic in mode.
+func NewBroker(kind, url, topic string, mode Mode) (Broker, error) {
+	switch kind {
+	case "nats":
+		return NewNATSBroker(url, topic, mode)
+	case "kafka":
+		return NewKafkaBroker(strings.Split(url, ","), topic, mode), nil
+	case "memory":
+		return NewInMemoryBroker(mode), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBroker, kind)
+	}
+}
+
+// CloudEventPublisher publishes events to a Broker as CloudEvents.
+type CloudEventPublisher struct {
+	broker Broker
+	source string
+}
+
+func NewCloudEventPublisher(broker Broker, source string) *CloudEventPublisher {
+	return &CloudEventPublisher{
+		broker: broker,
+		source: source,
+	}
+}
+
+func (p *CloudEventPublisher) Publish(ctx context.Context, event domain.Event) error {
+	return p.broker.Send(ctx, NewCloudEvent(p.source, event))
+}
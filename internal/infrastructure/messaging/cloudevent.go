@@ -0,0 +1,149 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+const (
+	CloudEventsSpecVersion = "1.0"
+
+	MIMEApplicationCloudEventsJSON = "application/cloudevents+json; charset=UTF-8"
+	MIMEApplicationJSON            = "application/json"
+
+	headerContentType = "content-type"
+)
+
+// SchemaBaseURI prefixes the versioned JSON schemas of event data, see
+// docs/schemas.
+const SchemaBaseURI = "https://github.com/Beriw98/user-management/blob/main/docs/schemas/"
+
+// Mode is the content mode of CloudEvents messages: structured messages
+// carry the whole event in their body, binary ones carry the attributes in
+// headers and only the data in the body.
+type Mode string
+
+const (
+	ModeStructured Mode = "structured"
+	ModeBinary     Mode = "binary"
+)
+
+var (
+	ErrUnknownMode       = errors.New("unknown CloudEvents mode")
+	ErrInvalidCloudEvent = errors.New("invalid CloudEvent")
+)
+
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case ModeStructured, ModeBinary:
+		return m, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownMode, s)
+	}
+}
+
+// CloudEvent is a CloudEvents 1.0 event with JSON data.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	DataSchema      string          `json:"dataschema,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// NewCloudEvent describes event as emitted by source. The subject is the
+// aggregate, so consumers can tell the users events are about without
+// parsing their data.
+func NewCloudEvent(source string, event domain.Event) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              event.ID,
+		Source:          source,
+		Type:            string(event.Type),
+		Subject:         event.AggregateID,
+		Time:            event.OccurredAt,
+		DataContentType: MIMEApplicationJSON,
+		DataSchema:      fmt.Sprintf("%suser-event/v%d.json", SchemaBaseURI, domain.UserEventDataVersion),
+		Data:            event.Data,
+	}
+}
+
+// Encode returns the headers and body of the message carrying e in mode,
+// binary mode headers are named after attributes with prefix, as defined by
+// the protocol binding.
+func Encode(e CloudEvent, mode Mode, prefix string) (map[string]string, []byte, error) {
+	if mode == ModeStructured {
+		body, err := json.Marshal(e)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return map[string]string{headerContentType: MIMEApplicationCloudEventsJSON}, body, nil
+	}
+
+	headers := map[string]string{
+		prefix + "specversion": e.SpecVersion,
+		prefix + "id":          e.ID,
+		prefix + "source":      e.Source,
+		prefix + "type":        e.Type,
+		prefix + "time":        e.Time.UTC().Format(time.RFC3339Nano),
+	}
+
+	if e.Subject != "" {
+		headers[prefix+"subject"] = e.Subject
+	}
+
+	if e.DataSchema != "" {
+		headers[prefix+"dataschema"] = e.DataSchema
+	}
+
+	if e.DataContentType != "" {
+		headers[headerContentType] = e.DataContentType
+	}
+
+	return headers, e.Data, nil
+}
+
+// Decode is the inverse of Encode, detecting the mode from the content type.
+func Decode(headers map[string]string, body []byte, prefix string) (CloudEvent, error) {
+	var e CloudEvent
+
+	if strings.HasPrefix(headers[headerContentType], "application/cloudevents+json") {
+		if err := json.Unmarshal(body, &e); err != nil {
+			return CloudEvent{}, fmt.Errorf("%w: %w", ErrInvalidCloudEvent, err)
+		}
+	} else {
+		e = CloudEvent{
+			SpecVersion:     headers[prefix+"specversion"],
+			ID:              headers[prefix+"id"],
+			Source:          headers[prefix+"source"],
+			Type:            headers[prefix+"type"],
+			Subject:         headers[prefix+"subject"],
+			DataContentType: headers[headerContentType],
+			DataSchema:      headers[prefix+"dataschema"],
+			Data:            body,
+		}
+
+		if t := headers[prefix+"time"]; t != "" {
+			var err error
+			if e.Time, err = time.Parse(time.RFC3339Nano, t); err != nil {
+				return CloudEvent{}, fmt.Errorf("%w: %w", ErrInvalidCloudEvent, err)
+			}
+		}
+	}
+
+	if e.SpecVersion != CloudEventsSpecVersion || e.ID == "" || e.Source == "" || e.Type == "" {
+		return CloudEvent{}, fmt.Errorf("%w: missing required attributes", ErrInvalidCloudEvent)
+	}
+
+	return e, nil
+}
@@ -0,0 +1,137 @@
+package messaging_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/messaging"
+)
+
+func TestNewCloudEvent(t *testing.T) {
+	event := domain.NewEvent(domain.UserCreated, "u1", domain.UserEventData{ID: "u1", Email: "john@doe.com"})
+
+	ce := messaging.NewCloudEvent("/user-management", event)
+
+	assert.Equal(t, messaging.CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              event.ID,
+		Source:          "/user-management",
+		Type:            "user.created",
+		Subject:         "u1",
+		Time:            event.OccurredAt,
+		DataContentType: "application/json",
+		DataSchema:      messaging.SchemaBaseURI + "user-event/v1.json",
+		Data:            event.Data,
+	}, ce)
+	assert.NotContains(t, string(ce.Data), "password")
+}
+
+func TestEncode(t *testing.T) {
+	ce := messaging.CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              "e1",
+		Source:          "/user-management",
+		Type:            "user.updated",
+		Subject:         "u1",
+		Time:            time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		DataContentType: "application/json",
+		DataSchema:      messaging.SchemaBaseURI + "user-event/v1.json",
+		Data:            json.RawMessage(`{"id":"u1"}`),
+	}
+
+	t.Run("Structured", func(t *testing.T) {
+		headers, body, err := messaging.Encode(ce, messaging.ModeStructured, "ce_")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"content-type": messaging.MIMEApplicationCloudEventsJSON}, headers)
+		assert.JSONEq(t, `{
+			"specversion": "1.0",
+			"id": "e1",
+			"source": "/user-management",
+			"type": "user.updated",
+			"subject": "u1",
+			"time": "2026-10-19T12:00:00Z",
+			"datacontenttype": "application/json",
+			"dataschema": "`+ce.DataSchema+`",
+			"data": {"id": "u1"}
+		}`, string(body))
+
+		decoded, err := messaging.Decode(headers, body, "ce_")
+		assert.NoError(t, err)
+		assert.Equal(t, ce, decoded)
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		headers, body, err := messaging.Encode(ce, messaging.ModeBinary, "ce_")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"ce_specversion": "1.0",
+			"ce_id":          "e1",
+			"ce_source":      "/user-management",
+			"ce_type":        "user.updated",
+			"ce_subject":     "u1",
+			"ce_time":        "2026-10-19T12:00:00Z",
+			"ce_dataschema":  ce.DataSchema,
+			"content-type":   "application/json",
+		}, headers)
+		assert.JSONEq(t, `{"id":"u1"}`, string(body))
+
+		decoded, err := messaging.Decode(headers, body, "ce_")
+		assert.NoError(t, err)
+		assert.Equal(t, ce, decoded)
+	})
+
+	t.Run("Decode invalid", func(t *testing.T) {
+		_, err := messaging.Decode(map[string]string{"ce_id": "e1"}, nil, "ce_")
+		assert.ErrorIs(t, err, messaging.ErrInvalidCloudEvent)
+
+		_, err = messaging.Decode(map[string]string{"content-type": messaging.MIMEApplicationCloudEventsJSON}, []byte("{"), "ce_")
+		assert.ErrorIs(t, err, messaging.ErrInvalidCloudEvent)
+	})
+}
+
+func TestCloudEventPublisher(t *testing.T) {
+	for _, mode := range []messaging.Mode{messaging.ModeStructured, messaging.ModeBinary} {
+		t.Run(string(mode), func(t *testing.T) {
+			broker := messaging.NewInMemoryBroker(mode)
+			p := messaging.NewCloudEventPublisher(broker, "/user-management")
+
+			event := domain.NewEvent(domain.UserEmailChanged, "u1", domain.UserEventData{ID: "u1", Email: "new@doe.com", OldEmail: "old@doe.com"})
+			assert.NoError(t, p.Publish(context.Background(), event))
+
+			events, err := broker.Events()
+			assert.NoError(t, err)
+			assert.Len(t, events, 1)
+			assert.Equal(t, event.ID, events[0].ID)
+			assert.Equal(t, "user.email_changed", events[0].Type)
+			assert.True(t, event.OccurredAt.Equal(events[0].Time))
+			assert.JSONEq(t, string(event.Data), string(events[0].Data))
+		})
+	}
+
+	t.Run("Broker error", func(t *testing.T) {
+		broker := messaging.NewInMemoryBroker(messaging.ModeBinary)
+		broker.Fail(assert.AnError)
+		p := messaging.NewCloudEventPublisher(broker, "/user-management")
+
+		err := p.Publish(context.Background(), domain.NewEvent(domain.UserDeleted, "u1", domain.UserEventData{ID: "u1"}))
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, broker.Messages())
+	})
+}
+
+func TestNewBroker(t *testing.T) {
+	b, err := messaging.NewBroker("memory", "", "user-events", messaging.ModeBinary)
+	assert.NoError(t, err)
+	assert.IsType(t, &messaging.InMemoryBroker{}, b)
+
+	_, err = messaging.NewBroker("rabbitmq", "", "user-events", messaging.ModeBinary)
+	assert.ErrorIs(t, err, messaging.ErrUnknownBroker)
+
+	_, err = messaging.ParseMode("batched")
+	assert.ErrorIs(t, err, messaging.ErrUnknownMode)
+}
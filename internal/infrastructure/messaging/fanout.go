@@ -0,0 +1,33 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/app/job"
+)
+
+// Fanout publishes every event to all of its publishers. It fails if any of
+// them does, so the event is published again to all of them, which they have
+// to tolerate anyway.
+type Fanout struct {
+	publishers []job.Publisher
+}
+
+func NewFanout(publishers ...job.Publisher) *Fanout {
+	return &Fanout{
+		publishers: publishers,
+	}
+}
+
+func (f *Fanout) Publish(ctx context.Context, event domain.Event) error {
+	var errs []error
+	for _, p := range f.publishers {
+		if err := p.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
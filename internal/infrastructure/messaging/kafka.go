@@ -0,0 +1,53 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaHeaderPrefix prefixes the attribute headers of binary mode messages.
+const kafkaHeaderPrefix = "ce_"
+
+// KafkaBroker writes events to a topic keyed by their subject, so the events
+// of a user land in the same partition and stay in order.
+type KafkaBroker struct {
+	writer *kafka.Writer
+	mode   Mode
+}
+
+func NewKafkaBroker(brokers []string, topic string, mode Mode) *KafkaBroker {
+	return &KafkaBroker{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			// The relay sends one event at a time in its transaction, a
+			// bigger batch would hold every write until BatchTimeout.
+			BatchSize: 1,
+		},
+		mode: mode,
+	}
+}
+
+func (b *KafkaBroker) Send(ctx context.Context, event CloudEvent) error {
+	headers, body, err := Encode(event, b.mode, kafkaHeaderPrefix)
+	if err != nil {
+		return err
+	}
+
+	msg := kafka.Message{
+		Key:   []byte(event.Subject),
+		Value: body,
+	}
+	for k, v := range headers {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+
+	return b.writer.WriteMessages(ctx, msg)
+}
+
+func (b *KafkaBroker) Close() error {
+	return b.writer.Close()
+}
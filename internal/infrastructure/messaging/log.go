@@ -0,0 +1,27 @@
+package messaging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// LogPublisher logs events instead of delivering them, it is the default
+// until a broker is configured.
+type LogPublisher struct{}
+
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(ctx context.Context, event domain.Event) error {
+	slog.Default().With("publisher", "log").InfoContext(ctx, "event",
+		"id", event.ID,
+		"type", event.Type,
+		"aggregate_id", event.AggregateID,
+		"data", string(event.Data),
+	)
+
+	return nil
+}
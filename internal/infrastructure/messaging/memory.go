@@ -0,0 +1,80 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+)
+
+// Message is an encoded CloudEvent as sent by InMemoryBroker.
+type Message struct {
+	Headers map[string]string
+	Body    []byte
+}
+
+// InMemoryBroker keeps the messages it is sent, it is meant for tests.
+type InMemoryBroker struct {
+	mu       sync.Mutex
+	mode     Mode
+	messages []Message
+	err      error
+}
+
+func NewInMemoryBroker(mode Mode) *InMemoryBroker {
+	return &InMemoryBroker{
+		mode: mode,
+	}
+}
+
+// Fail makes subsequent sends fail with err, nil to succeed again.
+func (b *InMemoryBroker) Fail(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.err = err
+}
+
+func (b *InMemoryBroker) Send(_ context.Context, event CloudEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.err != nil {
+		return b.err
+	}
+
+	headers, body, err := Encode(event, b.mode, natsHeaderPrefix)
+	if err != nil {
+		return err
+	}
+
+	b.messages = append(b.messages, Message{Headers: headers, Body: body})
+
+	return nil
+}
+
+// Messages returns the messages sent so far.
+func (b *InMemoryBroker) Messages() []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]Message(nil), b.messages...)
+}
+
+// Events decodes the messages sent so far.
+func (b *InMemoryBroker) Events() ([]CloudEvent, error) {
+	messages := b.Messages()
+
+	events := make([]CloudEvent, 0, len(messages))
+	for _, m := range messages {
+		e, err := Decode(m.Headers, m.Body, natsHeaderPrefix)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+func (b *InMemoryBroker) Close() error {
+	return nil
+}
@@ -0,0 +1,55 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsHeaderPrefix prefixes the attribute headers of binary mode messages.
+const natsHeaderPrefix = "ce-"
+
+// NATSBroker publishes every event on the subject `<subject>.<event type>`,
+// so subscribers can pick event types with wildcards.
+type NATSBroker struct {
+	conn    *nats.Conn
+	subject string
+	mode    Mode
+}
+
+func NewNATSBroker(url, subject string, mode Mode) (*NATSBroker, error) {
+	conn, err := nats.Connect(url, nats.Name("user-management"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &NATSBroker{
+		conn:    conn,
+		subject: subject,
+		mode:    mode,
+	}, nil
+}
+
+// Send returns once the server got the event, not its subscribers.
+func (b *NATSBroker) Send(ctx context.Context, event CloudEvent) error {
+	headers, body, err := Encode(event, b.mode, natsHeaderPrefix)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(b.subject + "." + event.Type)
+	msg.Data = body
+	for k, v := range headers {
+		msg.Header.Set(k, v)
+	}
+
+	if err = b.conn.PublishMsg(msg); err != nil {
+		return err
+	}
+
+	return b.conn.FlushWithContext(ctx)
+}
+
+func (b *NATSBroker) Close() error {
+	return b.conn.Drain()
+}
@@ -0,0 +1,72 @@
+package stream
+
+import (
+	"sync"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// Hub broadcasts the events of this instance to the subscribers of their
+// organization. Instead of blocking on a slow subscriber it drops it, closing
+// its channel, so the subscriber can resume from the event log.
+type Hub struct {
+	mu   sync.Mutex
+	subs map[chan domain.Event]string
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subs: make(map[chan domain.Event]string),
+	}
+}
+
+// Subscribe returns a channel of the events of the organization buffering up
+// to buffer of them and a function to unsubscribe.
+func (h *Hub) Subscribe(organizationID string, buffer int) (<-chan domain.Event, func()) {
+	ch := make(chan domain.Event, buffer)
+
+	h.mu.Lock()
+	h.subs[ch] = organizationID
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		h.drop(ch)
+	}
+}
+
+func (h *Hub) Broadcast(event domain.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch, organizationID := range h.subs {
+		if organizationID != event.OrganizationID {
+			continue
+		}
+
+		select {
+		case ch <- event:
+		default:
+			h.drop(ch)
+		}
+	}
+}
+
+// Reset drops all subscribers, for when events may have been missed.
+func (h *Hub) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subs {
+		h.drop(ch)
+	}
+}
+
+func (h *Hub) drop(ch chan domain.Event) {
+	if _, ok := h.subs[ch]; ok {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
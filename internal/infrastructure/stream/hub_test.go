@@ -0,0 +1,78 @@
+package stream_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/stream"
+)
+
+func TestHub(t *testing.T) {
+	t.Run("Broadcast", func(t *testing.T) {
+		hub := stream.NewHub()
+		a, cancelA := hub.Subscribe("acme", 1)
+		defer cancelA()
+		b, cancelB := hub.Subscribe("acme", 1)
+		defer cancelB()
+
+		hub.Broadcast(domain.Event{Sequence: 1, OrganizationID: "acme"})
+
+		assert.Equal(t, int64(1), (<-a).Sequence)
+		assert.Equal(t, int64(1), (<-b).Sequence)
+	})
+
+	t.Run("Broadcast to the organization", func(t *testing.T) {
+		hub := stream.NewHub()
+		acme, cancelAcme := hub.Subscribe("acme", 1)
+		defer cancelAcme()
+		other, cancelOther := hub.Subscribe("other", 1)
+		defer cancelOther()
+
+		hub.Broadcast(domain.Event{Sequence: 1, OrganizationID: "other"})
+		hub.Broadcast(domain.Event{Sequence: 2, OrganizationID: "acme"})
+
+		assert.Equal(t, int64(2), (<-acme).Sequence)
+		assert.Equal(t, int64(1), (<-other).Sequence)
+	})
+
+	t.Run("Drop slow subscriber", func(t *testing.T) {
+		hub := stream.NewHub()
+		ch, cancel := hub.Subscribe("acme", 1)
+		defer cancel()
+
+		hub.Broadcast(domain.Event{Sequence: 1, OrganizationID: "acme"})
+		hub.Broadcast(domain.Event{Sequence: 2, OrganizationID: "acme"})
+
+		e, ok := <-ch
+		assert.True(t, ok)
+		assert.Equal(t, int64(1), e.Sequence)
+
+		_, ok = <-ch
+		assert.False(t, ok)
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		hub := stream.NewHub()
+		ch, cancel := hub.Subscribe("acme", 1)
+		cancel()
+		cancel()
+
+		hub.Broadcast(domain.Event{Sequence: 1, OrganizationID: "acme"})
+
+		_, ok := <-ch
+		assert.False(t, ok)
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		hub := stream.NewHub()
+		ch, cancel := hub.Subscribe("acme", 1)
+		defer cancel()
+
+		hub.Reset()
+
+		_, ok := <-ch
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,84 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+const maxListenBackoff = 30 * time.Second
+
+// Listener feeds a Hub with the events notified on channel by any instance.
+type Listener struct {
+	pool    *pgxpool.Pool
+	channel string
+	hub     *Hub
+}
+
+func NewListener(pool *pgxpool.Pool, channel string, hub *Hub) *Listener {
+	return &Listener{
+		pool:    pool,
+		channel: channel,
+		hub:     hub,
+	}
+}
+
+// Run listens until ctx is done, reconnecting on errors. Subscribers are
+// dropped on reconnects as they may have missed events meanwhile, and once
+// done so streams end before the server shuts down.
+func (l *Listener) Run(ctx context.Context) {
+	log := slog.Default().With("job", "EventListener")
+	defer l.hub.Reset()
+
+	backoff := time.Second
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.ErrorContext(ctx, err.Error())
+		l.hub.Reset()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxListenBackoff)
+	}
+}
+
+func (l *Listener) listen(ctx context.Context) error {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		return err
+	}
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event domain.Event
+		if err = json.Unmarshal([]byte(n.Payload), &event); err != nil {
+			slog.Default().With("job", "EventListener").ErrorContext(ctx, err.Error())
+			continue
+		}
+
+		l.hub.Broadcast(event)
+	}
+}
@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+)
+
+// ErrForbiddenAddress is returned when a subscription URL, or one it
+// redirects to, resolves to an address of the internal network.
+var ErrForbiddenAddress = errors.New("forbidden webhook address")
+
+// Sender posts deliveries to their subscription URL. Unless allowPrivate is
+// set, it refuses to connect to loopback, private, carrier-grade NAT,
+// link-local and unspecified addresses, directly or through NAT64 and 6to4,
+// so subscriptions can't reach internal services.
+type Sender struct {
+	client *http.Client
+}
+
+func NewSender(timeout time.Duration, allowPrivate bool) *Sender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		// Checked on the resolved address being dialed, so DNS can't be used
+		// to get around it.
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+
+			if forbiddenAddr(ip.Unmap()) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+			}
+
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &Sender{
+		client: &http.Client{Timeout: timeout, Transport: transport},
+	}
+}
+
+var (
+	// nat64Prefix addresses embed, in their last 4 bytes, the IPv4 address
+	// a NAT64 gateway connects to.
+	nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+	// sixToFourPrefix addresses embed, after the prefix, the IPv4 address of
+	// the 6to4 relay they are routed through.
+	sixToFourPrefix = netip.MustParsePrefix("2002::/16")
+	// internalPrefixes are the internal ranges netip has no predicate for.
+	internalPrefixes = []netip.Prefix{
+		netip.MustParsePrefix("100.64.0.0/10"),  // Carrier-grade NAT.
+		netip.MustParsePrefix("64:ff9b:1::/48"), // Local-use NAT64.
+	}
+)
+
+func forbiddenAddr(ip netip.Addr) bool {
+	// Translated addresses are checked as the IPv4 address they reach.
+	switch b := ip.As16(); {
+	case ip.Is6() && nat64Prefix.Contains(ip):
+		return forbiddenAddr(netip.AddrFrom4([4]byte(b[12:16])))
+	case ip.Is6() && sixToFourPrefix.Contains(ip):
+		return forbiddenAddr(netip.AddrFrom4([4]byte(b[2:6])))
+	}
+
+	for _, p := range internalPrefixes {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified()
+}
+
+// Send returns the status code of the response, any non 2xx response is an
+// error.
+func (s *Sender) Send(ctx context.Context, d domain.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Subscription.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(HeaderEvent, string(d.EventType))
+	req.Header.Set(HeaderID, d.EventID)
+	req.Header.Set(HeaderSignature, Sign(d.Subscription.Secret, time.Now(), d.Payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
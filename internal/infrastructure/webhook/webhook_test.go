@@ -0,0 +1,144 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Beriw98/user-management/internal/app/domain"
+	"github.com/Beriw98/user-management/internal/infrastructure/webhook"
+)
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := time.Now()
+	header := webhook.Sign("secret", now, body)
+
+	tests := []struct {
+		name   string
+		secret string
+		header string
+		body   []byte
+		now    time.Time
+		err    error
+	}{
+		{name: "Valid", secret: "secret", header: header, body: body, now: now},
+		{name: "Wrong secret", secret: "other", header: header, body: body, now: now, err: webhook.ErrInvalidSignature},
+		{name: "Tampered body", secret: "secret", header: header, body: []byte(`{"id":"2"}`), now: now, err: webhook.ErrInvalidSignature},
+		{name: "Replayed", secret: "secret", header: header, body: body, now: now.Add(10 * time.Minute), err: webhook.ErrSignatureExpired},
+		{name: "Malformed", secret: "secret", header: "v1=zz", body: body, now: now, err: webhook.ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.Verify(tt.secret, tt.header, tt.body, 5*time.Minute, tt.now)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestSender_Send(t *testing.T) {
+	delivery := domain.WebhookDelivery{
+		EventID:      "e1",
+		EventType:    domain.UserCreated,
+		Payload:      []byte(`{"id":"e1"}`),
+		Subscription: &domain.WebhookSubscription{Secret: "secret"},
+	}
+
+	t.Run("Signed delivery", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			assert.Equal(t, string(domain.UserCreated), r.Header.Get(webhook.HeaderEvent))
+			assert.Equal(t, "e1", r.Header.Get(webhook.HeaderID))
+			assert.NoError(t, webhook.Verify("secret", r.Header.Get(webhook.HeaderSignature), body, time.Minute, time.Now()))
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		d := delivery
+		d.Subscription = &domain.WebhookSubscription{URL: srv.URL, Secret: "secret"}
+
+		code, err := webhook.NewSender(time.Second, true).Send(context.Background(), d)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, code)
+	})
+
+	t.Run("Error status", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		d := delivery
+		d.Subscription = &domain.WebhookSubscription{URL: srv.URL, Secret: "secret"}
+
+		code, err := webhook.NewSender(time.Second, true).Send(context.Background(), d)
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(100 * time.Millisecond)
+		}))
+		defer srv.Close()
+
+		d := delivery
+		d.Subscription = &domain.WebhookSubscription{URL: srv.URL, Secret: "secret"}
+
+		code, err := webhook.NewSender(10*time.Millisecond, true).Send(context.Background(), d)
+		assert.Error(t, err)
+		assert.Zero(t, code)
+	})
+
+	t.Run("Internal addresses", func(t *testing.T) {
+		tests := []struct {
+			name string
+			url  string
+		}{
+			{name: "Loopback", url: "http://127.0.0.1:1"},
+			{name: "Private", url: "http://10.0.0.1:1"},
+			{name: "Link-local", url: "http://169.254.169.254:1"},
+			{name: "Carrier-grade NAT", url: "http://100.64.0.1:1"},
+			{name: "IPv6 loopback", url: "http://[::1]:1"},
+			{name: "IPv4-mapped", url: "http://[::ffff:10.0.0.1]:1"},
+			{name: "NAT64", url: "http://[64:ff9b::a9fe:a9fe]:1"},
+			{name: "Local-use NAT64", url: "http://[64:ff9b:1::808:808]:1"},
+			{name: "6to4", url: "http://[2002:a00:1::1]:1"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				d := delivery
+				d.Subscription = &domain.WebhookSubscription{URL: tt.url, Secret: "secret"}
+
+				code, err := webhook.NewSender(time.Second, false).Send(context.Background(), d)
+				assert.ErrorIs(t, err, webhook.ErrForbiddenAddress)
+				assert.Zero(t, code)
+			})
+		}
+	})
+
+	t.Run("Internal address", func(t *testing.T) {
+		var called bool
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer srv.Close()
+
+		d := delivery
+		d.Subscription = &domain.WebhookSubscription{URL: srv.URL, Secret: "secret"}
+
+		code, err := webhook.NewSender(time.Second, false).Send(context.Background(), d)
+		assert.ErrorIs(t, err, webhook.ErrForbiddenAddress)
+		assert.Zero(t, code)
+		assert.False(t, called)
+	})
+}
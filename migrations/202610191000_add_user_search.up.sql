@@ -0,0 +1,13 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE users ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(surname, '')), 'A') ||
+    setweight(to_tsvector('simple', email), 'B')
+) STORED;
+
+CREATE INDEX users_search_vector_idx ON users USING GIN (search_vector);
+
+CREATE INDEX users_search_trgm_idx ON users USING GIN (
+    (coalesce(name, '') || ' ' || coalesce(surname, '') || ' ' || email) gin_trgm_ops
+);
@@ -0,0 +1,5 @@
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+DROP INDEX users_deleted_at_idx;
+DROP INDEX users_email_key;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE users DROP COLUMN deleted_at;
@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN version    INTEGER     NOT NULL DEFAULT 1 CHECK (version > 0);
@@ -0,0 +1,32 @@
+CREATE TABLE audit_events (
+    id          BIGSERIAL   PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    actor       VARCHAR     NOT NULL DEFAULT '',
+    action      VARCHAR     NOT NULL,
+    target_type VARCHAR     NOT NULL,
+    target_id   VARCHAR     NOT NULL,
+    changes     JSONB,
+    request_id  VARCHAR     NOT NULL DEFAULT '',
+    client_ip   VARCHAR     NOT NULL DEFAULT '',
+    user_agent  VARCHAR     NOT NULL DEFAULT '',
+    prev_hash   VARCHAR     NOT NULL,
+    hash        VARCHAR     NOT NULL UNIQUE
+);
+
+CREATE INDEX auditevent_target_type_target_id ON audit_events (target_type, target_id);
+CREATE INDEX auditevent_actor ON audit_events (actor);
+
+-- The log is append-only, the hash chain detects whatever bypasses this.
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
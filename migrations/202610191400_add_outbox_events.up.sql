@@ -0,0 +1,13 @@
+CREATE TABLE outbox_events (
+    id           BIGSERIAL   PRIMARY KEY,
+    event_id     VARCHAR     NOT NULL UNIQUE,
+    type         VARCHAR     NOT NULL,
+    aggregate_id VARCHAR     NOT NULL,
+    occurred_at  TIMESTAMPTZ NOT NULL,
+    data         JSONB       NOT NULL,
+    published_at TIMESTAMPTZ,
+    attempts     BIGINT      NOT NULL DEFAULT 0,
+    last_error   VARCHAR
+);
+
+CREATE INDEX outbox_events_pending_idx ON outbox_events (id) WHERE published_at IS NULL;
@@ -0,0 +1,27 @@
+CREATE TABLE webhook_subscriptions (
+    id          VARCHAR     PRIMARY KEY,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    url         VARCHAR     NOT NULL,
+    secret      VARCHAR     NOT NULL,
+    event_types JSONB,
+    active      BOOLEAN     NOT NULL DEFAULT true
+);
+
+CREATE TABLE webhook_deliveries (
+    id               BIGSERIAL   PRIMARY KEY,
+    subscription_id  VARCHAR     NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id         VARCHAR     NOT NULL,
+    event_type       VARCHAR     NOT NULL,
+    payload          JSONB       NOT NULL,
+    status           VARCHAR     NOT NULL DEFAULT 'pending',
+    attempts         BIGINT      NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_attempt_at  TIMESTAMPTZ,
+    last_status_code BIGINT,
+    last_error       VARCHAR,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX webhookdelivery_subscription_id_event_id ON webhook_deliveries (subscription_id, event_id);
+CREATE INDEX webhookdelivery_status_next_attempt_at ON webhook_deliveries (status, next_attempt_at);
@@ -0,0 +1,30 @@
+CREATE TABLE import_jobs (
+    id          VARCHAR     PRIMARY KEY,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    format      VARCHAR     NOT NULL,
+    dry_run     BOOLEAN     NOT NULL DEFAULT false,
+    status      VARCHAR     NOT NULL DEFAULT 'running',
+    processed   BIGINT      NOT NULL DEFAULT 0,
+    created     BIGINT      NOT NULL DEFAULT 0,
+    invited     BIGINT      NOT NULL DEFAULT 0,
+    failed      BIGINT      NOT NULL DEFAULT 0,
+    row_errors  JSONB,
+    error       VARCHAR,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE TABLE invitations (
+    id          VARCHAR     PRIMARY KEY,
+    email       VARCHAR     NOT NULL,
+    name        VARCHAR,
+    surname     VARCHAR,
+    token_hash  VARCHAR     NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    revoked_at  TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX invitation_token_hash ON invitations (token_hash);
+CREATE INDEX invitation_email ON invitations (email);
@@ -0,0 +1,15 @@
+CREATE TABLE data_exports (
+    id          VARCHAR     PRIMARY KEY,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    user_id     VARCHAR     NOT NULL,
+    status      VARCHAR     NOT NULL DEFAULT 'pending',
+    archive     BYTEA,
+    size        BIGINT      NOT NULL DEFAULT 0,
+    error       VARCHAR,
+    finished_at TIMESTAMPTZ,
+    expires_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX dataexport_user_id ON data_exports (user_id);
+CREATE INDEX dataexport_expires_at ON data_exports (expires_at);
@@ -0,0 +1,28 @@
+ALTER TABLE audit_events ADD COLUMN certificate JSONB;
+
+-- Erasures pseudonymize the personal data of events within a transaction
+-- setting audit.erasure, every other column stays append-only. The erasure
+-- certificate records the hash of the pseudonymized events.
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND current_setting('audit.erasure', true) = 'on'
+        AND (NEW.id, NEW.occurred_at, NEW.actor, NEW.action, NEW.target_type, NEW.target_id, NEW.request_id,
+             NEW.certificate, NEW.prev_hash, NEW.hash)
+            IS NOT DISTINCT FROM
+            (OLD.id, OLD.occurred_at, OLD.actor, OLD.action, OLD.target_type, OLD.target_id, OLD.request_id,
+             OLD.certificate, OLD.prev_hash, OLD.hash)
+    THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TABLE erasure_tombstones (
+    id         VARCHAR     PRIMARY KEY,
+    email_hash VARCHAR     NOT NULL UNIQUE,
+    user_id    VARCHAR     NOT NULL,
+    erased_at  TIMESTAMPTZ NOT NULL
+);
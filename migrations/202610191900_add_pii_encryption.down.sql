@@ -0,0 +1,24 @@
+-- Decrypt the users first, this only fits cleartext back into the columns.
+DROP INDEX IF EXISTS users_search_trgm_idx;
+DROP INDEX IF EXISTS users_search_vector_idx;
+ALTER TABLE users DROP COLUMN search_vector;
+
+DROP INDEX IF EXISTS users_email_index_key;
+
+ALTER TABLE users
+    DROP COLUMN email_index,
+    ALTER COLUMN name TYPE VARCHAR(50),
+    ALTER COLUMN surname TYPE VARCHAR(50),
+    ALTER COLUMN email TYPE VARCHAR(255);
+
+ALTER TABLE users ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(surname, '')), 'A') ||
+    setweight(to_tsvector('simple', email), 'B')
+) STORED;
+
+CREATE INDEX users_search_vector_idx ON users USING GIN (search_vector);
+
+CREATE INDEX users_search_trgm_idx ON users USING GIN (
+    (coalesce(name, '') || ' ' || coalesce(surname, '') || ' ' || email) gin_trgm_ops
+);
@@ -0,0 +1,25 @@
+-- Encrypted values are longer than the cleartext, the search columns depend
+-- on them so they are rebuilt around the change of type.
+DROP INDEX IF EXISTS users_search_trgm_idx;
+DROP INDEX IF EXISTS users_search_vector_idx;
+ALTER TABLE users DROP COLUMN search_vector;
+
+ALTER TABLE users
+    ALTER COLUMN name TYPE TEXT,
+    ALTER COLUMN surname TYPE TEXT,
+    ALTER COLUMN email TYPE TEXT,
+    ADD COLUMN email_index VARCHAR(64);
+
+CREATE UNIQUE INDEX users_email_index_key ON users (email_index) WHERE deleted_at IS NULL;
+
+ALTER TABLE users ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(surname, '')), 'A') ||
+    setweight(to_tsvector('simple', email), 'B')
+) STORED;
+
+CREATE INDEX users_search_vector_idx ON users USING GIN (search_vector);
+
+CREATE INDEX users_search_trgm_idx ON users USING GIN (
+    (coalesce(name, '') || ' ' || coalesce(surname, '') || ' ' || email) gin_trgm_ops
+);
@@ -0,0 +1,23 @@
+CREATE TABLE policy_documents (
+    id           VARCHAR     PRIMARY KEY,
+    kind         VARCHAR     NOT NULL,
+    version      VARCHAR     NOT NULL,
+    url          VARCHAR     NOT NULL,
+    mandatory    BOOLEAN     NOT NULL,
+    published_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX policydocument_kind_version ON policy_documents (kind, version);
+
+CREATE TABLE consents (
+    id          VARCHAR     PRIMARY KEY,
+    user_id     VARCHAR     NOT NULL,
+    document_id VARCHAR     NOT NULL REFERENCES policy_documents (id),
+    kind        VARCHAR     NOT NULL,
+    version     VARCHAR     NOT NULL,
+    accepted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    client_ip   VARCHAR,
+    user_agent  VARCHAR
+);
+
+CREATE INDEX consent_user_id ON consents (user_id);
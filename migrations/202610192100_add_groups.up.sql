@@ -0,0 +1,19 @@
+CREATE TABLE groups (
+    id          VARCHAR     PRIMARY KEY,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    name        VARCHAR     NOT NULL,
+    description VARCHAR,
+    parent_id   VARCHAR     REFERENCES groups (id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX group_name ON groups (name);
+CREATE INDEX group_parent_id ON groups (parent_id);
+
+CREATE TABLE group_members (
+    group_id VARCHAR NOT NULL REFERENCES groups (id) ON DELETE CASCADE,
+    user_id  VARCHAR NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (group_id, user_id)
+);
+
+CREATE INDEX group_members_user_id ON group_members (user_id);
@@ -0,0 +1,8 @@
+-- Emails have to be unique across organizations again before this runs.
+DROP INDEX IF EXISTS users_organization_id_email_index_key;
+DROP INDEX IF EXISTS users_organization_id_email_key;
+CREATE UNIQUE INDEX users_email_key ON users (email) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX users_email_index_key ON users (email_index) WHERE deleted_at IS NULL;
+
+ALTER TABLE users DROP COLUMN organization_id;
+DROP TABLE IF EXISTS organizations;
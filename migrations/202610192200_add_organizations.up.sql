@@ -0,0 +1,21 @@
+CREATE TABLE organizations (
+    id         VARCHAR     PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    name       VARCHAR     NOT NULL,
+    slug       VARCHAR     NOT NULL
+);
+
+CREATE UNIQUE INDEX organization_slug ON organizations (slug);
+
+-- Existing users become members of the default organization.
+INSERT INTO organizations (id, name, slug) VALUES ('default', 'Default', 'default');
+
+ALTER TABLE users ADD COLUMN organization_id VARCHAR REFERENCES organizations (id);
+UPDATE users SET organization_id = 'default';
+ALTER TABLE users ALTER COLUMN organization_id SET NOT NULL;
+
+DROP INDEX IF EXISTS users_email_key;
+DROP INDEX IF EXISTS users_email_index_key;
+CREATE UNIQUE INDEX users_organization_id_email_key ON users (organization_id, email) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX users_organization_id_email_index_key ON users (organization_id, email_index) WHERE deleted_at IS NULL;
@@ -0,0 +1,9 @@
+DROP POLICY IF EXISTS group_members_tenant_isolation ON group_members;
+ALTER TABLE group_members NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE group_members DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS users_tenant_isolation ON users;
+ALTER TABLE users NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE users DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION IF EXISTS app_tenant_visible(VARCHAR);
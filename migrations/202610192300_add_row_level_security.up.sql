@@ -0,0 +1,20 @@
+-- Users are only visible to the transactions of their organization, as set
+-- in app.tenant_id by repository.TenantDriver, or to the maintenance ones
+-- spanning every organization, with app.all_tenants on. Superusers and roles
+-- with BYPASSRLS are not subject to this, the owner of the tables is.
+CREATE FUNCTION app_tenant_visible(organization_id VARCHAR) RETURNS BOOLEAN AS $$
+    SELECT current_setting('app.all_tenants', true) = 'on'
+        OR organization_id = nullif(current_setting('app.tenant_id', true), '');
+$$ LANGUAGE SQL STABLE;
+
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users FORCE ROW LEVEL SECURITY;
+CREATE POLICY users_tenant_isolation ON users
+    USING (app_tenant_visible(organization_id))
+    WITH CHECK (app_tenant_visible(organization_id));
+
+-- Memberships are visible along with their user, and go away with it.
+ALTER TABLE group_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE group_members FORCE ROW LEVEL SECURITY;
+CREATE POLICY group_members_tenant_isolation ON group_members
+    USING (EXISTS (SELECT 1 FROM users WHERE users.id = group_members.user_id));
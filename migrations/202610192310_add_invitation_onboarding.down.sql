@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS invitation_organization_id;
+
+ALTER TABLE invitations
+    DROP COLUMN invited_by,
+    DROP COLUMN group_id,
+    DROP COLUMN organization_id;
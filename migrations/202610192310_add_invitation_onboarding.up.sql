@@ -0,0 +1,10 @@
+ALTER TABLE invitations
+    ADD COLUMN organization_id VARCHAR REFERENCES organizations (id),
+    ADD COLUMN group_id        VARCHAR REFERENCES groups (id) ON DELETE SET NULL,
+    ADD COLUMN invited_by      VARCHAR;
+
+-- Existing invitations were sent for the default organization.
+UPDATE invitations SET organization_id = 'default';
+ALTER TABLE invitations ALTER COLUMN organization_id SET NOT NULL;
+
+CREATE INDEX invitation_organization_id ON invitations (organization_id);
@@ -0,0 +1,5 @@
+DROP INDEX outbox_events_failed_idx;
+DROP INDEX outbox_events_pending_idx;
+CREATE INDEX outbox_events_pending_idx ON outbox_events (id) WHERE published_at IS NULL;
+
+ALTER TABLE outbox_events DROP COLUMN dead_at;
@@ -0,0 +1,6 @@
+ALTER TABLE outbox_events ADD COLUMN dead_at TIMESTAMPTZ;
+
+DROP INDEX outbox_events_pending_idx;
+CREATE INDEX outbox_events_pending_idx ON outbox_events (id) WHERE published_at IS NULL AND dead_at IS NULL;
+CREATE INDEX outbox_events_failed_idx ON outbox_events (aggregate_id, id)
+    WHERE published_at IS NULL AND dead_at IS NULL AND attempts > 0;
@@ -0,0 +1,19 @@
+-- Encrypted users (those with an email blind index) can't be searched, their
+-- ciphertext is kept out of the search column and indexes.
+DROP INDEX IF EXISTS users_search_trgm_idx;
+DROP INDEX IF EXISTS users_search_vector_idx;
+ALTER TABLE users DROP COLUMN search_vector;
+
+ALTER TABLE users ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    CASE WHEN email_index IS NULL THEN
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(surname, '')), 'A') ||
+        setweight(to_tsvector('simple', email), 'B')
+    END
+) STORED;
+
+CREATE INDEX users_search_vector_idx ON users USING GIN (search_vector) WHERE email_index IS NULL;
+
+CREATE INDEX users_search_trgm_idx ON users USING GIN (
+    (coalesce(name, '') || ' ' || coalesce(surname, '') || ' ' || email) gin_trgm_ops
+) WHERE email_index IS NULL;
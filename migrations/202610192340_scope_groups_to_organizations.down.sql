@@ -0,0 +1,4 @@
+DROP INDEX group_organization_id_name;
+CREATE UNIQUE INDEX group_name ON groups (name);
+
+ALTER TABLE groups DROP COLUMN organization_id;
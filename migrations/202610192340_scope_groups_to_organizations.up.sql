@@ -0,0 +1,6 @@
+ALTER TABLE groups ADD COLUMN organization_id VARCHAR REFERENCES organizations (id);
+UPDATE groups SET organization_id = 'default';
+ALTER TABLE groups ALTER COLUMN organization_id SET NOT NULL;
+
+DROP INDEX group_name;
+CREATE UNIQUE INDEX group_organization_id_name ON groups (organization_id, name);
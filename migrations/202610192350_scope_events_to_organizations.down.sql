@@ -0,0 +1,22 @@
+ALTER TABLE import_jobs DROP COLUMN organization_id;
+ALTER TABLE webhook_subscriptions DROP COLUMN organization_id;
+ALTER TABLE outbox_events DROP COLUMN organization_id;
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND current_setting('audit.erasure', true) = 'on'
+        AND (NEW.id, NEW.occurred_at, NEW.actor, NEW.action, NEW.target_type, NEW.target_id, NEW.request_id,
+             NEW.certificate, NEW.prev_hash, NEW.hash)
+            IS NOT DISTINCT FROM
+            (OLD.id, OLD.occurred_at, OLD.actor, OLD.action, OLD.target_type, OLD.target_id, OLD.request_id,
+             OLD.certificate, OLD.prev_hash, OLD.hash)
+    THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE audit_events DROP COLUMN organization_id;
@@ -0,0 +1,42 @@
+-- The default fills existing events without updating them, the log is
+-- append-only.
+ALTER TABLE audit_events ADD COLUMN organization_id VARCHAR NOT NULL DEFAULT 'default' REFERENCES organizations (id);
+ALTER TABLE audit_events ALTER COLUMN organization_id DROP DEFAULT;
+
+CREATE INDEX auditevent_organization_id_id ON audit_events (organization_id, id);
+
+-- Erasures can't move events to another organization either.
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND current_setting('audit.erasure', true) = 'on'
+        AND (NEW.id, NEW.occurred_at, NEW.actor, NEW.action, NEW.target_type, NEW.target_id, NEW.request_id,
+             NEW.certificate, NEW.prev_hash, NEW.hash, NEW.organization_id)
+            IS NOT DISTINCT FROM
+            (OLD.id, OLD.occurred_at, OLD.actor, OLD.action, OLD.target_type, OLD.target_id, OLD.request_id,
+             OLD.certificate, OLD.prev_hash, OLD.hash, OLD.organization_id)
+    THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE outbox_events ADD COLUMN organization_id VARCHAR REFERENCES organizations (id);
+UPDATE outbox_events SET organization_id = 'default';
+ALTER TABLE outbox_events ALTER COLUMN organization_id SET NOT NULL;
+
+CREATE INDEX outboxevent_organization_id_id ON outbox_events (organization_id, id);
+
+ALTER TABLE webhook_subscriptions ADD COLUMN organization_id VARCHAR REFERENCES organizations (id);
+UPDATE webhook_subscriptions SET organization_id = 'default';
+ALTER TABLE webhook_subscriptions ALTER COLUMN organization_id SET NOT NULL;
+
+CREATE INDEX webhooksubscription_organization_id ON webhook_subscriptions (organization_id);
+
+ALTER TABLE import_jobs ADD COLUMN organization_id VARCHAR REFERENCES organizations (id);
+UPDATE import_jobs SET organization_id = 'default';
+ALTER TABLE import_jobs ALTER COLUMN organization_id SET NOT NULL;
+
+CREATE INDEX importjob_organization_id ON import_jobs (organization_id);
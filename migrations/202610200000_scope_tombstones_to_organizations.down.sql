@@ -0,0 +1,4 @@
+DROP INDEX erasuretombstone_organization_id_email_hash;
+ALTER TABLE erasure_tombstones ADD CONSTRAINT erasure_tombstones_email_hash_key UNIQUE (email_hash);
+
+ALTER TABLE erasure_tombstones DROP COLUMN organization_id;
@@ -0,0 +1,6 @@
+ALTER TABLE erasure_tombstones ADD COLUMN organization_id VARCHAR REFERENCES organizations (id);
+UPDATE erasure_tombstones SET organization_id = 'default';
+ALTER TABLE erasure_tombstones ALTER COLUMN organization_id SET NOT NULL;
+
+ALTER TABLE erasure_tombstones DROP CONSTRAINT erasure_tombstones_email_hash_key;
+CREATE UNIQUE INDEX erasuretombstone_organization_id_email_hash ON erasure_tombstones (organization_id, email_hash);
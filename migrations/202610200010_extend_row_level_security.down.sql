@@ -0,0 +1,43 @@
+DROP POLICY IF EXISTS erasure_tombstones_tenant_isolation ON erasure_tombstones;
+ALTER TABLE erasure_tombstones NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE erasure_tombstones DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS import_jobs_tenant_isolation ON import_jobs;
+ALTER TABLE import_jobs NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE import_jobs DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS data_exports_tenant_isolation ON data_exports;
+ALTER TABLE data_exports NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE data_exports DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS webhook_deliveries_tenant_isolation ON webhook_deliveries;
+ALTER TABLE webhook_deliveries NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE webhook_deliveries DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS webhook_subscriptions_tenant_isolation ON webhook_subscriptions;
+ALTER TABLE webhook_subscriptions NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE webhook_subscriptions DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS outbox_events_tenant_isolation ON outbox_events;
+ALTER TABLE outbox_events NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE outbox_events DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS audit_events_tenant_isolation ON audit_events;
+ALTER TABLE audit_events NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE audit_events DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS consents_tenant_isolation ON consents;
+ALTER TABLE consents NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE consents DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS groups_tenant_isolation ON groups;
+ALTER TABLE groups NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE groups DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS invitations_tenant_isolation ON invitations;
+ALTER TABLE invitations NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE invitations DISABLE ROW LEVEL SECURITY;
+
+ALTER TABLE webhook_deliveries DROP COLUMN organization_id;
+ALTER TABLE data_exports DROP COLUMN organization_id;
+ALTER TABLE consents DROP COLUMN organization_id;
@@ -0,0 +1,82 @@
+-- Users are under row level security, their organization can only be read
+-- with app.all_tenants on.
+SELECT set_config('app.all_tenants', 'on', false);
+
+ALTER TABLE consents ADD COLUMN organization_id VARCHAR REFERENCES organizations (id);
+UPDATE consents SET organization_id = users.organization_id FROM users WHERE users.id = consents.user_id;
+UPDATE consents SET organization_id = 'default' WHERE organization_id IS NULL;
+ALTER TABLE consents ALTER COLUMN organization_id SET NOT NULL;
+
+ALTER TABLE data_exports ADD COLUMN organization_id VARCHAR REFERENCES organizations (id);
+UPDATE data_exports SET organization_id = users.organization_id FROM users WHERE users.id = data_exports.user_id;
+UPDATE data_exports SET organization_id = 'default' WHERE organization_id IS NULL;
+ALTER TABLE data_exports ALTER COLUMN organization_id SET NOT NULL;
+
+ALTER TABLE webhook_deliveries ADD COLUMN organization_id VARCHAR REFERENCES organizations (id);
+UPDATE webhook_deliveries SET organization_id = webhook_subscriptions.organization_id
+    FROM webhook_subscriptions WHERE webhook_subscriptions.id = webhook_deliveries.subscription_id;
+ALTER TABLE webhook_deliveries ALTER COLUMN organization_id SET NOT NULL;
+
+SELECT set_config('app.all_tenants', '', false);
+
+-- Every table holding the data of an organization gets the policy of users,
+-- see app_tenant_visible.
+ALTER TABLE invitations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invitations FORCE ROW LEVEL SECURITY;
+CREATE POLICY invitations_tenant_isolation ON invitations
+    USING (app_tenant_visible(organization_id))
+    WITH CHECK (app_tenant_visible(organization_id));
+
+ALTER TABLE groups ENABLE ROW LEVEL SECURITY;
+ALTER TABLE groups FORCE ROW LEVEL SECURITY;
+CREATE POLICY groups_tenant_isolation ON groups
+    USING (app_tenant_visible(organization_id))
+    WITH CHECK (app_tenant_visible(organization_id));
+
+ALTER TABLE consents ENABLE ROW LEVEL SECURITY;
+ALTER TABLE consents FORCE ROW LEVEL SECURITY;
+CREATE POLICY consents_tenant_isolation ON consents
+    USING (app_tenant_visible(organization_id))
+    WITH CHECK (app_tenant_visible(organization_id));
+
+ALTER TABLE audit_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE audit_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY audit_events_tenant_isolation ON audit_events
+    USING (app_tenant_visible(organization_id))
+    WITH CHECK (app_tenant_visible(organization_id));
+
+ALTER TABLE outbox_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE outbox_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY outbox_events_tenant_isolation ON outbox_events
+    USING (app_tenant_visible(organization_id))
+    WITH CHECK (app_tenant_visible(organization_id));
+
+ALTER TABLE webhook_subscriptions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE webhook_subscriptions FORCE ROW LEVEL SECURITY;
+CREATE POLICY webhook_subscriptions_tenant_isolation ON webhook_subscriptions
+    USING (app_tenant_visible(organization_id))
+    WITH CHECK (app_tenant_visible(organization_id));
+
+ALTER TABLE webhook_deliveries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE webhook_deliveries FORCE ROW LEVEL SECURITY;
+CREATE POLICY webhook_deliveries_tenant_isolation ON webhook_deliveries
+    USING (app_tenant_visible(organization_id))
+    WITH CHECK (app_tenant_visible(organization_id));
+
+ALTER TABLE data_exports ENABLE ROW LEVEL SECURITY;
+ALTER TABLE data_exports FORCE ROW LEVEL SECURITY;
+CREATE POLICY data_exports_tenant_isolation ON data_exports
+    USING (app_tenant_visible(organization_id))
+    WITH CHECK (app_tenant_visible(organization_id));
+
+ALTER TABLE import_jobs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE import_jobs FORCE ROW LEVEL SECURITY;
+CREATE POLICY import_jobs_tenant_isolation ON import_jobs
+    USING (app_tenant_visible(organization_id))
+    WITH CHECK (app_tenant_visible(organization_id));
+
+ALTER TABLE erasure_tombstones ENABLE ROW LEVEL SECURITY;
+ALTER TABLE erasure_tombstones FORCE ROW LEVEL SECURITY;
+CREATE POLICY erasure_tombstones_tenant_isolation ON erasure_tombstones
+    USING (app_tenant_visible(organization_id))
+    WITH CHECK (app_tenant_visible(organization_id));
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS invitation_email_index;
+ALTER TABLE invitations DROP COLUMN email_index;
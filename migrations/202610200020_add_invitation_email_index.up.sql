@@ -0,0 +1,2 @@
+ALTER TABLE invitations ADD COLUMN email_index VARCHAR(64);
+CREATE INDEX invitation_email_index ON invitations (email_index);